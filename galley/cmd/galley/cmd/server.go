@@ -107,6 +107,17 @@ func serverCmd() *cobra.Command {
 				log.Fatalf("Invalid validationArgs: %v", err)
 			}
 
+			if validationArgs.CrossResourceChecks != validation.CrossResourceChecksOff {
+				if serverArgs.EnableServer {
+					// the objects are checked against the configuration distributed by the server.
+					state := server.NewState()
+					serverArgs.State = state
+					validationArgs.ConfigState = state
+				} else {
+					log.Warnf("Cross-resource validation requires the server mode, and is disabled")
+				}
+			}
+
 			if serverArgs.EnableServer {
 				go server.RunServer(serverArgs, livenessProbeController, readinessProbeController)
 			}
@@ -180,6 +191,19 @@ func serverCmd() *cobra.Command {
 		serverArgs.SinkMeta, "Comma-separated list of key=values to attach as metadata to outgoing sink connections. Ex: 'key=value,key2=value2'")
 	serverCmd.PersistentFlags().BoolVar(&serverArgs.EnableServiceDiscovery, "enableServiceDiscovery", false,
 		"Enable service discovery processing in Galley")
+	serverCmd.PersistentFlags().StringSliceVar(&serverArgs.ClusterKubeConfigs, "clusterKubeconfigs", serverArgs.ClusterKubeConfigs,
+		"Comma-separated list of <cluster name>=<kubeconfig path> of the clusters to merge the config of, by decreasing precedence. "+
+			"Overrides --kubeconfig")
+	serverCmd.PersistentFlags().StringVar(&serverArgs.ClusterPrecedenceFile, "clusterPrecedenceFile", serverArgs.ClusterPrecedenceFile,
+		"YAML file of the rules overriding the precedence of the clusters for some collections and namespaces")
+	serverCmd.PersistentFlags().IntVar(&serverArgs.SnapshotHistorySize, "snapshotHistorySize", serverArgs.SnapshotHistorySize,
+		"Number of config snapshots kept for comparison and pinning through the ControlZ config topic, 0 to disable")
+	serverCmd.PersistentFlags().StringSliceVar(&serverArgs.CanarySinkAnnotations, "canarySinkAnnotations", serverArgs.CanarySinkAnnotations,
+		"Comma-separated list of key=value annotations selecting the sinks new config snapshots are first served to")
+	serverCmd.PersistentFlags().IntVar(&serverArgs.CanaryPercentage, "canaryPercentage", serverArgs.CanaryPercentage,
+		"Percentage of the sinks new config snapshots are first served to")
+	serverCmd.PersistentFlags().DurationVar(&serverArgs.CanarySoakTime, "canarySoakTime", serverArgs.CanarySoakTime,
+		"How long new config snapshots are served to the selected sinks without being NACK'd before they are served to all the sinks")
 
 	// validation config
 	serverCmd.PersistentFlags().StringVar(&validationArgs.WebhookConfigFile,
@@ -197,6 +221,9 @@ func serverCmd() *cobra.Command {
 		"Name of the validation service running in the same namespace as the deployment")
 	serverCmd.PersistentFlags().StringVar(&validationArgs.WebhookName, "webhook-name", "istio-galley",
 		"Name of the k8s validatingwebhookconfiguration")
+	serverCmd.PersistentFlags().StringVar(&validationArgs.CrossResourceChecks, "crossResourceValidation",
+		validationArgs.CrossResourceChecks, "Check the Pilot configuration against the existing configuration known to "+
+			"the server: off, warn (adding the conflicts to the audit annotations) or reject")
 
 	// Hidden, file only flags for validation specific TLS
 	serverCmd.PersistentFlags().StringVar(&validationArgs.CertFile, "validation.tls.clientCertificate", "",
@@ -0,0 +1,219 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	corev1 "k8s.io/api/core/v1"
+
+	mcp "istio.io/api/mcp/v1alpha1"
+	networking "istio.io/api/networking/v1alpha3"
+	"istio.io/istio/galley/pkg/metadata"
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// Modes of the checks of the objects against the configuration known to Galley.
+const (
+	// CrossResourceChecksOff disables the checks.
+	CrossResourceChecksOff = "off"
+
+	// CrossResourceChecksWarn admits the conflicting objects, and adds the conflicts to the audit
+	// annotations of the admission response.
+	CrossResourceChecksWarn = "warn"
+
+	// CrossResourceChecksReject rejects the conflicting objects.
+	CrossResourceChecksReject = "reject"
+)
+
+// conflictsAuditAnnotation is the audit annotation listing the conflicts of an admitted object.
+const conflictsAuditAnnotation = "conflicts"
+
+// ConfigState is the configuration known to Galley, which the objects are checked against.
+type ConfigState interface {
+	// Resources returns the resources of a collection, and whether the collection is known.
+	Resources(collection string) ([]*mcp.Resource, bool)
+}
+
+// checkConflicts returns the conflicts of an object with the other resources known to Galley.
+func (wh *Webhook) checkConflicts(cfg *model.Config) []string {
+	if wh.configState == nil ||
+		(wh.crossResourceChecks != CrossResourceChecksWarn && wh.crossResourceChecks != CrossResourceChecksReject) {
+		return nil
+	}
+
+	switch spec := cfg.Spec.(type) {
+	case *networking.Gateway:
+		return checkGateway(wh.configState, cfg, spec)
+	case *networking.ServiceEntry:
+		return checkServiceEntry(wh.configState, cfg, spec)
+	case *networking.DestinationRule:
+		return checkDestinationRule(wh.configState, cfg, spec)
+	default:
+		return nil
+	}
+}
+
+// checkGateway reports the hosts and ports bound with different TLS settings by another gateway
+// selecting the same workloads.
+func checkGateway(state ConfigState, cfg *model.Config, gateway *networking.Gateway) []string {
+	var conflicts []string
+	forEach(state, metadata.IstioNetworkingV1alpha3Gateways.Collection.String(), cfg, func(name string, body *types.Any) {
+		other := &networking.Gateway{}
+		if err := types.UnmarshalAny(body, other); err != nil || !sameLabels(gateway.Selector, other.Selector) {
+			return
+		}
+
+		for _, server := range gateway.Servers {
+			for _, otherServer := range other.Servers {
+				if server.Port.GetNumber() != otherServer.Port.GetNumber() || proto.Equal(server.Tls, otherServer.Tls) {
+					continue
+				}
+				for _, host := range server.Hosts {
+					if contains(otherServer.Hosts, host) {
+						conflicts = append(conflicts, fmt.Sprintf("gateway %s binds host %q on port %d with different TLS settings",
+							name, host, server.Port.GetNumber()))
+					}
+				}
+			}
+		}
+	})
+	return conflicts
+}
+
+// checkServiceEntry reports the hosts already defined by another service entry.
+func checkServiceEntry(state ConfigState, cfg *model.Config, entry *networking.ServiceEntry) []string {
+	var conflicts []string
+	forEach(state, metadata.IstioNetworkingV1alpha3Serviceentries.Collection.String(), cfg, func(name string, body *types.Any) {
+		other := &networking.ServiceEntry{}
+		if err := types.UnmarshalAny(body, other); err != nil {
+			return
+		}
+
+		for _, host := range entry.Hosts {
+			if contains(other.Hosts, host) {
+				conflicts = append(conflicts, fmt.Sprintf("host %q is already defined by service entry %s", host, name))
+			}
+		}
+	})
+	return conflicts
+}
+
+// checkDestinationRule reports the subsets whose labels match no pod of a Kubernetes service. Nothing is
+// reported if the services and pods aren't known to Galley.
+func checkDestinationRule(state ConfigState, cfg *model.Config, rule *networking.DestinationRule) []string {
+	service, namespace, ok := kubeService(rule.Host, cfg.Namespace)
+	if !ok || len(rule.Subsets) == 0 {
+		return nil
+	}
+
+	services, ok := state.Resources(metadata.K8sCoreV1Services.Collection.String())
+	if !ok {
+		return nil
+	}
+	pods, ok := state.Resources(metadata.K8sCoreV1Pods.Collection.String())
+	if !ok {
+		return nil
+	}
+
+	var selector map[string]string
+	for _, r := range services {
+		if r.Metadata.GetName() != namespace+"/"+service {
+			continue
+		}
+		spec := &corev1.ServiceSpec{}
+		if err := types.UnmarshalAny(r.Body, spec); err != nil {
+			return nil
+		}
+		selector = spec.Selector
+	}
+	if len(selector) == 0 {
+		// not a Kubernetes service, or one without a selector.
+		return nil
+	}
+
+	var conflicts []string
+	for _, subset := range rule.Subsets {
+		if len(subset.Labels) == 0 {
+			continue
+		}
+
+		matched := false
+		for _, pod := range pods {
+			if strings.HasPrefix(pod.Metadata.GetName(), namespace+"/") &&
+				matches(selector, pod.Metadata.GetLabels()) && matches(subset.Labels, pod.Metadata.GetLabels()) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			conflicts = append(conflicts, fmt.Sprintf("subset %q matches no pod of service %s/%s", subset.Name, namespace, service))
+		}
+	}
+	return conflicts
+}
+
+// forEach calls fn with the name and body of the resources of a collection, except the checked object.
+func forEach(state ConfigState, collection string, cfg *model.Config, fn func(name string, body *types.Any)) {
+	resources, _ := state.Resources(collection)
+	self := cfg.Namespace + "/" + cfg.Name
+	for _, r := range resources {
+		if name := r.Metadata.GetName(); name != self {
+			fn(name, r.Body)
+		}
+	}
+}
+
+// kubeService returns the name and namespace of the Kubernetes service of a host, either a short name
+// or a <name>.<namespace>.svc.<domain> FQDN.
+func kubeService(host, namespace string) (string, string, bool) {
+	if strings.Contains(host, "*") {
+		return "", "", false
+	}
+	parts := strings.Split(host, ".")
+	switch {
+	case len(parts) == 1:
+		return host, namespace, true
+	case len(parts) >= 3 && parts[2] == "svc":
+		return parts[0], parts[1], true
+	default:
+		return "", "", false
+	}
+}
+
+func matches(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func sameLabels(a, b map[string]string) bool {
+	return len(a) == len(b) && matches(a, b)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
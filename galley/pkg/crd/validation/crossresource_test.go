@@ -0,0 +1,227 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	mcp "istio.io/api/mcp/v1alpha1"
+	networking "istio.io/api/networking/v1alpha3"
+	"istio.io/istio/galley/pkg/metadata"
+	"istio.io/istio/pilot/pkg/config/kube/crd"
+	"istio.io/istio/pilot/pkg/model"
+)
+
+type fakeConfigState map[string][]*mcp.Resource
+
+func (s fakeConfigState) Resources(collection string) ([]*mcp.Resource, bool) {
+	r, ok := s[collection]
+	return r, ok
+}
+
+func (s fakeConfigState) add(t *testing.T, collection, name string, labels map[string]string, body proto.Message) {
+	t.Helper()
+
+	any, err := types.MarshalAny(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s[collection] = append(s[collection], &mcp.Resource{
+		Metadata: &mcp.Metadata{Name: name, Labels: labels},
+		Body:     any,
+	})
+}
+
+var (
+	gatewaysCollection       = metadata.IstioNetworkingV1alpha3Gateways.Collection.String()
+	serviceEntriesCollection = metadata.IstioNetworkingV1alpha3Serviceentries.Collection.String()
+	servicesCollection       = metadata.K8sCoreV1Services.Collection.String()
+	podsCollection           = metadata.K8sCoreV1Pods.Collection.String()
+
+	simpleTLS = &networking.Server_TLSOptions{
+		Mode:              networking.Server_TLSOptions_SIMPLE,
+		ServerCertificate: "/etc/certs/cert.pem",
+		PrivateKey:        "/etc/certs/key.pem",
+	}
+)
+
+func httpsGateway(tls *networking.Server_TLSOptions, hosts ...string) *networking.Gateway {
+	return &networking.Gateway{
+		Selector: map[string]string{"istio": "ingressgateway"},
+		Servers: []*networking.Server{{
+			Port:  &networking.Port{Number: 443, Protocol: "HTTPS", Name: "https"},
+			Hosts: hosts,
+			Tls:   tls,
+		}},
+	}
+}
+
+func newCrossResourceState(t *testing.T) fakeConfigState {
+	state := fakeConfigState{}
+	state.add(t, gatewaysCollection, "default/gateway", nil,
+		httpsGateway(&networking.Server_TLSOptions{Mode: networking.Server_TLSOptions_PASSTHROUGH}, "a.example.com"))
+	state.add(t, gatewaysCollection, "default/updated", nil, httpsGateway(simpleTLS, "b.example.com"))
+	state.add(t, serviceEntriesCollection, "default/external", nil, &networking.ServiceEntry{Hosts: []string{"api.example.com"}})
+	state.add(t, servicesCollection, "default/reviews", nil, &corev1.ServiceSpec{Selector: map[string]string{"app": "reviews"}})
+	state.add(t, podsCollection, "default/reviews-v1", map[string]string{"app": "reviews", "version": "v1"}, &corev1.Pod{})
+	state.add(t, podsCollection, "other/reviews-v2", map[string]string{"app": "reviews", "version": "v2"}, &corev1.Pod{})
+	return state
+}
+
+func TestCheckConflicts(t *testing.T) {
+	wh := &Webhook{configState: newCrossResourceState(t), crossResourceChecks: CrossResourceChecksWarn}
+
+	for _, tc := range []struct {
+		name string
+		cfg  model.Config
+		want []string
+	}{
+		{
+			name: "gateway with different TLS",
+			cfg:  model.Config{Spec: httpsGateway(simpleTLS, "a.example.com", "c.example.com")},
+			want: []string{`gateway default/gateway binds host "a.example.com" on port 443 with different TLS settings`},
+		},
+		{
+			name: "gateway with the same TLS",
+			cfg:  model.Config{Spec: httpsGateway(simpleTLS, "b.example.com")},
+		},
+		{
+			name: "updated gateway",
+			cfg: model.Config{
+				ConfigMeta: model.ConfigMeta{Namespace: "default", Name: "updated"},
+				Spec:       httpsGateway(nil, "b.example.com"),
+			},
+		},
+		{
+			name: "gateway selecting other workloads",
+			cfg: model.Config{Spec: &networking.Gateway{
+				Selector: map[string]string{"istio": "egressgateway"},
+				Servers:  httpsGateway(simpleTLS, "a.example.com").Servers,
+			}},
+		},
+		{
+			name: "duplicate service entry host",
+			cfg:  model.Config{Spec: &networking.ServiceEntry{Hosts: []string{"www.example.com", "api.example.com"}}},
+			want: []string{`host "api.example.com" is already defined by service entry default/external`},
+		},
+		{
+			name: "destination rule subsets",
+			cfg: model.Config{
+				ConfigMeta: model.ConfigMeta{Namespace: "default"},
+				Spec: &networking.DestinationRule{
+					Host: "reviews",
+					Subsets: []*networking.Subset{
+						{Name: "v1", Labels: map[string]string{"version": "v1"}},
+						{Name: "v2", Labels: map[string]string{"version": "v2"}},
+						{Name: "all"},
+					},
+				},
+			},
+			want: []string{`subset "v2" matches no pod of service default/reviews`},
+		},
+		{
+			name: "destination rule of a service entry",
+			cfg: model.Config{Spec: &networking.DestinationRule{
+				Host:    "api.example.com",
+				Subsets: []*networking.Subset{{Name: "v2", Labels: map[string]string{"version": "v2"}}},
+			}},
+		},
+		{
+			name: "destination rule with FQDN",
+			cfg: model.Config{Spec: &networking.DestinationRule{
+				Host:    "reviews.default.svc.cluster.local",
+				Subsets: []*networking.Subset{{Name: "v3", Labels: map[string]string{"version": "v3"}}},
+			}},
+			want: []string{`subset "v3" matches no pod of service default/reviews`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := wh.checkConflicts(&tc.cfg); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Got conflicts %q, expecting %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckConflicts_UnknownPods(t *testing.T) {
+	state := newCrossResourceState(t)
+	delete(state, podsCollection)
+	wh := &Webhook{configState: state, crossResourceChecks: CrossResourceChecksReject}
+
+	cfg := &model.Config{Spec: &networking.DestinationRule{
+		Host:    "reviews.default.svc.cluster.local",
+		Subsets: []*networking.Subset{{Name: "v3", Labels: map[string]string{"version": "v3"}}},
+	}}
+	if got := wh.checkConflicts(cfg); len(got) != 0 {
+		t.Errorf("Got conflicts %q, expecting none", got)
+	}
+}
+
+func TestAdmitPilot_CrossResourceChecks(t *testing.T) {
+	config := model.Config{
+		ConfigMeta: model.ConfigMeta{Type: model.Gateway.Type, Namespace: "default", Name: "new"},
+		Spec:       httpsGateway(simpleTLS, "a.example.com"),
+	}
+	obj, err := crd.ConvertConfig(model.Gateway, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := &admissionv1beta1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Kind: "Gateway"},
+		Namespace: "default",
+		Object:    runtime.RawExtension{Raw: raw},
+		Operation: admissionv1beta1.Create,
+	}
+
+	wh, cancel := createTestWebhook(t, dummyClient, createFakeWebhookSource(), createFakeEndpointsSource(), dummyConfig)
+	defer cancel()
+	wh.descriptor = model.IstioConfigTypes
+	wh.configState = newCrossResourceState(t)
+
+	for _, tc := range []struct {
+		mode        string
+		allowed     bool
+		annotations bool
+	}{
+		{mode: CrossResourceChecksOff, allowed: true},
+		{mode: CrossResourceChecksWarn, allowed: true, annotations: true},
+		{mode: CrossResourceChecksReject, allowed: false},
+	} {
+		t.Run(tc.mode, func(t *testing.T) {
+			wh.crossResourceChecks = tc.mode
+
+			got := wh.admitPilot(request)
+			if got.Allowed != tc.allowed {
+				t.Fatalf("Got allowed %v, expecting %v: %v", got.Allowed, tc.allowed, got.Result)
+			}
+			if _, ok := got.AuditAnnotations[conflictsAuditAnnotation]; ok != tc.annotations {
+				t.Errorf("Got audit annotations %v", got.AuditAnnotations)
+			}
+		})
+	}
+}
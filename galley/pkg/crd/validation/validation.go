@@ -191,6 +191,11 @@ func (args *WebhookParameters) Validate() error {
 		if err := validatePort(int(args.Port)); err != nil {
 			errs = multierror.Append(errs, err)
 		}
+		switch args.CrossResourceChecks {
+		case CrossResourceChecksOff, CrossResourceChecksWarn, CrossResourceChecksReject:
+		default:
+			errs = multierror.Append(errs, fmt.Errorf("invalid cross-resource validation mode: %q", args.CrossResourceChecks))
+		}
 	}
 
 	return errs.ErrorOrNil()
@@ -75,6 +75,10 @@ func TestValidate(t *testing.T) {
 			wrapFunc:      func(args *WebhookParameters) { args.Port = 100000 },
 			expectedError: "port number 100000 must be in the range 1..65535",
 		},
+		"invalid cross-resource validation mode": {
+			wrapFunc:      func(args *WebhookParameters) { args.CrossResourceChecks = "deny" },
+			expectedError: `invalid cross-resource validation mode: "deny"`,
+		},
 	}
 
 	for name, scenario := range scenarios {
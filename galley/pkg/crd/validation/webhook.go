@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -120,6 +121,13 @@ type WebhookParameters struct {
 
 	// Enable galley validation mode
 	EnableValidation bool
+
+	// CrossResourceChecks is the mode of the checks of the Pilot objects against the configuration
+	// known to Galley: off, warn or reject.
+	CrossResourceChecks string
+
+	// ConfigState provides the configuration known to Galley. The cross-resource checks are disabled if nil.
+	ConfigState ConfigState
 }
 
 type createInformerWebhookSource func(cl clientset.Interface, name string) cache.ListerWatcher
@@ -158,6 +166,7 @@ func (p *WebhookParameters) String() string {
 	fmt.Fprintf(buf, "DeploymentName: %s\n", p.DeploymentName)
 	fmt.Fprintf(buf, "ServiceName: %s\n", p.ServiceName)
 	fmt.Fprintf(buf, "EnableValidation: %v\n", p.EnableValidation)
+	fmt.Fprintf(buf, "CrossResourceChecks: %s\n", p.CrossResourceChecks)
 
 	return buf.String()
 }
@@ -174,6 +183,7 @@ func DefaultArgs() *WebhookParameters {
 		ServiceName:                   "istio-galley",
 		WebhookName:                   "istio-galley",
 		EnableValidation:              true,
+		CrossResourceChecks:           CrossResourceChecksOff,
 	}
 }
 
@@ -186,6 +196,10 @@ type Webhook struct {
 	descriptor   model.ConfigDescriptor
 	domainSuffix string
 
+	// cross-resource checks
+	crossResourceChecks string
+	configState         ConfigState
+
 	// mixer
 	validator store.BackendValidator
 
@@ -258,6 +272,8 @@ func NewWebhook(p WebhookParameters) (*Webhook, error) {
 		cert:                          &pair,
 		descriptor:                    p.PilotDescriptor,
 		validator:                     p.MixerValidator,
+		crossResourceChecks:           p.CrossResourceChecks,
+		configState:                   p.ConfigState,
 		caFile:                        p.CACertFile,
 		webhookConfigFile:             p.WebhookConfigFile,
 		clientset:                     p.Clientset,
@@ -480,6 +496,22 @@ func (wh *Webhook) admitPilot(request *admissionv1beta1.AdmissionRequest) *admis
 		return toAdmissionResponse(err)
 	}
 
+	if conflicts := wh.checkConflicts(out); len(conflicts) > 0 {
+		message := strings.Join(conflicts, "; ")
+		if wh.crossResourceChecks == CrossResourceChecksReject {
+			scope.Infof("configuration conflicts with existing resources: %s", message)
+			reportValidationFailed(request, reasonConflict)
+			return toAdmissionResponse(fmt.Errorf("configuration conflicts with existing resources: %s", message))
+		}
+
+		scope.Warnf("admitting %s %s/%s conflicting with existing resources: %s", obj.Kind, out.Namespace, out.Name, message)
+		reportValidationPass(request)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed:          true,
+			AuditAnnotations: map[string]string{conflictsAuditAnnotation: message},
+		}
+	}
+
 	reportValidationPass(request)
 	return &admissionv1beta1.AdmissionResponse{Allowed: true}
 }
@@ -25,10 +25,17 @@ import (
 	"istio.io/istio/galley/pkg/runtime/log"
 )
 
-const collection = "collection"
+const (
+	collection = "collection"
+	cluster    = "cluster"
+)
 
-// CollectionTag holds the type URL for the context.
-var CollectionTag tag.Key
+var (
+	// CollectionTag holds the type URL for the context.
+	CollectionTag tag.Key
+	// ClusterTag holds the name of the cluster a source watches.
+	ClusterTag tag.Key
+)
 
 var (
 	strategyOnChangeTotal = stats.Int64(
@@ -71,6 +78,14 @@ var (
 		"galley/runtime/state/type_instances_total",
 		"The number of type instances per type URL",
 		stats.UnitDimensionless)
+	sourceClusterSynced = stats.Int64(
+		"galley/runtime/source/cluster_synced",
+		"Whether the source of a cluster is synced (1) or not (0)",
+		stats.UnitDimensionless)
+	sourceClusterEventsTotal = stats.Int64(
+		"galley/runtime/source/cluster_events_total",
+		"The number of events received from the source of a cluster",
+		stats.UnitDimensionless)
 
 	durationDistributionMs = view.Distribution(0, 1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8193, 16384, 32768, 65536,
 		131072, 262144, 524288, 1048576, 2097152, 4194304, 8388608)
@@ -124,6 +139,30 @@ func RecordStateTypeCountWithContext(ctx context.Context, count int) {
 	}
 }
 
+// RecordClusterSynced
+func RecordClusterSynced(clusterName string, synced bool) {
+	ctx, err := tag.New(context.Background(), tag.Insert(ClusterTag, clusterName))
+	if err != nil {
+		log.Scope.Errorf("Error creating monitoring context for cluster sync status: %v", err)
+		return
+	}
+	var value int64
+	if synced {
+		value = 1
+	}
+	stats.Record(ctx, sourceClusterSynced.M(value))
+}
+
+// RecordClusterEvent
+func RecordClusterEvent(clusterName string) {
+	ctx, err := tag.New(context.Background(), tag.Insert(ClusterTag, clusterName))
+	if err != nil {
+		log.Scope.Errorf("Error creating monitoring context for cluster events: %v", err)
+		return
+	}
+	stats.Record(ctx, sourceClusterEventsTotal.M(1))
+}
+
 func newView(measure stats.Measure, keys []tag.Key, aggregation *view.Aggregation) *view.View {
 	return &view.View{
 		Name:        measure.Name(),
@@ -139,9 +178,13 @@ func init() {
 	if CollectionTag, err = tag.NewKey(collection); err != nil {
 		panic(err)
 	}
+	if ClusterTag, err = tag.NewKey(cluster); err != nil {
+		panic(err)
+	}
 
 	var noKeys []tag.Key
 	collectionKeys := []tag.Key{CollectionTag}
+	clusterKeys := []tag.Key{ClusterTag}
 
 	err = view.Register(
 		newView(strategyOnTimerResetTotal, noKeys, view.Count()),
@@ -154,6 +197,8 @@ func init() {
 		newView(processorEventsPerSnapshot, noKeys, view.Distribution(0, 1, 2, 4, 8, 16, 32, 64, 128, 256)),
 		newView(stateTypeInstancesTotal, collectionKeys, view.LastValue()),
 		newView(processorSnapshotLifetimesMs, noKeys, durationDistributionMs),
+		newView(sourceClusterSynced, clusterKeys, view.LastValue()),
+		newView(sourceClusterEventsTotal, clusterKeys, view.Count()),
 	)
 
 	if err != nil {
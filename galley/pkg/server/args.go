@@ -31,6 +31,9 @@ const (
 	defaultAccessListFile   = defaultConfigMapFolder + "accesslist.yaml"
 	defaultMeshConfigFile   = defaultMeshConfigFolder + "mesh"
 	defaultDomainSuffix     = "cluster.local"
+
+	defaultSnapshotHistorySize = 10
+	defaultCanarySoakTime      = 5 * time.Minute
 )
 
 // Args contains the startup arguments to instantiate Galley.
@@ -38,6 +41,14 @@ type Args struct {
 	// The path to kube configuration file.
 	KubeConfig string
 
+	// ClusterKubeConfigs lists the clusters to merge the configuration of, as <cluster name>=<kubeconfig path>
+	// entries by decreasing precedence. KubeConfig is ignored when set.
+	ClusterKubeConfigs []string
+
+	// ClusterPrecedenceFile is the YAML file of the rules overriding the precedence of the clusters
+	// for some collections and namespaces.
+	ClusterPrecedenceFile string
+
 	// resync period to be passed to the K8s machinery.
 	ResyncPeriod time.Duration
 
@@ -109,6 +120,24 @@ type Args struct {
 
 	// keep-alive options for the MCP gRPC Server.
 	KeepAlive *keepalive.Options
+
+	// SnapshotHistorySize is the number of config snapshots kept so that they can be compared, and
+	// an older one served to the sinks. Leaving 0 disables the history.
+	SnapshotHistorySize int
+
+	// CanarySinkAnnotations lists key=value annotations selecting the sinks a new config snapshot is
+	// first served to.
+	CanarySinkAnnotations []string
+
+	// CanaryPercentage is the percentage of the sinks a new config snapshot is first served to.
+	CanaryPercentage int
+
+	// CanarySoakTime is how long a new config snapshot is served to the selected sinks without being
+	// NACK'd before it is served to all the sinks.
+	CanarySoakTime time.Duration
+
+	// State is given access to the configuration distributed by the server, if set.
+	State *State
 }
 
 // DefaultArgs allocates an Args struct initialized with Mixer's default configuration.
@@ -130,7 +159,11 @@ func DefaultArgs() *Args {
 		DisableResourceReadyCheck:   false,
 		ExcludedResourceKinds:       defaultExcludedResourceKinds(),
 		SinkMeta:                    make([]string, 0),
+		ClusterKubeConfigs:          make([]string, 0),
 		KeepAlive:                   keepalive.DefaultOption(),
+		SnapshotHistorySize:         defaultSnapshotHistorySize,
+		CanarySinkAnnotations:       make([]string, 0),
+		CanarySoakTime:              defaultCanarySoakTime,
 	}
 }
 
@@ -147,6 +180,8 @@ func (a *Args) String() string {
 	buf := &bytes.Buffer{}
 
 	_, _ = fmt.Fprintf(buf, "KubeConfig: %s\n", a.KubeConfig)
+	_, _ = fmt.Fprintf(buf, "ClusterKubeConfigs: %v\n", a.ClusterKubeConfigs)
+	_, _ = fmt.Fprintf(buf, "ClusterPrecedenceFile: %s\n", a.ClusterPrecedenceFile)
 	_, _ = fmt.Fprintf(buf, "ResyncPeriod: %v\n", a.ResyncPeriod)
 	_, _ = fmt.Fprintf(buf, "APIAddress: %s\n", a.APIAddress)
 	_, _ = fmt.Fprintf(buf, "EnableGrpcTracing: %v\n", a.EnableGRPCTracing)
@@ -173,6 +208,10 @@ func (a *Args) String() string {
 	_, _ = fmt.Fprintf(buf, "KeepAlive.MaxServerConnectionAgeGrace: %v\n", a.KeepAlive.MaxServerConnectionAgeGrace)
 	_, _ = fmt.Fprintf(buf, "KeepAlive.Time: %v\n", a.KeepAlive.Time)
 	_, _ = fmt.Fprintf(buf, "KeepAlive.Timeout: %v\n", a.KeepAlive.Timeout)
+	_, _ = fmt.Fprintf(buf, "SnapshotHistorySize: %d\n", a.SnapshotHistorySize)
+	_, _ = fmt.Fprintf(buf, "CanarySinkAnnotations: %v\n", a.CanarySinkAnnotations)
+	_, _ = fmt.Fprintf(buf, "CanaryPercentage: %d\n", a.CanaryPercentage)
+	_, _ = fmt.Fprintf(buf, "CanarySoakTime: %v\n", a.CanarySoakTime)
 
 	return buf.String()
 }
@@ -51,6 +51,14 @@ func TestDefaultArgs(t *testing.T) {
 	if a.InitialConnectionWindowSize != 1024*1024*16 {
 		t.Fatal("Default of InitialConnectionWindowSize should be 1024 * 1024 * 16")
 	}
+
+	if a.SnapshotHistorySize != defaultSnapshotHistorySize {
+		t.Fatalf("unexpected SnapshotHistorySize: %d", a.SnapshotHistorySize)
+	}
+
+	if a.CanarySoakTime != defaultCanarySoakTime {
+		t.Fatalf("unexpected CanarySoakTime: %v", a.CanarySoakTime)
+	}
 }
 
 func TestArgs_String(t *testing.T) {
@@ -46,6 +46,7 @@ import (
 	"istio.io/istio/galley/pkg/source/kube/dynamic/converter"
 	"istio.io/istio/galley/pkg/source/kube/schema"
 	"istio.io/istio/galley/pkg/source/kube/schema/check"
+	"istio.io/istio/galley/pkg/source/multicluster"
 	configz "istio.io/istio/pkg/mcp/configz/server"
 	"istio.io/istio/pkg/mcp/creds"
 	"istio.io/istio/pkg/mcp/monitoring"
@@ -128,23 +129,13 @@ func newServer(a *Args, p patchTable) (*Server, error) {
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		k, err := p.newKubeFromConfigFile(a.KubeConfig)
-		if err != nil {
-			return nil, err
-		}
-		var found []schema.ResourceSpec
-
-		if !a.DisableResourceReadyCheck {
-			found, err = p.verifyResourceTypesPresence(k, sourceSchema.All())
-		} else {
-			found, err = p.findSupportedResources(k, sourceSchema.All())
-		}
+	} else if len(a.ClusterKubeConfigs) > 0 {
+		src, err = newMultiClusterSource(a, p, sourceSchema, converterCfg)
 		if err != nil {
 			return nil, err
 		}
-		sourceSchema = schema.New(found...)
-		src, err = p.newSource(k, a.ResyncPeriod, sourceSchema, converterCfg)
+	} else {
+		src, err = newKubeSource(a, p, a.KubeConfig, sourceSchema, converterCfg)
 		if err != nil {
 			return nil, err
 		}
@@ -158,7 +149,17 @@ func newServer(a *Args, p patchTable) (*Server, error) {
 		Schema:                   types,
 		SynthesizeServiceEntries: a.EnableServiceDiscovery,
 	}
-	distributor := snapshot.New(groups.IndexFunction)
+	distributor := snapshot.NewWithHistory(groups.IndexFunction, a.SnapshotHistorySize)
+	if len(a.CanarySinkAnnotations) > 0 || a.CanaryPercentage > 0 {
+		canaryOptions, err := getCanaryOptions(a)
+		if err != nil {
+			return nil, err
+		}
+		distributor.SetCanaryOptions(canaryOptions)
+	}
+	if a.State != nil {
+		a.State.set(distributor)
+	}
 	s.processor = runtime.NewProcessor(src, distributor, &processorCfg)
 
 	var grpcOptions []grpc.ServerOption
@@ -239,6 +240,74 @@ func newServer(a *Args, p patchTable) (*Server, error) {
 	return s, nil
 }
 
+func newKubeSource(a *Args, p patchTable, kubeConfig string, sourceSchema *schema.Instance,
+	converterCfg *converter.Config) (runtime.Source, error) {
+	k, err := p.newKubeFromConfigFile(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	var found []schema.ResourceSpec
+
+	if !a.DisableResourceReadyCheck {
+		found, err = p.verifyResourceTypesPresence(k, sourceSchema.All())
+	} else {
+		found, err = p.findSupportedResources(k, sourceSchema.All())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p.newSource(k, a.ResyncPeriod, schema.New(found...), converterCfg)
+}
+
+// newMultiClusterSource creates a source merging the resources of the clusters of ClusterKubeConfigs.
+func newMultiClusterSource(a *Args, p patchTable, sourceSchema *schema.Instance,
+	converterCfg *converter.Config) (runtime.Source, error) {
+	var rules []multicluster.Rule
+	if a.ClusterPrecedenceFile != "" {
+		var err error
+		if rules, err = multicluster.ReadRules(a.ClusterPrecedenceFile); err != nil {
+			return nil, err
+		}
+	}
+
+	clusters := make([]multicluster.Cluster, 0, len(a.ClusterKubeConfigs))
+	for _, c := range a.ClusterKubeConfigs {
+		parts := strings.SplitN(c, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid cluster kubeconfig %q, expecting <cluster name>=<kubeconfig path>", c)
+		}
+
+		src, err := newKubeSource(a, p, parts[1], sourceSchema, converterCfg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to watch cluster %q: %v", parts[0], err)
+		}
+		clusters = append(clusters, multicluster.Cluster{Name: parts[0], Source: src})
+	}
+
+	return multicluster.New(clusters, rules)
+}
+
+func getCanaryOptions(a *Args) (*snapshot.CanaryOptions, error) {
+	if a.CanaryPercentage < 0 || a.CanaryPercentage > 100 {
+		return nil, fmt.Errorf("canaryPercentage must be between 0 and 100: %d", a.CanaryPercentage)
+	}
+
+	annotations := make(map[string]string, len(a.CanarySinkAnnotations))
+	for _, v := range a.CanarySinkAnnotations {
+		kv := strings.Split(v, "=")
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("canarySinkAnnotations not in key=value format: %v", v)
+		}
+		annotations[kv[0]] = kv[1]
+	}
+
+	return &snapshot.CanaryOptions{
+		SinkAnnotations: annotations,
+		Percentage:      a.CanaryPercentage,
+		SoakTime:        a.CanarySoakTime,
+	}, nil
+}
+
 func getSourceSchema(a *Args) *schema.Instance {
 	b := schema.NewBuilder()
 	for _, spec := range kubeMeta.Types.All() {
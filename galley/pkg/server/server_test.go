@@ -69,6 +69,20 @@ loop:
 		case 4:
 			args.ConfigPath = "aaa"
 			p.fsNew = func(string, *schema.Instance, *converter.Config) (runtime.Source, error) { return nil, e }
+		case 5:
+			args.ClusterKubeConfigs = []string{"east"}
+		case 6:
+			args.ClusterKubeConfigs = []string{"east=/kube/east"}
+			p.newKubeFromConfigFile = func(string) (client.Interfaces, error) { return nil, e }
+		case 7:
+			args.ClusterKubeConfigs = []string{"east=/kube/east"}
+			args.ClusterPrecedenceFile = "/missing/precedence.yaml"
+		case 8:
+			args.ClusterKubeConfigs = []string{"east=/kube/east", "east=/kube/west"}
+		case 9:
+			args.CanarySinkAnnotations = []string{"canary"}
+		case 10:
+			args.CanaryPercentage = 101
 		default:
 			break loop
 		}
@@ -151,6 +165,57 @@ func TestNewServer(t *testing.T) {
 	}
 }
 
+func TestNewServer_MultiCluster(t *testing.T) {
+	args := DefaultArgs()
+	args.APIAddress = "tcp://0.0.0.0:0"
+	args.Insecure = true
+	args.ClusterKubeConfigs = []string{"east=/kube/east", "west=/kube/west"}
+
+	p := defaultPatchTable()
+	var gotKubeConfigs []string
+	p.newKubeFromConfigFile = func(kubeConfig string) (client.Interfaces, error) {
+		gotKubeConfigs = append(gotKubeConfigs, kubeConfig)
+		return mock.NewKube(), nil
+	}
+	p.newSource = func(client.Interfaces, time.Duration, *schema.Instance, *converter.Config) (runtime.Source, error) {
+		return runtime.NewInMemorySource(), nil
+	}
+	p.mcpMetricReporter = func(s string) monitoring.Reporter {
+		return mcptestmon.NewInMemoryStatsContext()
+	}
+	p.newMeshConfigCache = func(path string) (meshconfig.Cache, error) { return meshconfig.NewInMemory(), nil }
+	p.verifyResourceTypesPresence = func(_ client.Interfaces, specs []schema.ResourceSpec) ([]schema.ResourceSpec, error) {
+		return specs, nil
+	}
+
+	s, err := newServer(args, p)
+	if err != nil {
+		t.Fatalf("Unexpected error creating service: %v", err)
+	}
+	_ = s.Close()
+
+	if len(gotKubeConfigs) != 2 || gotKubeConfigs[0] != "/kube/east" || gotKubeConfigs[1] != "/kube/west" {
+		t.Fatalf("wrong kubeconfigs loaded: got %v", gotKubeConfigs)
+	}
+}
+
+func TestGetCanaryOptions(t *testing.T) {
+	args := DefaultArgs()
+	args.CanarySinkAnnotations = []string{"rollout=canary", "zone="}
+	args.CanaryPercentage = 10
+
+	o, err := getCanaryOptions(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(o.SinkAnnotations) != 2 || o.SinkAnnotations["rollout"] != "canary" || o.SinkAnnotations["zone"] != "" {
+		t.Fatalf("wrong sink annotations: %v", o.SinkAnnotations)
+	}
+	if o.Percentage != 10 || o.SoakTime != defaultCanarySoakTime {
+		t.Fatalf("wrong options: %+v", o)
+	}
+}
+
 func TestServer_Basic(t *testing.T) {
 	p := defaultPatchTable()
 	mk := mock.NewKube()
@@ -0,0 +1,57 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+
+	mcp "istio.io/api/mcp/v1alpha1"
+	"istio.io/istio/galley/pkg/runtime/groups"
+	"istio.io/istio/pkg/mcp/snapshot"
+)
+
+// State gives the other components of the process, such as the validation webhook, access to the
+// configuration distributed by the server. It holds nothing until the server is created.
+type State struct {
+	mu    sync.RWMutex
+	cache *snapshot.Cache
+}
+
+// NewState returns an empty State, to be passed to the server in its Args.
+func NewState() *State {
+	return &State{}
+}
+
+func (s *State) set(cache *snapshot.Cache) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = cache
+}
+
+// Resources returns the latest resources of a collection, and whether the collection is distributed.
+func (s *State) Resources(collection string) ([]*mcp.Resource, bool) {
+	s.mu.RLock()
+	cache := s.cache
+	s.mu.RUnlock()
+
+	if cache == nil {
+		return nil, false
+	}
+	sn := cache.Snapshot(groups.IndexFunction(collection, nil))
+	if sn == nil || sn.Version(collection) == "" {
+		return nil, false
+	}
+	return sn.Resources(collection), true
+}
@@ -0,0 +1,56 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"istio.io/istio/galley/pkg/metadata"
+	"istio.io/istio/galley/pkg/runtime/groups"
+	"istio.io/istio/pkg/mcp/snapshot"
+)
+
+func TestState(t *testing.T) {
+	gateways := metadata.IstioNetworkingV1alpha3Gateways.Collection.String()
+
+	s := NewState()
+	if _, ok := s.Resources(gateways); ok {
+		t.Fatal("Got resources, expecting none before the server is created")
+	}
+
+	cache := snapshot.New(groups.IndexFunction)
+	s.set(cache)
+	if _, ok := s.Resources(gateways); ok {
+		t.Fatal("Got resources, expecting none before a snapshot is set")
+	}
+
+	b := snapshot.NewInMemoryBuilder()
+	b.SetVersion(gateways, "1")
+	if err := b.SetEntry(gateways, "default/gateway", "v1", time.Now(), nil, nil, &types.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+	cache.SetSnapshot(groups.Default, b.Build())
+
+	resources, ok := s.Resources(gateways)
+	if !ok || len(resources) != 1 || resources[0].Metadata.Name != "default/gateway" {
+		t.Errorf("Got %v, %v, expecting the gateway", resources, ok)
+	}
+	if _, ok := s.Resources(metadata.K8sCoreV1Pods.Collection.String()); ok {
+		t.Error("Got pods, expecting the collection to be unknown")
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicluster
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+)
+
+// Rule overrides the precedence of the clusters for the resources of a collection and namespace.
+type Rule struct {
+	// Collection of the resources the rule applies to, all collections if empty.
+	Collection string `json:"collection,omitempty"`
+
+	// Namespace of the resources the rule applies to, all namespaces if empty.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Clusters by decreasing precedence. The clusters which aren't listed have a lower precedence,
+	// in their configured order.
+	Clusters []string `json:"clusters"`
+}
+
+type rules struct {
+	Rules []Rule `json:"rules"`
+}
+
+func (r *Rule) matches(collection, namespace string) bool {
+	return (r.Collection == "" || r.Collection == collection) && (r.Namespace == "" || r.Namespace == namespace)
+}
+
+// ReadRules reads precedence rules from a YAML file. The first rule matching a resource applies.
+func ReadRules(path string) ([]Rule, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var r rules
+	if err = yaml.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("unable to parse the cluster precedence rules in %s: %v", path, err)
+	}
+	return r.Rules, nil
+}
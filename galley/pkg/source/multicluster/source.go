@@ -0,0 +1,243 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicluster
+
+import (
+	"fmt"
+	"sync"
+
+	"istio.io/istio/galley/pkg/runtime"
+	"istio.io/istio/galley/pkg/runtime/monitoring"
+	"istio.io/istio/galley/pkg/runtime/resource"
+	"istio.io/istio/galley/pkg/source/kube/log"
+)
+
+// ClusterAnnotation is the annotation added to the merged resources, holding the name of the cluster they come from.
+const ClusterAnnotation = "galley.istio.io/cluster"
+
+// Cluster is a named source of configuration.
+type Cluster struct {
+	Name   string
+	Source runtime.Source
+}
+
+// source merges the resources of several clusters. When a resource with the same key is found in several
+// clusters, the resource of the cluster with the highest precedence is used.
+type source struct {
+	clusters []Cluster
+	rules    []Rule
+
+	// index of the clusters in the configuration, by name
+	order map[string]int
+
+	mu      sync.Mutex
+	handler resource.EventHandler
+	synced  map[string]bool
+	// the entries of each cluster, by key
+	entries map[resource.Key]map[string]resource.Entry
+	// the cluster of the entry currently published, by key
+	published map[resource.Key]string
+}
+
+var _ runtime.Source = &source{}
+
+// New returns a source merging the resources of the given clusters, listed by decreasing precedence. The
+// precedence can be overridden for some collections and namespaces with rules.
+func New(clusters []Cluster, rules []Rule) (runtime.Source, error) {
+	if len(clusters) == 0 {
+		return nil, fmt.Errorf("no cluster to watch")
+	}
+
+	order := make(map[string]int, len(clusters))
+	for i, c := range clusters {
+		if c.Name == "" {
+			return nil, fmt.Errorf("cluster %d has no name", i)
+		}
+		if _, ok := order[c.Name]; ok {
+			return nil, fmt.Errorf("duplicate cluster %q", c.Name)
+		}
+		order[c.Name] = i
+	}
+	for i, r := range rules {
+		if len(r.Clusters) == 0 {
+			return nil, fmt.Errorf("precedence rule %d lists no cluster", i)
+		}
+		for _, name := range r.Clusters {
+			if _, ok := order[name]; !ok {
+				return nil, fmt.Errorf("precedence rule %d refers to unknown cluster %q", i, name)
+			}
+		}
+	}
+
+	return &source{
+		clusters: clusters,
+		rules:    rules,
+		order:    order,
+	}, nil
+}
+
+// Start implements runtime.Source. A FullSync event is sent once all the clusters are synced.
+func (s *source) Start(handler resource.EventHandler) error {
+	s.mu.Lock()
+	if s.handler != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("already started")
+	}
+	s.handler = handler
+	s.synced = make(map[string]bool, len(s.clusters))
+	s.entries = make(map[resource.Key]map[string]resource.Entry)
+	s.published = make(map[resource.Key]string)
+	s.mu.Unlock()
+
+	for _, c := range s.clusters {
+		monitoring.RecordClusterSynced(c.Name, false)
+
+		name := c.Name
+		if err := c.Source.Start(func(e resource.Event) { s.handle(name, e) }); err != nil {
+			return fmt.Errorf("unable to start the source of cluster %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// Stop implements runtime.Source.
+func (s *source) Stop() {
+	for _, c := range s.clusters {
+		c.Source.Stop()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = nil
+}
+
+func (s *source) handle(cluster string, e resource.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.handler == nil {
+		return
+	}
+
+	switch e.Kind {
+	case resource.FullSync:
+		if s.synced[cluster] {
+			return
+		}
+		s.synced[cluster] = true
+		monitoring.RecordClusterSynced(cluster, true)
+		log.Scope.Infof("Cluster %q is synced (%d/%d)", cluster, len(s.synced), len(s.clusters))
+		if len(s.synced) == len(s.clusters) {
+			s.handler(resource.FullSyncEvent)
+		}
+
+	case resource.Added, resource.Updated:
+		monitoring.RecordClusterEvent(cluster)
+		key := e.Entry.ID.Key
+		entries, ok := s.entries[key]
+		if !ok {
+			entries = make(map[string]resource.Entry)
+			s.entries[key] = entries
+		}
+		entries[cluster] = e.Entry
+		s.publish(key, cluster)
+
+	case resource.Deleted:
+		monitoring.RecordClusterEvent(cluster)
+		key := e.Entry.ID.Key
+		delete(s.entries[key], cluster)
+		if len(s.entries[key]) == 0 {
+			delete(s.entries, key)
+		}
+		s.publish(key, cluster)
+
+	default:
+		log.Scope.Errorf("Unknown event kind from cluster %q: %v", cluster, e.Kind)
+	}
+}
+
+// publish sends the event for the entry of the cluster with the highest precedence for a key, once
+// the entry of a cluster changed.
+func (s *source) publish(key resource.Key, changed string) {
+	winner := ""
+	for cluster := range s.entries[key] {
+		if winner == "" || s.rank(key, cluster) < s.rank(key, winner) {
+			winner = cluster
+		}
+	}
+
+	previous, published := s.published[key]
+	if winner == "" {
+		if published {
+			delete(s.published, key)
+			s.handler(resource.Event{Kind: resource.Deleted, Entry: resource.Entry{ID: resource.VersionedKey{Key: key}}})
+		}
+		return
+	}
+
+	if published && previous == winner && winner != changed {
+		// the resource of a cluster with a lower precedence changed.
+		return
+	}
+	if published && previous != winner {
+		log.Scope.Debugf("Resource %v of cluster %q overrides the one of cluster %q", key, winner, previous)
+	}
+
+	entry := s.entries[key][winner]
+	s.published[key] = winner
+
+	kind := resource.Updated
+	if !published {
+		kind = resource.Added
+	}
+	s.handler(resource.Event{Kind: kind, Entry: withCluster(entry, winner)})
+}
+
+// rank returns the precedence of a cluster for a key, lower ranks taking precedence.
+func (s *source) rank(key resource.Key, cluster string) int {
+	if r := s.rule(key); r != nil {
+		for i, name := range r.Clusters {
+			if name == cluster {
+				return i
+			}
+		}
+		return len(r.Clusters) + s.order[cluster]
+	}
+	return s.order[cluster]
+}
+
+func (s *source) rule(key resource.Key) *Rule {
+	namespace, _ := key.FullName.InterpretAsNamespaceAndName()
+	for i := range s.rules {
+		if s.rules[i].matches(key.Collection.String(), namespace) {
+			return &s.rules[i]
+		}
+	}
+	return nil
+}
+
+// withCluster returns the entry annotated with its cluster. Its version is qualified with the cluster, as
+// the versions of different clusters are unrelated.
+func withCluster(e resource.Entry, cluster string) resource.Entry {
+	annotations := make(resource.Annotations, len(e.Metadata.Annotations)+1)
+	for k, v := range e.Metadata.Annotations {
+		annotations[k] = v
+	}
+	annotations[ClusterAnnotation] = cluster
+
+	e.Metadata.Annotations = annotations
+	e.ID.Version = resource.Version(cluster + "/" + string(e.ID.Version))
+	return e
+}
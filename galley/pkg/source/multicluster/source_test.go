@@ -0,0 +1,191 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+
+	"istio.io/istio/galley/pkg/runtime"
+	"istio.io/istio/galley/pkg/runtime/resource"
+	"istio.io/istio/galley/pkg/testing/resources"
+)
+
+type recorder struct {
+	events []resource.Event
+}
+
+func (r *recorder) handle(e resource.Event) {
+	r.events = append(r.events, e)
+}
+
+// next returns the kind, cluster and version of the only event received since the last call.
+func (r *recorder) next(t *testing.T) (resource.EventKind, string, resource.Version) {
+	t.Helper()
+
+	if len(r.events) != 1 {
+		t.Fatalf("Got events %v, expecting one", r.events)
+	}
+	e := r.events[0]
+	r.events = nil
+	return e.Kind, e.Entry.Metadata.Annotations[ClusterAnnotation], e.Entry.ID.Version
+}
+
+func newClusters(names ...string) ([]Cluster, map[string]*runtime.InMemorySource) {
+	clusters := make([]Cluster, 0, len(names))
+	sources := make(map[string]*runtime.InMemorySource)
+	for _, name := range names {
+		src := runtime.NewInMemorySource()
+		sources[name] = src
+		clusters = append(clusters, Cluster{Name: name, Source: src})
+	}
+	return clusters, sources
+}
+
+func TestNew_Errors(t *testing.T) {
+	clusters, _ := newClusters("east", "west")
+	for _, tc := range []struct {
+		name     string
+		clusters []Cluster
+		rules    []Rule
+	}{
+		{name: "no cluster"},
+		{name: "no name", clusters: []Cluster{{Source: runtime.NewInMemorySource()}}},
+		{name: "duplicate", clusters: append(clusters, clusters[0])},
+		{name: "empty rule", clusters: clusters, rules: []Rule{{Namespace: "ns"}}},
+		{name: "unknown cluster", clusters: clusters, rules: []Rule{{Clusters: []string{"north"}}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := New(tc.clusters, tc.rules); err == nil {
+				t.Fatal("Got no error, expecting the configuration to be rejected")
+			}
+		})
+	}
+}
+
+func TestSource(t *testing.T) {
+	info, _ := resources.TestSchema.Lookup("empty")
+	k1 := resource.Key{Collection: info.Collection, FullName: resource.FullNameFromNamespaceAndName("ns1", "r1")}
+	k2 := resource.Key{Collection: info.Collection, FullName: resource.FullNameFromNamespaceAndName("ns2", "r2")}
+
+	clusters, sources := newClusters("east", "west")
+	sources["west"].Set(k1, resource.Metadata{}, &types.Empty{})
+
+	// the resources of the ns2 namespace of the west cluster take precedence.
+	src, err := New(clusters, []Rule{{Collection: info.Collection.String(), Namespace: "ns2", Clusters: []string{"west"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &recorder{}
+	if err = src.Start(r.handle); err != nil {
+		t.Fatal(err)
+	}
+	defer src.Stop()
+
+	if err = src.Start(r.handle); err == nil {
+		t.Error("Got no error, expecting the source to be already started")
+	}
+
+	// the full sync is sent once both clusters are synced.
+	if len(r.events) != 2 || r.events[0].Kind != resource.Added || r.events[1].Kind != resource.FullSync {
+		t.Fatalf("Got events %v, expecting the west resource followed by a full sync", r.events)
+	}
+	r.events = nil
+
+	// east takes precedence by default.
+	sources["east"].Set(k1, resource.Metadata{Annotations: resource.Annotations{"a": "b"}}, &types.Empty{})
+	if len(r.events) == 1 {
+		if a := r.events[0].Entry.Metadata.Annotations; a["a"] != "b" || a[ClusterAnnotation] != "east" {
+			t.Errorf("Got annotations %v, expecting the cluster to be added", a)
+		}
+	}
+	if kind, cluster, version := r.next(t); kind != resource.Updated || cluster != "east" || version != "east/v1" {
+		t.Errorf("Got %v from %s @%s, expecting an update from east", kind, cluster, version)
+	}
+
+	// the west update is hidden.
+	sources["west"].Set(k1, resource.Metadata{}, &types.Empty{})
+	if len(r.events) != 0 {
+		t.Errorf("Got events %v, expecting none", r.events)
+	}
+
+	// the west resource is published again once east deletes it.
+	sources["east"].Delete(k1)
+	if kind, cluster, version := r.next(t); kind != resource.Updated || cluster != "west" || version != "west/v2" {
+		t.Errorf("Got %v from %s @%s, expecting an update from west", kind, cluster, version)
+	}
+
+	sources["west"].Delete(k1)
+	if kind, _, _ := r.next(t); kind != resource.Deleted {
+		t.Errorf("Got %v, expecting a deletion", kind)
+	}
+
+	// west takes precedence in the ns2 namespace.
+	sources["west"].Set(k2, resource.Metadata{}, &types.Empty{})
+	if kind, cluster, _ := r.next(t); kind != resource.Added || cluster != "west" {
+		t.Errorf("Got %v from %s, expecting an addition from west", kind, cluster)
+	}
+	sources["east"].Set(k2, resource.Metadata{}, &types.Empty{})
+	if len(r.events) != 0 {
+		t.Errorf("Got events %v, expecting none", r.events)
+	}
+}
+
+func TestReadRules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "multicluster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "precedence.yaml")
+	if err = ioutil.WriteFile(path, []byte(`
+rules:
+- collection: istio/networking/v1alpha3/virtualservices
+  namespace: payments
+  clusters: [west, east]
+- clusters: [east]
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := ReadRules(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Rule{
+		{Collection: "istio/networking/v1alpha3/virtualservices", Namespace: "payments", Clusters: []string{"west", "east"}},
+		{Clusters: []string{"east"}},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("Got rules %+v, expecting %+v", rules, want)
+	}
+
+	if err = ioutil.WriteFile(path, []byte("rules: {"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ReadRules(path); err == nil {
+		t.Error("Got no error, expecting the rules to be invalid")
+	}
+	if _, err = ReadRules(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("Got no error, expecting the file to be missing")
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"istio.io/istio/istioctl/pkg/analyze"
+	"istio.io/istio/istioctl/pkg/util/handlers"
+)
+
+func analyzeCmd() *cobra.Command {
+	var (
+		filenames     []string
+		useKube       bool
+		analyzerNames []string
+		domainSuffix  string
+	)
+
+	c := &cobra.Command{
+		Use:   "analyze [-f FILENAME]... [options]",
+		Short: "Analyze Istio configuration for mistakes spanning multiple resources",
+		Long: fmt.Sprintf(`
+Analyzes Istio configuration and reports mistakes that span multiple resources, which are
+not caught by 'istioctl validate'. Resources are read from files, from the live cluster, or
+both. Kubernetes Services, Pods and Deployments in the input are used to check references
+to services and workloads.
+
+Available analyzers: %v
+`, analyze.Names()),
+		Example: `
+# Analyze the resources in the cluster
+istioctl analyze
+
+# Analyze the resources in files
+istioctl analyze -f samples/bookinfo/networking/virtual-service-all-v1.yaml -f bookinfo.yaml
+
+# Analyze the resources in a file, as applied on top of the cluster resources
+istioctl analyze -k -f samples/bookinfo/networking/virtual-service-all-v1.yaml
+`,
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			selected := analyze.All()
+			if len(analyzerNames) > 0 {
+				var err error
+				if selected, err = analyze.Select(analyzerNames); err != nil {
+					return err
+				}
+			}
+
+			ctx := analyze.NewContext(domainSuffix)
+			if useKube || len(filenames) == 0 {
+				configClient, err := clientFactory()
+				if err != nil {
+					return err
+				}
+				kubeClient, err := createInterface(kubeconfig)
+				if err != nil {
+					return err
+				}
+				if err = ctx.AddCluster(configClient, kubeClient, namespace); err != nil {
+					return err
+				}
+			}
+			for _, filename := range filenames {
+				if err := addFile(ctx, filename); err != nil {
+					return err
+				}
+			}
+
+			return writeMessages(c.OutOrStdout(), analyze.Analyze(ctx, selected))
+		},
+	}
+
+	flags := c.PersistentFlags()
+	flags.StringSliceVarP(&filenames, "filename", "f", nil,
+		"Names of files to analyze, or '-' for stdin. The live cluster is analyzed if no file is specified")
+	flags.BoolVarP(&useKube, "use-kube", "k", false,
+		"Also analyze the resources of the live cluster when files are specified")
+	flags.StringSliceVar(&analyzerNames, "analyzers", nil, "Names of the analyzers to run, all by default")
+	flags.StringVar(&domainSuffix, "domain", "cluster.local", "DNS domain suffix")
+
+	return c
+}
+
+func addFile(ctx *analyze.Context, filename string) error {
+	var reader io.Reader = os.Stdin
+	if filename != "-" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return fmt.Errorf("cannot read file %q: %v", filename, err)
+		}
+		defer f.Close() // nolint: errcheck
+		reader = f
+	}
+	if err := ctx.AddYAML(reader, handlers.HandleNamespace(namespace, defaultNamespace)); err != nil {
+		return fmt.Errorf("%s: %v", filename, err)
+	}
+	return nil
+}
+
+// writeMessages prints the messages, and returns an error if any of them is an error, so
+// the command can be used in scripts.
+func writeMessages(w io.Writer, messages []analyze.Message) error {
+	if len(messages) == 0 {
+		fmt.Fprintln(w, "No issues found.")
+		return nil
+	}
+
+	errors := 0
+	for _, m := range messages {
+		fmt.Fprintln(w, m)
+		if m.Type.Level == analyze.Error {
+			errors++
+		}
+	}
+	if errors > 0 {
+		return fmt.Errorf("analysis found %d error(s)", errors)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/istioctl/pkg/analyze"
+	"istio.io/istio/istioctl/pkg/describe"
+	"istio.io/istio/istioctl/pkg/util/configdump"
+	"istio.io/istio/istioctl/pkg/util/handlers"
+)
+
+// proxyContainerName is the name of the injected sidecar container.
+const proxyContainerName = "istio-proxy"
+
+func describeCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "describe",
+		Short: "Describe the Istio configuration in force for a resource",
+	}
+	c.AddCommand(describePodCmd())
+	return c
+}
+
+func describePodCmd() *cobra.Command {
+	var domainSuffix string
+
+	c := &cobra.Command{
+		Use:     "pod <pod-name>[.<namespace>]",
+		Aliases: []string{"po"},
+		Short:   "Describe the Istio configuration in force for a pod",
+		Long: `
+Describes the services a pod belongs to and, for each of them, the DestinationRule subsets
+and VirtualServices that apply, the mTLS mode in force on each port, and the RBAC policies
+granting access to it. Results are derived from the config known to Pilot, and checked
+against the config dump of the pod's Envoy.
+`,
+		Example: `istioctl describe pod productpage-v1-c7765c886-7zzd4.default`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			podName, ns := handlers.InferPodInfo(args[0], handlers.HandleNamespace(namespace, defaultNamespace))
+
+			kubeClient, err := createInterface(kubeconfig)
+			if err != nil {
+				return err
+			}
+			pod, err := kubeClient.CoreV1().Pods(ns).Get(podName, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("cannot get pod %s.%s: %v", podName, ns, err)
+			}
+			services, err := kubeClient.CoreV1().Services(ns).List(metav1.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("cannot list services: %v", err)
+			}
+
+			configClient, err := clientFactory()
+			if err != nil {
+				return err
+			}
+			ctx := analyze.NewContext(domainSuffix)
+			if err = ctx.AddCluster(configClient, kubeClient, ""); err != nil {
+				return err
+			}
+
+			var dump *configdump.Wrapper
+			if hasSidecar(pod) {
+				if dump, err = envoyConfigDump(podName, ns); err != nil {
+					return err
+				}
+			}
+
+			d, err := describe.Describe(pod, services.Items, ctx, dump)
+			if err != nil {
+				return err
+			}
+			describe.Print(c.OutOrStdout(), d)
+			return nil
+		},
+	}
+
+	c.PersistentFlags().StringVar(&domainSuffix, "domain", "cluster.local", "DNS domain suffix")
+	return c
+}
+
+func hasSidecar(pod *v1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == proxyContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+func envoyConfigDump(podName, ns string) (*configdump.Wrapper, error) {
+	execClient, err := clientExecFactory(kubeconfig, configContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create k8s client: %v", err)
+	}
+	debug, err := execClient.EnvoyDo(podName, ns, "GET", "config_dump", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute command on envoy: %v", err)
+	}
+	dump := &configdump.Wrapper{}
+	if err = json.Unmarshal(debug, dump); err != nil {
+		return nil, fmt.Errorf("cannot parse the Envoy config dump: %v", err)
+	}
+	return dump, nil
+}
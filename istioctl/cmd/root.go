@@ -123,6 +123,8 @@ debug and diagnose their Istio mesh.
 	rootCmd.AddCommand(contextCmd)
 
 	rootCmd.AddCommand(validate.NewValidateCommand(&istioNamespace))
+	rootCmd.AddCommand(analyzeCmd())
+	rootCmd.AddCommand(describeCmd())
 
 	return rootCmd
 }
@@ -0,0 +1,177 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package analyze checks Istio configuration for mistakes spanning multiple resources,
+// which are not caught when validating each resource on its own.
+package analyze
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Level is the severity of a message.
+type Level int
+
+const (
+	// Info messages are informational only.
+	Info Level = iota
+	// Warning messages are likely mistakes, which may be intended.
+	Warning
+	// Error messages are mistakes that break traffic.
+	Error
+)
+
+func (l Level) String() string {
+	switch l {
+	case Info:
+		return "Info"
+	case Warning:
+		return "Warn"
+	case Error:
+		return "Error"
+	default:
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+}
+
+// MessageType describes a kind of message. Codes are stable, so they can be used in
+// scripts and documentation.
+type MessageType struct {
+	Code     string
+	Level    Level
+	template string
+}
+
+// Message is a problem found by an analyzer.
+type Message struct {
+	Type *MessageType
+
+	// Resource is the path of the resource the message applies to, in the form
+	// Kind/namespace/name.
+	Resource string
+
+	// Field is the path of the field within the resource, if known.
+	Field string
+
+	Text string
+}
+
+var (
+	// ReferencedSubsetNotFound is reported for routes to subsets not defined by any DestinationRule.
+	ReferencedSubsetNotFound = &MessageType{"IST0101", Error, "subset %q of host %q is not defined by any DestinationRule"}
+
+	// GatewaySelectorNotMatched is reported for Gateways that select no workload.
+	GatewaySelectorNotMatched = &MessageType{"IST0102", Warning, "selector %s matches no workload"}
+
+	// ConflictingMTLS is reported for DestinationRules whose TLS mode is not accepted by the
+	// destination, according to its authentication policy.
+	ConflictingMTLS = &MessageType{"IST0103", Error,
+		"TLS mode %s for host %q conflicts with %s, which %s"}
+
+	// SidecarEgressHostNotFound is reported for Sidecar egress hosts matching no service.
+	SidecarEgressHostNotFound = &MessageType{"IST0104", Warning, "egress host %q matches no service"}
+)
+
+// NewMessage creates a message of the type, formatting the arguments with the type template.
+func NewMessage(t *MessageType, resource, field string, args ...interface{}) Message {
+	return Message{
+		Type:     t,
+		Resource: resource,
+		Field:    field,
+		Text:     fmt.Sprintf(t.template, args...),
+	}
+}
+
+func (m Message) String() string {
+	origin := m.Resource
+	if m.Field != "" {
+		origin += " " + m.Field
+	}
+	return fmt.Sprintf("%s [%s] (%s) %s", m.Type.Level, m.Type.Code, origin, m.Text)
+}
+
+// Analyzer checks a set of resources.
+type Analyzer interface {
+	// Name is a unique, stable name used to select the analyzer.
+	Name() string
+
+	// Analyze returns the problems found in the resources of the context.
+	Analyze(ctx *Context) []Message
+}
+
+var analyzers = []Analyzer{
+	&subsetAnalyzer{},
+	&gatewayAnalyzer{},
+	&mtlsAnalyzer{},
+	&sidecarAnalyzer{},
+}
+
+// All returns all the analyzers.
+func All() []Analyzer {
+	out := make([]Analyzer, len(analyzers))
+	copy(out, analyzers)
+	return out
+}
+
+// Select returns the analyzers with the given names.
+func Select(names []string) ([]Analyzer, error) {
+	out := make([]Analyzer, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, a := range analyzers {
+			if a.Name() == name {
+				out = append(out, a)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown analyzer %q, must be one of %s", name, strings.Join(Names(), ", "))
+		}
+	}
+	return out, nil
+}
+
+// Names returns the names of all the analyzers.
+func Names() []string {
+	out := make([]string, 0, len(analyzers))
+	for _, a := range analyzers {
+		out = append(out, a.Name())
+	}
+	return out
+}
+
+// Analyze runs the analyzers, and returns their messages sorted by resource and code.
+func Analyze(ctx *Context, selected []Analyzer) []Message {
+	var out []Message
+	for _, a := range selected {
+		out = append(out, a.Analyze(ctx)...)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Resource != out[j].Resource {
+			return out[i].Resource < out[j].Resource
+		}
+		if out[i].Type.Code != out[j].Type.Code {
+			return out[i].Type.Code < out[j].Type.Code
+		}
+		return out[i].Field < out[j].Field
+	})
+	return out
+}
+
+func resourcePath(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
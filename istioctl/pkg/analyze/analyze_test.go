@@ -0,0 +1,88 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func loadContext(t *testing.T, files ...string) *Context {
+	t.Helper()
+	ctx := NewContext("cluster.local")
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ctx.AddYAML(f, "default")
+		_ = f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ctx
+}
+
+func TestAnalyze(t *testing.T) {
+	ctx := loadContext(t, "testdata/mesh.yaml", "testdata/config.yaml")
+
+	got := []string{}
+	for _, m := range Analyze(ctx, All()) {
+		got = append(got, m.Type.Code+" "+m.Resource+" "+m.Field)
+	}
+	want := []string{
+		"IST0103 DestinationRule/default/ratings spec.trafficPolicy.tls",
+		"IST0103 DestinationRule/default/reviews spec.trafficPolicy.tls",
+		"IST0102 Gateway/default/typo spec.selector",
+		"IST0104 Sidecar/default/default spec.egress[0].hosts[1]",
+		"IST0101 VirtualService/default/reviews spec.http[0].route[1].destination",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Analyze() => got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestAnalyzeWithoutServicesOrWorkloads(t *testing.T) {
+	ctx := loadContext(t, "testdata/config.yaml")
+
+	selected, err := Select([]string{"gateway.selector", "sidecar.egresshosts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if messages := Analyze(ctx, selected); len(messages) != 0 {
+		t.Errorf("Analyze() => got %v, want no message without services and workloads", messages)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	if _, err := Select([]string{"unknown"}); err == nil {
+		t.Error("Select() => expected error for unknown analyzer")
+	}
+	selected, err := Select([]string{"mtls.conflict"})
+	if err != nil || len(selected) != 1 || selected[0].Name() != "mtls.conflict" {
+		t.Errorf("Select() => got %v %v", selected, err)
+	}
+}
+
+func TestMessageString(t *testing.T) {
+	m := NewMessage(ReferencedSubsetNotFound, "VirtualService/default/reviews", "spec.http[0]", "v2", "reviews")
+	want := `Error [IST0101] (VirtualService/default/reviews spec.http[0]) subset "v2" of host "reviews" is not defined by any DestinationRule`
+	if m.String() != want {
+		t.Errorf("String() => got %q, want %q", m.String(), want)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+
+	networking "istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/pilot/pkg/config/kube/crd"
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// Workload is a set of pods sharing the same labels.
+type Workload struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Labels    model.Labels
+}
+
+// Context has the resources to analyze, read from files and/or a live cluster.
+type Context struct {
+	// DomainSuffix is the cluster domain, used to resolve short host names.
+	DomainSuffix string
+
+	configs   map[string][]model.Config
+	workloads []Workload
+	services  map[model.Hostname]struct{}
+}
+
+// NewContext creates an empty context.
+func NewContext(domainSuffix string) *Context {
+	return &Context{
+		DomainSuffix: domainSuffix,
+		configs:      map[string][]model.Config{},
+		services:     map[model.Hostname]struct{}{},
+	}
+}
+
+// AddConfig adds an Istio config object.
+func (c *Context) AddConfig(config model.Config) {
+	if config.Domain == "" {
+		config.Domain = c.DomainSuffix
+	}
+	c.configs[config.Type] = append(c.configs[config.Type], config)
+}
+
+// AddWorkload adds a workload, used to check selectors.
+func (c *Context) AddWorkload(w Workload) {
+	c.workloads = append(c.workloads, w)
+}
+
+// AddService adds a Kubernetes service.
+func (c *Context) AddService(name, namespace string) {
+	c.services[model.Hostname(fmt.Sprintf("%s.%s.svc.%s", name, namespace, c.DomainSuffix))] = struct{}{}
+}
+
+// Configs returns the config objects of a type, sorted by namespace and name.
+func (c *Context) Configs(typ string) []model.Config {
+	out := c.configs[typ]
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Namespace != out[j].Namespace {
+			return out[i].Namespace < out[j].Namespace
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// Workloads returns the workloads.
+func (c *Context) Workloads() []Workload {
+	return c.workloads
+}
+
+// HasServices returns true if any Kubernetes service was added. Checks for missing services
+// are skipped otherwise, since the context can't tell a missing service from one that
+// wasn't provided.
+func (c *Context) HasServices() bool {
+	return len(c.services) > 0
+}
+
+// ServiceExists returns true if the host matches a Kubernetes service or a ServiceEntry.
+func (c *Context) ServiceExists(host model.Hostname) bool {
+	if _, f := c.services[host]; f {
+		return true
+	}
+	for _, se := range c.configs[model.ServiceEntry.Type] {
+		for _, h := range se.Spec.(*networking.ServiceEntry).Hosts {
+			if host.SubsetOf(model.ResolveShortnameToFQDN(h, se.ConfigMeta)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AddYAML adds the resources of a YAML or JSON stream. Lists are expanded, and objects
+// without a namespace are added to the default namespace.
+func (c *Context) AddYAML(reader io.Reader, defaultNamespace string) error {
+	decoder := kubeyaml.NewYAMLOrJSONDecoder(reader, 512*1024)
+	for {
+		raw := map[string]interface{}{}
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("cannot parse resources: %v", err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+
+		un := &unstructured.Unstructured{Object: raw}
+		if un.IsList() {
+			err = un.EachListItem(func(item runtime.Object) error {
+				return c.addUnstructured(item.(*unstructured.Unstructured), defaultNamespace)
+			})
+		} else {
+			err = c.addUnstructured(un, defaultNamespace)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Context) addUnstructured(un *unstructured.Unstructured, defaultNamespace string) error {
+	if un.GetNamespace() == "" {
+		un.SetNamespace(defaultNamespace)
+	}
+
+	if schema, exists := model.IstioConfigTypes.GetByType(crd.CamelCaseToKebabCase(un.GetKind())); exists {
+		config, err := crd.ConvertObjectFromUnstructured(schema, un, c.DomainSuffix)
+		if err != nil {
+			return fmt.Errorf("%s: cannot parse proto message: %v",
+				resourcePath(un.GetKind(), un.GetNamespace(), un.GetName()), err)
+		}
+		c.AddConfig(*config)
+		return nil
+	}
+
+	switch un.GetKind() {
+	case "Service":
+		c.AddService(un.GetName(), un.GetNamespace())
+	case "Pod":
+		c.AddWorkload(Workload{Kind: un.GetKind(), Name: un.GetName(), Namespace: un.GetNamespace(), Labels: un.GetLabels()})
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet":
+		labels, _, _ := unstructured.NestedStringMap(un.Object, "spec", "template", "metadata", "labels")
+		c.AddWorkload(Workload{Kind: un.GetKind(), Name: un.GetName(), Namespace: un.GetNamespace(), Labels: labels})
+	}
+	return nil
+}
+
+// AddCluster adds the resources of a live cluster, in the namespace or in all namespaces if
+// the namespace is empty.
+func (c *Context) AddCluster(store model.ConfigStore, client kubernetes.Interface, namespace string) error {
+	for _, typ := range store.ConfigDescriptor().Types() {
+		configs, err := store.List(typ, namespace)
+		if err != nil {
+			// Some types may not be installed in the cluster.
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("cannot list %s: %v", crd.ResourceName(typ), err)
+		}
+		for _, config := range configs {
+			c.AddConfig(config)
+		}
+	}
+
+	services, err := client.CoreV1().Services(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot list services: %v", err)
+	}
+	for _, svc := range services.Items {
+		c.AddService(svc.Name, svc.Namespace)
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot list pods: %v", err)
+	}
+	for _, pod := range pods.Items {
+		c.AddWorkload(Workload{Kind: "Pod", Name: pod.Name, Namespace: pod.Namespace, Labels: pod.Labels})
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	networking "istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// gatewayAnalyzer checks that Gateway selectors match a workload. Gateways select workloads
+// in all namespaces.
+type gatewayAnalyzer struct{}
+
+func (*gatewayAnalyzer) Name() string {
+	return "gateway.selector"
+}
+
+func (*gatewayAnalyzer) Analyze(ctx *Context) []Message {
+	// Without workloads, all selectors would be reported.
+	if len(ctx.Workloads()) == 0 {
+		return nil
+	}
+
+	var out []Message
+	for _, gw := range ctx.Configs(model.Gateway.Type) {
+		selector := model.Labels(gw.Spec.(*networking.Gateway).Selector)
+		if len(selector) == 0 {
+			continue
+		}
+		matched := false
+		for _, w := range ctx.Workloads() {
+			if selector.SubsetOf(w.Labels) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			out = append(out, NewMessage(GatewaySelectorNotMatched,
+				resourcePath("Gateway", gw.Namespace, gw.Name), "spec.selector", selector))
+		}
+	}
+	return out
+}
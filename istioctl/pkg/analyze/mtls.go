@@ -0,0 +1,169 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	authn "istio.io/api/authentication/v1alpha1"
+	networking "istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// mtlsAnalyzer checks that the TLS mode of DestinationRules is accepted by the destination
+// service, according to the authentication policy in force for it.
+type mtlsAnalyzer struct{}
+
+func (*mtlsAnalyzer) Name() string {
+	return "mtls.conflict"
+}
+
+// MTLSMode is the mTLS mode accepted by a service.
+type MTLSMode int
+
+const (
+	// MTLSDisabled services accept plain text only.
+	MTLSDisabled MTLSMode = iota
+	// MTLSPermissive services accept both mTLS and plain text.
+	MTLSPermissive
+	// MTLSStrict services accept mTLS only.
+	MTLSStrict
+)
+
+func (m MTLSMode) String() string {
+	switch m {
+	case MTLSDisabled:
+		return "DISABLE"
+	case MTLSPermissive:
+		return "PERMISSIVE"
+	case MTLSStrict:
+		return "STRICT"
+	default:
+		return fmt.Sprintf("MTLSMode(%d)", int(m))
+	}
+}
+
+func (*mtlsAnalyzer) Analyze(ctx *Context) []Message {
+	var out []Message
+	for _, dr := range ctx.Configs(model.DestinationRule.Type) {
+		spec := dr.Spec.(*networking.DestinationRule)
+		host := model.ResolveShortnameToFQDN(spec.Host, dr.ConfigMeta)
+		name, namespace, ok := serviceOfHost(host, ctx.DomainSuffix)
+		if !ok {
+			// Only hosts of Kubernetes services have an authentication policy.
+			continue
+		}
+
+		path := resourcePath("DestinationRule", dr.Namespace, dr.Name)
+		check := func(field string, port uint32, tls *networking.TLSSettings) {
+			if tls == nil {
+				return
+			}
+			mode, policy := EffectiveMTLS(ctx, name, namespace, port)
+			switch {
+			case mode == MTLSStrict && tls.Mode != networking.TLSSettings_ISTIO_MUTUAL:
+				out = append(out, NewMessage(ConflictingMTLS, path, field, tls.Mode, host, policy, "requires mTLS"))
+			case mode == MTLSDisabled && tls.Mode == networking.TLSSettings_ISTIO_MUTUAL:
+				out = append(out, NewMessage(ConflictingMTLS, path, field, tls.Mode, host, policy, "does not enable mTLS"))
+			}
+		}
+
+		if spec.TrafficPolicy == nil {
+			continue
+		}
+		check("spec.trafficPolicy.tls", 0, spec.TrafficPolicy.Tls)
+		for i, pl := range spec.TrafficPolicy.PortLevelSettings {
+			check(fmt.Sprintf("spec.trafficPolicy.portLevelSettings[%d].tls", i), pl.GetPort().GetNumber(), pl.Tls)
+		}
+	}
+	return out
+}
+
+// serviceOfHost returns the name and namespace of the Kubernetes service of a host.
+func serviceOfHost(host model.Hostname, domainSuffix string) (string, string, bool) {
+	suffix := ".svc." + domainSuffix
+	if !strings.HasSuffix(string(host), suffix) || strings.HasPrefix(string(host), "*") {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimSuffix(string(host), suffix), ".")
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// EffectiveMTLS returns the mTLS mode of a service port, and the path of the policy that
+// sets it. Same as in Pilot, a policy targeting the service takes precedence over the
+// namespace policy, which takes precedence over the mesh policy. Port 0 matches any port.
+func EffectiveMTLS(ctx *Context, name, namespace string, port uint32) (MTLSMode, string) {
+	var namespacePolicy *model.Config
+	for _, p := range ctx.Configs(model.AuthenticationPolicy.Type) {
+		p := p
+		if p.Namespace != namespace {
+			continue
+		}
+		policy := p.Spec.(*authn.Policy)
+		if len(policy.Targets) == 0 {
+			if p.Name == model.DefaultAuthenticationPolicyName {
+				namespacePolicy = &p
+			}
+			continue
+		}
+		for _, target := range policy.Targets {
+			if target.Name == name && targetsPort(target, port) {
+				return policyMTLS(policy), resourcePath("Policy", p.Namespace, p.Name)
+			}
+		}
+	}
+	if namespacePolicy != nil {
+		return policyMTLS(namespacePolicy.Spec.(*authn.Policy)),
+			resourcePath("Policy", namespacePolicy.Namespace, namespacePolicy.Name)
+	}
+
+	for _, p := range ctx.Configs(model.AuthenticationMeshPolicy.Type) {
+		if p.Name == model.DefaultAuthenticationPolicyName {
+			return policyMTLS(p.Spec.(*authn.Policy)), "MeshPolicy/" + p.Name
+		}
+	}
+	return MTLSDisabled, "the absence of an authentication policy"
+}
+
+// targetsPort returns true if the target selects the port. Ports selected by name can't be
+// resolved without the service definition, and are assumed to match.
+func targetsPort(target *authn.TargetSelector, port uint32) bool {
+	if len(target.Ports) == 0 || port == 0 {
+		return true
+	}
+	for _, p := range target.Ports {
+		if p.GetName() != "" || p.GetNumber() == port {
+			return true
+		}
+	}
+	return false
+}
+
+func policyMTLS(policy *authn.Policy) MTLSMode {
+	for _, peer := range policy.Peers {
+		if mtls := peer.GetMtls(); mtls != nil {
+			if mtls.Mode == authn.MutualTls_PERMISSIVE {
+				return MTLSPermissive
+			}
+			return MTLSStrict
+		}
+	}
+	return MTLSDisabled
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	networking "istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// sidecarAnalyzer checks that the Sidecar egress hosts match a service. Wildcard hosts are
+// not checked.
+type sidecarAnalyzer struct{}
+
+func (*sidecarAnalyzer) Name() string {
+	return "sidecar.egresshosts"
+}
+
+func (*sidecarAnalyzer) Analyze(ctx *Context) []Message {
+	// Without services, all hosts would be reported.
+	if !ctx.HasServices() {
+		return nil
+	}
+
+	var out []Message
+	for _, sc := range ctx.Configs(model.Sidecar.Type) {
+		path := resourcePath("Sidecar", sc.Namespace, sc.Name)
+		for i, egress := range sc.Spec.(*networking.Sidecar).Egress {
+			for j, h := range egress.Hosts {
+				parts := strings.SplitN(h, "/", 2)
+				if len(parts) != 2 || strings.Contains(parts[1], "*") {
+					continue
+				}
+				namespace := parts[0]
+				if namespace == "." || namespace == "*" {
+					namespace = sc.Namespace
+				}
+				meta := sc.ConfigMeta
+				meta.Namespace = namespace
+				host := model.ResolveShortnameToFQDN(parts[1], meta)
+				if !ctx.ServiceExists(host) {
+					out = append(out, NewMessage(SidecarEgressHostNotFound, path,
+						fmt.Sprintf("spec.egress[%d].hosts[%d]", i, j), h))
+				}
+			}
+		}
+	}
+	return out
+}
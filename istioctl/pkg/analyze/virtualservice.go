@@ -0,0 +1,90 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"fmt"
+
+	networking "istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// subsetAnalyzer checks that the subsets VirtualServices route to are defined.
+type subsetAnalyzer struct{}
+
+func (*subsetAnalyzer) Name() string {
+	return "virtualservice.subsets"
+}
+
+func (*subsetAnalyzer) Analyze(ctx *Context) []Message {
+	type hostSubsets struct {
+		host    model.Hostname
+		subsets map[string]struct{}
+	}
+	var defined []hostSubsets
+	for _, dr := range ctx.Configs(model.DestinationRule.Type) {
+		spec := dr.Spec.(*networking.DestinationRule)
+		hs := hostSubsets{
+			host:    model.ResolveShortnameToFQDN(spec.Host, dr.ConfigMeta),
+			subsets: map[string]struct{}{},
+		}
+		for _, s := range spec.Subsets {
+			hs.subsets[s.Name] = struct{}{}
+		}
+		defined = append(defined, hs)
+	}
+	isDefined := func(host model.Hostname, subset string) bool {
+		for _, hs := range defined {
+			if _, f := hs.subsets[subset]; f && host.SubsetOf(hs.host) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var out []Message
+	for _, vs := range ctx.Configs(model.VirtualService.Type) {
+		path := resourcePath("VirtualService", vs.Namespace, vs.Name)
+		check := func(field string, dest *networking.Destination) {
+			if dest == nil || dest.Subset == "" {
+				return
+			}
+			host := model.ResolveShortnameToFQDN(dest.Host, vs.ConfigMeta)
+			if !isDefined(host, dest.Subset) {
+				out = append(out, NewMessage(ReferencedSubsetNotFound, path, field, dest.Subset, host))
+			}
+		}
+
+		spec := vs.Spec.(*networking.VirtualService)
+		for i, r := range spec.Http {
+			for j, d := range r.Route {
+				check(fmt.Sprintf("spec.http[%d].route[%d].destination", i, j), d.Destination)
+			}
+			check(fmt.Sprintf("spec.http[%d].mirror", i), r.Mirror)
+		}
+		for i, r := range spec.Tcp {
+			for j, d := range r.Route {
+				check(fmt.Sprintf("spec.tcp[%d].route[%d].destination", i, j), d.Destination)
+			}
+		}
+		for i, r := range spec.Tls {
+			for j, d := range r.Route {
+				check(fmt.Sprintf("spec.tls[%d].route[%d].destination", i, j), d.Destination)
+			}
+		}
+	}
+	return out
+}
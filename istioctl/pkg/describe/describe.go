@@ -0,0 +1,383 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package describe explains the Istio configuration in force for a pod, from both the
+// configuration known to Pilot and the configuration of the pod's Envoy.
+package describe
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	networking "istio.io/api/networking/v1alpha3"
+	rbac "istio.io/api/rbac/v1alpha1"
+
+	"istio.io/istio/istioctl/pkg/analyze"
+	"istio.io/istio/istioctl/pkg/util/configdump"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/serviceregistry/kube"
+)
+
+// Description is the configuration in force for a pod.
+type Description struct {
+	Pod       string
+	Namespace string
+	IP        string
+
+	// Sidecar is false if the pod has no Envoy config dump, in which case the Envoy
+	// fields of the description are empty.
+	Sidecar bool
+
+	Services []Service
+
+	// Rbac is the RBAC mode of the mesh, or empty if RBAC is not configured.
+	Rbac string
+}
+
+// Service is a Kubernetes service selecting the pod.
+type Service struct {
+	Name      string
+	Namespace string
+	Host      model.Hostname
+	Ports     []Port
+
+	// DestinationRule is the path of the DestinationRule of the service, if any.
+	DestinationRule string
+	// TLSMode is the client TLS mode set by the traffic policy of the DestinationRule.
+	TLSMode string
+	// Subsets are the subsets of the DestinationRule that select the pod, and
+	// OtherSubsets the ones that don't.
+	Subsets      []string
+	OtherSubsets []string
+
+	VirtualServices []VirtualService
+
+	// RbacEnabled is true if RBAC is enforced for the service.
+	RbacEnabled bool
+	// Roles are the ServiceRoles granting access to the service.
+	Roles []Role
+}
+
+// Port is a port of a service.
+type Port struct {
+	Name       string
+	Port       int
+	TargetPort int
+	Protocol   model.Protocol
+
+	// MTLS is the mTLS mode set by the authentication policy MTLSPolicy, according to
+	// the config known to Pilot.
+	MTLS       analyze.MTLSMode
+	MTLSPolicy string
+
+	// EnvoyListener is the inbound listener of the port, and EnvoyMTLS the mTLS mode it
+	// accepts. Both are empty if Envoy has no inbound listener for the port.
+	EnvoyListener string
+	EnvoyMTLS     string
+
+	// EnvoyClusters are the outbound clusters of the port in the pod's Envoy, and
+	// EnvoyRoutes the clusters targeted by its HTTP routes for the port.
+	EnvoyClusters []string
+	EnvoyRoutes   []string
+}
+
+// VirtualService is a VirtualService routing to a service.
+type VirtualService struct {
+	Path string
+	// Subsets are the subsets targeted by the routes, empty for routes to the whole service.
+	Subsets []string
+	// Routes is the number of routes with destinations in the service.
+	Routes int
+}
+
+// Role is a ServiceRole, and the bindings granting it.
+type Role struct {
+	Path     string
+	Bindings []string
+}
+
+// Describe describes the configuration in force for a pod. The services must include the
+// services of the pod's namespace, and the context the Istio configuration of the mesh. The
+// config dump of the pod's Envoy is optional.
+func Describe(pod *v1.Pod, services []v1.Service, ctx *analyze.Context, dump *configdump.Wrapper) (*Description, error) {
+	d := &Description{
+		Pod:       pod.Name,
+		Namespace: pod.Namespace,
+		IP:        pod.Status.PodIP,
+		Sidecar:   dump != nil,
+		Rbac:      rbacMode(ctx),
+	}
+
+	var envoy *envoyConfig
+	if dump != nil {
+		var err error
+		if envoy, err = newEnvoyConfig(dump); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range services {
+		svc := &services[i]
+		if !selectsPod(svc, pod) {
+			continue
+		}
+		s := Service{
+			Name:      svc.Name,
+			Namespace: svc.Namespace,
+			Host:      kube.ServiceHostname(svc.Name, svc.Namespace, ctx.DomainSuffix),
+		}
+		for _, p := range svc.Spec.Ports {
+			port := Port{
+				Name:       p.Name,
+				Port:       int(p.Port),
+				TargetPort: targetPort(pod, p),
+				Protocol:   kube.ConvertProtocol(p.Name, p.Protocol),
+			}
+			port.MTLS, port.MTLSPolicy = analyze.EffectiveMTLS(ctx, svc.Name, svc.Namespace, uint32(p.Port))
+			if envoy != nil {
+				envoy.describePort(&port, pod.Status.PodIP, s.Host)
+			}
+			s.Ports = append(s.Ports, port)
+		}
+		describeDestinationRule(&s, ctx, pod)
+		describeVirtualServices(&s, ctx)
+		describeRoles(&s, ctx, d.Rbac)
+		d.Services = append(d.Services, s)
+	}
+	sort.Slice(d.Services, func(i, j int) bool {
+		return d.Services[i].Name < d.Services[j].Name
+	})
+	return d, nil
+}
+
+// selectsPod returns true if the service selector matches the pod. Services without a
+// selector don't select pods.
+func selectsPod(svc *v1.Service, pod *v1.Pod) bool {
+	if svc.Namespace != pod.Namespace || len(svc.Spec.Selector) == 0 {
+		return false
+	}
+	return model.Labels(svc.Spec.Selector).SubsetOf(pod.Labels)
+}
+
+// targetPort returns the pod port targeted by a service port, resolving named ports.
+func targetPort(pod *v1.Pod, p v1.ServicePort) int {
+	if p.TargetPort.StrVal == "" {
+		if p.TargetPort.IntVal == 0 {
+			return int(p.Port)
+		}
+		return int(p.TargetPort.IntVal)
+	}
+	for _, c := range pod.Spec.Containers {
+		for _, cp := range c.Ports {
+			if cp.Name == p.TargetPort.StrVal {
+				return int(cp.ContainerPort)
+			}
+		}
+	}
+	return 0
+}
+
+// describeDestinationRule sets the DestinationRule of the service. Same as in Pilot, a
+// DestinationRule in the namespace of the service takes precedence over the others.
+func describeDestinationRule(s *Service, ctx *analyze.Context, pod *v1.Pod) {
+	var found *model.Config
+	for _, dr := range ctx.Configs(model.DestinationRule.Type) {
+		dr := dr
+		spec := dr.Spec.(*networking.DestinationRule)
+		if !s.Host.SubsetOf(model.ResolveShortnameToFQDN(spec.Host, dr.ConfigMeta)) {
+			continue
+		}
+		if found == nil || (dr.Namespace == s.Namespace && found.Namespace != s.Namespace) {
+			found = &dr
+		}
+	}
+	if found == nil {
+		return
+	}
+
+	spec := found.Spec.(*networking.DestinationRule)
+	s.DestinationRule = fmt.Sprintf("DestinationRule/%s/%s", found.Namespace, found.Name)
+	if tls := spec.GetTrafficPolicy().GetTls(); tls != nil {
+		s.TLSMode = tls.Mode.String()
+	}
+	for _, subset := range spec.Subsets {
+		if model.Labels(subset.Labels).SubsetOf(pod.Labels) {
+			s.Subsets = append(s.Subsets, subset.Name)
+		} else {
+			s.OtherSubsets = append(s.OtherSubsets, subset.Name)
+		}
+	}
+}
+
+// describeVirtualServices sets the VirtualServices with routes to the service.
+func describeVirtualServices(s *Service, ctx *analyze.Context) {
+	for _, vs := range ctx.Configs(model.VirtualService.Type) {
+		spec := vs.Spec.(*networking.VirtualService)
+		v := VirtualService{Path: fmt.Sprintf("VirtualService/%s/%s", vs.Namespace, vs.Name)}
+		subsets := map[string]struct{}{}
+		addDestination := func(dst *networking.Destination) bool {
+			if dst == nil || model.ResolveShortnameToFQDN(dst.Host, vs.ConfigMeta) != s.Host {
+				return false
+			}
+			if dst.Subset != "" {
+				subsets[dst.Subset] = struct{}{}
+			}
+			return true
+		}
+
+		for _, r := range spec.Http {
+			matched := false
+			for _, dst := range r.Route {
+				matched = addDestination(dst.Destination) || matched
+			}
+			matched = addDestination(r.Mirror) || matched
+			if matched {
+				v.Routes++
+			}
+		}
+		for _, r := range spec.Tcp {
+			matched := false
+			for _, dst := range r.Route {
+				matched = addDestination(dst.Destination) || matched
+			}
+			if matched {
+				v.Routes++
+			}
+		}
+		for _, r := range spec.Tls {
+			matched := false
+			for _, dst := range r.Route {
+				matched = addDestination(dst.Destination) || matched
+			}
+			if matched {
+				v.Routes++
+			}
+		}
+
+		if v.Routes == 0 {
+			continue
+		}
+		for subset := range subsets {
+			v.Subsets = append(v.Subsets, subset)
+		}
+		sort.Strings(v.Subsets)
+		s.VirtualServices = append(s.VirtualServices, v)
+	}
+}
+
+// rbacMode returns the mode of the RBAC config of the mesh. The ClusterRbacConfig takes
+// precedence over the deprecated RbacConfig, as in Pilot.
+func rbacMode(ctx *analyze.Context) string {
+	if config := rbacConfig(ctx); config != nil {
+		return config.Mode.String()
+	}
+	return ""
+}
+
+func rbacConfig(ctx *analyze.Context) *rbac.RbacConfig {
+	for _, typ := range []string{model.ClusterRbacConfig.Type, model.RbacConfig.Type} {
+		for _, c := range ctx.Configs(typ) {
+			if c.Name == model.DefaultRbacConfigName {
+				return c.Spec.(*rbac.RbacConfig)
+			}
+		}
+	}
+	return nil
+}
+
+// describeRoles sets the ServiceRoles of the namespace of the service that grant access to
+// it, and the ServiceRoleBindings referring to them.
+func describeRoles(s *Service, ctx *analyze.Context, mode string) {
+	if mode != "" {
+		s.RbacEnabled = rbacEnabled(rbacConfig(ctx), string(s.Host), s.Namespace)
+	}
+
+	bindings := map[string][]string{}
+	for _, b := range ctx.Configs(model.ServiceRoleBinding.Type) {
+		if b.Namespace != s.Namespace {
+			continue
+		}
+		spec := b.Spec.(*rbac.ServiceRoleBinding)
+		role := spec.GetRoleRef().GetName()
+		if role == "" {
+			role = spec.GetRole()
+		}
+		role = strings.TrimPrefix(role, "/")
+		bindings[role] = append(bindings[role], fmt.Sprintf("ServiceRoleBinding/%s/%s", b.Namespace, b.Name))
+	}
+
+	for _, r := range ctx.Configs(model.ServiceRole.Type) {
+		if r.Namespace != s.Namespace {
+			continue
+		}
+		for _, rule := range r.Spec.(*rbac.ServiceRole).Rules {
+			if len(rule.Services) == 0 || stringMatch(string(s.Host), rule.Services) {
+				s.Roles = append(s.Roles, Role{
+					Path:     fmt.Sprintf("ServiceRole/%s/%s", r.Namespace, r.Name),
+					Bindings: bindings[r.Name],
+				})
+				break
+			}
+		}
+	}
+}
+
+func rbacEnabled(config *rbac.RbacConfig, host, namespace string) bool {
+	inList := func(target *rbac.RbacConfig_Target) bool {
+		if target == nil {
+			return false
+		}
+		for _, ns := range target.Namespaces {
+			if ns == namespace {
+				return true
+			}
+		}
+		for _, svc := range target.Services {
+			if svc == host {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch config.Mode {
+	case rbac.RbacConfig_ON:
+		return true
+	case rbac.RbacConfig_ON_WITH_INCLUSION:
+		return inList(config.Inclusion)
+	case rbac.RbacConfig_ON_WITH_EXCLUSION:
+		return !inList(config.Exclusion)
+	default:
+		return false
+	}
+}
+
+// stringMatch matches the services of an access rule, same as the RBAC plugin of Pilot:
+// "*" matches any string, and patterns may have a leading or trailing "*".
+func stringMatch(a string, patterns []string) bool {
+	for _, p := range patterns {
+		switch {
+		case a == p || p == "*":
+			return true
+		case strings.HasSuffix(p, "*") && strings.HasPrefix(a, strings.TrimSuffix(p, "*")):
+			return true
+		case strings.HasPrefix(p, "*") && strings.HasSuffix(a, strings.TrimPrefix(p, "*")):
+			return true
+		}
+	}
+	return false
+}
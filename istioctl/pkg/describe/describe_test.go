@@ -0,0 +1,338 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package describe
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	adminapi "github.com/envoyproxy/go-control-plane/envoy/admin/v2alpha"
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	authn "istio.io/api/authentication/v1alpha1"
+	networking "istio.io/api/networking/v1alpha3"
+	rbac "istio.io/api/rbac/v1alpha1"
+
+	"istio.io/istio/istioctl/pkg/analyze"
+	"istio.io/istio/istioctl/pkg/util/configdump"
+	"istio.io/istio/pilot/pkg/model"
+)
+
+const podIP = "10.0.0.1"
+
+func testPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reviews-v1-abc",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "reviews", "version": "v1"},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:  "reviews",
+				Ports: []v1.ContainerPort{{Name: "web", ContainerPort: 9080}},
+			}},
+		},
+		Status: v1.PodStatus{PodIP: podIP},
+	}
+}
+
+func testServices() []v1.Service {
+	return []v1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "default"},
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{"app": "reviews"},
+				Ports: []v1.ServicePort{
+					{Name: "http", Port: 9080, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromString("web")},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ratings", Namespace: "default"},
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{"app": "ratings"},
+				Ports:    []v1.ServicePort{{Name: "http", Port: 9080, Protocol: v1.ProtocolTCP}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"},
+			Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Name: "http", Port: 80, Protocol: v1.ProtocolTCP}}},
+		},
+	}
+}
+
+func config(schema model.ProtoSchema, namespace, name string, spec proto.Message) model.Config {
+	return model.Config{
+		ConfigMeta: model.ConfigMeta{Type: schema.Type, Namespace: namespace, Name: name},
+		Spec:       spec,
+	}
+}
+
+func testContext() *analyze.Context {
+	ctx := analyze.NewContext("cluster.local")
+	ctx.AddConfig(config(model.DestinationRule, "default", "reviews", &networking.DestinationRule{
+		Host: "reviews",
+		TrafficPolicy: &networking.TrafficPolicy{
+			Tls: &networking.TLSSettings{Mode: networking.TLSSettings_ISTIO_MUTUAL},
+		},
+		Subsets: []*networking.Subset{
+			{Name: "v1", Labels: map[string]string{"version": "v1"}},
+			{Name: "v2", Labels: map[string]string{"version": "v2"}},
+		},
+	}))
+	ctx.AddConfig(config(model.VirtualService, "default", "reviews", &networking.VirtualService{
+		Hosts: []string{"reviews"},
+		Http: []*networking.HTTPRoute{
+			{Route: []*networking.HTTPRouteDestination{
+				{Destination: &networking.Destination{Host: "reviews", Subset: "v1"}, Weight: 50},
+				{Destination: &networking.Destination{Host: "reviews", Subset: "v2"}, Weight: 50},
+			}},
+			{Route: []*networking.HTTPRouteDestination{
+				{Destination: &networking.Destination{Host: "ratings"}},
+			}},
+		},
+	}))
+	ctx.AddConfig(config(model.VirtualService, "default", "ratings", &networking.VirtualService{
+		Hosts: []string{"ratings"},
+		Http: []*networking.HTTPRoute{{Route: []*networking.HTTPRouteDestination{
+			{Destination: &networking.Destination{Host: "ratings"}},
+		}}},
+	}))
+	ctx.AddConfig(config(model.AuthenticationMeshPolicy, "", "default", &authn.Policy{
+		Peers: []*authn.PeerAuthenticationMethod{{
+			Params: &authn.PeerAuthenticationMethod_Mtls{Mtls: &authn.MutualTls{Mode: authn.MutualTls_PERMISSIVE}},
+		}},
+	}))
+	ctx.AddConfig(config(model.AuthenticationPolicy, "default", "reviews", &authn.Policy{
+		Targets: []*authn.TargetSelector{{Name: "reviews"}},
+		Peers: []*authn.PeerAuthenticationMethod{{
+			Params: &authn.PeerAuthenticationMethod_Mtls{Mtls: &authn.MutualTls{}},
+		}},
+	}))
+	ctx.AddConfig(config(model.ClusterRbacConfig, "", "default", &rbac.RbacConfig{
+		Mode:      rbac.RbacConfig_ON_WITH_INCLUSION,
+		Inclusion: &rbac.RbacConfig_Target{Namespaces: []string{"default"}},
+	}))
+	ctx.AddConfig(config(model.ServiceRole, "default", "reviews-viewer", &rbac.ServiceRole{
+		Rules: []*rbac.AccessRule{{Services: []string{"reviews.*"}, Methods: []string{"GET"}}},
+	}))
+	ctx.AddConfig(config(model.ServiceRole, "default", "ratings-viewer", &rbac.ServiceRole{
+		Rules: []*rbac.AccessRule{{Services: []string{"ratings.default.svc.cluster.local"}}},
+	}))
+	ctx.AddConfig(config(model.ServiceRoleBinding, "default", "bind-reviews", &rbac.ServiceRoleBinding{
+		Subjects: []*rbac.Subject{{User: "*"}},
+		RoleRef:  &rbac.RoleRef{Kind: "ServiceRole", Name: "reviews-viewer"},
+	}))
+	return ctx
+}
+
+func mustAny(t *testing.T, m proto.Message) types.Any {
+	t.Helper()
+	a, err := types.MarshalAny(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *a
+}
+
+func testDump(t *testing.T, filterChains []listener.FilterChain) *configdump.Wrapper {
+	t.Helper()
+	listeners := &adminapi.ListenersConfigDump{
+		DynamicActiveListeners: []adminapi.ListenersConfigDump_DynamicListener{{
+			Listener: &xdsapi.Listener{
+				Name: podIP + "_9080",
+				Address: core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+					Address:       podIP,
+					PortSpecifier: &core.SocketAddress_PortValue{PortValue: 9080},
+				}}},
+				FilterChains: filterChains,
+			},
+		}},
+	}
+	clusters := &adminapi.ClustersConfigDump{}
+	for _, name := range []string{
+		"outbound|9080||reviews.default.svc.cluster.local",
+		"outbound|9080|v1|reviews.default.svc.cluster.local",
+		"outbound|9080|v2|reviews.default.svc.cluster.local",
+		"outbound|9080||ratings.default.svc.cluster.local",
+		"inbound|9080||reviews.default.svc.cluster.local",
+		"BlackHoleCluster",
+	} {
+		clusters.DynamicActiveClusters = append(clusters.DynamicActiveClusters,
+			adminapi.ClustersConfigDump_DynamicCluster{Cluster: &xdsapi.Cluster{Name: name}})
+	}
+	routes := &adminapi.RoutesConfigDump{
+		DynamicRouteConfigs: []adminapi.RoutesConfigDump_DynamicRouteConfig{{
+			RouteConfig: &xdsapi.RouteConfiguration{
+				Name: "9080",
+				VirtualHosts: []route.VirtualHost{{
+					Name: "reviews.default.svc.cluster.local:9080",
+					Routes: []route.Route{{
+						Action: &route.Route_Route{Route: &route.RouteAction{
+							ClusterSpecifier: &route.RouteAction_WeightedClusters{WeightedClusters: &route.WeightedCluster{
+								Clusters: []*route.WeightedCluster_ClusterWeight{
+									{Name: "outbound|9080|v2|reviews.default.svc.cluster.local"},
+									{Name: "outbound|9080|v1|reviews.default.svc.cluster.local"},
+								},
+							}},
+						}},
+					}},
+				}},
+			},
+		}},
+	}
+	return &configdump.Wrapper{ConfigDump: &adminapi.ConfigDump{Configs: []types.Any{
+		mustAny(t, &adminapi.BootstrapConfigDump{}),
+		mustAny(t, clusters),
+		mustAny(t, listeners),
+		mustAny(t, routes),
+	}}}
+}
+
+var (
+	plainChain = listener.FilterChain{}
+	mtlsChain  = listener.FilterChain{TlsContext: &auth.DownstreamTlsContext{
+		RequireClientCertificate: &types.BoolValue{Value: true},
+	}}
+)
+
+func TestDescribe(t *testing.T) {
+	d, err := Describe(testPod(), testServices(), testContext(), testDump(t, []listener.FilterChain{mtlsChain}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Description{
+		Pod:       "reviews-v1-abc",
+		Namespace: "default",
+		IP:        podIP,
+		Sidecar:   true,
+		Rbac:      "ON_WITH_INCLUSION",
+		Services: []Service{{
+			Name:      "reviews",
+			Namespace: "default",
+			Host:      "reviews.default.svc.cluster.local",
+			Ports: []Port{{
+				Name:          "http",
+				Port:          9080,
+				TargetPort:    9080,
+				Protocol:      model.ProtocolHTTP,
+				MTLS:          analyze.MTLSStrict,
+				MTLSPolicy:    "Policy/default/reviews",
+				EnvoyListener: podIP + "_9080",
+				EnvoyMTLS:     "STRICT",
+				EnvoyClusters: []string{
+					"outbound|9080|v1|reviews.default.svc.cluster.local",
+					"outbound|9080|v2|reviews.default.svc.cluster.local",
+					"outbound|9080||reviews.default.svc.cluster.local",
+				},
+				EnvoyRoutes: []string{
+					"outbound|9080|v1|reviews.default.svc.cluster.local",
+					"outbound|9080|v2|reviews.default.svc.cluster.local",
+				},
+			}},
+			DestinationRule: "DestinationRule/default/reviews",
+			TLSMode:         "ISTIO_MUTUAL",
+			Subsets:         []string{"v1"},
+			OtherSubsets:    []string{"v2"},
+			VirtualServices: []VirtualService{
+				{Path: "VirtualService/default/reviews", Subsets: []string{"v1", "v2"}, Routes: 1},
+			},
+			RbacEnabled: true,
+			Roles: []Role{
+				{Path: "ServiceRole/default/reviews-viewer", Bindings: []string{"ServiceRoleBinding/default/bind-reviews"}},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("Describe() =\n%+v\nwant\n%+v", d, want)
+	}
+}
+
+func TestDescribeWithoutSidecar(t *testing.T) {
+	d, err := Describe(testPod(), testServices(), testContext(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Sidecar || len(d.Services) != 1 {
+		t.Fatalf("got %+v, want a single service without sidecar", d)
+	}
+	p := d.Services[0].Ports[0]
+	if p.EnvoyListener != "" || p.EnvoyClusters != nil || p.MTLS != analyze.MTLSStrict {
+		t.Errorf("got port %+v, want the Pilot config only", p)
+	}
+}
+
+func TestListenerMTLS(t *testing.T) {
+	cases := []struct {
+		name   string
+		chains []listener.FilterChain
+		want   analyze.MTLSMode
+	}{
+		{"plain text", []listener.FilterChain{plainChain}, analyze.MTLSDisabled},
+		{"mtls", []listener.FilterChain{mtlsChain}, analyze.MTLSStrict},
+		{"both", []listener.FilterChain{mtlsChain, plainChain}, analyze.MTLSPermissive},
+		{"tls without client certificate", []listener.FilterChain{
+			{TlsContext: &auth.DownstreamTlsContext{}},
+		}, analyze.MTLSDisabled},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := listenerMTLS(&xdsapi.Listener{FilterChains: c.chains}); got != c.want {
+				t.Errorf("listenerMTLS() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	// The Envoy listener is permissive, while Pilot requires mTLS.
+	d, err := Describe(testPod(), testServices(), testContext(),
+		testDump(t, []listener.FilterChain{mtlsChain, plainChain}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	Print(&out, d)
+
+	for _, want := range []string{
+		"Pod: reviews-v1-abc.default",
+		"Service: reviews.default",
+		"Port: http 9080/HTTP targets pod port 9080",
+		"Pilot: mTLS STRICT set by Policy/default/reviews",
+		"Envoy: inbound listener 10.0.0.1_9080 accepts mTLS PERMISSIVE",
+		"WARNING: the mTLS mode of Envoy differs from the Pilot config",
+		"Matching subsets: v1",
+		"(Non-matching subsets v2)",
+		"1 route(s) to \"reviews.default.svc.cluster.local\", subsets v1,v2",
+		"ServiceRole/default/reviews-viewer bound by ServiceRoleBinding/default/bind-reviews",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
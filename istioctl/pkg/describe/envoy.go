@@ -0,0 +1,149 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package describe
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+
+	"istio.io/istio/istioctl/pkg/analyze"
+	"istio.io/istio/istioctl/pkg/util/configdump"
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// envoyConfig is the dynamic configuration of an Envoy, read from its config dump.
+type envoyConfig struct {
+	listeners []*xdsapi.Listener
+	clusters  []*xdsapi.Cluster
+	routes    map[string]*xdsapi.RouteConfiguration
+}
+
+func newEnvoyConfig(dump *configdump.Wrapper) (*envoyConfig, error) {
+	listeners, err := dump.GetDynamicListenerDump(true)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read listeners from the config dump: %v", err)
+	}
+	clusters, err := dump.GetDynamicClusterDump(true)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read clusters from the config dump: %v", err)
+	}
+	routes, err := dump.GetDynamicRouteDump(true)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read routes from the config dump: %v", err)
+	}
+
+	e := &envoyConfig{routes: map[string]*xdsapi.RouteConfiguration{}}
+	for _, l := range listeners.DynamicActiveListeners {
+		if l.Listener != nil {
+			e.listeners = append(e.listeners, l.Listener)
+		}
+	}
+	for _, c := range clusters.DynamicActiveClusters {
+		if c.Cluster != nil {
+			e.clusters = append(e.clusters, c.Cluster)
+		}
+	}
+	for _, r := range routes.DynamicRouteConfigs {
+		if r.RouteConfig != nil {
+			e.routes[r.RouteConfig.Name] = r.RouteConfig
+		}
+	}
+	return e, nil
+}
+
+// describePort sets the Envoy fields of a service port.
+func (e *envoyConfig) describePort(port *Port, podIP string, host model.Hostname) {
+	if l := e.inboundListener(podIP, port.TargetPort); l != nil {
+		port.EnvoyListener = l.Name
+		port.EnvoyMTLS = listenerMTLS(l).String()
+	}
+
+	for _, c := range e.clusters {
+		direction, _, h, p := model.ParseSubsetKey(c.Name)
+		if direction == model.TrafficDirectionOutbound && h == host && p == port.Port {
+			port.EnvoyClusters = append(port.EnvoyClusters, c.Name)
+		}
+	}
+	sort.Strings(port.EnvoyClusters)
+
+	if !port.Protocol.IsHTTP() {
+		return
+	}
+	rc := e.routes[strconv.Itoa(port.Port)]
+	if rc == nil {
+		return
+	}
+	name := fmt.Sprintf("%s:%d", host, port.Port)
+	targets := map[string]struct{}{}
+	for _, vh := range rc.VirtualHosts {
+		if vh.Name != name {
+			continue
+		}
+		for _, r := range vh.Routes {
+			action := r.GetRoute()
+			if action == nil {
+				continue
+			}
+			if c := action.GetCluster(); c != "" {
+				targets[c] = struct{}{}
+			}
+			for _, wc := range action.GetWeightedClusters().GetClusters() {
+				targets[wc.Name] = struct{}{}
+			}
+		}
+	}
+	for c := range targets {
+		port.EnvoyRoutes = append(port.EnvoyRoutes, c)
+	}
+	sort.Strings(port.EnvoyRoutes)
+}
+
+// inboundListener returns the listener of the pod IP and port, if any.
+func (e *envoyConfig) inboundListener(podIP string, port int) *xdsapi.Listener {
+	for _, l := range e.listeners {
+		addr := l.Address.GetSocketAddress()
+		if addr != nil && addr.Address == podIP && int(addr.GetPortValue()) == port {
+			return l
+		}
+	}
+	return nil
+}
+
+// listenerMTLS returns the mTLS mode accepted by an inbound listener: strict if all its
+// filter chains require a client certificate, permissive if only some of them do.
+func listenerMTLS(l *xdsapi.Listener) analyze.MTLSMode {
+	mtls := 0
+	for _, fc := range l.FilterChains {
+		if requiresClientCertificate(fc) {
+			mtls++
+		}
+	}
+	switch {
+	case mtls == 0:
+		return analyze.MTLSDisabled
+	case mtls < len(l.FilterChains):
+		return analyze.MTLSPermissive
+	default:
+		return analyze.MTLSStrict
+	}
+}
+
+func requiresClientCertificate(fc listener.FilterChain) bool {
+	return fc.TlsContext != nil && fc.TlsContext.RequireClientCertificate.GetValue()
+}
@@ -0,0 +1,117 @@
+// Copyright 2019 Istio Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package describe
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Print writes a human readable description.
+func Print(w io.Writer, d *Description) {
+	fmt.Fprintf(w, "Pod: %s.%s\n", d.Pod, d.Namespace)
+	if d.IP != "" {
+		fmt.Fprintf(w, "   Pod IP: %s\n", d.IP)
+	}
+	if !d.Sidecar {
+		fmt.Fprintln(w, "   WARNING: the pod has no Envoy config dump, only the Pilot config is described")
+	}
+	if len(d.Services) == 0 {
+		fmt.Fprintln(w, "   The pod is not selected by any service")
+		return
+	}
+
+	for _, s := range d.Services {
+		fmt.Fprintln(w, "--------------------")
+		fmt.Fprintf(w, "Service: %s.%s\n", s.Name, s.Namespace)
+		for _, p := range s.Ports {
+			printPort(w, d, p)
+		}
+
+		if s.DestinationRule != "" {
+			fmt.Fprintf(w, "%s for %q\n", s.DestinationRule, s.Host)
+			if len(s.Subsets) > 0 {
+				fmt.Fprintf(w, "   Matching subsets: %s\n", strings.Join(s.Subsets, ","))
+			}
+			if len(s.OtherSubsets) > 0 {
+				fmt.Fprintf(w, "   (Non-matching subsets %s)\n", strings.Join(s.OtherSubsets, ","))
+			}
+			if s.TLSMode != "" {
+				fmt.Fprintf(w, "   Traffic policy TLS mode: %s\n", s.TLSMode)
+			}
+		} else {
+			fmt.Fprintln(w, "No DestinationRule")
+		}
+
+		for _, vs := range s.VirtualServices {
+			fmt.Fprintf(w, "%s\n", vs.Path)
+			fmt.Fprintf(w, "   %d route(s) to %q", vs.Routes, s.Host)
+			if len(vs.Subsets) > 0 {
+				fmt.Fprintf(w, ", subsets %s", strings.Join(vs.Subsets, ","))
+			}
+			fmt.Fprintln(w)
+		}
+		if len(s.VirtualServices) == 0 {
+			fmt.Fprintln(w, "No VirtualService")
+		}
+
+		printAuthorization(w, d, s)
+	}
+}
+
+func printPort(w io.Writer, d *Description, p Port) {
+	fmt.Fprintf(w, "   Port: %s %d/%s targets pod port %d\n", p.Name, p.Port, p.Protocol, p.TargetPort)
+	fmt.Fprintf(w, "      Pilot: mTLS %s set by %s\n", p.MTLS, p.MTLSPolicy)
+	if !d.Sidecar {
+		return
+	}
+	if p.EnvoyListener == "" {
+		fmt.Fprintln(w, "      Envoy: no inbound listener")
+	} else {
+		fmt.Fprintf(w, "      Envoy: inbound listener %s accepts mTLS %s\n", p.EnvoyListener, p.EnvoyMTLS)
+		if p.EnvoyMTLS != p.MTLS.String() {
+			fmt.Fprintln(w, "      WARNING: the mTLS mode of Envoy differs from the Pilot config, the proxy may be out of sync")
+		}
+	}
+	if len(p.EnvoyClusters) > 0 {
+		fmt.Fprintf(w, "      Envoy: clusters %s\n", strings.Join(p.EnvoyClusters, ", "))
+	}
+	if len(p.EnvoyRoutes) > 0 {
+		fmt.Fprintf(w, "      Envoy: routes to %s\n", strings.Join(p.EnvoyRoutes, ", "))
+	}
+}
+
+func printAuthorization(w io.Writer, d *Description, s Service) {
+	switch {
+	case d.Rbac == "":
+		fmt.Fprintln(w, "RBAC: not configured")
+		return
+	case !s.RbacEnabled:
+		fmt.Fprintf(w, "RBAC: %s, not enforced for the service\n", d.Rbac)
+		return
+	}
+	fmt.Fprintf(w, "RBAC: %s, enforced for the service\n", d.Rbac)
+	if len(s.Roles) == 0 {
+		fmt.Fprintln(w, "   No ServiceRole grants access, all requests are denied")
+	}
+	for _, r := range s.Roles {
+		if len(r.Bindings) == 0 {
+			fmt.Fprintf(w, "   %s (not bound)\n", r.Path)
+			continue
+		}
+		fmt.Fprintf(w, "   %s bound by %s\n", r.Path, strings.Join(r.Bindings, ", "))
+	}
+}
@@ -23,11 +23,13 @@ import (
 	denier "istio.io/istio/mixer/adapter/denier"
 	dogstatsd "istio.io/istio/mixer/adapter/dogstatsd"
 	fluentd "istio.io/istio/mixer/adapter/fluentd"
+	kafka "istio.io/istio/mixer/adapter/kafka"
 	kubernetesenv "istio.io/istio/mixer/adapter/kubernetesenv"
 	list "istio.io/istio/mixer/adapter/list"
 	memquota "istio.io/istio/mixer/adapter/memquota"
 	noop "istio.io/istio/mixer/adapter/noop"
 	opa "istio.io/istio/mixer/adapter/opa"
+	otlp "istio.io/istio/mixer/adapter/otlp"
 	prometheus "istio.io/istio/mixer/adapter/prometheus"
 	rbac "istio.io/istio/mixer/adapter/rbac"
 	redisquota "istio.io/istio/mixer/adapter/redisquota"
@@ -49,11 +51,13 @@ func Inventory() []adptr.InfoFn {
 		denier.GetInfo,
 		dogstatsd.GetInfo,
 		fluentd.GetInfo,
+		kafka.GetInfo,
 		kubernetesenv.GetInfo,
 		list.GetInfo,
 		memquota.GetInfo,
 		noop.GetInfo,
 		opa.GetInfo,
+		otlp.GetInfo,
 		prometheus.GetInfo,
 		rbac.GetInfo,
 		redisquota.GetInfo,
@@ -0,0 +1,1195 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: mixer/adapter/kafka/config/config.proto
+
+// The `kafka` adapter publishes Istio log entries to [Apache Kafka](https://kafka.apache.org/)
+// topics, one message per log entry.
+//
+// The messages are JSON objects holding the variables of the log entry, along with its `name`,
+// `severity` and `timestamp`. They are batched and sent asynchronously. The number of messages
+// waiting to be acknowledged by the brokers is bounded: the log entries exceeding the bound are
+// dropped, and the dispatch of the log entries fails.
+//
+// This adapter supports the [logentry template](https://istio.io/docs/reference/config/policy-and-telemetry/templates/logentry/).
+
+package config
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	io "io"
+	math "math"
+	reflect "reflect"
+	strconv "strconv"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// Acknowledgement required from the brokers for a message to be considered sent.
+type Params_RequiredAcks int32
+
+const (
+	// Wait for the leader of the partition to commit the message.
+	WAIT_FOR_LOCAL Params_RequiredAcks = 0
+	// Do not wait for any acknowledgement.
+	NO_RESPONSE Params_RequiredAcks = 1
+	// Wait for all the in-sync replicas of the partition to commit the message.
+	WAIT_FOR_ALL Params_RequiredAcks = 2
+)
+
+var Params_RequiredAcks_name = map[int32]string{
+	0: "WAIT_FOR_LOCAL",
+	1: "NO_RESPONSE",
+	2: "WAIT_FOR_ALL",
+}
+
+var Params_RequiredAcks_value = map[string]int32{
+	"WAIT_FOR_LOCAL": 0,
+	"NO_RESPONSE":    1,
+	"WAIT_FOR_ALL":   2,
+}
+
+func (Params_RequiredAcks) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_8bd28b8c42a6f139, []int{0, 0}
+}
+
+// Compression codec of the messages.
+type Params_Compression int32
+
+const (
+	// No compression.
+	NONE   Params_Compression = 0
+	GZIP   Params_Compression = 1
+	SNAPPY Params_Compression = 2
+	LZ4    Params_Compression = 3
+)
+
+var Params_Compression_name = map[int32]string{
+	0: "NONE",
+	1: "GZIP",
+	2: "SNAPPY",
+	3: "LZ4",
+}
+
+var Params_Compression_value = map[string]int32{
+	"NONE":   0,
+	"GZIP":   1,
+	"SNAPPY": 2,
+	"LZ4":    3,
+}
+
+func (Params_Compression) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_8bd28b8c42a6f139, []int{0, 1}
+}
+
+// Configuration format for the `kafka` adapter.
+type Params struct {
+	// Addresses of the Kafka brokers used to bootstrap the connection, as `host:port`.
+	//
+	// Required.
+	Brokers []string `protobuf:"bytes,1,rep,name=brokers,proto3" json:"brokers,omitempty"`
+	// Topic the log entries are published to.
+	//
+	// Required.
+	Topic string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Topics the log entries of some instances are published to instead of `topic`, by the fully
+	// qualified name of the instances.
+	//
+	// Optional.
+	InstanceTopics map[string]string `protobuf:"bytes,3,rep,name=instance_topics,json=instanceTopics,proto3" json:"instance_topics,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Name of the log entry variable used as the message key. The messages with the same key are
+	// published to the same partition, preserving their order. The key is typically an attribute
+	// expression of the instance, such as `destination.workload.uid | ""`. The messages are
+	// distributed randomly across the partitions if unset, or if the variable is missing.
+	//
+	// Optional.
+	KeyVariable string `protobuf:"bytes,4,opt,name=key_variable,json=keyVariable,proto3" json:"key_variable,omitempty"`
+	// Client id sent to the brokers. Defaults to `istio-mixer` if unset.
+	//
+	// Optional.
+	ClientId string `protobuf:"bytes,5,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Version of the Kafka brokers, for example `1.0.0`. Defaults to `0.10.2.0` if unset.
+	//
+	// Optional.
+	KafkaVersion string `protobuf:"bytes,6,opt,name=kafka_version,json=kafkaVersion,proto3" json:"kafka_version,omitempty"`
+	// Optional. Acknowledgement required from the brokers. Defaults to `WAIT_FOR_LOCAL`.
+	RequiredAcks Params_RequiredAcks `protobuf:"varint,7,opt,name=required_acks,json=requiredAcks,proto3,enum=adapter.kafka.config.Params_RequiredAcks" json:"required_acks,omitempty"`
+	// Optional. Compression codec of the messages. Defaults to no compression.
+	Compression Params_Compression `protobuf:"varint,8,opt,name=compression,proto3,enum=adapter.kafka.config.Params_Compression" json:"compression,omitempty"`
+	// Number of messages triggering the publication of a batch.
+	// Defaults to 100 if unset.
+	//
+	// Optional.
+	MaxBatchSize int32 `protobuf:"varint,9,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	// Maximum time a message waits to be batched before being published.
+	// Defaults to 500ms if unset.
+	//
+	// Optional.
+	FlushInterval time.Duration `protobuf:"bytes,10,opt,name=flush_interval,json=flushInterval,proto3,stdduration" json:"flush_interval"`
+	// Maximum number of messages waiting to be acknowledged by the brokers. The log entries
+	// exceeding the limit are dropped. Defaults to 10000 if unset.
+	//
+	// Optional.
+	BufferSize int32 `protobuf:"varint,11,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+	// Timeout of the requests to the brokers. Defaults to 10s if unset.
+	//
+	// Optional.
+	Timeout time.Duration `protobuf:"bytes,12,opt,name=timeout,proto3,stdduration" json:"timeout"`
+	// Number of times the publication of a message is retried before it is dropped.
+	// Defaults to 3 if unset.
+	//
+	// Optional.
+	MaxRetries int32 `protobuf:"varint,13,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	// Whether to connect to the brokers with TLS.
+	//
+	// Optional.
+	Tls bool `protobuf:"varint,14,opt,name=tls,proto3" json:"tls,omitempty"`
+	// Path of the PEM encoded CA certificates used to verify the certificates of the brokers when
+	// `tls` is set. The system roots are used if unset.
+	//
+	// Optional.
+	CaCertPath string `protobuf:"bytes,15,opt,name=ca_cert_path,json=caCertPath,proto3" json:"ca_cert_path,omitempty"`
+}
+
+func (m *Params) Reset()      { *m = Params{} }
+func (*Params) ProtoMessage() {}
+func (*Params) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bd28b8c42a6f139, []int{0}
+}
+func (m *Params) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params.Merge(m, src)
+}
+func (m *Params) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("adapter.kafka.config.Params_RequiredAcks", Params_RequiredAcks_name, Params_RequiredAcks_value)
+	proto.RegisterEnum("adapter.kafka.config.Params_Compression", Params_Compression_name, Params_Compression_value)
+	proto.RegisterType((*Params)(nil), "adapter.kafka.config.Params")
+	proto.RegisterMapType((map[string]string)(nil), "adapter.kafka.config.Params.InstanceTopicsEntry")
+}
+
+func init() {
+	proto.RegisterFile("mixer/adapter/kafka/config/config.proto", fileDescriptor_8bd28b8c42a6f139)
+}
+
+var fileDescriptor_8bd28b8c42a6f139 = []byte{
+	// 682 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0xf5, 0x24, 0x6d, 0x7e, 0xc6, 0x4e, 0x6a, 0xcd, 0xd7, 0x85, 0xbf, 0x22, 0x4d, 0x4d, 0x41,
+	0xc2, 0x6c, 0x1c, 0x54, 0x10, 0x42, 0x48, 0x48, 0xa4, 0x25, 0xa0, 0x54, 0x51, 0x12, 0x39, 0x55,
+	0x51, 0xbb, 0xb1, 0x26, 0xce, 0x24, 0x19, 0x39, 0xb1, 0xc3, 0x78, 0x12, 0x25, 0x5d, 0xf1, 0x08,
+	0x2c, 0x79, 0x04, 0x1e, 0xa5, 0xcb, 0x2e, 0xbb, 0x02, 0x92, 0x6e, 0x58, 0xf6, 0x05, 0x90, 0x90,
+	0xc7, 0x09, 0x04, 0xa9, 0xaa, 0x58, 0xe5, 0xde, 0x73, 0xcf, 0xb9, 0x67, 0xe2, 0x73, 0xe1, 0xa3,
+	0x21, 0x9b, 0x52, 0x5e, 0x22, 0x1d, 0x32, 0x12, 0x94, 0x97, 0x7c, 0xd2, 0xf5, 0x49, 0xc9, 0x0b,
+	0x83, 0x2e, 0xeb, 0x2d, 0x7f, 0xec, 0x11, 0x0f, 0x45, 0x88, 0xb6, 0x97, 0x14, 0x5b, 0x52, 0xec,
+	0x64, 0xb6, 0xb3, 0xdd, 0x0b, 0x7b, 0xa1, 0x24, 0x94, 0xe2, 0x2a, 0xe1, 0xee, 0xe0, 0x5e, 0x18,
+	0xf6, 0x06, 0xb4, 0x24, 0xbb, 0xf6, 0xb8, 0x5b, 0xea, 0x8c, 0x39, 0x11, 0x2c, 0x0c, 0x92, 0xf9,
+	0xde, 0xcf, 0x0c, 0xcc, 0x34, 0x09, 0x27, 0xc3, 0x08, 0x19, 0x30, 0xdb, 0xe6, 0xa1, 0x4f, 0x79,
+	0x64, 0x00, 0x33, 0x6d, 0xe5, 0x9d, 0x55, 0x8b, 0xb6, 0xe1, 0xa6, 0x08, 0x47, 0xcc, 0x33, 0x52,
+	0x26, 0xb0, 0xf2, 0x4e, 0xd2, 0xa0, 0x53, 0xb8, 0xc5, 0x82, 0x48, 0x90, 0xc0, 0xa3, 0xae, 0x44,
+	0x22, 0x23, 0x6d, 0xa6, 0x2d, 0x75, 0xff, 0x89, 0x7d, 0xdb, 0x03, 0xed, 0xc4, 0xc6, 0xae, 0x2e,
+	0x35, 0xc7, 0x52, 0x52, 0x09, 0x04, 0x9f, 0x39, 0x45, 0xf6, 0x17, 0x88, 0xee, 0x43, 0xcd, 0xa7,
+	0x33, 0x77, 0x42, 0x38, 0x23, 0xed, 0x01, 0x35, 0x36, 0xa4, 0xaf, 0xea, 0xd3, 0xd9, 0xc9, 0x12,
+	0x42, 0xf7, 0x60, 0xde, 0x1b, 0x30, 0x1a, 0x08, 0x97, 0x75, 0x8c, 0x4d, 0x39, 0xcf, 0x25, 0x40,
+	0xb5, 0x83, 0x1e, 0xc0, 0x82, 0xb4, 0x76, 0x27, 0x94, 0x47, 0x2c, 0x0c, 0x8c, 0x8c, 0x24, 0x68,
+	0x12, 0x3c, 0x49, 0x30, 0x54, 0x87, 0x05, 0x4e, 0x3f, 0x8c, 0x19, 0xa7, 0x1d, 0x97, 0x78, 0x7e,
+	0x64, 0x64, 0x4d, 0x60, 0x15, 0xf7, 0x1f, 0xdf, 0xf9, 0x7a, 0x67, 0xa9, 0x28, 0x7b, 0x7e, 0xe4,
+	0x68, 0x7c, 0xad, 0x43, 0x47, 0x50, 0xf5, 0xc2, 0xe1, 0x88, 0xd3, 0x48, 0x5a, 0xe6, 0xe4, 0x36,
+	0xeb, 0xce, 0x6d, 0x87, 0x7f, 0xf8, 0xce, 0xba, 0x18, 0x3d, 0x84, 0xc5, 0x21, 0x99, 0xba, 0x6d,
+	0x22, 0xbc, 0xbe, 0x1b, 0xb1, 0x73, 0x6a, 0xe4, 0x4d, 0x60, 0x6d, 0x3a, 0xda, 0x90, 0x4c, 0x0f,
+	0x62, 0xb0, 0xc5, 0xce, 0x29, 0x3a, 0x82, 0xc5, 0xee, 0x60, 0x1c, 0xf5, 0x5d, 0x16, 0x08, 0xca,
+	0x27, 0x64, 0x60, 0x40, 0x13, 0x58, 0xea, 0xfe, 0xff, 0x76, 0x92, 0xba, 0xbd, 0x4a, 0xdd, 0x7e,
+	0xb3, 0x4c, 0xfd, 0x20, 0x77, 0xf1, 0x75, 0x57, 0xf9, 0xfc, 0x6d, 0x17, 0x38, 0x05, 0x29, 0xad,
+	0x2e, 0x95, 0x68, 0x17, 0xaa, 0xed, 0x71, 0xb7, 0x4b, 0x79, 0x62, 0xa7, 0x4a, 0x3b, 0x98, 0x40,
+	0xd2, 0xec, 0x15, 0xcc, 0x0a, 0x36, 0xa4, 0xe1, 0x58, 0x18, 0xda, 0xbf, 0xbb, 0xac, 0x34, 0xf1,
+	0xfe, 0xf8, 0x1f, 0x71, 0x2a, 0x38, 0xa3, 0x91, 0x51, 0x48, 0xf6, 0x0f, 0xc9, 0xd4, 0x49, 0x10,
+	0xa4, 0xc3, 0xb4, 0x18, 0x44, 0x46, 0xd1, 0x04, 0x56, 0xce, 0x89, 0x4b, 0x64, 0x42, 0xcd, 0x23,
+	0xae, 0x47, 0xb9, 0x70, 0x47, 0x44, 0xf4, 0x8d, 0x2d, 0x19, 0x22, 0xf4, 0xc8, 0x21, 0xe5, 0xa2,
+	0x49, 0x44, 0x7f, 0xa7, 0x0c, 0xff, 0xbb, 0xe5, 0x9c, 0xe2, 0x55, 0x3e, 0x9d, 0x19, 0x40, 0xf2,
+	0xe3, 0x32, 0xbe, 0xe0, 0x09, 0x19, 0x8c, 0xe9, 0xea, 0x82, 0x65, 0xf3, 0x32, 0xf5, 0x02, 0xec,
+	0x55, 0xa0, 0xb6, 0x9e, 0x29, 0x42, 0xb0, 0xf8, 0xbe, 0x5c, 0x3d, 0x76, 0xdf, 0x36, 0x1c, 0xb7,
+	0xd6, 0x38, 0x2c, 0xd7, 0x74, 0x05, 0x6d, 0x41, 0xb5, 0xde, 0x70, 0x9d, 0x4a, 0xab, 0xd9, 0xa8,
+	0xb7, 0x2a, 0x3a, 0x40, 0x3a, 0xd4, 0x7e, 0x93, 0xca, 0xb5, 0x9a, 0x9e, 0xda, 0x7b, 0x0e, 0xd5,
+	0xb5, 0x30, 0x51, 0x0e, 0x6e, 0xd4, 0x1b, 0xf5, 0x8a, 0xae, 0xc4, 0xd5, 0xbb, 0xb3, 0x6a, 0x53,
+	0x07, 0x08, 0xc2, 0x4c, 0xab, 0x5e, 0x6e, 0x36, 0x4f, 0xf5, 0x14, 0xca, 0xc2, 0x74, 0xed, 0xec,
+	0x99, 0x9e, 0x3e, 0x78, 0x7d, 0x31, 0xc7, 0xca, 0xe5, 0x1c, 0x2b, 0x57, 0x73, 0xac, 0xdc, 0xcc,
+	0xb1, 0xf2, 0x71, 0x81, 0xc1, 0x97, 0x05, 0x56, 0x2e, 0x16, 0x18, 0x5c, 0x2e, 0x30, 0xf8, 0xbe,
+	0xc0, 0xe0, 0xc7, 0x02, 0x2b, 0x37, 0x0b, 0x0c, 0x3e, 0x5d, 0x63, 0xe5, 0xf2, 0x1a, 0x2b, 0x57,
+	0xd7, 0x58, 0x39, 0xcb, 0x24, 0xa7, 0xd4, 0xce, 0xc8, 0xcf, 0xff, 0xf4, 0x57, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0x16, 0xd7, 0x8d, 0x6b, 0x3f, 0x04, 0x00, 0x00,
+}
+
+func (x Params_RequiredAcks) String() string {
+	s, ok := Params_RequiredAcks_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x Params_Compression) String() string {
+	s, ok := Params_Compression_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Brokers) > 0 {
+		for _, s := range m.Brokers {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if len(m.InstanceTopics) > 0 {
+		for k, _ := range m.InstanceTopics {
+			dAtA[i] = 0x1a
+			i++
+			v := m.InstanceTopics[k]
+			mapSize := 1 + len(k) + sovConfig(uint64(len(k))) + 1 + len(v) + sovConfig(uint64(len(v)))
+			i = encodeVarintConfig(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintConfig(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintConfig(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if len(m.KeyVariable) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.KeyVariable)))
+		i += copy(dAtA[i:], m.KeyVariable)
+	}
+	if len(m.ClientId) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.ClientId)))
+		i += copy(dAtA[i:], m.ClientId)
+	}
+	if len(m.KafkaVersion) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.KafkaVersion)))
+		i += copy(dAtA[i:], m.KafkaVersion)
+	}
+	if m.RequiredAcks != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.RequiredAcks))
+	}
+	if m.Compression != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.Compression))
+	}
+	if m.MaxBatchSize != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxBatchSize))
+	}
+	dAtA[i] = 0x52
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.FlushInterval)))
+	n1, err1 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.FlushInterval, dAtA[i:])
+	if err1 != nil {
+		return 0, err1
+	}
+	i += n1
+	if m.BufferSize != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.BufferSize))
+	}
+	dAtA[i] = 0x62
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout)))
+	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Timeout, dAtA[i:])
+	if err2 != nil {
+		return 0, err2
+	}
+	i += n2
+	if m.MaxRetries != 0 {
+		dAtA[i] = 0x68
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxRetries))
+	}
+	if m.Tls {
+		dAtA[i] = 0x70
+		i++
+		if m.Tls {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.CaCertPath) > 0 {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.CaCertPath)))
+		i += copy(dAtA[i:], m.CaCertPath)
+	}
+	return i, nil
+}
+
+func encodeVarintConfig(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Brokers) > 0 {
+		for _, s := range m.Brokers {
+			l = len(s)
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if len(m.InstanceTopics) > 0 {
+		for k, v := range m.InstanceTopics {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovConfig(uint64(len(k))) + 1 + len(v) + sovConfig(uint64(len(v)))
+			n += mapEntrySize + 1 + sovConfig(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.KeyVariable)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.ClientId)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.KafkaVersion)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.RequiredAcks != 0 {
+		n += 1 + sovConfig(uint64(m.RequiredAcks))
+	}
+	if m.Compression != 0 {
+		n += 1 + sovConfig(uint64(m.Compression))
+	}
+	if m.MaxBatchSize != 0 {
+		n += 1 + sovConfig(uint64(m.MaxBatchSize))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.FlushInterval)
+	n += 1 + l + sovConfig(uint64(l))
+	if m.BufferSize != 0 {
+		n += 1 + sovConfig(uint64(m.BufferSize))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout)
+	n += 1 + l + sovConfig(uint64(l))
+	if m.MaxRetries != 0 {
+		n += 1 + sovConfig(uint64(m.MaxRetries))
+	}
+	if m.Tls {
+		n += 2
+	}
+	l = len(m.CaCertPath)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	return n
+}
+
+func sovConfig(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozConfig(x uint64) (n int) {
+	return sovConfig(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Params) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForInstanceTopics := make([]string, 0, len(this.InstanceTopics))
+	for k, _ := range this.InstanceTopics {
+		keysForInstanceTopics = append(keysForInstanceTopics, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForInstanceTopics)
+	mapStringForInstanceTopics := "map[string]string{"
+	for _, k := range keysForInstanceTopics {
+		mapStringForInstanceTopics += fmt.Sprintf("%v: %v,", k, this.InstanceTopics[k])
+	}
+	mapStringForInstanceTopics += "}"
+	s := strings.Join([]string{`&Params{`,
+		`Brokers:` + fmt.Sprintf("%v", this.Brokers) + `,`,
+		`Topic:` + fmt.Sprintf("%v", this.Topic) + `,`,
+		`InstanceTopics:` + mapStringForInstanceTopics + `,`,
+		`KeyVariable:` + fmt.Sprintf("%v", this.KeyVariable) + `,`,
+		`ClientId:` + fmt.Sprintf("%v", this.ClientId) + `,`,
+		`KafkaVersion:` + fmt.Sprintf("%v", this.KafkaVersion) + `,`,
+		`RequiredAcks:` + fmt.Sprintf("%v", this.RequiredAcks) + `,`,
+		`Compression:` + fmt.Sprintf("%v", this.Compression) + `,`,
+		`MaxBatchSize:` + fmt.Sprintf("%v", this.MaxBatchSize) + `,`,
+		`FlushInterval:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.FlushInterval), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`BufferSize:` + fmt.Sprintf("%v", this.BufferSize) + `,`,
+		`Timeout:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Timeout), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`MaxRetries:` + fmt.Sprintf("%v", this.MaxRetries) + `,`,
+		`Tls:` + fmt.Sprintf("%v", this.Tls) + `,`,
+		`CaCertPath:` + fmt.Sprintf("%v", this.CaCertPath) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringConfig(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Brokers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Brokers = append(m.Brokers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceTopics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InstanceTopics == nil {
+				m.InstanceTopics = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipConfig(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.InstanceTopics[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyVariable", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyVariable = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KafkaVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KafkaVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequiredAcks", wireType)
+			}
+			m.RequiredAcks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequiredAcks |= Params_RequiredAcks(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			m.Compression = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Compression |= Params_Compression(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBatchSize", wireType)
+			}
+			m.MaxBatchSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBatchSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FlushInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.FlushInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BufferSize", wireType)
+			}
+			m.BufferSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BufferSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Timeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRetries", wireType)
+			}
+			m.MaxRetries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxRetries |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tls", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Tls = bool(v != 0)
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CaCertPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CaCertPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipConfig(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthConfig
+			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthConfig
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipConfig(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthConfig
+				}
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthConfig = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowConfig   = fmt.Errorf("proto: integer overflow")
+)
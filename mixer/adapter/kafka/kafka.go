@@ -0,0 +1,358 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint: lll
+//go:generate $GOPATH/src/istio.io/istio/bin/mixer_codegen.sh -a mixer/adapter/kafka/config/config.proto -x "-n kafka -t logentry"
+
+// Package kafka adapter for Mixer. Conforms to interfaces in
+// mixer/pkg/adapter. Accepts logentries and publishes them to
+// Apache Kafka topics.
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/Shopify/sarama"
+	multierror "github.com/hashicorp/go-multierror"
+
+	"istio.io/istio/mixer/adapter/kafka/config"
+	"istio.io/istio/mixer/adapter/metadata"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/template/logentry"
+)
+
+const (
+	defaultClientID      = "istio-mixer"
+	defaultKafkaVersion  = "0.10.2.0"
+	defaultMaxBatchSize  = 100
+	defaultFlushInterval = 500 * time.Millisecond
+	defaultBufferSize    = 10000
+	defaultTimeout       = 10 * time.Second
+	defaultMaxRetries    = 3
+)
+
+type (
+	builder struct {
+		adpCfg *config.Params
+	}
+
+	handler struct {
+		env            adapter.Env
+		producer       sarama.AsyncProducer
+		topic          string
+		instanceTopics map[string]string
+		keyVariable    string
+
+		// pending is the number of messages waiting to be acknowledged, at most bufferSize.
+		pending    int64
+		bufferSize int64
+
+		done chan struct{}
+	}
+)
+
+// ensure types implement the requisite interfaces
+var _ logentry.HandlerBuilder = &builder{}
+var _ logentry.Handler = &handler{}
+
+///////////////// Configuration-time Methods ///////////////
+
+// adapter.HandlerBuilder#Build
+func (b *builder) Build(ctx context.Context, env adapter.Env) (adapter.Handler, error) {
+	cfg, err := newProducerConfig(b.adpCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// the producer connects to the brokers to fetch the cluster metadata.
+	producer, err := sarama.NewAsyncProducer(b.adpCfg.Brokers, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the Kafka producer: %v", err)
+	}
+
+	h := &handler{
+		env:            env,
+		producer:       producer,
+		topic:          b.adpCfg.Topic,
+		instanceTopics: b.adpCfg.InstanceTopics,
+		keyVariable:    b.adpCfg.KeyVariable,
+		bufferSize:     int64(cfg.ChannelBufferSize),
+		done:           make(chan struct{}),
+	}
+	env.ScheduleDaemon(h.collectResults)
+
+	return h, nil
+}
+
+// newProducerConfig returns the configuration of the producer for the adapter configuration.
+func newProducerConfig(p *config.Params) (*sarama.Config, error) {
+	cfg := sarama.NewConfig()
+
+	cfg.ClientID = defaultClientID
+	if p.ClientId != "" {
+		cfg.ClientID = p.ClientId
+	}
+
+	version := defaultKafkaVersion
+	if p.KafkaVersion != "" {
+		version = p.KafkaVersion
+	}
+	var err error
+	if cfg.Version, err = sarama.ParseKafkaVersion(version); err != nil {
+		return nil, err
+	}
+
+	switch p.RequiredAcks {
+	case config.WAIT_FOR_LOCAL:
+		cfg.Producer.RequiredAcks = sarama.WaitForLocal
+	case config.NO_RESPONSE:
+		cfg.Producer.RequiredAcks = sarama.NoResponse
+	case config.WAIT_FOR_ALL:
+		cfg.Producer.RequiredAcks = sarama.WaitForAll
+	}
+
+	switch p.Compression {
+	case config.NONE:
+		cfg.Producer.Compression = sarama.CompressionNone
+	case config.GZIP:
+		cfg.Producer.Compression = sarama.CompressionGZIP
+	case config.SNAPPY:
+		cfg.Producer.Compression = sarama.CompressionSnappy
+	case config.LZ4:
+		cfg.Producer.Compression = sarama.CompressionLZ4
+	}
+
+	cfg.Producer.Flush.Messages = defaultMaxBatchSize
+	if p.MaxBatchSize > 0 {
+		cfg.Producer.Flush.Messages = int(p.MaxBatchSize)
+	}
+	cfg.Producer.Flush.MaxMessages = cfg.Producer.Flush.Messages
+
+	cfg.Producer.Flush.Frequency = defaultFlushInterval
+	if p.FlushInterval > 0 {
+		cfg.Producer.Flush.Frequency = p.FlushInterval
+	}
+
+	// the channel can hold all the pending messages, so that the publication never blocks.
+	cfg.ChannelBufferSize = defaultBufferSize
+	if p.BufferSize > 0 {
+		cfg.ChannelBufferSize = int(p.BufferSize)
+	}
+
+	timeout := defaultTimeout
+	if p.Timeout > 0 {
+		timeout = p.Timeout
+	}
+	cfg.Net.DialTimeout = timeout
+	cfg.Net.ReadTimeout = timeout
+	cfg.Net.WriteTimeout = timeout
+	cfg.Producer.Timeout = timeout
+
+	cfg.Producer.Retry.Max = defaultMaxRetries
+	if p.MaxRetries > 0 {
+		cfg.Producer.Retry.Max = int(p.MaxRetries)
+	}
+
+	cfg.Producer.Return.Successes = true
+	cfg.Producer.Return.Errors = true
+
+	if p.Tls {
+		cfg.Net.TLS.Enable = true
+		cfg.Net.TLS.Config = &tls.Config{}
+		if p.CaCertPath != "" {
+			pem, err := ioutil.ReadFile(p.CaCertPath)
+			if err != nil {
+				return nil, fmt.Errorf("could not read the CA certificates: %v", err)
+			}
+			cfg.Net.TLS.Config.RootCAs = x509.NewCertPool()
+			if !cfg.Net.TLS.Config.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no CA certificate found in %s", p.CaCertPath)
+			}
+		}
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// adapter.HandlerBuilder#SetAdapterConfig
+func (b *builder) SetAdapterConfig(cfg adapter.Config) {
+	b.adpCfg = cfg.(*config.Params)
+}
+
+// adapter.HandlerBuilder#Validate
+func (b *builder) Validate() (ce *adapter.ConfigErrors) {
+	if len(b.adpCfg.Brokers) == 0 {
+		ce = ce.Appendf("brokers", "no broker specified")
+	}
+	for _, broker := range b.adpCfg.Brokers {
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			ce = ce.Appendf("brokers", "broker address '%s' is malformed: %v", broker, err)
+		}
+	}
+	if b.adpCfg.Topic == "" {
+		ce = ce.Appendf("topic", "topic is empty")
+	}
+	for instance, topic := range b.adpCfg.InstanceTopics {
+		if topic == "" {
+			ce = ce.Appendf("instanceTopics", "topic of instance '%s' is empty", instance)
+		}
+	}
+	if b.adpCfg.KafkaVersion != "" {
+		if _, err := sarama.ParseKafkaVersion(b.adpCfg.KafkaVersion); err != nil {
+			ce = ce.Appendf("kafkaVersion", "%v", err)
+		}
+	}
+	if b.adpCfg.MaxBatchSize < 0 {
+		ce = ce.Appendf("maxBatchSize", "value must be a positive number")
+	}
+	if b.adpCfg.BufferSize < 0 {
+		ce = ce.Appendf("bufferSize", "value must be a positive number")
+	}
+	if b.adpCfg.MaxRetries < 0 {
+		ce = ce.Appendf("maxRetries", "value must be a positive number")
+	}
+	if b.adpCfg.FlushInterval < 0 {
+		ce = ce.Appendf("flushInterval", "duration must be positive")
+	}
+	if b.adpCfg.Timeout < 0 {
+		ce = ce.Appendf("timeout", "duration must be positive")
+	}
+	if b.adpCfg.CaCertPath != "" && !b.adpCfg.Tls {
+		ce = ce.Appendf("caCertPath", "caCertPath requires tls")
+	}
+	return
+}
+
+// logentry.HandlerBuilder#SetLogEntryTypes
+func (b *builder) SetLogEntryTypes(types map[string]*logentry.Type) {}
+
+////////////////// Request-time Methods //////////////////////////
+
+// logentry.Handler#HandleLogEntry
+func (h *handler) HandleLogEntry(ctx context.Context, insts []*logentry.Instance) error {
+	var handleErr *multierror.Error
+	dropped := make(map[string]int64)
+
+	for _, inst := range insts {
+		msg, err := h.message(inst)
+		if err != nil {
+			recordDropped(msg.Topic, reasonEncoding, 1)
+			handleErr = multierror.Append(handleErr, err)
+			continue
+		}
+
+		// bound the number of pending messages, rather than blocking the dispatch.
+		if atomic.AddInt64(&h.pending, 1) > h.bufferSize {
+			atomic.AddInt64(&h.pending, -1)
+			dropped[msg.Topic]++
+			continue
+		}
+		h.producer.Input() <- msg
+	}
+	record(h.topic, messagesPending, atomic.LoadInt64(&h.pending))
+
+	for topic, count := range dropped {
+		recordDropped(topic, reasonBufferFull, count)
+		h.env.Logger().Infof("Kafka buffer full; dropping %d log entries for topic %s.", count, topic)
+		handleErr = multierror.Append(handleErr, fmt.Errorf("kafka buffer full; dropped %d log entries for topic %s", count, topic))
+	}
+	return handleErr.ErrorOrNil()
+}
+
+// message returns the Kafka message for a log entry. The topic of the message is set even if the
+// log entry can't be encoded.
+func (h *handler) message(inst *logentry.Instance) (*sarama.ProducerMessage, error) {
+	msg := &sarama.ProducerMessage{Topic: h.topic, Timestamp: inst.Timestamp}
+	if topic, found := h.instanceTopics[inst.Name]; found {
+		msg.Topic = topic
+	}
+
+	if h.keyVariable != "" {
+		if key, found := inst.Variables[h.keyVariable]; found {
+			msg.Key = sarama.StringEncoder(adapter.Stringify(key))
+		}
+	}
+
+	entry := make(map[string]interface{}, len(inst.Variables)+3)
+	for k, v := range inst.Variables {
+		switch v.(type) {
+		case string, int64, float64, bool:
+			entry[k] = v
+		default:
+			entry[k] = adapter.Stringify(v)
+		}
+	}
+	entry["name"] = inst.Name
+	entry["severity"] = inst.Severity
+	entry["timestamp"] = inst.Timestamp.Format(time.RFC3339Nano)
+
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return msg, fmt.Errorf("could not encode log entry %s: %v", inst.Name, err)
+	}
+	msg.Value = sarama.ByteEncoder(value)
+	return msg, nil
+}
+
+// collectResults records the outcome of the publication of the messages, until the producer is
+// closed.
+func (h *handler) collectResults() {
+	defer close(h.done)
+
+	successes, errors := h.producer.Successes(), h.producer.Errors()
+	for successes != nil || errors != nil {
+		select {
+		case msg, ok := <-successes:
+			if !ok {
+				successes = nil
+				continue
+			}
+			atomic.AddInt64(&h.pending, -1)
+			record(msg.Topic, messagesSent, 1)
+		case err, ok := <-errors:
+			if !ok {
+				errors = nil
+				continue
+			}
+			atomic.AddInt64(&h.pending, -1)
+			recordDropped(err.Msg.Topic, reasonSend, 1)
+			h.env.Logger().Warningf("could not publish log entry to Kafka topic %s: %v", err.Msg.Topic, err.Err)
+		}
+	}
+}
+
+// adapter.Handler#Close
+func (h *handler) Close() error {
+	// the buffered messages are published before the producer is closed.
+	h.producer.AsyncClose()
+	<-h.done
+	return nil
+}
+
+////////////////// Bootstrap //////////////////////////
+
+// GetInfo returns the adapter.Info specific to this adapter.
+func GetInfo() adapter.Info {
+	info := metadata.GetInfo("kafka")
+	info.NewBuilder = func() adapter.HandlerBuilder { return &builder{} }
+	return info
+}
@@ -0,0 +1,315 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/stats/view"
+
+	"istio.io/istio/mixer/adapter/kafka/config"
+	"istio.io/istio/mixer/pkg/adapter/test"
+	"istio.io/istio/mixer/template/logentry"
+)
+
+func TestBasic(t *testing.T) {
+	info := GetInfo()
+	if !reflect.DeepEqual(info.SupportedTemplates, []string{logentry.TemplateName}) {
+		t.Errorf("got templates %v, want logentry", info.SupportedTemplates)
+	}
+
+	b := info.NewBuilder().(*builder)
+	b.SetAdapterConfig(info.DefaultConfig)
+	if err := b.Validate(); err != nil {
+		t.Errorf("Got error %v, expecting success", err)
+	}
+	if _, err := newProducerConfig(b.adpCfg); err != nil {
+		t.Errorf("Got error %v, expecting success", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(*config.Params)
+		err    string
+	}{
+		{name: "Valid", modify: func(*config.Params) {}},
+		{name: "NoBroker", modify: func(p *config.Params) { p.Brokers = nil }, err: "no broker specified"},
+		{name: "MalformedBroker", modify: func(p *config.Params) { p.Brokers = []string{"kafka"} }, err: "is malformed"},
+		{name: "NoTopic", modify: func(p *config.Params) { p.Topic = "" }, err: "topic is empty"},
+		{
+			name:   "EmptyInstanceTopic",
+			modify: func(p *config.Params) { p.InstanceTopics = map[string]string{"accesslog": ""} },
+			err:    "topic of instance 'accesslog' is empty",
+		},
+		{name: "InvalidVersion", modify: func(p *config.Params) { p.KafkaVersion = "latest" }, err: "kafkaVersion"},
+		{name: "NegativeBufferSize", modify: func(p *config.Params) { p.BufferSize = -1 }, err: "bufferSize"},
+		{name: "NegativeFlushInterval", modify: func(p *config.Params) { p.FlushInterval = -time.Second }, err: "flushInterval"},
+		{name: "CAWithoutTLS", modify: func(p *config.Params) { p.CaCertPath = "ca.pem" }, err: "caCertPath requires tls"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := &config.Params{Brokers: []string{"kafka:9092"}, Topic: "logs"}
+			c.modify(cfg)
+			b := &builder{}
+			b.SetAdapterConfig(cfg)
+
+			err := b.Validate()
+			if c.err == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+				t.Errorf("got error %v, want %q", err, c.err)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	h := &handler{
+		topic:          "logs",
+		instanceTopics: map[string]string{"tcpaccesslog.logentry.istio-system": "tcp-logs"},
+		keyVariable:    "destinationUid",
+	}
+	ts := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name  string
+		inst  *logentry.Instance
+		topic string
+		key   sarama.Encoder
+		value map[string]interface{}
+	}{
+		{
+			name: "Default topic",
+			inst: &logentry.Instance{
+				Name:      "accesslog.logentry.istio-system",
+				Severity:  "Info",
+				Timestamp: ts,
+				Variables: map[string]interface{}{
+					"destinationUid": "kubernetes://reviews-v1",
+					"responseCode":   int64(200),
+					"sourceIp":       []byte(net.ParseIP("10.0.0.1").To4()),
+					"latency":        10 * time.Millisecond,
+				},
+			},
+			topic: "logs",
+			key:   sarama.StringEncoder("kubernetes://reviews-v1"),
+			value: map[string]interface{}{
+				"destinationUid": "kubernetes://reviews-v1",
+				"responseCode":   float64(200),
+				"sourceIp":       "10.0.0.1",
+				"latency":        "10ms",
+				"name":           "accesslog.logentry.istio-system",
+				"severity":       "Info",
+				"timestamp":      "2019-06-01T10:00:00Z",
+			},
+		},
+		{
+			name: "Instance topic without key",
+			inst: &logentry.Instance{
+				Name:      "tcpaccesslog.logentry.istio-system",
+				Severity:  "Warning",
+				Timestamp: ts,
+				Variables: map[string]interface{}{"connectionEvent": "close"},
+			},
+			topic: "tcp-logs",
+			value: map[string]interface{}{
+				"connectionEvent": "close",
+				"name":            "tcpaccesslog.logentry.istio-system",
+				"severity":        "Warning",
+				"timestamp":       "2019-06-01T10:00:00Z",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			msg, err := h.message(c.inst)
+			if err != nil {
+				t.Fatalf("message() failed: %v", err)
+			}
+			if msg.Topic != c.topic || !reflect.DeepEqual(msg.Key, c.key) || msg.Timestamp != ts {
+				t.Errorf("got topic %s, key %v and timestamp %v, want %s, %v and %v", msg.Topic, msg.Key, msg.Timestamp, c.topic, c.key, ts)
+			}
+			var value map[string]interface{}
+			if err = json.Unmarshal(msg.Value.(sarama.ByteEncoder), &value); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(value, c.value) {
+				t.Errorf("got value %v, want %v", value, c.value)
+			}
+		})
+	}
+}
+
+// newBroker returns an embedded fake broker leading the partition 0 of the topic, answering the
+// produce requests with the error.
+func newBroker(t *testing.T, topic string, kerr sarama.KError) *sarama.MockBroker {
+	broker := sarama.NewMockBroker(t, 1)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()),
+		"ProduceRequest": sarama.NewMockProduceResponse(t).
+			SetVersion(2).
+			SetError(topic, 0, kerr),
+	})
+	return broker
+}
+
+func build(t *testing.T, broker *sarama.MockBroker, topic string, bufferSize int32) *handler {
+	t.Helper()
+	b := GetInfo().NewBuilder().(*builder)
+	b.SetAdapterConfig(&config.Params{
+		Brokers:       []string{broker.Addr()},
+		Topic:         topic,
+		FlushInterval: 10 * time.Millisecond,
+		BufferSize:    bufferSize,
+		MaxRetries:    1,
+	})
+	if err := b.Validate(); err != nil {
+		t.Fatalf("Validate() failed: %v", err)
+	}
+	h, err := b.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	return h.(*handler)
+}
+
+func entries(n int) []*logentry.Instance {
+	insts := make([]*logentry.Instance, n)
+	for i := range insts {
+		insts[i] = &logentry.Instance{
+			Name:      "accesslog.logentry.istio-system",
+			Timestamp: time.Now(),
+			Variables: map[string]interface{}{"responseCode": int64(200)},
+		}
+	}
+	return insts
+}
+
+// sum returns the value of the sum view for the topic, and the reason if not empty.
+func sum(t *testing.T, v string, topic, reason string) float64 {
+	t.Helper()
+	rows, err := view.RetrieveData(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total float64
+	for _, row := range rows {
+		tags := make(map[string]string)
+		for _, tag := range row.Tags {
+			tags[tag.Key.Name()] = tag.Value
+		}
+		if tags["topic"] == topic && (reason == "" || tags["reason"] == reason) {
+			total += row.Data.(*view.SumData).Value
+		}
+	}
+	return total
+}
+
+func TestPublish(t *testing.T) {
+	broker := newBroker(t, "published", sarama.ErrNoError)
+	defer broker.Close()
+
+	h := build(t, broker, "published", 0)
+	if err := h.HandleLogEntry(context.Background(), entries(3)); err != nil {
+		t.Fatalf("HandleLogEntry() failed: %v", err)
+	}
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	produced := 0
+	for _, rr := range broker.History() {
+		if _, ok := rr.Request.(*sarama.ProduceRequest); ok {
+			produced++
+		}
+	}
+	if produced == 0 {
+		t.Error("no produce request received by the broker")
+	}
+	if sent := sum(t, messagesSent.Name(), "published", ""); sent != 3 {
+		t.Errorf("got %v messages sent, want 3", sent)
+	}
+	if h.pending != 0 {
+		t.Errorf("got %d pending messages after close, want 0", h.pending)
+	}
+}
+
+func TestBufferFull(t *testing.T) {
+	broker := newBroker(t, "bounded", sarama.ErrNoError)
+	defer broker.Close()
+	// keep the messages pending while the log entries are handled.
+	broker.SetLatency(100 * time.Millisecond)
+
+	h := build(t, broker, "bounded", 2)
+	err := h.HandleLogEntry(context.Background(), entries(5))
+	if err == nil || !strings.Contains(err.Error(), "dropped 3 log entries for topic bounded") {
+		t.Errorf("got error %v, want the dropped log entries", err)
+	}
+	_ = h.Close()
+
+	if dropped := sum(t, messagesDropped.Name(), "bounded", reasonBufferFull); dropped != 3 {
+		t.Errorf("got %v dropped log entries, want 3", dropped)
+	}
+	if sent := sum(t, messagesSent.Name(), "bounded", ""); sent != 2 {
+		t.Errorf("got %v messages sent, want 2", sent)
+	}
+}
+
+func TestSendFailure(t *testing.T) {
+	broker := newBroker(t, "rejected", sarama.ErrMessageSizeTooLarge)
+	defer broker.Close()
+
+	h := build(t, broker, "rejected", 0)
+	if err := h.HandleLogEntry(context.Background(), entries(2)); err != nil {
+		t.Fatalf("HandleLogEntry() failed: %v", err)
+	}
+	_ = h.Close()
+
+	if dropped := sum(t, messagesDropped.Name(), "rejected", reasonSend); dropped != 2 {
+		t.Errorf("got %v log entries failing to be sent, want 2", dropped)
+	}
+	if h.pending != 0 {
+		t.Errorf("got %d pending messages after close, want 0", h.pending)
+	}
+}
+
+func TestBuildUnreachable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	b := &builder{}
+	b.SetAdapterConfig(&config.Params{Brokers: []string{addr}, Topic: "logs", Timeout: 100 * time.Millisecond})
+	if _, err := b.Build(context.Background(), test.NewEnv(t)); err == nil {
+		t.Error("Build() succeeded with an unreachable broker")
+	}
+}
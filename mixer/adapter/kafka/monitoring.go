@@ -0,0 +1,90 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const (
+	// reasons for dropping log entries
+	reasonBufferFull = "buffer_full"
+	reasonEncoding   = "encoding"
+	reasonSend       = "send_failed"
+)
+
+var (
+	topicTag  tag.Key
+	reasonTag tag.Key
+
+	messagesSent = stats.Int64(
+		"mixer/adapter/kafka/messages_sent_total",
+		"The number of messages acknowledged by the Kafka brokers.",
+		stats.UnitDimensionless)
+
+	messagesDropped = stats.Int64(
+		"mixer/adapter/kafka/messages_dropped_total",
+		"The number of log entries dropped, because the buffer was full, they could not be encoded, or sent.",
+		stats.UnitDimensionless)
+
+	messagesPending = stats.Int64(
+		"mixer/adapter/kafka/pending_messages",
+		"The number of messages waiting to be acknowledged by the Kafka brokers, by default topic of the handler.",
+		stats.UnitDimensionless)
+)
+
+func newView(measure stats.Measure, keys []tag.Key, aggregation *view.Aggregation) *view.View {
+	return &view.View{
+		Name:        measure.Name(),
+		Description: measure.Description(),
+		Measure:     measure,
+		TagKeys:     keys,
+		Aggregation: aggregation,
+	}
+}
+
+func init() {
+	var err error
+	if topicTag, err = tag.NewKey("topic"); err != nil {
+		panic(err)
+	}
+	if reasonTag, err = tag.NewKey("reason"); err != nil {
+		panic(err)
+	}
+
+	if err = view.Register(
+		newView(messagesSent, []tag.Key{topicTag}, view.Sum()),
+		newView(messagesDropped, []tag.Key{topicTag, reasonTag}, view.Sum()),
+		newView(messagesPending, []tag.Key{topicTag}, view.LastValue()),
+	); err != nil {
+		panic(err)
+	}
+}
+
+func record(topic string, m *stats.Int64Measure, value int64, tags ...tag.Mutator) {
+	ctx, err := tag.New(context.Background(), append(tags, tag.Insert(topicTag, topic))...)
+	if err != nil {
+		return
+	}
+	stats.Record(ctx, m.M(value))
+}
+
+func recordDropped(topic, reason string, count int64) {
+	record(topic, messagesDropped, count, tag.Insert(reasonTag, reason))
+}
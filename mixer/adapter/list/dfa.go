@@ -0,0 +1,233 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"encoding/binary"
+	"regexp/syntax"
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// maxDFAStates bounds the number of states built by a dfa.
+const maxDFAStates = 10000
+
+// the class of the previous rune, which determines the empty-width assertions that hold.
+const (
+	classBegin = iota
+	classNewline
+	classWord
+	classOther
+)
+
+var classRunes = [...]rune{classBegin: -1, classNewline: '\n', classWord: 'a', classOther: ' '}
+
+type (
+	// dfa reports whether a regular expression matches a string, by running a deterministic automaton
+	// built lazily from the compiled program of the expression. The standard regexp package simulates
+	// the non-deterministic automaton instead, which is slow for the alternation of many expressions.
+	dfa struct {
+		prog *syntax.Prog
+
+		mu     sync.RWMutex
+		start  *dfaState
+		states map[string]*dfaState
+	}
+
+	// dfaState is a set of threads waiting for the next rune, along with the class of the previous rune.
+	dfaState struct {
+		pcs   []uint32
+		class int
+
+		ascii  [utf8.RuneSelf]dfaTransition
+		others map[rune]dfaTransition
+		end    dfaTransition
+	}
+
+	// dfaTransition is the outcome of reading a rune in a state.
+	dfaTransition struct {
+		known bool
+
+		// whether the expression matches before the rune
+		match bool
+		next  *dfaState
+	}
+)
+
+func newDFA(prog *syntax.Prog) *dfa {
+	d := &dfa{prog: prog, states: make(map[string]*dfaState)}
+	d.start = d.state([]uint32{uint32(prog.Start)}, classBegin)
+	return d
+}
+
+// match returns whether the expression matches s. It returns false as its second value if the
+// number of states became too large to answer.
+func (d *dfa) match(s string) (bool, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	st := d.start
+	for i := 0; ; {
+		r, width := rune(-1), 0
+		if i < len(s) {
+			r, width = utf8.DecodeRuneInString(s[i:])
+		}
+
+		t := st.transition(r)
+		if !t.known {
+			d.mu.RUnlock()
+			d.mu.Lock()
+			t = d.step(st, r)
+			d.mu.Unlock()
+			d.mu.RLock()
+
+			if !t.known {
+				return false, false
+			}
+		}
+
+		if t.match {
+			return true, true
+		}
+		if r < 0 {
+			return false, true
+		}
+
+		st = t.next
+		i += width
+	}
+}
+
+func (st *dfaState) transition(r rune) dfaTransition {
+	switch {
+	case r < 0:
+		return st.end
+	case r < utf8.RuneSelf:
+		return st.ascii[r]
+	default:
+		return st.others[r]
+	}
+}
+
+// step computes and records the transition of the state on the rune. Must be called with the write lock held.
+func (d *dfa) step(st *dfaState, r rune) dfaTransition {
+	if t := st.transition(r); t.known {
+		// computed while the lock was released
+		return t
+	}
+
+	if len(d.states) >= maxDFAStates {
+		return dfaTransition{}
+	}
+
+	flags := syntax.EmptyOpContext(classRunes[st.class], r)
+	visited := make([]bool, len(d.prog.Inst))
+	var next []uint32
+	match := false
+
+	stack := append([]uint32{}, st.pcs...)
+	for len(stack) > 0 && !match {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[pc] {
+			continue
+		}
+		visited[pc] = true
+
+		inst := &d.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, inst.Arg, inst.Out)
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, inst.Out)
+		case syntax.InstEmptyWidth:
+			if syntax.EmptyOp(inst.Arg)&^flags == 0 {
+				stack = append(stack, inst.Out)
+			}
+		case syntax.InstMatch:
+			match = true
+		case syntax.InstRune, syntax.InstRune1:
+			if r >= 0 && inst.MatchRune(r) {
+				next = append(next, inst.Out)
+			}
+		case syntax.InstRuneAny:
+			if r >= 0 {
+				next = append(next, inst.Out)
+			}
+		case syntax.InstRuneAnyNotNL:
+			if r >= 0 && r != '\n' {
+				next = append(next, inst.Out)
+			}
+		}
+	}
+
+	t := dfaTransition{known: true, match: match}
+	if !match && r >= 0 {
+		// the expression may match from any position
+		next = append(next, uint32(d.prog.Start))
+		t.next = d.state(next, runeClass(r))
+	}
+
+	switch {
+	case r < 0:
+		st.end = t
+	case r < utf8.RuneSelf:
+		st.ascii[r] = t
+	default:
+		if st.others == nil {
+			st.others = make(map[rune]dfaTransition)
+		}
+		st.others[r] = t
+	}
+	return t
+}
+
+// state returns the state for the set of threads, creating it if needed.
+func (d *dfa) state(pcs []uint32, class int) *dfaState {
+	sort.Slice(pcs, func(i, j int) bool { return pcs[i] < pcs[j] })
+	unique := pcs[:0]
+	for _, pc := range pcs {
+		if len(unique) == 0 || pc != unique[len(unique)-1] {
+			unique = append(unique, pc)
+		}
+	}
+
+	key := make([]byte, 1+len(unique)*binary.MaxVarintLen32)
+	key[0] = byte(class)
+	n := 1
+	for _, pc := range unique {
+		n += binary.PutUvarint(key[n:], uint64(pc))
+	}
+	key = key[:n]
+
+	if st, ok := d.states[string(key)]; ok {
+		return st
+	}
+	st := &dfaState{pcs: unique, class: class}
+	d.states[string(key)] = st
+	return st
+}
+
+func runeClass(r rune) int {
+	switch {
+	case r == '\n':
+		return classNewline
+	case syntax.IsWordChar(r):
+		return classWord
+	default:
+		return classOther
+	}
+}
@@ -16,6 +16,7 @@ package list
 
 import (
 	"fmt"
+	"math/bits"
 	"net"
 	"strings"
 
@@ -23,8 +24,30 @@ import (
 )
 
 type (
+	// ipList stores the IPv4 and IPv6 entries in separate tries, IPv4-mapped IPv6
+	// entries being stored as IPv4 entries.
 	ipList struct {
-		entries []*net.IPNet
+		v4    cidrTrie
+		v6    cidrTrie
+		count int
+	}
+
+	// cidrTrie is a path-compressed binary trie of network prefixes, checking whether
+	// an address belongs to any of the networks in a number of steps bounded by the
+	// address length.
+	cidrTrie struct {
+		root trieNode
+	}
+
+	trieNode struct {
+		// the prefix of the node is the first bits of ip
+		ip   net.IP
+		bits int
+
+		// whether the prefix is a network of the list, in which case the node has no children
+		terminal bool
+
+		children [2]*trieNode
 	}
 
 	// represents the format of the data in a list
@@ -40,7 +63,7 @@ func parseIPList(buf []byte, overrides []string) (list, error) {
 		return nil, fmt.Errorf("could not unmarshal data from list %s", err)
 	}
 
-	ls := &ipList{make([]*net.IPNet, 0, len(lp.WhiteList)+len(overrides))}
+	ls := &ipList{}
 	var err error
 
 	// copy to the internal format
@@ -69,7 +92,16 @@ func (ls *ipList) addEntry(ip string) error {
 	if err != nil {
 		return fmt.Errorf("could not parse list entry %s: %v", orig, err)
 	}
-	ls.entries = append(ls.entries, ipnet)
+
+	ones, _ := ipnet.Mask.Size()
+	if len(ipnet.IP) == net.IPv4len {
+		ls.v4.insert(ipnet.IP, ones)
+	} else if v4 := ipnet.IP.To4(); v4 != nil && ones >= 96 {
+		ls.v4.insert(v4, ones-96)
+	} else {
+		ls.v6.insert(ipnet.IP, ones)
+	}
+	ls.count++
 
 	return nil
 }
@@ -81,16 +113,99 @@ func (ls *ipList) checkList(symbol string) (bool, error) {
 		return false, fmt.Errorf("%s is not a valid IP address", symbol)
 	}
 
-	for _, ipnet := range ls.entries {
-		if ipnet.Contains(ipa) {
-			return true, nil
+	if v4 := ipa.To4(); v4 != nil {
+		return ls.v4.contains(v4), nil
+	}
+	return ls.v6.contains(ipa), nil
+}
+
+func (ls *ipList) numEntries() int {
+	return ls.count
+}
+
+// insert adds the network of ip with a prefix of the given number of ones.
+func (t *cidrTrie) insert(ip net.IP, ones int) {
+	n := &t.root
+	for {
+		// the prefix of n is a prefix of the network
+		if n.terminal {
+			// the network is already part of a larger one
+			return
+		}
+
+		if n.bits == ones {
+			// the network contains all the networks below
+			n.terminal = true
+			n.children = [2]*trieNode{}
+			return
+		}
+
+		b := bit(ip, n.bits)
+		child := n.children[b]
+		if child == nil {
+			n.children[b] = &trieNode{ip: ip, bits: ones, terminal: true}
+			return
 		}
+
+		common := commonPrefix(ip, child.ip, min(ones, child.bits))
+		if common == child.bits {
+			n = child
+			continue
+		}
+
+		// the network and the child diverge before the end of the child prefix
+		split := &trieNode{ip: ip, bits: common}
+		split.children[bit(child.ip, common)] = child
+		n.children[b] = split
+		if common == ones {
+			split.terminal = true
+			split.children = [2]*trieNode{}
+		} else {
+			split.children[bit(ip, common)] = &trieNode{ip: ip, bits: ones, terminal: true}
+		}
+		return
 	}
+}
 
-	// not found in the list
-	return false, nil
+// contains returns true if ip belongs to any of the networks.
+func (t *cidrTrie) contains(ip net.IP) bool {
+	n := &t.root
+	for n != nil {
+		if commonPrefix(ip, n.ip, n.bits) < n.bits {
+			return false
+		}
+		if n.terminal {
+			return true
+		}
+		if n.bits >= len(ip)*8 {
+			return false
+		}
+		n = n.children[bit(ip, n.bits)]
+	}
+	return false
 }
 
-func (ls *ipList) numEntries() int {
-	return len(ls.entries)
+// bit returns the bit of ip at the index, starting from the most significant bit.
+func bit(ip net.IP, index int) int {
+	return int(ip[index/8]>>(7-uint(index%8))) & 1
+}
+
+// commonPrefix returns the number of leading bits a and b have in common, up to max.
+func commonPrefix(a, b net.IP, max int) int {
+	for i := 0; i*8 < max; i++ {
+		if x := a[i] ^ b[i]; x != 0 {
+			if n := i*8 + bits.LeadingZeros8(x); n < max {
+				return n
+			}
+			return max
+		}
+	}
+	return max
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
 }
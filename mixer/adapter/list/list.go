@@ -57,6 +57,10 @@ type (
 
 		latestSHA [sha1.Size]byte
 
+		// validators of the installed list, sent to the provider to only get a list when it has changed
+		etag         string
+		lastModified string
+
 		// indirection to enable fault injection
 		readAll func(io.Reader) ([]byte, error)
 	}
@@ -149,7 +153,9 @@ func (h *handler) listRefresher() {
 	}
 }
 
-// fetchList retrieves and prepares an updated list
+// fetchList retrieves and prepares an updated list. The requests are conditional, the provider
+// only returning the list when it has changed since the last fetch. The current list keeps on
+// being used until the updated one is ready to be installed.
 //
 // TODO: This should implement some more aggressive retry mechanism.
 //       Right now, it a fetch fails, the code will just punt and wait
@@ -161,13 +167,24 @@ func (h *handler) fetchList() {
 	buf := []byte{}
 	sha := h.latestSHA
 
+	var resp *http.Response
 	var err error
 
 	if h.config.ProviderUrl != "" {
 		h.log.Infof("Fetching list from %s", h.config.ProviderUrl)
 
-		var resp *http.Response
-		resp, err = http.Get(h.config.ProviderUrl)
+		resp, err = h.get()
+		if resp != nil {
+			defer func() { _ = resp.Body.Close() }()
+		}
+
+		if err == nil && resp.StatusCode == http.StatusNotModified {
+			// the list hasn't changed since last time
+			h.log.Infof("Fetched list is unchanged")
+			h.resetPurgeTimer()
+			return
+		}
+
 		if err != nil || resp.StatusCode != http.StatusOK {
 			if err != nil {
 				err = h.log.Errorf("could not fetch list from %s: %v", h.config.ProviderUrl, err)
@@ -195,6 +212,7 @@ func (h *handler) fetchList() {
 		if sha == h.latestSHA && h.list != nil {
 			// the list hasn't changed since last time
 			h.log.Infof("Fetched list is unchanged")
+			h.setValidators(resp)
 			h.resetPurgeTimer()
 			return
 		}
@@ -236,9 +254,41 @@ func (h *handler) fetchList() {
 	h.lock.Unlock()
 
 	h.latestSHA = sha
+	if resp != nil {
+		h.setValidators(resp)
+	}
 	h.resetPurgeTimer()
 }
 
+// get requests the list from the provider, conditionally to its change if a list is installed.
+func (h *handler) get() (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, h.config.ProviderUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	installed := h.list != nil
+	h.lock.Unlock()
+
+	if installed {
+		if h.etag != "" {
+			req.Header.Set("If-None-Match", h.etag)
+		}
+		if h.lastModified != "" {
+			req.Header.Set("If-Modified-Since", h.lastModified)
+		}
+	}
+
+	return http.DefaultClient.Do(req)
+}
+
+// setValidators records the validators of the list returned by the provider.
+func (h *handler) setValidators(resp *http.Response) {
+	h.etag = resp.Header.Get("ETag")
+	h.lastModified = resp.Header.Get("Last-Modified")
+}
+
 func (h *handler) resetPurgeTimer() {
 	if h.purgeTimer == nil {
 		return
@@ -15,12 +15,17 @@
 package list
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
+	"math/rand"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -626,3 +631,288 @@ func TestValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestIPTrie(t *testing.T) {
+	ls := &ipList{}
+	for _, ip := range []string{
+		"10.1.2.0/24",
+		"10.1.2.128/25", // covered by the previous network
+		"10.1.3.7",
+		"10.0.0.0/16",
+		"10.0.4.0/22",
+		"192.168.0.0/16",
+		"192.168.1.0/24",
+		"2001:db8::/32",
+		"2001:db8:1::1/128",
+		"::ffff:172.16.0.0/108",
+	} {
+		if err := ls.addEntry(ip); err != nil {
+			t.Fatalf("addEntry(%s) failed: %v", ip, err)
+		}
+	}
+
+	cases := []struct {
+		ip    string
+		found bool
+	}{
+		{"10.1.2.0", true},
+		{"10.1.2.255", true},
+		{"10.1.3.7", true},
+		{"10.1.3.8", false},
+		{"10.0.255.255", true},
+		{"10.2.0.0", false},
+		{"192.168.200.1", true},
+		{"192.169.0.0", false},
+		{"172.16.1.1", true},
+		{"172.31.0.1", true},
+		{"172.32.0.1", false},
+		{"::ffff:10.1.2.3", true},
+		{"2001:db8:ffff::1", true},
+		{"2001:db9::1", false},
+		{"::1", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.ip, func(t *testing.T) {
+			found, err := ls.checkList(c.ip)
+			if err != nil {
+				t.Fatalf("checkList failed: %v", err)
+			}
+			if found != c.found {
+				t.Errorf("Got %v, expecting %v", found, c.found)
+			}
+		})
+	}
+
+	if ls.numEntries() != 10 {
+		t.Errorf("Got %d entries, expecting 10", ls.numEntries())
+	}
+
+	all := &ipList{}
+	_ = all.addEntry("0.0.0.0/0")
+	if found, _ := all.checkList("203.0.113.9"); !found {
+		t.Error("Address not found in the list of all IPv4 addresses")
+	}
+}
+
+// TestIPTrieMatchesNetworks compares the trie to the matching of each network.
+func TestIPTrieMatchesNetworks(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	ls := &ipList{}
+	var networks []*net.IPNet
+	for i := 0; i < 2000; i++ {
+		cidr := randomCIDR(rnd, 8+rnd.Intn(25))
+		_, ipnet, _ := net.ParseCIDR(cidr)
+		networks = append(networks, ipnet)
+		if err := ls.addEntry(cidr); err != nil {
+			t.Fatalf("addEntry(%s) failed: %v", cidr, err)
+		}
+	}
+
+	for i := 0; i < 20000; i++ {
+		ip := randomIP(rnd)
+		want := false
+		for _, n := range networks {
+			want = want || n.Contains(ip)
+		}
+		if found, _ := ls.checkList(ip.String()); found != want {
+			t.Fatalf("Got %v for %s, expecting %v", found, ip, want)
+		}
+	}
+}
+
+func randomIP(rnd *rand.Rand) net.IP {
+	// a narrow address space, so that networks overlap
+	return net.IPv4(10, byte(rnd.Intn(4)), byte(rnd.Intn(256)), byte(rnd.Intn(256)))
+}
+
+func randomCIDR(rnd *rand.Rand, ones int) string {
+	return randomIP(rnd).String() + "/" + strconv.Itoa(ones)
+}
+
+func TestRegexListScopedFlags(t *testing.T) {
+	l, err := parseRegexList([]byte("(?i)^abc$\n^def$"), []string{"^g+$"})
+	if err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+
+	for symbol, want := range map[string]bool{"ABC": true, "def": true, "DEF": false, "ggg": true, "abcdef": false} {
+		if found, _ := l.checkList(symbol); found != want {
+			t.Errorf("Got %v for %s, expecting %v", found, symbol, want)
+		}
+	}
+	if l.numEntries() != 3 {
+		t.Errorf("Got %d entries, expecting 3", l.numEntries())
+	}
+
+	if _, err = parseRegexList([]byte("a)|(b"), nil); err == nil {
+		t.Error("Got success, expecting error")
+	}
+}
+
+func TestConditionalFetch(t *testing.T) {
+	var full, notModified int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 03 Jun 2019 10:00:00 GMT")
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") != "" {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		atomic.AddInt32(&full, 1)
+		if _, err := w.Write([]byte("ABC")); err != nil {
+			t.Errorf("w.Write failed: %v", err)
+		}
+	}))
+	defer ts.Close()
+
+	cfg := config.Params{
+		ProviderUrl:     ts.URL,
+		RefreshInterval: time.Hour,
+		Ttl:             2 * time.Hour,
+		EntryType:       config.STRINGS,
+	}
+	h, err := buildHandler(t, &cfg)
+	if err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+	defer func() { _ = h.Close() }()
+
+	h.fetchList()
+	h.fetchList()
+	if atomic.LoadInt32(&full) != 1 || atomic.LoadInt32(&notModified) != 2 {
+		t.Errorf("Got %d full and %d conditional fetches, expecting 1 and 2", full, notModified)
+	}
+	checkCases(t, []listTestCase{{"ABC", rpc.OK, false}, {"DEF", rpc.PERMISSION_DENIED, false}}, h)
+
+	// the list is fetched again once purged
+	h.purgeList()
+	h.fetchList()
+	if atomic.LoadInt32(&full) != 2 {
+		t.Errorf("Got %d full fetches, expecting 2", full)
+	}
+	checkCases(t, []listTestCase{{"ABC", rpc.OK, false}}, h)
+}
+
+// TestRefreshWithoutGaps checks that the entries present in all the versions of the list are found
+// while the list is being refreshed.
+func TestRefreshWithoutGaps(t *testing.T) {
+	var version int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := atomic.AddInt32(&version, 1)
+		if _, err := w.Write([]byte("STABLE\nV" + strconv.Itoa(int(v)))); err != nil {
+			t.Errorf("w.Write failed: %v", err)
+		}
+	}))
+	defer ts.Close()
+
+	cfg := config.Params{
+		ProviderUrl:     ts.URL,
+		RefreshInterval: time.Millisecond,
+		Ttl:             time.Minute,
+		EntryType:       config.STRINGS,
+	}
+	b := GetInfo().NewBuilder().(*builder)
+	b.SetAdapterConfig(&cfg)
+	if err := b.Validate(); err == nil {
+		t.Fatal("Got success, expecting the refresh interval to be rejected")
+	}
+	h, err := buildHandler(t, &cfg)
+	if err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+	defer func() { _ = h.Close() }()
+
+	for atomic.LoadInt32(&version) < 50 {
+		result, err := h.HandleListEntry(context.Background(), &listentry.Instance{Value: "STABLE"})
+		if err != nil || result.Status.Code != int32(rpc.OK) {
+			t.Fatalf("Got %v and error %v during refresh, expecting success", result.Status, err)
+		}
+	}
+}
+
+func BenchmarkIPList(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	ls := &ipList{}
+	for i := 0; i < 200000; i++ {
+		ip := net.IPv4(byte(rnd.Intn(256)), byte(rnd.Intn(256)), byte(rnd.Intn(256)), 0)
+		_ = ls.addEntry(ip.String() + "/" + strconv.Itoa(16+rnd.Intn(17)))
+	}
+	symbols := make([]string, 1024)
+	for i := range symbols {
+		symbols[i] = net.IPv4(byte(rnd.Intn(256)), byte(rnd.Intn(256)), byte(rnd.Intn(256)), byte(rnd.Intn(256))).String()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ls.checkList(symbols[i%len(symbols)])
+	}
+}
+
+func BenchmarkStringList(b *testing.B) {
+	var buf bytes.Buffer
+	for i := 0; i < 200000; i++ {
+		buf.WriteString("entry-" + strconv.Itoa(i) + "\n")
+	}
+	ls := parseStringList(buf.Bytes(), nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ls.checkList("entry-" + strconv.Itoa(i%400000))
+	}
+}
+
+func BenchmarkRegexList(b *testing.B) {
+	var buf bytes.Buffer
+	for i := 0; i < 1000; i++ {
+		buf.WriteString("^/api/v" + strconv.Itoa(i) + "/[a-z]+$\n")
+	}
+	ls, err := parseRegexList(buf.Bytes(), nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ls.checkList("/api/v" + strconv.Itoa(i%2000) + "/users")
+	}
+}
+
+// TestRegexListMatchesRegexp compares the automaton of the list to the regexp package.
+func TestRegexListMatchesRegexp(t *testing.T) {
+	pieces := []string{"a", "b", "ab", "[a-c]", "[^a]", ".", "x*", "(?:ab)+", "a?", "\\b", "\\B", "^", "$",
+		"(?i)B", "(?m)^a", "(?m)b$", "(?s).", "\\d", "é", "\\pL", "(a|bc)", "a{2,3}"}
+	alphabet := []rune("abcx1 \né")
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		var entries []string
+		for j := 0; j < 1+rnd.Intn(4); j++ {
+			var e string
+			for k := 0; k < 1+rnd.Intn(4); k++ {
+				e += pieces[rnd.Intn(len(pieces))]
+			}
+			entries = append(entries, e)
+		}
+
+		l, err := parseRegexList([]byte(strings.Join(entries, "\n")), nil)
+		if err != nil {
+			t.Fatalf("parseRegexList(%q) failed: %v", entries, err)
+		}
+		exp := regexp.MustCompile("(?:" + strings.Join(entries, ")|(?:") + ")")
+
+		for j := 0; j < 50; j++ {
+			symbol := make([]rune, rnd.Intn(6))
+			for k := range symbol {
+				symbol[k] = alphabet[rnd.Intn(len(alphabet))]
+			}
+
+			want := exp.MatchString(string(symbol))
+			if found, _ := l.checkList(string(symbol)); found != want {
+				t.Fatalf("Got %v for %q with %q, expecting %v", found, string(symbol), entries, want)
+			}
+		}
+	}
+}
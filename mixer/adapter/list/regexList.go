@@ -16,24 +16,32 @@ package list
 
 import (
 	"regexp"
+	"regexp/syntax"
 	"strings"
 )
 
+// regexList matches the regular expressions of the list as a single automaton, the
+// alternation of all the expressions.
 type regexList struct {
-	regexpList []*regexp.Regexp
+	regexp *regexp.Regexp
+	dfa    *dfa
+	count  int
 }
 
 func (l *regexList) checkList(symbol string) (bool, error) {
-	for _, exp := range l.regexpList {
-		if exp.MatchString(symbol) {
-			return true, nil
-		}
+	if l.regexp == nil {
+		return false, nil
+	}
+	if found, ok := l.dfa.match(symbol); ok {
+		return found, nil
 	}
-	return false, nil
+
+	// the automaton grew too large for this list
+	return l.regexp.MatchString(symbol), nil
 }
 
 func (l *regexList) numEntries() int {
-	return len(l.regexpList)
+	return l.count
 }
 
 // parseRegexList parses regexp list from buf and overrides. buf is assumed to be '\n' separated regular expressions.
@@ -43,21 +51,38 @@ func (l *regexList) numEntries() int {
 // buf:  "a+.*\nabc" expands to two regex, "a+.*" and "abc"
 func parseRegexList(buf []byte, overrides []string) (*regexList, error) {
 	lines := strings.Split(string(buf), "\n")
-	entries := make([]*regexp.Regexp, 0, len(lines)+len(overrides))
+	entries := make([]string, 0, len(lines)+len(overrides))
 	for _, line := range lines {
 		if line != "" {
-			exp, err := regexp.Compile(line)
-			if err != nil {
+			// each expression is checked on its own, so that errors refer to the faulty entry
+			if _, err := syntax.Parse(line, syntax.Perl); err != nil {
 				return nil, err
 			}
-			entries = append(entries, exp)
+			entries = append(entries, line)
 		}
 	}
 
-	for _, override := range overrides {
-		// cannot fail, override syntax was checked in the Validate method
-		exp, _ := regexp.Compile(override)
-		entries = append(entries, exp)
+	// override syntax was checked in the Validate method
+	entries = append(entries, overrides...)
+	if len(entries) == 0 {
+		return &regexList{}, nil
+	}
+
+	// the flags set by an expression are scoped to its group
+	union := "(?:" + strings.Join(entries, ")|(?:") + ")"
+	exp, err := regexp.Compile(union)
+	if err != nil {
+		return nil, err
 	}
-	return &regexList{entries}, nil
+
+	re, err := syntax.Parse(union, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return nil, err
+	}
+
+	return &regexList{regexp: exp, dfa: newDFA(prog), count: len(entries)}, nil
 }
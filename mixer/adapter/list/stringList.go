@@ -19,29 +19,29 @@ import (
 )
 
 type stringList struct {
-	entries map[string]bool
+	entries map[string]struct{}
 }
 
 type caseInsensitiveStringList struct {
-	entries map[string]bool
+	entries map[string]struct{}
 }
 
 func parseStringList(buf []byte, overrides []string) list {
 	lines := strings.Split(string(buf), "\n")
 
-	entries := make(map[string]bool, len(lines)+len(overrides))
+	entries := make(map[string]struct{}, len(lines)+len(overrides))
 
 	// copy the main strings
 	for _, s := range lines {
 		if s != "" {
-			entries[s] = true
+			entries[s] = struct{}{}
 		}
 	}
 
 	// apply overrides
 	for _, s := range overrides {
 		if s != "" {
-			entries[s] = true
+			entries[s] = struct{}{}
 		}
 	}
 
@@ -51,19 +51,19 @@ func parseStringList(buf []byte, overrides []string) list {
 func parseCaseInsensitiveStringList(buf []byte, overrides []string) list {
 	lines := strings.Split(string(buf), "\n")
 
-	entries := make(map[string]bool, len(lines)+len(overrides))
+	entries := make(map[string]struct{}, len(lines)+len(overrides))
 
 	// copy the main strings
 	for _, s := range lines {
 		if s != "" {
-			entries[strings.ToUpper(s)] = true
+			entries[strings.ToUpper(s)] = struct{}{}
 		}
 	}
 
 	// apply overrides
 	for _, s := range overrides {
 		if s != "" {
-			entries[strings.ToUpper(s)] = true
+			entries[strings.ToUpper(s)] = struct{}{}
 		}
 	}
 
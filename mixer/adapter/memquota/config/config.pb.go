@@ -4,10 +4,16 @@
 // The `memquota` adapter can be used to support Istio's quota management
 // system. Although functional, this adapter is not intended for production
 // use and is suited for local testing only. The reason for this limitation
-// is that this adapter can only be used in meshes where there is a single
-// instance of Mixer running for the whole mesh (i.e. non-HA configuration)
-// and if that single instance crashes, all outstanding quota values will
-// be lost.
+// is that, unless gossip is configured, this adapter can only be used in
+// meshes where there is a single instance of Mixer running for the whole
+// mesh (i.e. non-HA configuration), and if that single instance crashes,
+// all outstanding quota values will be lost.
+//
+// When gossip is configured, the Mixer replicas periodically exchange the
+// amounts allocated in their quota windows, and each replica deducts the
+// amounts allocated by its peers from the quota it grants. The limits are
+// then approximately enforced for the whole mesh: allocations made by a peer
+// since its last exchange are not accounted for.
 //
 // This adapter supports the [quota template](https://istio.io/docs/reference/config/policy-and-telemetry/templates/quota/).
 
@@ -45,6 +51,9 @@ type Params struct {
 	Quotas []Params_Quota `protobuf:"bytes,1,rep,name=quotas,proto3" json:"quotas"`
 	// Minimum number of seconds that deduplication is possible for a given operation.
 	MinDeduplicationDuration time.Duration `protobuf:"bytes,2,opt,name=min_deduplication_duration,json=minDeduplicationDuration,proto3,stdduration" json:"min_deduplication_duration"`
+	// Optional sharing of the quota allocations between Mixer replicas. When absent,
+	// each replica enforces the limits on its own.
+	Gossip *Params_Gossip `protobuf:"bytes,3,opt,name=gossip,proto3" json:"gossip,omitempty"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
@@ -221,11 +230,106 @@ func (m *Params_Override) GetValidDuration() time.Duration {
 	return 0
 }
 
+// Defines how the quota allocations are shared between Mixer replicas.
+type Params_Gossip struct {
+	// The address on which allocations are received from the peers, e.g. `:9093`.
+	ListenAddress string `protobuf:"bytes,1,opt,name=listen_address,json=listenAddress,proto3" json:"listen_address,omitempty"`
+	// Static list of peer addresses, as `host:port`. The address of this replica
+	// may be included, its own allocations are ignored.
+	Peers []string `protobuf:"bytes,2,rep,name=peers,proto3" json:"peers,omitempty"`
+	// DNS name resolving to the addresses of all the replicas, e.g. the name of a
+	// headless Kubernetes service. The peers are reached on the port of `listen_address`.
+	PeerDnsName string `protobuf:"bytes,3,opt,name=peer_dns_name,json=peerDnsName,proto3" json:"peer_dns_name,omitempty"`
+	// Interval at which the allocations are sent to the peers. Defaults to 1s.
+	Interval time.Duration `protobuf:"bytes,4,opt,name=interval,proto3,stdduration" json:"interval"`
+	// Interval at which `peer_dns_name` is resolved again. Defaults to 30s.
+	RefreshInterval time.Duration `protobuf:"bytes,5,opt,name=refresh_interval,json=refreshInterval,proto3,stdduration" json:"refresh_interval"`
+	// Secret shared by the replicas. When set, the allocations sent to the peers are
+	// signed with it, and the allocations not signed with it are ignored. Allocations
+	// received from an address which isn't one of the peers are always ignored.
+	SharedSecret string `protobuf:"bytes,6,opt,name=shared_secret,json=sharedSecret,proto3" json:"shared_secret,omitempty"`
+}
+
+func (m *Params_Gossip) Reset()      { *m = Params_Gossip{} }
+func (*Params_Gossip) ProtoMessage() {}
+func (*Params_Gossip) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67b4efe0be29bdbf, []int{0, 2}
+}
+func (m *Params_Gossip) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params_Gossip) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params_Gossip.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params_Gossip) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params_Gossip.Merge(m, src)
+}
+func (m *Params_Gossip) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params_Gossip) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params_Gossip.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params_Gossip proto.InternalMessageInfo
+
+func (m *Params_Gossip) GetListenAddress() string {
+	if m != nil {
+		return m.ListenAddress
+	}
+	return ""
+}
+
+func (m *Params_Gossip) GetPeers() []string {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *Params_Gossip) GetPeerDnsName() string {
+	if m != nil {
+		return m.PeerDnsName
+	}
+	return ""
+}
+
+func (m *Params_Gossip) GetInterval() time.Duration {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *Params_Gossip) GetRefreshInterval() time.Duration {
+	if m != nil {
+		return m.RefreshInterval
+	}
+	return 0
+}
+
+func (m *Params_Gossip) GetSharedSecret() string {
+	if m != nil {
+		return m.SharedSecret
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Params)(nil), "adapter.memquota.config.Params")
 	proto.RegisterType((*Params_Quota)(nil), "adapter.memquota.config.Params.Quota")
 	proto.RegisterType((*Params_Override)(nil), "adapter.memquota.config.Params.Override")
 	proto.RegisterMapType((map[string]string)(nil), "adapter.memquota.config.Params.Override.DimensionsEntry")
+	proto.RegisterType((*Params_Gossip)(nil), "adapter.memquota.config.Params.Gossip")
 }
 
 func init() {
@@ -233,36 +337,45 @@ func init() {
 }
 
 var fileDescriptor_67b4efe0be29bdbf = []byte{
-	// 457 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x91, 0x3f, 0x8b, 0x13, 0x41,
-	0x18, 0xc6, 0x67, 0xf2, 0x8f, 0xcb, 0x7b, 0xf8, 0x87, 0xe1, 0xc0, 0x75, 0xc1, 0x49, 0x10, 0x84,
-	0x60, 0x31, 0x0b, 0x67, 0x73, 0x1c, 0x08, 0x1a, 0x63, 0x23, 0x82, 0xba, 0x95, 0xd8, 0x84, 0xb9,
-	0xdb, 0xb9, 0x65, 0x30, 0x33, 0x13, 0x27, 0xbb, 0x21, 0xd7, 0x59, 0x5a, 0x5a, 0x58, 0x58, 0x5a,
-	0x58, 0xf8, 0x51, 0x52, 0xa6, 0x3c, 0x2c, 0xd4, 0x6c, 0x1a, 0xcb, 0xfb, 0x08, 0xb2, 0x33, 0xbb,
-	0xde, 0x21, 0x88, 0xa9, 0xac, 0xf6, 0xdd, 0x99, 0xe7, 0x79, 0xde, 0x1f, 0xcf, 0xc0, 0x5d, 0x25,
-	0x17, 0xc2, 0x46, 0x3c, 0xe1, 0xd3, 0x4c, 0xd8, 0x48, 0x09, 0xf5, 0x26, 0x37, 0x19, 0x8f, 0x8e,
-	0x8d, 0x3e, 0x91, 0x69, 0xf5, 0x61, 0x53, 0x6b, 0x32, 0x43, 0x6e, 0x54, 0x2a, 0x56, 0xab, 0x98,
-	0xbf, 0x0e, 0x69, 0x6a, 0x4c, 0x3a, 0x11, 0x91, 0x93, 0x1d, 0xe5, 0x27, 0x51, 0x92, 0x5b, 0x9e,
-	0x49, 0xa3, 0xbd, 0x31, 0xdc, 0x4b, 0x4d, 0x6a, 0xdc, 0x18, 0x95, 0x93, 0x3f, 0xbd, 0xfd, 0xb9,
-	0x0d, 0x9d, 0xe7, 0xdc, 0x72, 0x35, 0x23, 0x8f, 0xa0, 0xe3, 0x02, 0x67, 0x01, 0xee, 0x37, 0x07,
-	0xbb, 0xfb, 0x77, 0xd8, 0x5f, 0x56, 0x31, 0x6f, 0x60, 0x2f, 0xca, 0xb3, 0x61, 0x6b, 0xf9, 0xad,
-	0x87, 0xe2, 0xca, 0x4a, 0x38, 0x84, 0x4a, 0xea, 0x71, 0x22, 0x92, 0x7c, 0x3a, 0x91, 0xc7, 0x0e,
-	0x60, 0x5c, 0x93, 0x04, 0x8d, 0x3e, 0x1e, 0xec, 0xee, 0xdf, 0x64, 0x1e, 0x95, 0xd5, 0xa8, 0x6c,
-	0x54, 0x09, 0x86, 0x3b, 0x65, 0xd8, 0xc7, 0xef, 0x3d, 0x1c, 0x07, 0x4a, 0xea, 0xd1, 0xe5, 0x94,
-	0x5a, 0x13, 0x7e, 0xc5, 0xd0, 0x76, 0xab, 0x09, 0x81, 0x96, 0xe6, 0x4a, 0x04, 0xb8, 0x8f, 0x07,
-	0xdd, 0xd8, 0xcd, 0xe4, 0x16, 0x80, 0xe2, 0x8b, 0x31, 0x57, 0x26, 0xd7, 0x99, 0x5b, 0xd8, 0x8c,
-	0xbb, 0x8a, 0x2f, 0x1e, 0xba, 0x03, 0xf2, 0x04, 0xae, 0xce, 0xf9, 0x44, 0x26, 0x17, 0x4c, 0xcd,
-	0xed, 0x99, 0xae, 0x38, 0x6b, 0x7d, 0x41, 0x9e, 0x42, 0xd7, 0xcc, 0x85, 0xb5, 0x32, 0x11, 0xb3,
-	0xa0, 0xe5, 0x3a, 0x1b, 0xfc, 0xab, 0xb3, 0x67, 0x95, 0xa1, 0xaa, 0xed, 0x22, 0xe0, 0xb0, 0xf5,
-	0xee, 0x53, 0x0f, 0x87, 0x1f, 0x1a, 0xb0, 0x53, 0x6b, 0xc8, 0x4b, 0x80, 0x44, 0x2a, 0xa1, 0x67,
-	0xd2, 0xe8, 0xfa, 0x55, 0x0e, 0xb6, 0xdd, 0xc0, 0x46, 0xbf, 0xad, 0x8f, 0x75, 0x66, 0x4f, 0xe3,
-	0x4b, 0x59, 0xff, 0xb1, 0xa5, 0xf0, 0x3e, 0x5c, 0xfb, 0x83, 0x84, 0x5c, 0x87, 0xe6, 0x6b, 0x71,
-	0x5a, 0x3d, 0x5b, 0x39, 0x92, 0x3d, 0x68, 0xcf, 0xf9, 0x24, 0x17, 0x0e, 0xa5, 0x1b, 0xfb, 0x9f,
-	0xc3, 0xc6, 0x01, 0xf6, 0xb5, 0x0c, 0x1f, 0x2c, 0xd7, 0x14, 0xad, 0xd6, 0x14, 0x9d, 0xad, 0x29,
-	0x3a, 0x5f, 0x53, 0xf4, 0xb6, 0xa0, 0xf8, 0x4b, 0x41, 0xd1, 0xb2, 0xa0, 0x78, 0x55, 0x50, 0xfc,
-	0xa3, 0xa0, 0xf8, 0x67, 0x41, 0xd1, 0x79, 0x41, 0xf1, 0xfb, 0x0d, 0x45, 0xab, 0x0d, 0x45, 0x67,
-	0x1b, 0x8a, 0x5e, 0x75, 0x7c, 0x3b, 0x47, 0x1d, 0x87, 0x7c, 0xef, 0x57, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x56, 0x02, 0x85, 0x8b, 0x6c, 0x03, 0x00, 0x00,
+	// 596 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x52, 0xbf, 0x6f, 0xd4, 0x30,
+	0x18, 0x8d, 0x2f, 0xd7, 0xe8, 0xce, 0xe5, 0xda, 0xca, 0xaa, 0x44, 0x38, 0x09, 0xf7, 0x54, 0x54,
+	0x74, 0x62, 0x48, 0xa4, 0xb2, 0x54, 0x95, 0xf8, 0xd1, 0x72, 0x08, 0x81, 0x50, 0x81, 0xb0, 0x20,
+	0x96, 0xc8, 0x6d, 0xdc, 0xd4, 0x22, 0xb1, 0x0f, 0x3b, 0x39, 0xb5, 0x1b, 0x23, 0x03, 0x03, 0x03,
+	0x03, 0x23, 0x0b, 0x12, 0x7f, 0x4a, 0xc7, 0x8e, 0x15, 0x03, 0x70, 0xb9, 0x85, 0xb1, 0x7f, 0x02,
+	0x8a, 0x9d, 0x5c, 0x2b, 0x24, 0xd4, 0x9b, 0x98, 0xf2, 0xf9, 0xe5, 0xbd, 0xe7, 0xf7, 0x7d, 0xfe,
+	0xe0, 0xad, 0x94, 0x1d, 0x52, 0xe9, 0x93, 0x88, 0x0c, 0x33, 0x2a, 0xfd, 0x94, 0xa6, 0x6f, 0x73,
+	0x91, 0x11, 0x7f, 0x4f, 0xf0, 0x7d, 0x16, 0x57, 0x1f, 0x6f, 0x28, 0x45, 0x26, 0xd0, 0xd5, 0x8a,
+	0xe5, 0xd5, 0x2c, 0xcf, 0xfc, 0xee, 0xe2, 0x58, 0x88, 0x38, 0xa1, 0xbe, 0xa6, 0xed, 0xe6, 0xfb,
+	0x7e, 0x94, 0x4b, 0x92, 0x31, 0xc1, 0x8d, 0xb0, 0xbb, 0x1c, 0x8b, 0x58, 0xe8, 0xd2, 0x2f, 0x2b,
+	0x83, 0xae, 0x7e, 0x68, 0x41, 0xe7, 0x39, 0x91, 0x24, 0x55, 0xe8, 0x01, 0x74, 0xb4, 0xa1, 0x72,
+	0x41, 0xcf, 0xee, 0xcf, 0xaf, 0xaf, 0x79, 0xff, 0xb8, 0xca, 0x33, 0x02, 0xef, 0x45, 0x89, 0x6d,
+	0x37, 0x8f, 0x7f, 0xac, 0x58, 0x41, 0x25, 0x45, 0x04, 0x76, 0x53, 0xc6, 0xc3, 0x88, 0x46, 0xf9,
+	0x30, 0x61, 0x7b, 0x3a, 0x40, 0x58, 0x27, 0x71, 0x1b, 0x3d, 0xd0, 0x9f, 0x5f, 0xbf, 0xe6, 0x99,
+	0xa8, 0x5e, 0x1d, 0xd5, 0x1b, 0x54, 0x84, 0xed, 0x56, 0x69, 0xf6, 0xf9, 0xe7, 0x0a, 0x08, 0xdc,
+	0x94, 0xf1, 0xc1, 0x45, 0x97, 0x9a, 0x83, 0xee, 0x42, 0x27, 0x16, 0x4a, 0xb1, 0xa1, 0x6b, 0x6b,
+	0xbb, 0x9b, 0x97, 0xe5, 0x7c, 0xa4, 0xd9, 0x41, 0xa5, 0xea, 0x7e, 0x07, 0x70, 0x4e, 0x47, 0x47,
+	0x08, 0x36, 0x39, 0x49, 0xa9, 0x0b, 0x7a, 0xa0, 0xdf, 0x0e, 0x74, 0x8d, 0xae, 0x43, 0x98, 0x92,
+	0xc3, 0x90, 0xa4, 0x22, 0xe7, 0x99, 0x0e, 0x6c, 0x07, 0xed, 0x94, 0x1c, 0x6e, 0x69, 0x00, 0x3d,
+	0x81, 0x0b, 0x23, 0x92, 0xb0, 0xe8, 0xbc, 0x27, 0x7b, 0xf6, 0x9e, 0x3a, 0x5a, 0x3a, 0x6d, 0xe4,
+	0x29, 0x6c, 0x8b, 0x11, 0x95, 0x92, 0x45, 0x54, 0xb9, 0x4d, 0x3d, 0xf3, 0xfe, 0x65, 0xbd, 0x3c,
+	0xab, 0x04, 0xd5, 0xd8, 0xcf, 0x0d, 0x36, 0x9b, 0xef, 0xbf, 0xac, 0x80, 0xee, 0xa7, 0x06, 0x6c,
+	0xd5, 0x1c, 0xf4, 0x0a, 0xc2, 0x88, 0xa5, 0x94, 0x2b, 0x26, 0x78, 0xfd, 0xaa, 0x1b, 0xb3, 0xde,
+	0xe0, 0x0d, 0xa6, 0xd2, 0x87, 0x3c, 0x93, 0x47, 0xc1, 0x05, 0xaf, 0xff, 0x38, 0xa5, 0xee, 0x1d,
+	0xb8, 0xf8, 0x57, 0x12, 0xb4, 0x04, 0xed, 0x37, 0xf4, 0xa8, 0x7a, 0xb6, 0xb2, 0x44, 0xcb, 0x70,
+	0x6e, 0x44, 0x92, 0x9c, 0xea, 0x28, 0xed, 0xc0, 0x1c, 0x36, 0x1b, 0x1b, 0xa0, 0x1a, 0xcb, 0xd7,
+	0x06, 0x74, 0xcc, 0x1a, 0xa0, 0x35, 0xb8, 0x90, 0x30, 0x95, 0x51, 0x1e, 0x92, 0x28, 0x92, 0x54,
+	0xa9, 0xca, 0xa7, 0x63, 0xd0, 0x2d, 0x03, 0x96, 0x8e, 0x43, 0x4a, 0xa5, 0x72, 0x1b, 0x3d, 0xbb,
+	0x74, 0xd4, 0x07, 0xb4, 0x0a, 0x3b, 0x65, 0x11, 0x46, 0x5c, 0x85, 0x7a, 0x75, 0x6c, 0xad, 0x9d,
+	0x2f, 0xc1, 0x01, 0x57, 0x3b, 0xe5, 0x06, 0xdd, 0x83, 0x2d, 0xc6, 0x33, 0x2a, 0x47, 0x24, 0x71,
+	0x9b, 0xb3, 0xb7, 0x3d, 0x15, 0xa1, 0x1d, 0xb8, 0x24, 0xe9, 0xbe, 0xa4, 0xea, 0x20, 0x9c, 0x1a,
+	0xcd, 0xcd, 0x6e, 0xb4, 0x58, 0x89, 0x1f, 0xd7, 0x7e, 0x37, 0x60, 0x47, 0x1d, 0x10, 0x49, 0xa3,
+	0x50, 0xd1, 0x3d, 0x49, 0x33, 0xd7, 0xd1, 0xa1, 0xaf, 0x18, 0xf0, 0xa5, 0xc6, 0xcc, 0x9c, 0xb6,
+	0xef, 0x1f, 0x8f, 0xb1, 0x75, 0x32, 0xc6, 0xd6, 0xe9, 0x18, 0x5b, 0x67, 0x63, 0x6c, 0xbd, 0x2b,
+	0x30, 0xf8, 0x56, 0x60, 0xeb, 0xb8, 0xc0, 0xe0, 0xa4, 0xc0, 0xe0, 0x57, 0x81, 0xc1, 0xef, 0x02,
+	0x5b, 0x67, 0x05, 0x06, 0x1f, 0x27, 0xd8, 0x3a, 0x99, 0x60, 0xeb, 0x74, 0x82, 0xad, 0xd7, 0x8e,
+	0xd9, 0xa2, 0x5d, 0x47, 0x47, 0xbb, 0xfd, 0x27, 0x00, 0x00, 0xff, 0xff, 0x97, 0xad, 0xf1, 0xcf,
+	0xd4, 0x04, 0x00, 0x00,
 }
 
 func (m *Params) Marshal() (dAtA []byte, err error) {
@@ -300,6 +413,16 @@ func (m *Params) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err1
 	}
 	i += n1
+	if m.Gossip != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.Gossip.Size()))
+		n2, err2 := m.Gossip.MarshalTo(dAtA[i:])
+		if err2 != nil {
+			return 0, err2
+		}
+		i += n2
+	}
 	return i, nil
 }
 
@@ -332,11 +455,11 @@ func (m *Params_Quota) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.ValidDuration)))
-	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ValidDuration, dAtA[i:])
-	if err2 != nil {
-		return 0, err2
+	n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ValidDuration, dAtA[i:])
+	if err3 != nil {
+		return 0, err3
 	}
-	i += n2
+	i += n3
 	if len(m.Overrides) > 0 {
 		for _, msg := range m.Overrides {
 			dAtA[i] = 0x22
@@ -392,11 +515,78 @@ func (m *Params_Override) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.ValidDuration)))
-	n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ValidDuration, dAtA[i:])
-	if err3 != nil {
-		return 0, err3
+	n4, err4 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ValidDuration, dAtA[i:])
+	if err4 != nil {
+		return 0, err4
+	}
+	i += n4
+	return i, nil
+}
+
+func (m *Params_Gossip) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params_Gossip) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ListenAddress) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.ListenAddress)))
+		i += copy(dAtA[i:], m.ListenAddress)
+	}
+	if len(m.Peers) > 0 {
+		for _, s := range m.Peers {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.PeerDnsName) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.PeerDnsName)))
+		i += copy(dAtA[i:], m.PeerDnsName)
+	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Interval)))
+	n5, err5 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Interval, dAtA[i:])
+	if err5 != nil {
+		return 0, err5
+	}
+	i += n5
+	dAtA[i] = 0x2a
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.RefreshInterval)))
+	n6, err6 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RefreshInterval, dAtA[i:])
+	if err6 != nil {
+		return 0, err6
+	}
+	i += n6
+	if len(m.SharedSecret) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.SharedSecret)))
+		i += copy(dAtA[i:], m.SharedSecret)
 	}
-	i += n3
 	return i, nil
 }
 
@@ -423,6 +613,10 @@ func (m *Params) Size() (n int) {
 	}
 	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MinDeduplicationDuration)
 	n += 1 + l + sovConfig(uint64(l))
+	if m.Gossip != nil {
+		l = m.Gossip.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
 	return n
 }
 
@@ -472,6 +666,37 @@ func (m *Params_Override) Size() (n int) {
 	return n
 }
 
+func (m *Params_Gossip) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ListenAddress)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if len(m.Peers) > 0 {
+		for _, s := range m.Peers {
+			l = len(s)
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	l = len(m.PeerDnsName)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Interval)
+	n += 1 + l + sovConfig(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.RefreshInterval)
+	n += 1 + l + sovConfig(uint64(l))
+	l = len(m.SharedSecret)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	return n
+}
+
 func sovConfig(x uint64) (n int) {
 	for {
 		n++
@@ -497,6 +722,7 @@ func (this *Params) String() string {
 	s := strings.Join([]string{`&Params{`,
 		`Quotas:` + repeatedStringForQuotas + `,`,
 		`MinDeduplicationDuration:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.MinDeduplicationDuration), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`Gossip:` + strings.Replace(fmt.Sprintf("%v", this.Gossip), "Params_Gossip", "Params_Gossip", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -541,6 +767,21 @@ func (this *Params_Override) String() string {
 	}, "")
 	return s
 }
+func (this *Params_Gossip) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Params_Gossip{`,
+		`ListenAddress:` + fmt.Sprintf("%v", this.ListenAddress) + `,`,
+		`Peers:` + fmt.Sprintf("%v", this.Peers) + `,`,
+		`PeerDnsName:` + fmt.Sprintf("%v", this.PeerDnsName) + `,`,
+		`Interval:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Interval), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`RefreshInterval:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.RefreshInterval), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`SharedSecret:` + fmt.Sprintf("%v", this.SharedSecret) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringConfig(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -645,6 +886,42 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gossip", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Gossip == nil {
+				m.Gossip = &Params_Gossip{}
+			}
+			if err := m.Gossip.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -1072,6 +1349,253 @@ func (m *Params_Override) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Params_Gossip) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Gossip: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Gossip: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListenAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ListenAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerDnsName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerDnsName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Interval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefreshInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.RefreshInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SharedSecret", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SharedSecret = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipConfig(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,574 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memquota
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"istio.io/istio/mixer/adapter/memquota/config"
+	"istio.io/istio/mixer/pkg/adapter"
+)
+
+const (
+	// usagePath is the path on which the peers post their usage.
+	usagePath = "/memquota/v1/usage"
+
+	// signatureHeader carries the HMAC-SHA256 of the message, in hex, when a secret is shared.
+	signatureHeader = "X-Memquota-Signature"
+
+	// maxMessageSize bounds the size of the messages read from the peers.
+	maxMessageSize = 1 << 20
+
+	defaultGossipInterval  = time.Second
+	defaultRefreshInterval = 30 * time.Second
+
+	// the usage of a peer is ignored after this many intervals without news from it.
+	staleIntervals = 3
+)
+
+type (
+	// usageMessage is the message periodically sent by each handler to the peers.
+	usageMessage struct {
+		// Sender identifies the replica sending the message.
+		Sender string `json:"sender"`
+
+		// Handler identifies the handler within the replica. A replica runs several handlers
+		// while its configuration changes.
+		Handler string `json:"handler"`
+
+		// Scope identifies the quota configuration of the handler. The usage is only shared
+		// between the handlers with the same configuration.
+		Scope string `json:"scope"`
+
+		// Usage is the amount currently allocated, by quota key.
+		Usage map[string]int64 `json:"usage"`
+
+		// Closed is set in the last message of a handler, so that the peers stop counting its usage.
+		Closed bool `json:"closed,omitempty"`
+	}
+
+	// peerUsage is the last usage received from a handler of a peer.
+	peerUsage struct {
+		scope    string
+		usage    map[string]int64
+		received time.Time
+
+		// the handler is closed, its late messages are ignored until the entry is pruned
+		closed bool
+	}
+
+	// gossipServer receives the usage of the peers. It is shared by the handlers listening on
+	// the same address, so that a handler can be rebuilt while the previous one is still running.
+	gossipServer struct {
+		// the identity of this replica, used to ignore its own messages
+		id string
+
+		// the registry key, empty if the server is not shared
+		key string
+
+		listener net.Listener
+		server   *http.Server
+
+		// number of handlers using the server, protected by the registry lock
+		refs int
+
+		mu      sync.RWMutex
+		peers   map[string]peerUsage // by sender and handler
+		gossips map[*gossip]struct{} // the handlers using the server, which authorize the messages
+	}
+
+	// gossip shares the usage of a handler with the peers, and gives the usage of the peers.
+	gossip struct {
+		id       string
+		scope    string
+		server   *gossipServer
+		usage    func() map[string]int64
+		client   *http.Client
+		interval time.Duration
+		refresh  time.Duration
+		port     string
+		static   []string
+		dnsName  string
+		secret   []byte
+		logger   adapter.Logger
+		done     chan struct{}
+
+		mu    sync.Mutex
+		peers []string
+		ips   map[string]bool // the addresses from which the messages of the peers are accepted
+	}
+)
+
+var (
+	serversMu sync.Mutex
+	servers   = make(map[string]*gossipServer)
+)
+
+// validateGossip checks the gossip configuration.
+func validateGossip(g *config.Params_Gossip) (ce *adapter.ConfigErrors) {
+	if _, _, err := net.SplitHostPort(g.ListenAddress); err != nil {
+		ce = ce.Appendf("gossip.listenAddress", "listen address '%s' is malformed: %v", g.ListenAddress, err)
+	}
+	if len(g.Peers) == 0 && g.PeerDnsName == "" {
+		ce = ce.Appendf("gossip", "either peers or peerDnsName must be specified")
+	}
+	for _, p := range g.Peers {
+		if _, _, err := net.SplitHostPort(p); err != nil {
+			ce = ce.Appendf("gossip.peers", "peer address '%s' is malformed: %v", p, err)
+		}
+	}
+	if g.Interval < 0 {
+		ce = ce.Appendf("gossip.interval", "interval of %v is invalid, must be >= 0", g.Interval)
+	}
+	if g.RefreshInterval < 0 {
+		ce = ce.Appendf("gossip.refreshInterval", "refresh interval of %v is invalid, must be >= 0", g.RefreshInterval)
+	}
+	return
+}
+
+// quotaScope returns the scope of the usage of a handler with the given quotas.
+func quotaScope(quotas []config.Params_Quota) (string, error) {
+	sorted := append([]config.Params_Quota{}, quotas...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	b, err := json.Marshal(sorted)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// newGossip starts sharing the usage returned by the function with the configured peers. The
+// usage is only shared with the handlers of the peers using the same quotas.
+func newGossip(g *config.Params_Gossip, quotas []config.Params_Quota, usage func() map[string]int64,
+	env adapter.Env) (*gossip, error) {
+	_, port, err := net.SplitHostPort(g.ListenAddress)
+	if err != nil {
+		return nil, err
+	}
+	scope, err := quotaScope(quotas)
+	if err != nil {
+		return nil, err
+	}
+
+	server, err := acquireServer(g.ListenAddress, env.Logger())
+	if err != nil {
+		return nil, err
+	}
+
+	gs := &gossip{
+		id:       newID(),
+		scope:    scope,
+		server:   server,
+		usage:    usage,
+		interval: g.Interval,
+		refresh:  g.RefreshInterval,
+		port:     port,
+		static:   g.Peers,
+		dnsName:  g.PeerDnsName,
+		secret:   []byte(g.SharedSecret),
+		logger:   env.Logger(),
+		done:     make(chan struct{}),
+	}
+	if gs.interval == 0 {
+		gs.interval = defaultGossipInterval
+	}
+	if gs.refresh == 0 {
+		gs.refresh = defaultRefreshInterval
+	}
+	gs.client = &http.Client{Timeout: gs.interval}
+
+	if err = gs.resolvePeers(); err != nil {
+		// the replicas may not be published yet, the resolution is retried periodically.
+		gs.logger.Warningf("unable to resolve the memquota peers: %v", err)
+	}
+	server.register(gs)
+
+	env.ScheduleDaemon(gs.run)
+	return gs, nil
+}
+
+// run periodically sends the usage to the peers, until the gossip is closed.
+func (g *gossip) run() {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	var refresh <-chan time.Time
+	if g.dnsName != "" || hasHostNames(g.static) {
+		t := time.NewTicker(g.refresh)
+		defer t.Stop()
+		refresh = t.C
+	}
+
+	for {
+		select {
+		case <-g.done:
+			return
+		case <-refresh:
+			if err := g.resolvePeers(); err != nil {
+				g.logger.Warningf("unable to resolve the memquota peers: %v", err)
+			}
+		case <-ticker.C:
+			g.server.prune(staleIntervals * g.interval)
+			if err := g.sync(); err != nil {
+				g.logger.Debugf("unable to send the quota usage to some peers: %v", err)
+			}
+		}
+	}
+}
+
+// resolvePeers updates the peers from the static list and the DNS name.
+func (g *gossip) resolvePeers() error {
+	peers := append([]string{}, g.static...)
+
+	var err error
+	if g.dnsName != "" {
+		var addrs []string
+		if addrs, err = net.LookupHost(g.dnsName); err == nil {
+			for _, a := range addrs {
+				peers = append(peers, net.JoinHostPort(a, g.port))
+			}
+		}
+	}
+	sort.Strings(peers)
+
+	if err == nil || len(peers) > 0 {
+		if ipErr := g.setPeers(peers); ipErr != nil && err == nil {
+			err = ipErr
+		}
+	}
+	return err
+}
+
+// setPeers updates the peers, and the addresses from which their messages are accepted. The
+// peers whose host can't be resolved are still sent the usage, but their messages are ignored.
+func (g *gossip) setPeers(peers []string) error {
+	ips := make(map[string]bool)
+	var result *multierror.Error
+	for _, p := range peers {
+		host, _, err := net.SplitHostPort(p)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			ips[ip.String()] = true
+			continue
+		}
+		addrs, err := net.LookupHost(host)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+		for _, a := range addrs {
+			if ip := net.ParseIP(a); ip != nil {
+				ips[ip.String()] = true
+			}
+		}
+	}
+
+	g.mu.Lock()
+	g.peers = peers
+	g.ips = ips
+	g.mu.Unlock()
+	return result.ErrorOrNil()
+}
+
+// hasHostNames returns whether some of the peers are given by host name rather than IP address.
+func hasHostNames(peers []string) bool {
+	for _, p := range peers {
+		if host, _, err := net.SplitHostPort(p); err == nil && net.ParseIP(host) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// accepts returns whether the message was sent from the address of a peer and, if a secret is
+// shared, signed with it.
+func (g *gossip) accepts(ip string, body []byte, signature []byte) bool {
+	g.mu.Lock()
+	ok := g.ips[ip]
+	g.mu.Unlock()
+
+	return ok && (len(g.secret) == 0 || hmac.Equal(signature, g.sign(body)))
+}
+
+func (g *gossip) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, g.secret)
+	_, _ = mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// sync sends the usage to all the peers once.
+func (g *gossip) sync() error {
+	return g.broadcast(usageMessage{Sender: g.server.id, Handler: g.id, Scope: g.scope, Usage: g.usage()})
+}
+
+// broadcast sends the message to all the peers.
+func (g *gossip) broadcast(msg usageMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	peers := g.peers
+	g.mu.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(peers))
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(i int, peer string) {
+			defer wg.Done()
+			errs[i] = g.send(peer, body)
+		}(i, peer)
+	}
+	wg.Wait()
+
+	var result *multierror.Error
+	for _, err := range errs {
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	return result.ErrorOrNil()
+}
+
+func (g *gossip) send(peer string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, "http://"+peer+usagePath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(g.secret) > 0 {
+		req.Header.Set(signatureHeader, hex.EncodeToString(g.sign(body)))
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("peer %s answered with status %d", peer, resp.StatusCode)
+	}
+	return nil
+}
+
+// remoteUsage returns the amount allocated by the peers for the quota key.
+func (g *gossip) remoteUsage(key string) int64 {
+	return g.server.usage(g.scope, key, staleIntervals*g.interval)
+}
+
+// close stops the gossip, and tells the peers to stop counting the usage of the handler.
+func (g *gossip) close() {
+	close(g.done)
+	g.server.unregister(g)
+	if err := g.broadcast(usageMessage{Sender: g.server.id, Handler: g.id, Scope: g.scope, Closed: true}); err != nil {
+		g.logger.Debugf("unable to tell some peers that the quota handler is closed: %v", err)
+	}
+	releaseServer(g.server)
+}
+
+// acquireServer returns the server listening on the address, starting it if needed.
+// Servers listening on an ephemeral port can't be shared.
+func acquireServer(address string, logger adapter.Logger) (*gossipServer, error) {
+	serversMu.Lock()
+	defer serversMu.Unlock()
+
+	key := address
+	if _, port, _ := net.SplitHostPort(address); port == "0" {
+		key = ""
+	}
+
+	if s, ok := servers[key]; ok && key != "" {
+		s.refs++
+		return s, nil
+	}
+
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen for the memquota peers on %s: %v", address, err)
+	}
+
+	s := &gossipServer{
+		id:       newID(),
+		key:      key,
+		listener: l,
+		refs:     1,
+		peers:    make(map[string]peerUsage),
+		gossips:  make(map[*gossip]struct{}),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(usagePath, s.handleUsage)
+	s.server = &http.Server{Handler: mux}
+
+	go func() {
+		if err := s.server.Serve(l); err != http.ErrServerClosed {
+			logger.Errorf("memquota peer server on %s stopped: %v", address, err)
+		}
+	}()
+
+	if key != "" {
+		servers[key] = s
+	}
+	return s, nil
+}
+
+// releaseServer stops the server once it is not used anymore.
+func releaseServer(s *gossipServer) {
+	serversMu.Lock()
+	defer serversMu.Unlock()
+
+	s.refs--
+	if s.refs > 0 {
+		return
+	}
+	if s.key != "" {
+		delete(servers, s.key)
+	}
+	_ = s.server.Close()
+}
+
+func (s *gossipServer) register(g *gossip) {
+	s.mu.Lock()
+	s.gossips[g] = struct{}{}
+	s.mu.Unlock()
+}
+
+func (s *gossipServer) unregister(g *gossip) {
+	s.mu.Lock()
+	delete(s.gossips, g)
+	s.mu.Unlock()
+}
+
+// accepts returns whether a handler using the server accepts the message.
+func (s *gossipServer) accepts(r *http.Request, body []byte) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	signature, err := hex.DecodeString(r.Header.Get(signatureHeader))
+	if err != nil {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for g := range s.gossips {
+		if g.accepts(ip.String(), body, signature) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *gossipServer) handleUsage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !s.accepts(r, body) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var msg usageMessage
+	if err = json.Unmarshal(body, &msg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if msg.Sender != s.id {
+		key := msg.Sender + "/" + msg.Handler
+		s.mu.Lock()
+		// a message sent before the handler closed may arrive after the closing one.
+		if p, ok := s.peers[key]; msg.Closed || !ok || !p.closed {
+			s.peers[key] = peerUsage{scope: msg.Scope, usage: msg.Usage, received: time.Now(), closed: msg.Closed}
+		}
+		s.mu.Unlock()
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// usage returns the amount allocated by the peers for the key within the scope, ignoring the
+// closed handlers and the peers not heard from within maxAge.
+func (s *gossipServer) usage(scope string, key string, maxAge time.Duration) int64 {
+	oldest := time.Now().Add(-maxAge)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var total int64
+	for _, p := range s.peers {
+		if !p.closed && p.scope == scope && p.received.After(oldest) {
+			total += p.usage[key]
+		}
+	}
+	return total
+}
+
+// prune forgets the peers not heard from within maxAge.
+func (s *gossipServer) prune(maxAge time.Duration) {
+	oldest := time.Now().Add(-maxAge)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, p := range s.peers {
+		if !p.received.After(oldest) {
+			delete(s.peers, k)
+		}
+	}
+}
+
+func (s *gossipServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
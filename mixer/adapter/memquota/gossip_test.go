@@ -0,0 +1,368 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memquota
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"istio.io/istio/mixer/adapter/memquota/config"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/adapter/test"
+	"istio.io/istio/mixer/template/quota"
+)
+
+// newReplicas builds handlers sharing their usage with each other. The usage is only sent when
+// sync is called.
+func newReplicas(t *testing.T, n int) []*handler {
+	t.Helper()
+	return newReplicasWithSecrets(t, make([]string, n)...)
+}
+
+// newReplicasWithSecrets builds a replica for each of the shared secrets.
+func newReplicasWithSecrets(t *testing.T, secrets ...string) []*handler {
+	t.Helper()
+
+	params := make([]*config.Params, len(secrets))
+	for i := range params {
+		params[i] = replicaParams(10)
+		params[i].Gossip.SharedSecret = secrets[i]
+	}
+	return newReplicasWithParams(t, params...)
+}
+
+// replicaParams returns the configuration of a replica whose quotas have the given limit.
+func replicaParams(maxAmount int64) *config.Params {
+	return &config.Params{
+		MinDeduplicationDuration: time.Hour,
+		Quotas: []config.Params_Quota{
+			{Name: "rate", MaxAmount: maxAmount, ValidDuration: time.Minute},
+			{Name: "alloc", MaxAmount: maxAmount},
+		},
+		Gossip: &config.Params_Gossip{
+			ListenAddress: "127.0.0.1:0",
+			Peers:         []string{"127.0.0.1:1"},
+			Interval:      time.Hour,
+		},
+	}
+}
+
+// newReplicasWithParams builds a replica for each of the configurations.
+func newReplicasWithParams(t *testing.T, params ...*config.Params) []*handler {
+	t.Helper()
+
+	replicas := make([]*handler, len(params))
+	for i := range replicas {
+		b := GetInfo().NewBuilder().(*builder)
+		b.SetAdapterConfig(params[i])
+		if err := b.Validate(); err != nil {
+			t.Fatalf("Validate() failed: %v", err)
+		}
+		h, err := b.Build(context.Background(), test.NewEnv(t))
+		if err != nil {
+			t.Fatalf("Build() failed: %v", err)
+		}
+		replicas[i] = h.(*handler)
+	}
+
+	var peers []string
+	for _, h := range replicas {
+		peers = append(peers, h.gossip.server.addr())
+	}
+	for _, h := range replicas {
+		if err := h.gossip.setPeers(peers); err != nil {
+			t.Fatalf("setPeers() failed: %v", err)
+		}
+	}
+	return replicas
+}
+
+func syncReplicas(t *testing.T, replicas []*handler) {
+	t.Helper()
+	for _, h := range replicas {
+		if err := h.gossip.sync(); err != nil {
+			t.Fatalf("sync() failed: %v", err)
+		}
+	}
+}
+
+func closeReplicas(replicas []*handler) {
+	for _, h := range replicas {
+		_ = h.Close()
+	}
+}
+
+func allocate(t *testing.T, h *handler, name, dedup string, amount int64, bestEffort bool) int64 {
+	t.Helper()
+	res, err := h.HandleQuota(context.Background(), &quota.Instance{Name: name},
+		adapter.QuotaArgs{DeduplicationID: dedup, QuotaAmount: amount, BestEffort: bestEffort})
+	if err != nil {
+		t.Fatalf("HandleQuota() failed: %v", err)
+	}
+	return res.Amount
+}
+
+func TestGossipSharesAllocations(t *testing.T) {
+	for _, name := range []string{"rate", "alloc"} {
+		t.Run(name, func(t *testing.T) {
+			replicas := newReplicas(t, 3)
+			defer closeReplicas(replicas)
+
+			if got := allocate(t, replicas[0], name, "0", 6, false); got != 6 {
+				t.Fatalf("got %d from the first replica, want 6", got)
+			}
+
+			// before the replicas gossip, each one enforces the limit on its own.
+			if got := allocate(t, replicas[1], name, "1", 6, false); got != 6 {
+				t.Fatalf("got %d from the second replica, want 6", got)
+			}
+
+			syncReplicas(t, replicas)
+
+			if got := allocate(t, replicas[2], name, "2", 1, false); got != 0 {
+				t.Errorf("got %d from the third replica, want 0", got)
+			}
+			if got := allocate(t, replicas[0], name, "3", 1, true); got != 0 {
+				t.Errorf("got %d from the first replica, want 0", got)
+			}
+
+			// freeing quota on a replica makes it available to the others once they gossip.
+			if got := allocate(t, replicas[1], name, "4", -6, false); got != 6 {
+				t.Fatalf("got %d released by the second replica, want 6", got)
+			}
+			syncReplicas(t, replicas)
+
+			if got := allocate(t, replicas[2], name, "5", 5, true); got != 4 {
+				t.Errorf("got %d from the third replica, want 4", got)
+			}
+		})
+	}
+}
+
+func TestGossipIgnoresStalePeers(t *testing.T) {
+	replicas := newReplicas(t, 2)
+	defer closeReplicas(replicas)
+
+	if got := allocate(t, replicas[0], "rate", "0", 10, false); got != 10 {
+		t.Fatalf("got %d from the first replica, want 10", got)
+	}
+	syncReplicas(t, replicas)
+
+	if got := replicas[1].remoteUsage(makeKey("rate", nil)); got != 10 {
+		t.Errorf("got a remote usage of %d, want 10", got)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if got := replicas[1].gossip.server.usage(replicas[1].gossip.scope, makeKey("rate", nil), time.Millisecond); got != 0 {
+		t.Errorf("got a remote usage of %d from a stale peer, want 0", got)
+	}
+	replicas[1].gossip.server.prune(time.Millisecond)
+	if len(replicas[1].gossip.server.peers) != 0 {
+		t.Errorf("got %d peers after pruning, want 0", len(replicas[1].gossip.server.peers))
+	}
+}
+
+func TestGossipSharedServer(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	// a handler rebuilt on a configuration change listens on the same address as the previous one.
+	s1, err := acquireServer(addr, test.NewEnv(t).Logger())
+	if err != nil {
+		t.Fatalf("acquireServer() failed: %v", err)
+	}
+	s2, err := acquireServer(addr, test.NewEnv(t).Logger())
+	if err != nil {
+		t.Fatalf("acquireServer() failed: %v", err)
+	}
+	if s1 != s2 {
+		t.Error("got distinct servers for the same address")
+	}
+
+	releaseServer(s1)
+	c, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("server stopped while still in use: %v", err)
+	}
+	_ = c.Close()
+	releaseServer(s2)
+	if c, err = net.Dial("tcp", addr); err == nil {
+		_ = c.Close()
+		t.Error("server still listening after being released")
+	}
+}
+
+func TestGossipResolvePeers(t *testing.T) {
+	g := &gossip{static: []string{"10.0.0.1:9093"}, dnsName: "localhost", port: "9093"}
+	if err := g.resolvePeers(); err != nil {
+		t.Fatalf("resolvePeers() failed: %v", err)
+	}
+
+	found := false
+	for _, p := range g.peers {
+		found = found || p == "127.0.0.1:9093"
+	}
+	if !found || g.peers[0] != "10.0.0.1:9093" {
+		t.Errorf("got peers %v, want 10.0.0.1:9093 and 127.0.0.1:9093", g.peers)
+	}
+	if !g.ips["10.0.0.1"] || !g.ips["127.0.0.1"] {
+		t.Errorf("got peer addresses %v, want 10.0.0.1 and 127.0.0.1", g.ips)
+	}
+}
+
+func TestGossipRejectsUnknownSenders(t *testing.T) {
+	replicas := newReplicas(t, 2)
+	defer closeReplicas(replicas)
+
+	body, err := json.Marshal(usageMessage{Sender: "intruder", Handler: "h", Scope: replicas[0].gossip.scope,
+		Usage: map[string]int64{makeKey("rate", nil): 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, remote := range []string{"10.0.0.1:1234", "127.0.0.1:1234"} {
+		r := httptest.NewRequest(http.MethodPost, usagePath, bytes.NewReader(body))
+		r.RemoteAddr = remote
+		w := httptest.NewRecorder()
+		replicas[0].gossip.server.handleUsage(w, r)
+
+		want := http.StatusNoContent
+		if remote == "10.0.0.1:1234" {
+			want = http.StatusForbidden
+		}
+		if w.Code != want {
+			t.Errorf("got status %d for a message from %s, want %d", w.Code, remote, want)
+		}
+	}
+	if got := replicas[0].remoteUsage(makeKey("rate", nil)); got != 10 {
+		t.Errorf("got a remote usage of %d, want 10 from the peer address only", got)
+	}
+}
+
+func TestGossipClosedHandler(t *testing.T) {
+	replicas := newReplicas(t, 2)
+	defer closeReplicas(replicas[1:])
+
+	if got := allocate(t, replicas[0], "rate", "0", 10, false); got != 10 {
+		t.Fatalf("got %d from the first replica, want 10", got)
+	}
+	syncReplicas(t, replicas)
+	if got := replicas[1].remoteUsage(makeKey("rate", nil)); got != 10 {
+		t.Fatalf("got a remote usage of %d, want 10", got)
+	}
+
+	// a message sent before the handler closed may arrive after the closing one.
+	g := replicas[0].gossip
+	late := usageMessage{Sender: g.server.id, Handler: g.id, Scope: g.scope, Usage: g.usage()}
+
+	// the handler rebuilt on a configuration change starts without any allocation.
+	_ = replicas[0].Close()
+	if got := replicas[1].remoteUsage(makeKey("rate", nil)); got != 0 {
+		t.Errorf("got a remote usage of %d after the peer handler closed, want 0", got)
+	}
+
+	body, err := json.Marshal(late)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodPost, usagePath, bytes.NewReader(body))
+	r.RemoteAddr = "127.0.0.1:1234"
+	replicas[1].gossip.server.handleUsage(httptest.NewRecorder(), r)
+	if got := replicas[1].remoteUsage(makeKey("rate", nil)); got != 0 {
+		t.Errorf("got a remote usage of %d after a late message of the closed handler, want 0", got)
+	}
+}
+
+func TestGossipScopedByQuotas(t *testing.T) {
+	replicas := newReplicasWithParams(t, replicaParams(10), replicaParams(10), replicaParams(20))
+	defer closeReplicas(replicas)
+
+	if got := allocate(t, replicas[0], "rate", "0", 6, false); got != 6 {
+		t.Fatalf("got %d from the first replica, want 6", got)
+	}
+	if got := allocate(t, replicas[2], "rate", "1", 15, false); got != 15 {
+		t.Fatalf("got %d from the third replica, want 15", got)
+	}
+	syncReplicas(t, replicas)
+
+	if got := replicas[1].remoteUsage(makeKey("rate", nil)); got != 6 {
+		t.Errorf("got a remote usage of %d for the replica with the same quotas, want 6", got)
+	}
+	if got := replicas[2].remoteUsage(makeKey("rate", nil)); got != 0 {
+		t.Errorf("got a remote usage of %d for the replica with other quotas, want 0", got)
+	}
+}
+
+func TestGossipSharedSecret(t *testing.T) {
+	replicas := newReplicasWithSecrets(t, "secret", "secret", "other")
+	defer closeReplicas(replicas)
+
+	if got := allocate(t, replicas[0], "rate", "0", 6, false); got != 6 {
+		t.Fatalf("got %d from the first replica, want 6", got)
+	}
+	if err := replicas[0].gossip.sync(); err == nil {
+		t.Error("sync() succeeded, want the replica with another secret to reject the usage")
+	}
+
+	if got := replicas[1].remoteUsage(makeKey("rate", nil)); got != 6 {
+		t.Errorf("got a remote usage of %d for the replica sharing the secret, want 6", got)
+	}
+	if got := replicas[2].remoteUsage(makeKey("rate", nil)); got != 0 {
+		t.Errorf("got a remote usage of %d for the replica with another secret, want 0", got)
+	}
+}
+
+func TestGossipValidate(t *testing.T) {
+	cases := []struct {
+		gossip *config.Params_Gossip
+		err    string
+	}{
+		{&config.Params_Gossip{ListenAddress: ":9093", Peers: []string{"mixer-0:9093"}}, ""},
+		{&config.Params_Gossip{ListenAddress: ":9093", PeerDnsName: "mixer-peers"}, ""},
+		{&config.Params_Gossip{ListenAddress: "9093", PeerDnsName: "mixer-peers"}, "listen address '9093' is malformed"},
+		{&config.Params_Gossip{ListenAddress: ":9093"}, "either peers or peerDnsName must be specified"},
+		{&config.Params_Gossip{ListenAddress: ":9093", Peers: []string{"mixer-0"}}, "peer address 'mixer-0' is malformed"},
+		{&config.Params_Gossip{ListenAddress: ":9093", PeerDnsName: "mixer-peers", Interval: -time.Second}, "interval of -1s is invalid"},
+		{&config.Params_Gossip{ListenAddress: ":9093", PeerDnsName: "mixer-peers", RefreshInterval: -time.Second},
+			"refresh interval of -1s is invalid"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			b := &builder{}
+			b.SetAdapterConfig(&config.Params{MinDeduplicationDuration: time.Second, Gossip: c.gossip})
+
+			err := b.Validate()
+			if c.err == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+				t.Errorf("got error %v, want %q", err, c.err)
+			}
+		})
+	}
+}
@@ -24,9 +24,15 @@
 // This means this isn't good for allocation quotas although
 // it works well enough for rate limits quotas.
 //
-// - Since the data is all memory-resident and there isn't any cross-node
-// synchronization, this adapter can't be used in an Istio mixer where
-// a single service can be handled by different mixer instances.
+// - Since the data is all memory-resident, this adapter can't be used in an
+// Istio mixer where a single service can be handled by different mixer
+// instances, unless gossip is configured. With gossip, the replicas periodically
+// send their usage to each other, and each replica deducts the usage of its
+// peers from the quota it grants. The limits are then only approximately
+// enforced, as the allocations made by a peer since its last message are not
+// known. Only the messages sent from the addresses of the peers, and signed
+// with the shared secret when one is configured, are accepted. The usage is
+// only shared between handlers configured with the same quotas.
 package memquota
 
 import (
@@ -54,6 +60,9 @@ type handler struct {
 	// the limits we know about
 	limits map[string]*config.Params_Quota
 
+	// shares the usage with the peers, nil if not configured
+	gossip *gossip
+
 	// logger provided by the framework
 	logger adapter.Logger
 }
@@ -110,18 +119,19 @@ func (h *handler) alloc(instance *quota.Instance, args adapter.QuotaArgs, q Limi
 	amount, exp, key := h.common.handleDedup(instance, args, func(key string, currentTime time.Time, currentTick int64) (int64, time.Time,
 		time.Duration) {
 		result := args.QuotaAmount
+		remote := h.remoteUsage(key)
 
 		// we optimize storage for non-expiring quotas
 		if q.GetValidDuration() == 0 {
 			inUse := h.cells[key]
 
-			if result > q.GetMaxAmount()-inUse {
+			if avail := q.GetMaxAmount() - inUse - remote; result > avail {
 				if !args.BestEffort {
 					return 0, time.Time{}, 0
 				}
 
 				// grab as much as we can
-				result = q.GetMaxAmount() - inUse
+				result = max64(avail, 0)
 			}
 			h.cells[key] = inUse + result
 			return result, time.Time{}, 0
@@ -134,15 +144,16 @@ func (h *handler) alloc(instance *quota.Instance, args adapter.QuotaArgs, q Limi
 			h.windows[key] = window
 		}
 
-		if !window.alloc(result, currentTick) {
+		window.roll(currentTick)
+		if avail := window.available() - remote; result > avail {
 			if !args.BestEffort {
 				return 0, time.Time{}, 0
 			}
 
 			// grab as much as we can
-			result = window.available()
-			_ = window.alloc(result, currentTick)
+			result = max64(avail, 0)
 		}
+		_ = window.alloc(result, currentTick)
 
 		return result, currentTime.Add(q.GetValidDuration()), q.GetValidDuration()
 	})
@@ -199,8 +210,46 @@ func (h *handler) free(instance *quota.Instance, args adapter.QuotaArgs, q Limit
 	}, nil
 }
 
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// remoteUsage returns the amount allocated by the peers for the key.
+func (h *handler) remoteUsage(key string) int64 {
+	if h.gossip == nil {
+		return 0
+	}
+	return h.gossip.remoteUsage(key)
+}
+
+// usage returns the amount currently allocated by this replica, by key.
+func (h *handler) usage() map[string]int64 {
+	h.common.Lock()
+	defer h.common.Unlock()
+
+	currentTick := h.common.getTime().UnixNano() / nanosPerTick
+
+	result := make(map[string]int64, len(h.cells)+len(h.windows))
+	for key, inUse := range h.cells {
+		result[key] = inUse
+	}
+	for key, window := range h.windows {
+		window.roll(currentTick)
+		if used := window.used(); used > 0 {
+			result[key] = used
+		}
+	}
+	return result
+}
+
 func (h *handler) Close() error {
 	h.common.ticker.Stop()
+	if h.gossip != nil {
+		h.gossip.close()
+	}
 	return nil
 }
 
@@ -227,6 +276,9 @@ func (b *builder) Validate() (ce *adapter.ConfigErrors) {
 	if ac.MinDeduplicationDuration <= 0 {
 		ce = ce.Appendf("minDeduplicationDuration", "deduplication window of %v is invalid, must be > 0", ac.MinDeduplicationDuration)
 	}
+	if ac.Gossip != nil {
+		ce = ce.Extend(validateGossip(ac.Gossip))
+	}
 	return
 }
 
@@ -264,6 +316,14 @@ func (b *builder) buildWithDedup(_ context.Context, env adapter.Env, ticker *tim
 		logger:  env.Logger(),
 	}
 
+	if ac.Gossip != nil {
+		g, err := newGossip(ac.Gossip, ac.Quotas, h.usage, env)
+		if err != nil {
+			return nil, err
+		}
+		h.gossip = g
+	}
+
 	env.ScheduleDaemon(func() {
 		for range h.common.ticker.C {
 			h.common.Lock()
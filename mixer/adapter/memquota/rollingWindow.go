@@ -112,3 +112,12 @@ func (w *rollingWindow) roll(currentTick int64) {
 func (w *rollingWindow) available() int64 {
 	return w.avail
 }
+
+// used returns the total # of units allocated in the window.
+func (w *rollingWindow) used() int64 {
+	var total int64
+	for _, s := range w.slots {
+		total += s
+	}
+	return total
+}
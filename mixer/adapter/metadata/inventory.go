@@ -28,10 +28,12 @@ import (
 	denier "istio.io/istio/mixer/adapter/denier/config"
 	dogstatsd "istio.io/istio/mixer/adapter/dogstatsd/config"
 	fluentd "istio.io/istio/mixer/adapter/fluentd/config"
+	kafka "istio.io/istio/mixer/adapter/kafka/config"
 	kubernetesenv "istio.io/istio/mixer/adapter/kubernetesenv/config"
 	list "istio.io/istio/mixer/adapter/list/config"
 	memquota "istio.io/istio/mixer/adapter/memquota/config"
 	opa "istio.io/istio/mixer/adapter/opa/config"
+	otlp "istio.io/istio/mixer/adapter/otlp/config"
 	prometheus "istio.io/istio/mixer/adapter/prometheus/config"
 	rbac "istio.io/istio/mixer/adapter/rbac/config"
 	redisquota "istio.io/istio/mixer/adapter/redisquota/config"
@@ -131,6 +133,26 @@ var (
 			},
 		},
 
+		{
+			Name:        "kafka",
+			Impl:        "istio.io/istio/mixer/adapter/kafka",
+			Description: "Publishes logentrys to Kafka topics",
+			SupportedTemplates: []string{
+				logentry.TemplateName,
+			},
+			DefaultConfig: &kafka.Params{
+				Brokers:       []string{"localhost:9092"},
+				Topic:         "istio-logs",
+				ClientId:      "istio-mixer",
+				KafkaVersion:  "0.10.2.0",
+				MaxBatchSize:  100,
+				FlushInterval: 500 * time.Millisecond,
+				BufferSize:    10000,
+				Timeout:       10 * time.Second,
+				MaxRetries:    3,
+			},
+		},
+
 		{
 			Name:        "kubernetesenv",
 			Impl:        "istio.io/istio/mixer/adapter/kubernetesenv",
@@ -201,6 +223,28 @@ var (
 			DefaultConfig: &opa.Params{},
 		},
 
+		{
+			Name:        "otlp",
+			Impl:        "istio.io/istio/mixer/adapter/otlp",
+			Description: "Exports metrics and traces over the OpenTelemetry protocol",
+			SupportedTemplates: []string{
+				metric.TemplateName,
+				tracespan.TemplateName,
+			},
+			DefaultConfig: &otlp.Params{
+				Address:       "localhost:4317",
+				MaxBatchSize:  512,
+				MaxQueueSize:  8192,
+				FlushInterval: 5 * time.Second,
+				ExportTimeout: 10 * time.Second,
+				RetryPolicy: &otlp.Params_RetryPolicy{
+					InitialBackoff: time.Second,
+					MaxBackoff:     30 * time.Second,
+					MaxElapsedTime: time.Minute,
+				},
+			},
+		},
+
 		{
 			Name:        "prometheus",
 			Impl:        "istio.io/istio/mixer/adapter/prometheus",
@@ -0,0 +1,393 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/util"
+
+	"istio.io/istio/mixer/adapter/opa/config"
+)
+
+const (
+	regoExt       = ".rego"
+	dataFile      = "data.json"
+	manifestFile  = ".manifest"
+	signatureFile = ".signature"
+)
+
+type (
+	// bundle is a set of policies and data loaded from an OPA bundle.
+	bundle struct {
+		// the revision given by the manifest, or the digest of the bundle
+		revision string
+		modules  map[string]*ast.Module
+		data     map[string]interface{}
+	}
+
+	// bundleLoader loads a bundle from a URL or from the file system.
+	bundleLoader struct {
+		url    string
+		path   string
+		key    crypto.PublicKey
+		client *http.Client
+
+		// the validators of the last bundle loaded
+		etag   string
+		digest []byte
+	}
+
+	// manifest is the content of the manifest file of a bundle.
+	manifest struct {
+		Revision string `json:"revision"`
+	}
+
+	// ecdsaSignature is the ASN.1 encoding of an ECDSA signature.
+	ecdsaSignature struct {
+		R, S *big.Int
+	}
+)
+
+func newBundleLoader(cfg *config.Params_Bundle, key crypto.PublicKey) *bundleLoader {
+	return &bundleLoader{
+		url:    cfg.Url,
+		path:   cfg.Path,
+		key:    key,
+		client: &http.Client{Timeout: refreshInterval(cfg)},
+	}
+}
+
+// load returns the bundle, or nil if it didn't change since the last call.
+func (l *bundleLoader) load() (*bundle, error) {
+	var files map[string][]byte
+	var etag string
+	var err error
+
+	if l.path != "" {
+		files, err = readPath(l.path)
+	} else {
+		files, etag, err = l.download()
+	}
+	if err != nil || files == nil {
+		return nil, err
+	}
+
+	digest := digestFiles(files)
+	if bytes.Equal(digest, l.digest) {
+		l.etag = etag
+		return nil, nil
+	}
+
+	if l.key != nil {
+		if err = verifySignature(l.key, digest, files[signatureFile]); err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := parseBundle(files)
+	if err != nil {
+		return nil, err
+	}
+	if b.revision == "" {
+		b.revision = hex.EncodeToString(digest)
+	}
+
+	l.etag = etag
+	l.digest = digest
+	return b, nil
+}
+
+// download fetches the bundle from the URL, returning no files if it wasn't modified.
+func (l *bundleLoader) download() (map[string][]byte, string, error) {
+	req, err := http.NewRequest(http.MethodGet, l.url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if l.etag != "" {
+		req.Header.Set("If-None-Match", l.etag)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, "", nil
+	default:
+		return nil, "", fmt.Errorf("unable to download the bundle from %s: status %d", l.url, resp.StatusCode)
+	}
+
+	files, err := readTarball(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to read the bundle from %s: %v", l.url, err)
+	}
+	return files, resp.Header.Get("ETag"), nil
+}
+
+// readPath returns the files of the bundle stored at the path, either as a directory or as a tarball.
+func readPath(p string) (map[string][]byte, error) {
+	fi, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fi.IsDir() {
+		f, err := os.Open(p)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+
+		files, err := readTarball(f)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the bundle %s: %v", p, err)
+		}
+		return files, nil
+	}
+
+	files := make(map[string][]byte)
+	err = filepath.Walk(p, func(name string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+
+		rel, err := filepath.Rel(p, name)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)], err = ioutil.ReadFile(name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// readTarball returns the regular files of a gzipped tarball.
+func readTarball(r io.Reader) (map[string][]byte, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		if files[name], err = ioutil.ReadAll(tr); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// digestFiles returns the SHA-256 digest of the files, ignoring the signature. The digest is
+// computed over the hashes of the files, so that the bundle can be signed using standard tools.
+func digestFiles(files map[string][]byte) []byte {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if name != signatureFile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		_, _ = fmt.Fprintf(h, "%x  %s\n", sha256.Sum256(files[name]), name)
+	}
+	return h.Sum(nil)
+}
+
+// parseSigningKey returns the RSA or ECDSA public key encoded in PEM.
+func parseSigningKey(s string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, errors.New("no PEM encoded key found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		// keys generated by openssl genrsa are PKCS #1 encoded.
+		if rsaKey, rsaErr := x509.ParsePKCS1PublicKey(block.Bytes); rsaErr == nil {
+			return rsaKey, nil
+		}
+		return nil, err
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("keys of type %T are not supported", key)
+	}
+}
+
+// verifySignature checks that the base64 encoded signature is the signature of the digest.
+func verifySignature(key crypto.PublicKey, digest []byte, signature []byte) error {
+	if signature == nil {
+		return errors.New("the bundle is not signed")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("the signature of the bundle is malformed: %v", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		err = rsa.VerifyPKCS1v15(k, crypto.SHA256, digest, sig)
+	case *ecdsa.PublicKey:
+		var es ecdsaSignature
+		if rest, asnErr := asn1.Unmarshal(sig, &es); asnErr != nil || len(rest) > 0 || !ecdsa.Verify(k, digest, es.R, es.S) {
+			err = errors.New("verification error")
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("the signature of the bundle is invalid: %v", err)
+	}
+	return nil
+}
+
+// parseBundle builds a bundle from its files.
+func parseBundle(files map[string][]byte) (*bundle, error) {
+	b := &bundle{
+		modules: make(map[string]*ast.Module),
+		data:    make(map[string]interface{}),
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		content := files[name]
+
+		switch {
+		case strings.HasSuffix(name, regoExt):
+			module, err := ast.ParseModule(name, string(content))
+			if err != nil {
+				return nil, err
+			}
+			b.modules[name] = module
+
+		case path.Base(name) == dataFile:
+			var value interface{}
+			if err := util.NewJSONDecoder(bytes.NewReader(content)).Decode(&value); err != nil {
+				return nil, fmt.Errorf("unable to parse %s: %v", name, err)
+			}
+
+			var key []string
+			if dir := path.Dir(name); dir != "." {
+				key = strings.Split(dir, "/")
+			}
+			if err := insertData(b.data, key, value); err != nil {
+				return nil, fmt.Errorf("unable to load %s: %v", name, err)
+			}
+
+		case name == manifestFile:
+			var m manifest
+			if err := util.NewJSONDecoder(bytes.NewReader(content)).Decode(&m); err != nil {
+				return nil, fmt.Errorf("unable to parse the manifest: %v", err)
+			}
+			b.revision = m.Revision
+		}
+	}
+
+	return b, nil
+}
+
+// insertData merges the value in the data under the key.
+func insertData(data map[string]interface{}, key []string, value interface{}) error {
+	for _, k := range key {
+		child, ok := data[k]
+		if !ok {
+			child = make(map[string]interface{})
+			data[k] = child
+		}
+		if data, ok = child.(map[string]interface{}); !ok {
+			return fmt.Errorf("the document at %s is not an object", k)
+		}
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New("the document must be an object")
+	}
+	return mergeData(data, obj)
+}
+
+// mergeData merges the documents of src in dst. A document defined in both must be an object in both.
+func mergeData(dst, src map[string]interface{}) error {
+	for k, v := range src {
+		existing, found := dst[k]
+		if !found {
+			dst[k] = v
+			continue
+		}
+
+		dstObj, dstOk := existing.(map[string]interface{})
+		srcObj, srcOk := v.(map[string]interface{})
+		if !dstOk || !srcOk {
+			return fmt.Errorf("the document %s is defined more than once", k)
+		}
+		if err := mergeData(dstObj, srcObj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,435 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/googleapis/google/rpc"
+
+	"istio.io/istio/mixer/adapter/opa/config"
+	"istio.io/istio/mixer/pkg/adapter/test"
+	"istio.io/istio/mixer/template/authorization"
+)
+
+const bundlePolicy = `package mixerauthz
+
+default allow = false
+
+allow = true {
+  input.subject.user = data.users[_]
+}`
+
+// bundleServer serves a bundle as a gzipped tarball, and supports conditional requests.
+type bundleServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	bundle      []byte
+	etag        string
+	notModified int
+}
+
+func newBundleServer(t *testing.T, files map[string]string) *bundleServer {
+	s := &bundleServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if r.Header.Get("If-None-Match") == s.etag {
+			s.notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", s.etag)
+		_, _ = w.Write(s.bundle)
+	}))
+	s.set(t, files)
+	return s
+}
+
+func (s *bundleServer) set(t *testing.T, files map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bundle = makeTarball(t, files)
+	s.etag = fmt.Sprintf(`"%x"`, sha256.Sum256(s.bundle))
+}
+
+func (s *bundleServer) notModifiedCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.notModified
+}
+
+func makeTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		// bundles built by the opa tools have absolute paths.
+		if err := tw.WriteHeader(&tar.Header{Name: "/" + name, Mode: 0600, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// signBundle adds the signature of the files to the files.
+func signBundle(t *testing.T, key crypto.Signer, files map[string]string) map[string]string {
+	t.Helper()
+
+	raw := make(map[string][]byte)
+	for name, content := range files {
+		raw[name] = []byte(content)
+	}
+
+	sig, err := key.Sign(rand.Reader, digestFiles(raw), crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := map[string]string{signatureFile: base64.StdEncoding.EncodeToString(sig)}
+	for name, content := range files {
+		signed[name] = content
+	}
+	return signed
+}
+
+func encodePublicKey(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func buildHandler(t *testing.T, cfg *config.Params) *handler {
+	t.Helper()
+
+	b := GetInfo().NewBuilder().(*builder)
+	b.SetAdapterConfig(cfg)
+	if err := b.Validate(); err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+
+	h, err := b.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+	return h.(*handler)
+}
+
+func checkUsers(t *testing.T, h *handler, cases map[string]rpc.Code) {
+	t.Helper()
+
+	for user, expected := range cases {
+		instance := authorization.Instance{
+			Subject: &authorization.Subject{User: user},
+			Action:  &authorization.Action{},
+		}
+
+		result, err := h.HandleAuthorization(context.Background(), &instance)
+		if err != nil {
+			t.Errorf("%v: Got error %v, expecting success", user, err)
+		}
+		if result.Status.Code != int32(expected) {
+			t.Errorf("%v: Got %v, expecting %v", user, result.Status.Code, expected)
+		}
+	}
+}
+
+func TestBundleFromServer(t *testing.T) {
+	server := newBundleServer(t, map[string]string{
+		"mixerauthz/policy.rego": bundlePolicy,
+		"data.json":              `{"users": ["alice"]}`,
+		".manifest":              `{"revision": "v1"}`,
+	})
+	defer server.Close()
+
+	h := buildHandler(t, &config.Params{
+		CheckMethod: "data.mixerauthz.allow",
+		FailClose:   true,
+		Bundle:      &config.Params_Bundle{Url: server.URL, RefreshInterval: time.Hour},
+	})
+	defer func() { _ = h.Close() }()
+
+	if revision := h.currentPolicy().revision; revision != "v1" {
+		t.Errorf("Got revision %v, expecting v1", revision)
+	}
+	checkUsers(t, h, map[string]rpc.Code{
+		"alice": rpc.OK,
+		"bob":   rpc.PERMISSION_DENIED,
+	})
+
+	// an unmodified bundle is not downloaded again.
+	if err := h.loadBundle(); err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+	if n := server.notModifiedCount(); n != 1 {
+		t.Errorf("Got %d unmodified responses, expecting 1", n)
+	}
+
+	server.set(t, map[string]string{
+		"mixerauthz/policy.rego": bundlePolicy,
+		"data.json":              `{"users": ["bob"]}`,
+		".manifest":              `{"revision": "v2"}`,
+	})
+	if err := h.loadBundle(); err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+
+	if revision := h.currentPolicy().revision; revision != "v2" {
+		t.Errorf("Got revision %v, expecting v2", revision)
+	}
+	checkUsers(t, h, map[string]rpc.Code{
+		"alice": rpc.PERMISSION_DENIED,
+		"bob":   rpc.OK,
+	})
+
+	// a broken bundle doesn't replace the current one.
+	server.set(t, map[string]string{
+		"mixerauthz/policy.rego": "package mixerauthz\nallow = ",
+	})
+	if err := h.loadBundle(); err == nil {
+		t.Error("Got success, expecting an error")
+	}
+	checkUsers(t, h, map[string]rpc.Code{
+		"bob": rpc.OK,
+	})
+}
+
+func TestBundleRefresh(t *testing.T) {
+	server := newBundleServer(t, map[string]string{
+		"mixerauthz/policy.rego": bundlePolicy,
+		"data.json":              `{"users": ["alice"]}`,
+	})
+	defer server.Close()
+
+	h := buildHandler(t, &config.Params{
+		CheckMethod: "data.mixerauthz.allow",
+		Bundle:      &config.Params_Bundle{Url: server.URL, RefreshInterval: 10 * time.Millisecond},
+	})
+	defer func() { _ = h.Close() }()
+
+	revision := h.currentPolicy().revision
+	server.set(t, map[string]string{
+		"mixerauthz/policy.rego": bundlePolicy,
+		"data.json":              `{"users": ["bob"]}`,
+	})
+
+	for i := 0; i < 500 && h.currentPolicy().revision == revision; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	checkUsers(t, h, map[string]rpc.Code{
+		"alice": rpc.PERMISSION_DENIED,
+		"bob":   rpc.OK,
+	})
+}
+
+func TestBundleUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	for _, failClose := range []bool{false, true} {
+		h := buildHandler(t, &config.Params{
+			CheckMethod: "data.mixerauthz.allow",
+			FailClose:   failClose,
+			Bundle:      &config.Params_Bundle{Url: server.URL},
+		})
+
+		expected := rpc.OK
+		if failClose {
+			expected = rpc.PERMISSION_DENIED
+		}
+		checkUsers(t, h, map[string]rpc.Code{"alice": expected})
+		_ = h.Close()
+	}
+}
+
+func TestBundleFromDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "opa-bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	files := map[string]string{
+		"mixerauthz/data.json":       `{"admins": ["alice"]}`,
+		"mixerauthz/roles/data.json": `{"users": ["bob"]}`,
+		"data.json":                  `{"mixerauthz": {"roles": {"guests": ["carol"]}}}`,
+	}
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the policy is inlined, and the data is loaded from the bundle.
+	h := buildHandler(t, &config.Params{
+		Policy: []string{`package mixerauthz
+
+default allow = false
+
+allow = true {
+  input.subject.user = data.mixerauthz.admins[_]
+}
+
+allow = true {
+  input.subject.user = data.mixerauthz.roles.users[_]
+}
+
+allow = true {
+  input.subject.user = data.mixerauthz.roles.guests[_]
+}`},
+		CheckMethod: "data.mixerauthz.allow",
+		FailClose:   true,
+		Bundle:      &config.Params_Bundle{Path: dir},
+	})
+	defer func() { _ = h.Close() }()
+
+	checkUsers(t, h, map[string]rpc.Code{
+		"alice": rpc.OK,
+		"bob":   rpc.OK,
+		"carol": rpc.OK,
+		"dave":  rpc.PERMISSION_DENIED,
+	})
+}
+
+func TestBundleSignature(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"mixerauthz/policy.rego": bundlePolicy,
+		"data.json":              `{"users": ["alice"]}`,
+	}
+	tampered := signBundle(t, rsaKey, files)
+	tampered["data.json"] = `{"users": ["alice", "mallory"]}`
+
+	cases := map[string]struct {
+		key      crypto.PublicKey
+		files    map[string]string
+		expected rpc.Code
+	}{
+		"RSA":       {&rsaKey.PublicKey, signBundle(t, rsaKey, files), rpc.OK},
+		"ECDSA":     {&ecdsaKey.PublicKey, signBundle(t, ecdsaKey, files), rpc.OK},
+		"Unsigned":  {&rsaKey.PublicKey, files, rpc.PERMISSION_DENIED},
+		"Tampered":  {&rsaKey.PublicKey, tampered, rpc.PERMISSION_DENIED},
+		"Other key": {&otherKey.PublicKey, signBundle(t, ecdsaKey, files), rpc.PERMISSION_DENIED},
+	}
+
+	for id, c := range cases {
+		t.Run(id, func(t *testing.T) {
+			server := newBundleServer(t, c.files)
+			defer server.Close()
+
+			h := buildHandler(t, &config.Params{
+				CheckMethod: "data.mixerauthz.allow",
+				FailClose:   true,
+				Bundle:      &config.Params_Bundle{Url: server.URL, SigningKey: encodePublicKey(t, c.key)},
+			})
+			defer func() { _ = h.Close() }()
+
+			checkUsers(t, h, map[string]rpc.Code{"alice": c.expected})
+		})
+	}
+}
+
+func TestBundleValidate(t *testing.T) {
+	cases := map[string]struct {
+		bundle *config.Params_Bundle
+		err    string
+	}{
+		"URL":          {&config.Params_Bundle{Url: "https://example.com/bundle.tar.gz"}, ""},
+		"Path":         {&config.Params_Bundle{Path: "/etc/opa/bundle"}, ""},
+		"None":         {&config.Params_Bundle{}, "exactly one of url or path must be specified"},
+		"Both":         {&config.Params_Bundle{Url: "http://example.com", Path: "/etc"}, "exactly one of url or path must be specified"},
+		"Scheme":       {&config.Params_Bundle{Url: "ftp://example.com"}, "url scheme must be http or https"},
+		"Interval":     {&config.Params_Bundle{Path: "/etc", RefreshInterval: -time.Second}, "refresh interval of -1s is invalid"},
+		"Signing key":  {&config.Params_Bundle{Path: "/etc", SigningKey: "key"}, "unable to parse the signing key"},
+		"Decision log": {nil, "path must be specified for the FILE sink"},
+	}
+
+	for id, c := range cases {
+		t.Run(id, func(t *testing.T) {
+			cfg := &config.Params{CheckMethod: "data.mixerauthz.allow", Bundle: c.bundle}
+			if c.bundle == nil {
+				cfg.Policy = []string{bundlePolicy}
+				cfg.DecisionLogs = &config.Params_DecisionLogs{Sink: config.FILE}
+			}
+
+			b := GetInfo().NewBuilder().(*builder)
+			b.SetAdapterConfig(cfg)
+
+			err := b.Validate()
+			if c.err == "" && err != nil {
+				t.Errorf("Got error %v, expecting success", err)
+			}
+			if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+				t.Errorf("Got error %v, expecting %q", err, c.err)
+			}
+		})
+	}
+}
@@ -4,6 +4,14 @@
 // The `opa` adapter exposes an [Open Policy Agent](http://www.openpolicyagent.org) engine
 // that provides sophisticated access control mechanisms.
 //
+// Policies are either inlined in the handler configuration, or loaded from an
+// OPA bundle served over HTTP or stored on the local file system. Bundles are
+// periodically refreshed, and may be signed so that only the policies released
+// by a trusted party are enforced.
+//
+// Each decision can be logged along with its input and the revision of the
+// policies, so that auditors can trace why a request was allowed or denied.
+//
 // This adapter supports the [authorization template](https://istio.io/docs/reference/config/policy-and-telemetry/templates/authorization/).
 
 package config
@@ -12,16 +20,21 @@ import (
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	io "io"
 	math "math"
 	reflect "reflect"
+	strconv "strconv"
 	strings "strings"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -29,6 +42,34 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+// Sinks of the decision logs.
+type Params_DecisionLogs_Sink int32
+
+const (
+	// The decisions are written to the Mixer log.
+	LOG Params_DecisionLogs_Sink = 0
+	// The decisions are appended to a file, one per line.
+	FILE Params_DecisionLogs_Sink = 1
+	// The decisions are posted in batches to an HTTP endpoint, as a JSON array.
+	HTTP Params_DecisionLogs_Sink = 2
+)
+
+var Params_DecisionLogs_Sink_name = map[int32]string{
+	0: "LOG",
+	1: "FILE",
+	2: "HTTP",
+}
+
+var Params_DecisionLogs_Sink_value = map[string]int32{
+	"LOG":  0,
+	"FILE": 1,
+	"HTTP": 2,
+}
+
+func (Params_DecisionLogs_Sink) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_05827bfc1c8a686c, []int{0, 1, 0}
+}
+
 // Configuration format for the `opa` adapter.
 //
 // Example configuration:
@@ -69,6 +110,10 @@ type Params struct {
 	// If failClose is set to true and there is a runtime error,
 	// instead of disabling the adapter, close the client request
 	FailClose bool `protobuf:"varint,3,opt,name=fail_close,json=failClose,proto3" json:"fail_close,omitempty"`
+	// Bundle loaded along with the inline policies. Only one of `url` or `path` must be set.
+	Bundle *Params_Bundle `protobuf:"bytes,4,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// Decision logs configuration. No decision is logged when not set.
+	DecisionLogs *Params_DecisionLogs `protobuf:"bytes,5,opt,name=decision_logs,json=decisionLogs,proto3" json:"decision_logs,omitempty"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
@@ -103,8 +148,178 @@ func (m *Params) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Params proto.InternalMessageInfo
 
+// An OPA bundle of policies and data.
+//
+// A bundle is a gzipped tarball, or a directory, holding `.rego` policy files,
+// `data.json` documents loaded under the path of their directory, and an optional
+// `.manifest` file giving the `revision` of the bundle.
+//
+// A signed bundle also holds a `.signature` file, containing the base64 encoded
+// signature of the SHA-256 digest of the bundle. The digest is computed over the
+// lines `<SHA-256 of the file, in hex>  <path of the file>`, terminated by a newline,
+// of all the other files sorted by path. Paths are relative to the root of the bundle,
+// separated by `/`, without a leading `/`.
+type Params_Bundle struct {
+	// URL from which the bundle is downloaded, as a gzipped tarball.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Path of the bundle on the local file system, either a directory or a gzipped tarball.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Interval between the refreshes of the bundle. Defaults to 60 seconds.
+	RefreshInterval time.Duration `protobuf:"bytes,3,opt,name=refresh_interval,json=refreshInterval,proto3,stdduration" json:"refresh_interval"`
+	// PEM encoded RSA or ECDSA public key verifying the signature of the bundle.
+	// Unsigned bundles, or bundles whose signature doesn't match, are rejected when set.
+	SigningKey string `protobuf:"bytes,4,opt,name=signing_key,json=signingKey,proto3" json:"signing_key,omitempty"`
+}
+
+func (m *Params_Bundle) Reset()      { *m = Params_Bundle{} }
+func (*Params_Bundle) ProtoMessage() {}
+func (*Params_Bundle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_05827bfc1c8a686c, []int{0, 0}
+}
+func (m *Params_Bundle) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params_Bundle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params_Bundle.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params_Bundle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params_Bundle.Merge(m, src)
+}
+func (m *Params_Bundle) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params_Bundle) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params_Bundle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params_Bundle proto.InternalMessageInfo
+
+func (m *Params_Bundle) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Params_Bundle) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Params_Bundle) GetRefreshInterval() time.Duration {
+	if m != nil {
+		return m.RefreshInterval
+	}
+	return 0
+}
+
+func (m *Params_Bundle) GetSigningKey() string {
+	if m != nil {
+		return m.SigningKey
+	}
+	return ""
+}
+
+// Destination of the decision logs. Each decision is logged as a JSON object giving the
+// `input` of the policy, the `result` of the query, or the `error` which prevented it to
+// be computed, and the `revision` of the policies.
+type Params_DecisionLogs struct {
+	// The sink receiving the decisions.
+	Sink Params_DecisionLogs_Sink `protobuf:"varint,1,opt,name=sink,proto3,enum=adapter.opa.config.Params_DecisionLogs_Sink" json:"sink,omitempty"`
+	// Path of the file to which the decisions are appended, for the `FILE` sink.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// URL of the endpoint to which the decisions are posted, for the `HTTP` sink.
+	Url string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	// Maximum delay before the decisions are written to the sink. Defaults to 5 seconds.
+	FlushInterval time.Duration `protobuf:"bytes,4,opt,name=flush_interval,json=flushInterval,proto3,stdduration" json:"flush_interval"`
+	// Maximum number of decisions waiting to be written. Decisions are dropped when the
+	// sink can't keep up. Defaults to 10000.
+	BufferSize int32 `protobuf:"varint,5,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+}
+
+func (m *Params_DecisionLogs) Reset()      { *m = Params_DecisionLogs{} }
+func (*Params_DecisionLogs) ProtoMessage() {}
+func (*Params_DecisionLogs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_05827bfc1c8a686c, []int{0, 1}
+}
+func (m *Params_DecisionLogs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params_DecisionLogs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params_DecisionLogs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params_DecisionLogs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params_DecisionLogs.Merge(m, src)
+}
+func (m *Params_DecisionLogs) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params_DecisionLogs) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params_DecisionLogs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params_DecisionLogs proto.InternalMessageInfo
+
+func (m *Params_DecisionLogs) GetSink() Params_DecisionLogs_Sink {
+	if m != nil {
+		return m.Sink
+	}
+	return LOG
+}
+
+func (m *Params_DecisionLogs) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Params_DecisionLogs) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Params_DecisionLogs) GetFlushInterval() time.Duration {
+	if m != nil {
+		return m.FlushInterval
+	}
+	return 0
+}
+
+func (m *Params_DecisionLogs) GetBufferSize() int32 {
+	if m != nil {
+		return m.BufferSize
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("adapter.opa.config.Params_DecisionLogs_Sink", Params_DecisionLogs_Sink_name, Params_DecisionLogs_Sink_value)
 	proto.RegisterType((*Params)(nil), "adapter.opa.config.Params")
+	proto.RegisterType((*Params_Bundle)(nil), "adapter.opa.config.Params.Bundle")
+	proto.RegisterType((*Params_DecisionLogs)(nil), "adapter.opa.config.Params.DecisionLogs")
 }
 
 func init() {
@@ -112,24 +327,50 @@ func init() {
 }
 
 var fileDescriptor_05827bfc1c8a686c = []byte{
-	// 236 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0xcd, 0xcd, 0xac, 0x48,
-	0x2d, 0xd2, 0x4f, 0x4c, 0x49, 0x2c, 0x28, 0x49, 0x2d, 0xd2, 0xcf, 0x2f, 0x48, 0xd4, 0x4f, 0xce,
-	0xcf, 0x4b, 0xcb, 0x4c, 0x87, 0x52, 0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0x42, 0x50, 0x05,
-	0x7a, 0xf9, 0x05, 0x89, 0x7a, 0x10, 0x19, 0x29, 0x91, 0xf4, 0xfc, 0xf4, 0x7c, 0xb0, 0xb4, 0x3e,
-	0x88, 0x05, 0x51, 0xa9, 0x94, 0xc4, 0xc5, 0x16, 0x90, 0x58, 0x94, 0x98, 0x5b, 0x2c, 0x24, 0xc6,
-	0xc5, 0x56, 0x90, 0x9f, 0x93, 0x99, 0x5c, 0x29, 0xc1, 0xa8, 0xc0, 0xac, 0xc1, 0x19, 0x04, 0xe5,
-	0x09, 0x29, 0x72, 0xf1, 0x24, 0x67, 0xa4, 0x26, 0x67, 0xc7, 0xe7, 0xa6, 0x96, 0x64, 0xe4, 0xa7,
-	0x48, 0x30, 0x29, 0x30, 0x6a, 0x70, 0x06, 0x71, 0x83, 0xc5, 0x7c, 0xc1, 0x42, 0x42, 0xb2, 0x5c,
-	0x5c, 0x69, 0x89, 0x99, 0x39, 0xf1, 0xc9, 0x39, 0xf9, 0xc5, 0xa9, 0x12, 0xcc, 0x0a, 0x8c, 0x1a,
-	0x1c, 0x41, 0x9c, 0x20, 0x11, 0x67, 0x90, 0x80, 0x93, 0xc3, 0x89, 0x87, 0x72, 0x0c, 0x17, 0x1e,
-	0xca, 0x31, 0xdc, 0x78, 0x28, 0xc7, 0xf0, 0xe1, 0xa1, 0x1c, 0x43, 0xc3, 0x23, 0x39, 0xc6, 0x15,
-	0x8f, 0xe4, 0x18, 0x4e, 0x3c, 0x92, 0x63, 0xbc, 0xf0, 0x48, 0x8e, 0xf1, 0xc1, 0x23, 0x39, 0xc6,
-	0x17, 0x8f, 0xe4, 0x18, 0x3e, 0x3c, 0x92, 0x63, 0x9c, 0xf0, 0x58, 0x8e, 0xe1, 0xc2, 0x63, 0x39,
-	0x86, 0x1b, 0x8f, 0xe5, 0x18, 0xa2, 0xd8, 0x20, 0x6e, 0x4f, 0x62, 0x03, 0x3b, 0xd6, 0x18, 0x10,
-	0x00, 0x00, 0xff, 0xff, 0x8e, 0xb7, 0xf6, 0xf2, 0xff, 0x00, 0x00, 0x00,
+	// 534 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0x3f, 0x6f, 0xd3, 0x4e,
+	0x1c, 0xc6, 0xef, 0x12, 0xc7, 0xbf, 0xe4, 0x92, 0xf6, 0x67, 0x9d, 0x10, 0x32, 0x91, 0xb8, 0xa4,
+	0x45, 0x88, 0x0c, 0xc8, 0x96, 0xc2, 0x04, 0x53, 0x15, 0xca, 0x9f, 0x42, 0x80, 0xca, 0xed, 0xc4,
+	0x62, 0x39, 0xf6, 0xd9, 0x39, 0xc5, 0xf1, 0x59, 0xfe, 0x83, 0x48, 0x27, 0x46, 0x26, 0xc4, 0xc8,
+	0xc8, 0x00, 0x12, 0x2f, 0x25, 0x63, 0xc6, 0x4e, 0x40, 0x9c, 0x85, 0xb1, 0x2f, 0x01, 0xf9, 0x7c,
+	0x88, 0x48, 0x20, 0xd4, 0xc9, 0xdf, 0x7b, 0xfc, 0x7d, 0xce, 0xcf, 0xe7, 0x31, 0xba, 0x39, 0x67,
+	0xaf, 0x69, 0x62, 0x3a, 0x9e, 0x13, 0x67, 0x34, 0x31, 0x79, 0xec, 0x98, 0x2e, 0x8f, 0x7c, 0x16,
+	0xc8, 0x87, 0x11, 0x27, 0x3c, 0xe3, 0x18, 0xcb, 0x05, 0x83, 0xc7, 0x8e, 0x51, 0xbd, 0xe9, 0x92,
+	0x80, 0xf3, 0x20, 0xa4, 0xa6, 0xd8, 0x98, 0xe4, 0xbe, 0xe9, 0xe5, 0x89, 0x93, 0x31, 0x1e, 0x55,
+	0x9e, 0xee, 0x95, 0x80, 0x07, 0x5c, 0x8c, 0x66, 0x39, 0x55, 0xea, 0xfe, 0xa7, 0x06, 0x52, 0x8f,
+	0x9d, 0xc4, 0x99, 0xa7, 0xf8, 0x2a, 0x52, 0x63, 0x1e, 0x32, 0x77, 0xa1, 0xc3, 0x7e, 0x7d, 0xd0,
+	0xb2, 0xe4, 0x09, 0xef, 0xa1, 0x8e, 0x3b, 0xa5, 0xee, 0xcc, 0x9e, 0xd3, 0x6c, 0xca, 0x3d, 0xbd,
+	0xd6, 0x87, 0x83, 0x96, 0xd5, 0x16, 0xda, 0x33, 0x21, 0xe1, 0xeb, 0x08, 0xf9, 0x0e, 0x0b, 0x6d,
+	0x37, 0xe4, 0x29, 0xd5, 0xeb, 0x7d, 0x38, 0x68, 0x5a, 0xad, 0x52, 0xb9, 0x5f, 0x0a, 0xf8, 0x2e,
+	0x52, 0x27, 0x79, 0xe4, 0x85, 0x54, 0x57, 0xfa, 0x70, 0xd0, 0x1e, 0xee, 0x19, 0x7f, 0xe6, 0x37,
+	0xaa, 0x14, 0xc6, 0x48, 0x2c, 0x5a, 0xd2, 0x80, 0xc7, 0x68, 0xc7, 0xa3, 0x2e, 0x4b, 0x19, 0x8f,
+	0xec, 0x90, 0x07, 0xa9, 0xde, 0x10, 0x37, 0xdc, 0xfa, 0xc7, 0x0d, 0x87, 0x72, 0x7f, 0xcc, 0x83,
+	0xd4, 0xea, 0x78, 0x5b, 0xa7, 0xee, 0x67, 0x88, 0xd4, 0xea, 0x03, 0x58, 0x43, 0xf5, 0x3c, 0x09,
+	0x75, 0x28, 0x60, 0xca, 0x11, 0x63, 0xa4, 0xc4, 0x4e, 0x36, 0x95, 0x7c, 0x62, 0xc6, 0xcf, 0x91,
+	0x96, 0x50, 0x3f, 0xa1, 0xe9, 0xd4, 0x66, 0x51, 0x46, 0x93, 0x57, 0x4e, 0x28, 0xf0, 0xda, 0xc3,
+	0x6b, 0x46, 0xd5, 0xb7, 0xf1, 0xab, 0x6f, 0xe3, 0x50, 0xf6, 0x3d, 0x6a, 0x2e, 0xbf, 0xf6, 0xc0,
+	0x87, 0x6f, 0x3d, 0x68, 0xfd, 0x2f, 0xcd, 0x47, 0xd2, 0x8b, 0x7b, 0xa8, 0x9d, 0xb2, 0x20, 0x62,
+	0x51, 0x60, 0xcf, 0xe8, 0x42, 0xd4, 0xd1, 0xb2, 0x90, 0x94, 0x9e, 0xd2, 0xc5, 0x3d, 0xe5, 0xed,
+	0xc7, 0x1e, 0xec, 0xbe, 0xab, 0xa1, 0xce, 0x36, 0x06, 0x3e, 0x40, 0x4a, 0xca, 0xa2, 0x99, 0x88,
+	0xbb, 0x3b, 0xbc, 0x7d, 0x49, 0x7a, 0xe3, 0x84, 0x45, 0x33, 0x4b, 0x38, 0xff, 0x4a, 0x27, 0x3b,
+	0xa8, 0xff, 0xee, 0xe0, 0x09, 0xda, 0xf5, 0xc3, 0x7c, 0x9b, 0x56, 0xb9, 0x3c, 0xed, 0x8e, 0xb0,
+	0x6e, 0xb3, 0x4e, 0x72, 0xdf, 0xa7, 0x89, 0x9d, 0xb2, 0x33, 0x2a, 0x7e, 0x5c, 0xc3, 0x42, 0x95,
+	0x74, 0xc2, 0xce, 0xe8, 0xfe, 0x0d, 0xa4, 0x94, 0x01, 0xf1, 0x7f, 0xa8, 0x3e, 0x7e, 0xf1, 0x48,
+	0x03, 0xb8, 0x89, 0x94, 0x87, 0x47, 0xe3, 0x07, 0x1a, 0x2c, 0xa7, 0xc7, 0xa7, 0xa7, 0xc7, 0x5a,
+	0xad, 0x2a, 0x64, 0x74, 0xb0, 0x5c, 0x13, 0xb0, 0x5a, 0x13, 0x70, 0xbe, 0x26, 0xe0, 0x62, 0x4d,
+	0xc0, 0x9b, 0x82, 0xc0, 0x2f, 0x05, 0x01, 0xcb, 0x82, 0xc0, 0x55, 0x41, 0xe0, 0xf7, 0x82, 0xc0,
+	0x1f, 0x05, 0x01, 0x17, 0x05, 0x81, 0xef, 0x37, 0x04, 0xac, 0x36, 0x04, 0x9c, 0x6f, 0x08, 0x78,
+	0xa9, 0x56, 0xf5, 0x4c, 0x54, 0x91, 0xfc, 0xce, 0xcf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5c, 0x94,
+	0xe1, 0x0a, 0x62, 0x03, 0x00, 0x00,
 }
 
+func (x Params_DecisionLogs_Sink) String() string {
+	s, ok := Params_DecisionLogs_Sink_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
 func (m *Params) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -176,6 +417,118 @@ func (m *Params) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.Bundle != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.Bundle.Size()))
+		n1, err1 := m.Bundle.MarshalTo(dAtA[i:])
+		if err1 != nil {
+			return 0, err1
+		}
+		i += n1
+	}
+	if m.DecisionLogs != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.DecisionLogs.Size()))
+		n2, err2 := m.DecisionLogs.MarshalTo(dAtA[i:])
+		if err2 != nil {
+			return 0, err2
+		}
+		i += n2
+	}
+	return i, nil
+}
+
+func (m *Params_Bundle) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params_Bundle) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Url) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Url)))
+		i += copy(dAtA[i:], m.Url)
+	}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.RefreshInterval)))
+	n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RefreshInterval, dAtA[i:])
+	if err3 != nil {
+		return 0, err3
+	}
+	i += n3
+	if len(m.SigningKey) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.SigningKey)))
+		i += copy(dAtA[i:], m.SigningKey)
+	}
+	return i, nil
+}
+
+func (m *Params_DecisionLogs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params_DecisionLogs) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Sink != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.Sink))
+	}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if len(m.Url) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Url)))
+		i += copy(dAtA[i:], m.Url)
+	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.FlushInterval)))
+	n4, err4 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.FlushInterval, dAtA[i:])
+	if err4 != nil {
+		return 0, err4
+	}
+	i += n4
+	if m.BufferSize != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.BufferSize))
+	}
 	return i, nil
 }
 
@@ -207,6 +560,62 @@ func (m *Params) Size() (n int) {
 	if m.FailClose {
 		n += 2
 	}
+	if m.Bundle != nil {
+		l = m.Bundle.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.DecisionLogs != nil {
+		l = m.DecisionLogs.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	return n
+}
+
+func (m *Params_Bundle) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Url)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.RefreshInterval)
+	n += 1 + l + sovConfig(uint64(l))
+	l = len(m.SigningKey)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	return n
+}
+
+func (m *Params_DecisionLogs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Sink != 0 {
+		n += 1 + sovConfig(uint64(m.Sink))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.Url)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.FlushInterval)
+	n += 1 + l + sovConfig(uint64(l))
+	if m.BufferSize != 0 {
+		n += 1 + sovConfig(uint64(m.BufferSize))
+	}
 	return n
 }
 
@@ -231,6 +640,35 @@ func (this *Params) String() string {
 		`Policy:` + fmt.Sprintf("%v", this.Policy) + `,`,
 		`CheckMethod:` + fmt.Sprintf("%v", this.CheckMethod) + `,`,
 		`FailClose:` + fmt.Sprintf("%v", this.FailClose) + `,`,
+		`Bundle:` + strings.Replace(fmt.Sprintf("%v", this.Bundle), "Params_Bundle", "Params_Bundle", 1) + `,`,
+		`DecisionLogs:` + strings.Replace(fmt.Sprintf("%v", this.DecisionLogs), "Params_DecisionLogs", "Params_DecisionLogs", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Params_Bundle) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Params_Bundle{`,
+		`Url:` + fmt.Sprintf("%v", this.Url) + `,`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`RefreshInterval:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.RefreshInterval), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`SigningKey:` + fmt.Sprintf("%v", this.SigningKey) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Params_DecisionLogs) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Params_DecisionLogs{`,
+		`Sink:` + fmt.Sprintf("%v", this.Sink) + `,`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`Url:` + fmt.Sprintf("%v", this.Url) + `,`,
+		`FlushInterval:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.FlushInterval), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`BufferSize:` + fmt.Sprintf("%v", this.BufferSize) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -356,6 +794,448 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.FailClose = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bundle", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Bundle == nil {
+				m.Bundle = &Params_Bundle{}
+			}
+			if err := m.Bundle.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DecisionLogs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DecisionLogs == nil {
+				m.DecisionLogs = &Params_DecisionLogs{}
+			}
+			if err := m.DecisionLogs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params_Bundle) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Bundle: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Bundle: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Url = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefreshInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.RefreshInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SigningKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SigningKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params_DecisionLogs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DecisionLogs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DecisionLogs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sink", wireType)
+			}
+			m.Sink = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sink |= Params_DecisionLogs_Sink(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Url = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FlushInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.FlushInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BufferSize", wireType)
+			}
+			m.BufferSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BufferSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
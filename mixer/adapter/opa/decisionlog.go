@@ -0,0 +1,263 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"istio.io/istio/mixer/adapter/opa/config"
+	"istio.io/istio/mixer/pkg/adapter"
+)
+
+const (
+	defaultFlushInterval = 5 * time.Second
+	defaultBufferSize    = 10000
+
+	// maximum number of decisions written at once to the sink
+	maxBatchSize = 1000
+)
+
+type (
+	// decision is the record of the evaluation of a request.
+	decision struct {
+		DecisionID string                 `json:"decision_id"`
+		Timestamp  time.Time              `json:"timestamp"`
+		Query      string                 `json:"query"`
+		Input      map[string]interface{} `json:"input"`
+		Result     *bool                  `json:"result,omitempty"`
+		Error      string                 `json:"error,omitempty"`
+		Revision   string                 `json:"revision,omitempty"`
+	}
+
+	// decisionSink writes decisions to their destination.
+	decisionSink interface {
+		write(decisions []*decision) error
+		close() error
+	}
+
+	// decisionLogger writes the decisions to a sink in the background.
+	decisionLogger struct {
+		sink          decisionSink
+		decisions     chan *decision
+		flushInterval time.Duration
+		logger        adapter.Logger
+
+		// number of decisions dropped since the last flush
+		dropped int64
+
+		done    chan struct{}
+		stopped chan struct{}
+	}
+
+	logSink struct {
+		logger adapter.Logger
+	}
+
+	fileSink struct {
+		file *os.File
+	}
+
+	httpSink struct {
+		url    string
+		client *http.Client
+	}
+)
+
+// validateDecisionLogs checks the decision logs configuration.
+func validateDecisionLogs(dl *config.Params_DecisionLogs) (ce *adapter.ConfigErrors) {
+	switch dl.Sink {
+	case config.FILE:
+		if dl.Path == "" {
+			ce = ce.Appendf("decisionLogs.path", "path must be specified for the FILE sink")
+		}
+	case config.HTTP:
+		if dl.Url == "" {
+			ce = ce.Appendf("decisionLogs.url", "url must be specified for the HTTP sink")
+		}
+	}
+	if dl.FlushInterval < 0 {
+		ce = ce.Appendf("decisionLogs.flushInterval", "flush interval of %v is invalid, must be >= 0", dl.FlushInterval)
+	}
+	if dl.BufferSize < 0 {
+		ce = ce.Appendf("decisionLogs.bufferSize", "buffer size of %d is invalid, must be >= 0", dl.BufferSize)
+	}
+	return
+}
+
+// newDecisionLogger starts writing the decisions to the configured sink.
+func newDecisionLogger(dl *config.Params_DecisionLogs, env adapter.Env) (*decisionLogger, error) {
+	flushInterval := dl.FlushInterval
+	if flushInterval == 0 {
+		flushInterval = defaultFlushInterval
+	}
+	bufferSize := int(dl.BufferSize)
+	if bufferSize == 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	var sink decisionSink
+	switch dl.Sink {
+	case config.FILE:
+		f, err := os.OpenFile(dl.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open the decision log: %v", err)
+		}
+		sink = &fileSink{file: f}
+	case config.HTTP:
+		sink = &httpSink{url: dl.Url, client: &http.Client{Timeout: flushInterval}}
+	default:
+		sink = &logSink{logger: env.Logger()}
+	}
+
+	l := &decisionLogger{
+		sink:          sink,
+		decisions:     make(chan *decision, bufferSize),
+		flushInterval: flushInterval,
+		logger:        env.Logger(),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+	env.ScheduleDaemon(l.run)
+	return l, nil
+}
+
+// log queues the decision, dropping it if the sink can't keep up.
+func (l *decisionLogger) log(d *decision) {
+	select {
+	case l.decisions <- d:
+	default:
+		atomic.AddInt64(&l.dropped, 1)
+	}
+}
+
+// run writes the queued decisions in batches, until the logger is closed.
+func (l *decisionLogger) run() {
+	defer close(l.stopped)
+
+	ticker := time.NewTicker(l.flushInterval)
+	defer ticker.Stop()
+
+	var batch []*decision
+	for {
+		select {
+		case d := <-l.decisions:
+			if batch = append(batch, d); len(batch) >= maxBatchSize {
+				batch = l.flush(batch)
+			}
+		case <-ticker.C:
+			batch = l.flush(batch)
+		case <-l.done:
+			for {
+				select {
+				case d := <-l.decisions:
+					batch = append(batch, d)
+				default:
+					l.flush(batch)
+					if err := l.sink.close(); err != nil {
+						l.logger.Warningf("unable to close the decision log: %v", err)
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush writes the batch to the sink, and returns the batch emptied.
+func (l *decisionLogger) flush(batch []*decision) []*decision {
+	if dropped := atomic.SwapInt64(&l.dropped, 0); dropped > 0 {
+		l.logger.Warningf("%d decisions were dropped from the decision log", dropped)
+	}
+	if len(batch) == 0 {
+		return batch
+	}
+
+	if err := l.sink.write(batch); err != nil {
+		l.logger.Warningf("unable to write %d decisions to the decision log: %v", len(batch), err)
+	}
+	return batch[:0]
+}
+
+// close writes the pending decisions, and closes the sink.
+func (l *decisionLogger) close() {
+	close(l.done)
+	<-l.stopped
+}
+
+func (s *logSink) write(decisions []*decision) error {
+	for _, d := range decisions {
+		b, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		s.logger.Infof("opa decision: %s", b)
+	}
+	return nil
+}
+
+func (s *logSink) close() error {
+	return nil
+}
+
+func (s *fileSink) write(decisions []*decision) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, d := range decisions {
+		if err := enc.Encode(d); err != nil {
+			return err
+		}
+	}
+	_, err := s.file.Write(buf.Bytes())
+	return err
+}
+
+func (s *fileSink) close() error {
+	return s.file.Close()
+}
+
+func (s *httpSink) write(decisions []*decision) error {
+	body, err := json.Marshal(decisions)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s answered with status %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *httpSink) close() error {
+	return nil
+}
+
+func newDecisionID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,217 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/googleapis/google/rpc"
+
+	"istio.io/istio/mixer/adapter/opa/config"
+	"istio.io/istio/mixer/pkg/adapter/test"
+)
+
+func checkDecisions(t *testing.T, decisions []decision, revision string) {
+	t.Helper()
+
+	if len(decisions) != 2 {
+		t.Fatalf("Got %d decisions, expecting 2", len(decisions))
+	}
+
+	expected := map[string]bool{"alice": true, "bob": false}
+	for _, d := range decisions {
+		if d.DecisionID == "" || d.Timestamp.IsZero() {
+			t.Errorf("Got decision %+v, expecting an id and a timestamp", d)
+		}
+		if d.Query != "data.mixerauthz.allow" {
+			t.Errorf("Got query %v, expecting data.mixerauthz.allow", d.Query)
+		}
+		if d.Revision != revision {
+			t.Errorf("Got revision %v, expecting %v", d.Revision, revision)
+		}
+
+		user, _ := d.Input["subject"].(map[string]interface{})["user"].(string)
+		if d.Result == nil || *d.Result != expected[user] {
+			t.Errorf("Got result %v for %v, expecting %v", d.Result, user, expected[user])
+		}
+	}
+}
+
+func TestDecisionLogsToFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "opa-decisions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "decisions.log")
+
+	server := newBundleServer(t, map[string]string{
+		"mixerauthz/policy.rego": bundlePolicy,
+		"data.json":              `{"users": ["alice"]}`,
+		".manifest":              `{"revision": "v1"}`,
+	})
+	defer server.Close()
+
+	h := buildHandler(t, &config.Params{
+		CheckMethod:  "data.mixerauthz.allow",
+		Bundle:       &config.Params_Bundle{Url: server.URL},
+		DecisionLogs: &config.Params_DecisionLogs{Sink: config.FILE, Path: path},
+	})
+
+	checkUsers(t, h, map[string]rpc.Code{
+		"alice": rpc.OK,
+		"bob":   rpc.PERMISSION_DENIED,
+	})
+
+	// the pending decisions are written when the handler is closed.
+	_ = h.Close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var decisions []decision
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var d decision
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+			t.Fatalf("Got error %v, expecting a decision", err)
+		}
+		decisions = append(decisions, d)
+	}
+	checkDecisions(t, decisions, "v1")
+}
+
+func TestDecisionLogsToHTTP(t *testing.T) {
+	var mu sync.Mutex
+	var decisions []decision
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []decision
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		decisions = append(decisions, batch...)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	h := buildHandler(t, &config.Params{
+		Policy:      []string{bundlePolicy},
+		CheckMethod: "data.mixerauthz.allow",
+		DecisionLogs: &config.Params_DecisionLogs{
+			Sink:          config.HTTP,
+			Url:           server.URL,
+			FlushInterval: 10 * time.Millisecond,
+		},
+	})
+	defer func() { _ = h.Close() }()
+
+	// the inline policies have no data, and allow no user.
+	checkUsers(t, h, map[string]rpc.Code{
+		"alice": rpc.PERMISSION_DENIED,
+	})
+
+	for i := 0; i < 500; i++ {
+		mu.Lock()
+		n := len(decisions)
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(decisions) != 1 {
+		t.Fatalf("Got %d decisions, expecting 1", len(decisions))
+	}
+	if d := decisions[0]; d.Result == nil || *d.Result || d.Revision != h.currentPolicy().revision || d.Revision == "" {
+		t.Errorf("Got decision %+v, expecting a denial by revision %v", d, h.currentPolicy().revision)
+	}
+}
+
+func TestDecisionLogsErrors(t *testing.T) {
+	env := test.NewEnv(t)
+	b := GetInfo().NewBuilder().(*builder)
+	b.SetAdapterConfig(&config.Params{
+		CheckMethod:  "data.mixerauthz.allow",
+		FailClose:    true,
+		Bundle:       &config.Params_Bundle{Url: "http://127.0.0.1:1"},
+		DecisionLogs: &config.Params_DecisionLogs{},
+	})
+	if err := b.Validate(); err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+	h, err := b.Build(context.Background(), env)
+	if err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+
+	checkUsers(t, h.(*handler), map[string]rpc.Code{"alice": rpc.PERMISSION_DENIED})
+	_ = h.Close()
+
+	var logged []decision
+	for _, l := range env.GetLogs() {
+		if i := strings.Index(l, "opa decision: "); i >= 0 {
+			var d decision
+			if err := json.Unmarshal([]byte(l[i+len("opa decision: "):]), &d); err != nil {
+				t.Fatalf("Got error %v, expecting a decision", err)
+			}
+			logged = append(logged, d)
+		}
+	}
+
+	if len(logged) != 1 {
+		t.Fatalf("Got %d decisions logged, expecting 1", len(logged))
+	}
+	if d := logged[0]; d.Result != nil || !strings.Contains(d.Error, "the bundle is not loaded") {
+		t.Errorf("Got decision %+v, expecting an error", d)
+	}
+}
+
+func TestDecisionLogsDropped(t *testing.T) {
+	env := test.NewEnv(t)
+	l := &decisionLogger{
+		sink:      &logSink{logger: env},
+		decisions: make(chan *decision, 1),
+		logger:    env,
+	}
+
+	l.log(&decision{})
+	l.log(&decision{})
+	l.flush(nil)
+
+	if logs := strings.Join(env.GetLogs(), "\n"); !strings.Contains(logs, "1 decisions were dropped") {
+		t.Errorf("Got logs %v, expecting a dropped decision", logs)
+	}
+}
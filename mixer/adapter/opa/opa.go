@@ -19,10 +19,17 @@ package opa // import "istio.io/istio/mixer/adapter/opa"
 
 import (
 	"context"
+	"crypto"
+	"encoding/hex"
 	"fmt"
+	"net/url"
+	"sync"
+	"time"
 
 	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/storage"
+	"github.com/open-policy-agent/opa/storage/inmem"
 
 	"istio.io/istio/mixer/adapter/metadata"
 	"istio.io/istio/mixer/adapter/opa/config"
@@ -36,19 +43,38 @@ type (
 		adapterConfig *config.Params
 		configErrors  []error
 		compiler      *ast.Compiler
+		modules       map[string]*ast.Module
+		signingKey    crypto.PublicKey
 	}
 
 	handler struct {
 		checkMethod    string
-		compiler       *ast.Compiler
 		logger         adapter.Logger
 		hasConfigError bool
 		failClose      bool
+
+		// the inline policies, compiled along with the bundle
+		modules   map[string]*ast.Module
+		loader    *bundleLoader
+		decisions *decisionLogger
+		done      chan struct{}
+
+		mu     sync.RWMutex
+		policy *policy
+	}
+
+	// policy is the set of policies and data against which the requests are evaluated.
+	policy struct {
+		compiler *ast.Compiler
+		store    storage.Store
+		revision string
 	}
 )
 
 const (
 	policyFileNamePrefix = "opa_policy"
+
+	defaultRefreshInterval = time.Minute
 )
 
 ///////////////// Configuration Methods ///////////////
@@ -71,6 +97,13 @@ func (b *builder) Validate() (ce *adapter.ConfigErrors) {
 		}
 	}
 
+	if bundle := b.adapterConfig.Bundle; bundle != nil {
+		ce = b.appendConfigErrors(ce, b.validateBundle(bundle))
+	}
+	if dl := b.adapterConfig.DecisionLogs; dl != nil {
+		ce = b.appendConfigErrors(ce, validateDecisionLogs(dl))
+	}
+
 	parsedModuleCount := 0
 	moduleParseErrorCount := 0
 	modules := map[string]*ast.Module{}
@@ -93,6 +126,12 @@ func (b *builder) Validate() (ce *adapter.ConfigErrors) {
 	if moduleParseErrorCount > 0 {
 		return
 	}
+	b.modules = modules
+
+	// the inline policies are compiled along with the bundle once it is loaded.
+	if b.adapterConfig.Bundle != nil {
+		return
+	}
 
 	if parsedModuleCount == 0 {
 		msg := "policies are not configured"
@@ -121,18 +160,90 @@ func (b *builder) Validate() (ce *adapter.ConfigErrors) {
 	return
 }
 
+func (b *builder) validateBundle(bundle *config.Params_Bundle) (ce *adapter.ConfigErrors) {
+	if (bundle.Url == "") == (bundle.Path == "") {
+		ce = ce.Appendf("Bundle", "exactly one of url or path must be specified")
+	}
+	if bundle.Url != "" {
+		if u, err := url.Parse(bundle.Url); err != nil {
+			ce = ce.Append("Bundle.Url", err)
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			ce = ce.Appendf("Bundle.Url", "url scheme must be http or https, got '%s'", u.Scheme)
+		}
+	}
+	if bundle.RefreshInterval < 0 {
+		ce = ce.Appendf("Bundle.RefreshInterval", "refresh interval of %v is invalid, must be >= 0", bundle.RefreshInterval)
+	}
+	if bundle.SigningKey != "" {
+		key, err := parseSigningKey(bundle.SigningKey)
+		if err != nil {
+			ce = ce.Appendf("Bundle.SigningKey", "unable to parse the signing key: %v", err)
+		}
+		b.signingKey = key
+	}
+	return
+}
+
+// appendConfigErrors adds the errors to ce, or to the configErrors when failClose is set.
+func (b *builder) appendConfigErrors(ce *adapter.ConfigErrors, errs *adapter.ConfigErrors) *adapter.ConfigErrors {
+	if errs == nil {
+		return ce
+	}
+	if !b.adapterConfig.FailClose {
+		return ce.Extend(errs)
+	}
+	for _, err := range errs.Multi.Errors {
+		b.configErrors = append(b.configErrors, err)
+	}
+	return ce
+}
+
 func (b *builder) Build(context context.Context, env adapter.Env) (adapter.Handler, error) {
 	for _, err := range b.configErrors {
 		_ = env.Logger().Errorf("%v", err)
 	}
 
-	return &handler{
-		compiler:       b.compiler,
+	h := &handler{
 		checkMethod:    b.adapterConfig.CheckMethod,
 		failClose:      b.adapterConfig.FailClose,
 		logger:         env.Logger(),
 		hasConfigError: len(b.configErrors) > 0,
-	}, nil
+		modules:        b.modules,
+		done:           make(chan struct{}),
+	}
+
+	if b.compiler != nil {
+		files := make(map[string][]byte, len(b.adapterConfig.Policy))
+		for index, policy := range b.adapterConfig.Policy {
+			files[fmt.Sprintf("%v.%v", policyFileNamePrefix, index)] = []byte(policy)
+		}
+		h.policy = &policy{compiler: b.compiler, revision: hex.EncodeToString(digestFiles(files))}
+	}
+
+	if dl := b.adapterConfig.DecisionLogs; dl != nil && !h.hasConfigError {
+		var err error
+		if h.decisions, err = newDecisionLogger(dl, env); err != nil {
+			return nil, err
+		}
+	}
+
+	if bundle := b.adapterConfig.Bundle; bundle != nil && !h.hasConfigError {
+		h.loader = newBundleLoader(bundle, b.signingKey)
+		if err := h.loadBundle(); err != nil {
+			// the requests are handled as if the policies were broken until the bundle is loaded.
+			_ = env.Logger().Errorf("unable to load the OPA bundle: %v", err)
+		}
+		env.ScheduleDaemon(func() { h.refreshBundle(refreshInterval(bundle)) })
+	}
+
+	return h, nil
+}
+
+func refreshInterval(bundle *config.Params_Bundle) time.Duration {
+	if bundle.RefreshInterval == 0 {
+		return defaultRefreshInterval
+	}
+	return bundle.RefreshInterval
 }
 
 ////////////////// Runtime Methods //////////////////////////
@@ -206,47 +317,151 @@ func (h *handler) handleFailClose(err error) (adapter.CheckResult, error) {
 }
 
 func (h *handler) HandleAuthorization(context context.Context, instance *authorization.Instance) (adapter.CheckResult, error) {
+	input := map[string]interface{}{
+		"action":  convertActionObjectToMap(instance.Action),
+		"subject": convertSubjectObjectToMap(instance.Subject),
+	}
+
+	p := h.currentPolicy()
+	result, err := h.evaluate(context, p, input)
+	if h.decisions != nil {
+		h.logDecision(p, input, result, err)
+	}
+
+	if err != nil {
+		return h.handleFailClose(err)
+	}
+
+	// rejected by policy scripts
+	if !result {
+		return adapter.CheckResult{
+			Status: status.WithPermissionDenied("opa: request was rejected"),
+		}, nil
+	}
+
+	// Accepted
+	return adapter.CheckResult{Status: status.OK}, nil
+}
+
+// evaluate returns whether the policy allows the request.
+func (h *handler) evaluate(context context.Context, p *policy, input map[string]interface{}) (bool, error) {
 	// Handle configuration error
 	if h.hasConfigError {
-		return h.handleFailClose(fmt.Errorf("opa: request was rejected"))
+		return false, fmt.Errorf("opa: request was rejected")
+	}
+
+	if p == nil {
+		return false, fmt.Errorf("opa: request was rejected. err: the bundle is not loaded")
 	}
 
 	// eval rego policy scripts
-	rs, err := rego.New(
-		rego.Compiler(h.compiler),
+	options := []func(*rego.Rego){
+		rego.Compiler(p.compiler),
 		rego.Query(h.checkMethod),
-		rego.Input(map[string]interface{}{
-			"action":  convertActionObjectToMap(instance.Action),
-			"subject": convertSubjectObjectToMap(instance.Subject),
-		}),
-	).Eval(context)
+		rego.Input(input),
+	}
+	if p.store != nil {
+		options = append(options, rego.Store(p.store))
+	}
+	rs, err := rego.New(options...).Eval(context)
 
 	// Handle errors from OPA engine, policy scripts
 	if err != nil {
-		return h.handleFailClose(fmt.Errorf("opa: request was rejected. err: %v", err))
+		return false, fmt.Errorf("opa: request was rejected. err: %v", err)
 	}
 
 	if len(rs) != 1 {
-		return h.handleFailClose(fmt.Errorf("opa: request was rejected"))
+		return false, fmt.Errorf("opa: request was rejected")
 	}
 
 	result, ok := rs[0].Expressions[0].Value.(bool)
 	if !ok {
-		return h.handleFailClose(fmt.Errorf("opa: request was rejected"))
+		return false, fmt.Errorf("opa: request was rejected")
 	}
 
-	// rejected by policy scripts
-	if !result {
-		return adapter.CheckResult{
-			Status: status.WithPermissionDenied("opa: request was rejected"),
-		}, nil
+	return result, nil
+}
+
+func (h *handler) logDecision(p *policy, input map[string]interface{}, result bool, err error) {
+	d := &decision{
+		DecisionID: newDecisionID(),
+		Timestamp:  time.Now().UTC(),
+		Query:      h.checkMethod,
+		Input:      input,
+	}
+	if p != nil {
+		d.Revision = p.revision
+	}
+	if err != nil {
+		d.Error = err.Error()
+	} else {
+		d.Result = &result
 	}
+	h.decisions.log(d)
+}
 
-	// Accepted
-	return adapter.CheckResult{Status: status.OK}, nil
+func (h *handler) currentPolicy() *policy {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.policy
+}
+
+// loadBundle installs the bundle along with the inline policies, if it changed since it was last loaded.
+func (h *handler) loadBundle() error {
+	b, err := h.loader.load()
+	if err != nil || b == nil {
+		return err
+	}
+
+	modules := make(map[string]*ast.Module, len(h.modules)+len(b.modules))
+	for name, module := range h.modules {
+		modules[name] = module
+	}
+	for name, module := range b.modules {
+		modules[name] = module
+	}
+
+	compiler := ast.NewCompiler()
+	if compiler.Compile(modules); compiler.Failed() {
+		return fmt.Errorf("unable to compile the policies of revision %s: %v", b.revision, compiler.Errors)
+	}
+
+	p := &policy{
+		compiler: compiler,
+		store:    inmem.NewFromObject(b.data),
+		revision: b.revision,
+	}
+
+	h.mu.Lock()
+	h.policy = p
+	h.mu.Unlock()
+
+	h.logger.Infof("loaded revision %s of the OPA bundle", b.revision)
+	return nil
+}
+
+// refreshBundle periodically reloads the bundle, until the handler is closed.
+func (h *handler) refreshBundle(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-ticker.C:
+			if err := h.loadBundle(); err != nil {
+				h.logger.Warningf("unable to refresh the OPA bundle: %v", err)
+			}
+		}
+	}
 }
 
 func (h *handler) Close() error {
+	close(h.done)
+	if h.decisions != nil {
+		h.decisions.close()
+	}
 	return nil
 }
 
@@ -0,0 +1,1871 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: mixer/adapter/otlp/config/config.proto
+
+// The `otlp` adapter enables Istio to export metrics and tracing data over the
+// [OpenTelemetry protocol](https://opentelemetry.io/docs/specs/otlp/) (OTLP/gRPC) to an
+// OpenTelemetry collector, or any other backend accepting OTLP.
+//
+// The metrics and spans are batched, and the exports failing with a retryable error are retried
+// with an exponential backoff.
+//
+// This adapter supports the [metric template](https://istio.io/docs/reference/config/policy-and-telemetry/templates/metric/)
+// and the [tracespan template](https://istio.io/docs/reference/config/policy-and-telemetry/templates/tracespan/).
+
+package config
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	io "io"
+	math "math"
+	reflect "reflect"
+	strconv "strconv"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// Describes what kind of metric this is.
+type Params_MetricInfo_Kind int32
+
+const (
+	UNSPECIFIED Params_MetricInfo_Kind = 0
+	// The last value of the instance in an export interval is exported as an OTLP gauge.
+	GAUGE Params_MetricInfo_Kind = 1
+	// The values of the instance are summed over an export interval, and exported as a
+	// monotonic OTLP sum with a delta temporality.
+	COUNTER Params_MetricInfo_Kind = 2
+	// The values of the instance are aggregated over an export interval, and exported as
+	// an OTLP histogram with a delta temporality.
+	DISTRIBUTION Params_MetricInfo_Kind = 3
+)
+
+var Params_MetricInfo_Kind_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "GAUGE",
+	2: "COUNTER",
+	3: "DISTRIBUTION",
+}
+
+var Params_MetricInfo_Kind_value = map[string]int32{
+	"UNSPECIFIED":  0,
+	"GAUGE":        1,
+	"COUNTER":      2,
+	"DISTRIBUTION": 3,
+}
+
+func (Params_MetricInfo_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_db8256f5344d6f8d, []int{0, 3, 0}
+}
+
+// Configuration format for the `otlp` adapter.
+type Params struct {
+	// Address of the OTLP gRPC endpoint, as `host:port`.
+	//
+	// Required.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Whether to connect to the endpoint without TLS.
+	//
+	// Optional.
+	Insecure bool `protobuf:"varint,2,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	// Path of the PEM encoded CA certificates used to verify the certificate of the endpoint.
+	// The system roots are used if unset.
+	//
+	// Optional.
+	CaCertPath string `protobuf:"bytes,3,opt,name=ca_cert_path,json=caCertPath,proto3" json:"ca_cert_path,omitempty"`
+	// gRPC metadata sent with every export, for example to authenticate with the backend.
+	//
+	// Optional.
+	Headers map[string]string `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Attributes of the resource the metrics and spans are exported for, for example
+	// `service.name`.
+	//
+	// Optional.
+	ResourceAttributes map[string]string `protobuf:"bytes,5,rep,name=resource_attributes,json=resourceAttributes,proto3" json:"resource_attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Maximum number of spans, or of metric data points, sent in a single export.
+	// Defaults to 512 if unset.
+	//
+	// Optional.
+	MaxBatchSize int32 `protobuf:"varint,6,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	// Maximum number of spans buffered while waiting to be exported. The spans exceeding the
+	// limit are dropped. Defaults to 8192 if unset.
+	//
+	// Optional.
+	MaxQueueSize int32 `protobuf:"varint,7,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"`
+	// Interval at which the buffered spans and the aggregated metrics are exported.
+	// Defaults to 5s if unset.
+	//
+	// Optional.
+	FlushInterval time.Duration `protobuf:"bytes,8,opt,name=flush_interval,json=flushInterval,proto3,stdduration" json:"flush_interval"`
+	// Timeout of a single export request. Defaults to 10s if unset.
+	//
+	// Optional.
+	ExportTimeout time.Duration `protobuf:"bytes,9,opt,name=export_timeout,json=exportTimeout,proto3,stdduration" json:"export_timeout"`
+	// Retry policy of the exports.
+	//
+	// Optional.
+	RetryPolicy *Params_RetryPolicy `protobuf:"bytes,10,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	// The metrics to export. The instances of metrics not listed here are ignored.
+	Metrics []*Params_MetricInfo `protobuf:"bytes,11,rep,name=metrics,proto3" json:"metrics,omitempty"`
+}
+
+func (m *Params) Reset()      { *m = Params{} }
+func (*Params) ProtoMessage() {}
+func (*Params) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db8256f5344d6f8d, []int{0}
+}
+func (m *Params) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params.Merge(m, src)
+}
+func (m *Params) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params proto.InternalMessageInfo
+
+// Describes how failed exports are retried. Only the exports failing with a retryable gRPC
+// status (`UNAVAILABLE`, `RESOURCE_EXHAUSTED`, `ABORTED`, `DEADLINE_EXCEEDED`, ...) are retried.
+type Params_RetryPolicy struct {
+	// Disables the retries.
+	Disabled bool `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	// Backoff before the first retry. Defaults to 1s if unset.
+	InitialBackoff time.Duration `protobuf:"bytes,2,opt,name=initial_backoff,json=initialBackoff,proto3,stdduration" json:"initial_backoff"`
+	// Maximum backoff between retries, the backoff doubling after every retry.
+	// Defaults to 30s if unset.
+	MaxBackoff time.Duration `protobuf:"bytes,3,opt,name=max_backoff,json=maxBackoff,proto3,stdduration" json:"max_backoff"`
+	// Maximum time spent retrying an export, after which its data is dropped.
+	// Defaults to 1m if unset.
+	MaxElapsedTime time.Duration `protobuf:"bytes,4,opt,name=max_elapsed_time,json=maxElapsedTime,proto3,stdduration" json:"max_elapsed_time"`
+}
+
+func (m *Params_RetryPolicy) Reset()      { *m = Params_RetryPolicy{} }
+func (*Params_RetryPolicy) ProtoMessage() {}
+func (*Params_RetryPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db8256f5344d6f8d, []int{0, 2}
+}
+func (m *Params_RetryPolicy) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params_RetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params_RetryPolicy.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params_RetryPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params_RetryPolicy.Merge(m, src)
+}
+func (m *Params_RetryPolicy) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params_RetryPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params_RetryPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params_RetryPolicy proto.InternalMessageInfo
+
+// Describes how a metric instance is exported.
+type Params_MetricInfo struct {
+	// Required. The fully qualified name of the Istio metric instance that this MetricInfo
+	// processes.
+	InstanceName string `protobuf:"bytes,1,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
+	// Optional. The name of the exported metric. The instance name is used if unset.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional. A human readable description of the metric.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Optional. The unit of the metric, following the [UCUM](https://ucum.org/ucum.html)
+	// case-sensitive codes, for example `ms` or `By`.
+	Unit string `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	// Required. The kind of the metric.
+	Kind Params_MetricInfo_Kind `protobuf:"varint,5,opt,name=kind,proto3,enum=adapter.otlp.config.Params_MetricInfo_Kind" json:"kind,omitempty"`
+	// The increasing upper bounds of the histogram buckets of a DISTRIBUTION metric. There
+	// are `size(buckets) + 1` buckets, the last one counting the values above the largest
+	// bound. This field must be provided for DISTRIBUTION metrics, and is ignored otherwise.
+	Buckets []float64 `protobuf:"fixed64,6,rep,packed,name=buckets,proto3" json:"buckets,omitempty"`
+}
+
+func (m *Params_MetricInfo) Reset()      { *m = Params_MetricInfo{} }
+func (*Params_MetricInfo) ProtoMessage() {}
+func (*Params_MetricInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db8256f5344d6f8d, []int{0, 3}
+}
+func (m *Params_MetricInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params_MetricInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params_MetricInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params_MetricInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params_MetricInfo.Merge(m, src)
+}
+func (m *Params_MetricInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params_MetricInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params_MetricInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params_MetricInfo proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("adapter.otlp.config.Params_MetricInfo_Kind", Params_MetricInfo_Kind_name, Params_MetricInfo_Kind_value)
+	proto.RegisterType((*Params)(nil), "adapter.otlp.config.Params")
+	proto.RegisterMapType((map[string]string)(nil), "adapter.otlp.config.Params.HeadersEntry")
+	proto.RegisterMapType((map[string]string)(nil), "adapter.otlp.config.Params.ResourceAttributesEntry")
+	proto.RegisterType((*Params_RetryPolicy)(nil), "adapter.otlp.config.Params.RetryPolicy")
+	proto.RegisterType((*Params_MetricInfo)(nil), "adapter.otlp.config.Params.MetricInfo")
+}
+
+func init() {
+	proto.RegisterFile("mixer/adapter/otlp/config/config.proto", fileDescriptor_db8256f5344d6f8d)
+}
+
+var fileDescriptor_db8256f5344d6f8d = []byte{
+	// 783 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4d, 0x8f, 0xdb, 0x44,
+	0x18, 0xb6, 0xf3, 0x9d, 0xd7, 0x69, 0x1a, 0x4d, 0x2b, 0x61, 0x72, 0x70, 0xad, 0x82, 0x8a, 0x25,
+	0x24, 0x47, 0xda, 0x5e, 0x50, 0x2f, 0x74, 0xb3, 0x09, 0x25, 0x85, 0xa6, 0xc1, 0x9b, 0x5c, 0xb8,
+	0x58, 0x13, 0x7b, 0x92, 0x8c, 0xe2, 0x2f, 0xc6, 0xe3, 0x2a, 0xe9, 0x89, 0x1f, 0xc0, 0x81, 0x23,
+	0xfc, 0x03, 0xc4, 0x2f, 0xd9, 0xe3, 0x1e, 0x7b, 0x02, 0x92, 0xbd, 0x70, 0xec, 0x4f, 0x40, 0x9e,
+	0x71, 0xd2, 0x95, 0x80, 0xd5, 0xee, 0x29, 0xf3, 0xbe, 0xef, 0xf3, 0x3c, 0x79, 0x3d, 0xcf, 0xa3,
+	0x81, 0x27, 0x21, 0xdd, 0x10, 0xd6, 0xc3, 0x3e, 0x4e, 0x38, 0x61, 0xbd, 0x98, 0x07, 0x49, 0xcf,
+	0x8b, 0xa3, 0x05, 0x5d, 0x16, 0x3f, 0x76, 0xc2, 0x62, 0x1e, 0xa3, 0x07, 0x05, 0xc2, 0xce, 0x11,
+	0xb6, 0x1c, 0x75, 0x1f, 0x2e, 0xe3, 0x65, 0x2c, 0xe6, 0xbd, 0xfc, 0x24, 0xa1, 0x5d, 0x63, 0x19,
+	0xc7, 0xcb, 0x80, 0xf4, 0x44, 0x35, 0xcf, 0x16, 0x3d, 0x3f, 0x63, 0x98, 0xd3, 0x38, 0x92, 0xf3,
+	0xc7, 0xbf, 0x03, 0xd4, 0x26, 0x98, 0xe1, 0x30, 0x45, 0x3a, 0xd4, 0xb1, 0xef, 0x33, 0x92, 0xa6,
+	0xba, 0x6a, 0xaa, 0x56, 0xd3, 0x39, 0x94, 0xa8, 0x0b, 0x0d, 0x1a, 0xa5, 0xc4, 0xcb, 0x18, 0xd1,
+	0x4b, 0xa6, 0x6a, 0x35, 0x9c, 0x63, 0x8d, 0x4c, 0x68, 0x79, 0xd8, 0xf5, 0x08, 0xe3, 0x6e, 0x82,
+	0xf9, 0x4a, 0x2f, 0x0b, 0x2a, 0x78, 0xf8, 0x8c, 0x30, 0x3e, 0xc1, 0x7c, 0x85, 0xfa, 0x50, 0x5f,
+	0x11, 0xec, 0x13, 0x96, 0xea, 0x15, 0xb3, 0x6c, 0x69, 0x27, 0x96, 0xfd, 0x1f, 0xfb, 0xdb, 0x72,
+	0x0b, 0xfb, 0x6b, 0x09, 0x1d, 0x46, 0x9c, 0x6d, 0x9d, 0x03, 0x11, 0xf9, 0xf0, 0x80, 0x91, 0x34,
+	0xce, 0x98, 0x47, 0x5c, 0xcc, 0x39, 0xa3, 0xf3, 0x8c, 0x93, 0x54, 0xaf, 0x0a, 0xbd, 0xa7, 0x37,
+	0xe9, 0x39, 0x05, 0xed, 0xf4, 0xc8, 0x92, 0xd2, 0x88, 0xfd, 0x6b, 0x80, 0x3e, 0x85, 0x76, 0x88,
+	0x37, 0xee, 0x1c, 0x73, 0x6f, 0xe5, 0xa6, 0xf4, 0x2d, 0xd1, 0x6b, 0xa6, 0x6a, 0x55, 0x9d, 0x56,
+	0x88, 0x37, 0xfd, 0xbc, 0x79, 0x4e, 0xdf, 0x92, 0x03, 0xea, 0x87, 0x8c, 0x64, 0x44, 0xa2, 0xea,
+	0x47, 0xd4, 0x77, 0x79, 0x53, 0xa0, 0x5e, 0x42, 0x7b, 0x11, 0x64, 0xe9, 0xca, 0xa5, 0x11, 0x27,
+	0xec, 0x0d, 0x0e, 0xf4, 0x86, 0xa9, 0x5a, 0xda, 0xc9, 0xc7, 0xb6, 0x74, 0xc4, 0x3e, 0x38, 0x62,
+	0x0f, 0x0a, 0x47, 0xfa, 0x8d, 0x8b, 0x3f, 0x1e, 0x29, 0xbf, 0xfc, 0xf9, 0x48, 0x75, 0xee, 0x09,
+	0xea, 0xa8, 0x60, 0xe6, 0x5a, 0x64, 0x93, 0xc4, 0x8c, 0xbb, 0x9c, 0x86, 0x24, 0xce, 0xb8, 0xde,
+	0xbc, 0x83, 0x96, 0xa4, 0x4e, 0x25, 0x13, 0xbd, 0x84, 0x16, 0x23, 0x9c, 0x6d, 0xdd, 0x24, 0x0e,
+	0xa8, 0xb7, 0xd5, 0x41, 0x28, 0x7d, 0x76, 0xf3, 0x15, 0x72, 0xb6, 0x9d, 0x08, 0xb8, 0xa3, 0xb1,
+	0x0f, 0x05, 0x7a, 0x0e, 0xf5, 0x90, 0x70, 0x46, 0xbd, 0x54, 0xd7, 0x84, 0x13, 0x4f, 0x6e, 0x92,
+	0x79, 0x25, 0xa0, 0xa3, 0x68, 0x11, 0x3b, 0x07, 0x5a, 0xf7, 0x19, 0xb4, 0xae, 0x1b, 0x8e, 0x3a,
+	0x50, 0x5e, 0x93, 0x6d, 0x91, 0xbf, 0xfc, 0x88, 0x1e, 0x42, 0xf5, 0x0d, 0x0e, 0x32, 0x19, 0xbc,
+	0xa6, 0x23, 0x8b, 0x67, 0xa5, 0x2f, 0xd4, 0xee, 0x10, 0x3e, 0xfa, 0x1f, 0x73, 0xef, 0x24, 0xf3,
+	0x53, 0x09, 0xb4, 0x6b, 0x5f, 0x98, 0x87, 0xdd, 0xa7, 0x29, 0x9e, 0x07, 0xc4, 0x17, 0x02, 0x0d,
+	0xe7, 0x58, 0xa3, 0x6f, 0xe1, 0x3e, 0x8d, 0x28, 0xa7, 0x38, 0x70, 0xe7, 0xd8, 0x5b, 0xc7, 0x8b,
+	0x85, 0x5e, 0xba, 0xbd, 0x13, 0xed, 0x82, 0xdb, 0x97, 0x54, 0x34, 0x00, 0x4d, 0xc6, 0x4d, 0x2a,
+	0x95, 0x6f, 0xaf, 0x04, 0x22, 0x90, 0x52, 0xe5, 0x15, 0x74, 0x72, 0x15, 0x12, 0xe0, 0x24, 0x25,
+	0xbe, 0x48, 0x88, 0x5e, 0xb9, 0xc3, 0x52, 0x21, 0xde, 0x0c, 0x25, 0x37, 0x8f, 0x48, 0xf7, 0xd7,
+	0x12, 0xc0, 0x07, 0xa7, 0xd0, 0x27, 0x70, 0x8f, 0x46, 0x29, 0xc7, 0x91, 0x47, 0xdc, 0x08, 0x87,
+	0xa4, 0xb8, 0xd3, 0xd6, 0xa1, 0x39, 0xc6, 0x21, 0x41, 0x08, 0x2a, 0x62, 0x26, 0xef, 0x56, 0x9c,
+	0x91, 0x09, 0x9a, 0x4f, 0x52, 0x8f, 0xd1, 0x24, 0xff, 0xc3, 0xe2, 0x59, 0xb8, 0xde, 0xca, 0x59,
+	0x59, 0x44, 0xb9, 0x58, 0xb6, 0xe9, 0x88, 0x33, 0xfa, 0x12, 0x2a, 0x6b, 0x1a, 0xf9, 0x7a, 0xd5,
+	0x54, 0xad, 0xf6, 0xc9, 0xe7, 0xb7, 0x8b, 0x93, 0xfd, 0x0d, 0x8d, 0x7c, 0x47, 0x10, 0xf3, 0x47,
+	0x6c, 0x9e, 0x79, 0x6b, 0xc2, 0x53, 0xbd, 0x66, 0x96, 0x2d, 0xd5, 0x39, 0x94, 0x8f, 0x4f, 0xa1,
+	0x92, 0xe3, 0xd0, 0x7d, 0xd0, 0x66, 0xe3, 0xf3, 0xc9, 0xf0, 0x6c, 0xf4, 0xd5, 0x68, 0x38, 0xe8,
+	0x28, 0xa8, 0x09, 0xd5, 0x17, 0xa7, 0xb3, 0x17, 0xc3, 0x8e, 0x8a, 0x34, 0xa8, 0x9f, 0xbd, 0x9e,
+	0x8d, 0xa7, 0x43, 0xa7, 0x53, 0x42, 0x1d, 0x68, 0x0d, 0x46, 0xe7, 0x53, 0x67, 0xd4, 0x9f, 0x4d,
+	0x47, 0xaf, 0xc7, 0x9d, 0x72, 0xff, 0xf9, 0xc5, 0xce, 0x50, 0x2e, 0x77, 0x86, 0xf2, 0x6e, 0x67,
+	0x28, 0xef, 0x77, 0x86, 0xf2, 0xe3, 0xde, 0x50, 0x7f, 0xdb, 0x1b, 0xca, 0xc5, 0xde, 0x50, 0x2f,
+	0xf7, 0x86, 0xfa, 0xd7, 0xde, 0x50, 0xff, 0xde, 0x1b, 0xca, 0xfb, 0xbd, 0xa1, 0xfe, 0x7c, 0x65,
+	0x28, 0x97, 0x57, 0x86, 0xf2, 0xee, 0xca, 0x50, 0xbe, 0xaf, 0xc9, 0xdd, 0xe7, 0x35, 0x61, 0xc5,
+	0xd3, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xcd, 0x85, 0xcb, 0x76, 0xea, 0x05, 0x00, 0x00,
+}
+
+func (x Params_MetricInfo_Kind) String() string {
+	s, ok := Params_MetricInfo_Kind_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Address)))
+		i += copy(dAtA[i:], m.Address)
+	}
+	if m.Insecure {
+		dAtA[i] = 0x10
+		i++
+		if m.Insecure {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.CaCertPath) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.CaCertPath)))
+		i += copy(dAtA[i:], m.CaCertPath)
+	}
+	if len(m.Headers) > 0 {
+		for k, _ := range m.Headers {
+			dAtA[i] = 0x22
+			i++
+			v := m.Headers[k]
+			mapSize := 1 + len(k) + sovConfig(uint64(len(k))) + 1 + len(v) + sovConfig(uint64(len(v)))
+			i = encodeVarintConfig(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintConfig(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintConfig(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if len(m.ResourceAttributes) > 0 {
+		for k, _ := range m.ResourceAttributes {
+			dAtA[i] = 0x2a
+			i++
+			v := m.ResourceAttributes[k]
+			mapSize := 1 + len(k) + sovConfig(uint64(len(k))) + 1 + len(v) + sovConfig(uint64(len(v)))
+			i = encodeVarintConfig(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintConfig(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintConfig(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if m.MaxBatchSize != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxBatchSize))
+	}
+	if m.MaxQueueSize != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxQueueSize))
+	}
+	dAtA[i] = 0x42
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.FlushInterval)))
+	n1, err1 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.FlushInterval, dAtA[i:])
+	if err1 != nil {
+		return 0, err1
+	}
+	i += n1
+	dAtA[i] = 0x4a
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.ExportTimeout)))
+	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ExportTimeout, dAtA[i:])
+	if err2 != nil {
+		return 0, err2
+	}
+	i += n2
+	if m.RetryPolicy != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.RetryPolicy.Size()))
+		n3, err3 := m.RetryPolicy.MarshalTo(dAtA[i:])
+		if err3 != nil {
+			return 0, err3
+		}
+		i += n3
+	}
+	if len(m.Metrics) > 0 {
+		for _, msg := range m.Metrics {
+			dAtA[i] = 0x5a
+			i++
+			i = encodeVarintConfig(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *Params_RetryPolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params_RetryPolicy) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Disabled {
+		dAtA[i] = 0x8
+		i++
+		if m.Disabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.InitialBackoff)))
+	n4, err4 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.InitialBackoff, dAtA[i:])
+	if err4 != nil {
+		return 0, err4
+	}
+	i += n4
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxBackoff)))
+	n5, err5 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxBackoff, dAtA[i:])
+	if err5 != nil {
+		return 0, err5
+	}
+	i += n5
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintConfig(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxElapsedTime)))
+	n6, err6 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxElapsedTime, dAtA[i:])
+	if err6 != nil {
+		return 0, err6
+	}
+	i += n6
+	return i, nil
+}
+
+func (m *Params_MetricInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params_MetricInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.InstanceName) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.InstanceName)))
+		i += copy(dAtA[i:], m.InstanceName)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Description) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Description)))
+		i += copy(dAtA[i:], m.Description)
+	}
+	if len(m.Unit) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Unit)))
+		i += copy(dAtA[i:], m.Unit)
+	}
+	if m.Kind != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.Kind))
+	}
+	if len(m.Buckets) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Buckets)*8))
+		for _, num := range m.Buckets {
+			f7 := math.Float64bits(float64(num))
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f7))
+			i += 8
+		}
+	}
+	return i, nil
+}
+
+func encodeVarintConfig(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.Insecure {
+		n += 2
+	}
+	l = len(m.CaCertPath)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if len(m.Headers) > 0 {
+		for k, v := range m.Headers {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovConfig(uint64(len(k))) + 1 + len(v) + sovConfig(uint64(len(v)))
+			n += mapEntrySize + 1 + sovConfig(uint64(mapEntrySize))
+		}
+	}
+	if len(m.ResourceAttributes) > 0 {
+		for k, v := range m.ResourceAttributes {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovConfig(uint64(len(k))) + 1 + len(v) + sovConfig(uint64(len(v)))
+			n += mapEntrySize + 1 + sovConfig(uint64(mapEntrySize))
+		}
+	}
+	if m.MaxBatchSize != 0 {
+		n += 1 + sovConfig(uint64(m.MaxBatchSize))
+	}
+	if m.MaxQueueSize != 0 {
+		n += 1 + sovConfig(uint64(m.MaxQueueSize))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.FlushInterval)
+	n += 1 + l + sovConfig(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.ExportTimeout)
+	n += 1 + l + sovConfig(uint64(l))
+	if m.RetryPolicy != nil {
+		l = m.RetryPolicy.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if len(m.Metrics) > 0 {
+		for _, e := range m.Metrics {
+			l = e.Size()
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Params_RetryPolicy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Disabled {
+		n += 2
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.InitialBackoff)
+	n += 1 + l + sovConfig(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxBackoff)
+	n += 1 + l + sovConfig(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxElapsedTime)
+	n += 1 + l + sovConfig(uint64(l))
+	return n
+}
+
+func (m *Params_MetricInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.InstanceName)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.Unit)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.Kind != 0 {
+		n += 1 + sovConfig(uint64(m.Kind))
+	}
+	if len(m.Buckets) > 0 {
+		n += 1 + sovConfig(uint64(len(m.Buckets)*8)) + len(m.Buckets)*8
+	}
+	return n
+}
+
+func sovConfig(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozConfig(x uint64) (n int) {
+	return sovConfig(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Params) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetrics := "[]*Params_MetricInfo{"
+	for _, f := range this.Metrics {
+		repeatedStringForMetrics += strings.Replace(fmt.Sprintf("%v", f), "Params_MetricInfo", "Params_MetricInfo", 1) + ","
+	}
+	repeatedStringForMetrics += "}"
+	keysForHeaders := make([]string, 0, len(this.Headers))
+	for k, _ := range this.Headers {
+		keysForHeaders = append(keysForHeaders, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForHeaders)
+	mapStringForHeaders := "map[string]string{"
+	for _, k := range keysForHeaders {
+		mapStringForHeaders += fmt.Sprintf("%v: %v,", k, this.Headers[k])
+	}
+	mapStringForHeaders += "}"
+	keysForResourceAttributes := make([]string, 0, len(this.ResourceAttributes))
+	for k, _ := range this.ResourceAttributes {
+		keysForResourceAttributes = append(keysForResourceAttributes, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForResourceAttributes)
+	mapStringForResourceAttributes := "map[string]string{"
+	for _, k := range keysForResourceAttributes {
+		mapStringForResourceAttributes += fmt.Sprintf("%v: %v,", k, this.ResourceAttributes[k])
+	}
+	mapStringForResourceAttributes += "}"
+	s := strings.Join([]string{`&Params{`,
+		`Address:` + fmt.Sprintf("%v", this.Address) + `,`,
+		`Insecure:` + fmt.Sprintf("%v", this.Insecure) + `,`,
+		`CaCertPath:` + fmt.Sprintf("%v", this.CaCertPath) + `,`,
+		`Headers:` + mapStringForHeaders + `,`,
+		`ResourceAttributes:` + mapStringForResourceAttributes + `,`,
+		`MaxBatchSize:` + fmt.Sprintf("%v", this.MaxBatchSize) + `,`,
+		`MaxQueueSize:` + fmt.Sprintf("%v", this.MaxQueueSize) + `,`,
+		`FlushInterval:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.FlushInterval), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`ExportTimeout:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ExportTimeout), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`RetryPolicy:` + strings.Replace(fmt.Sprintf("%v", this.RetryPolicy), "Params_RetryPolicy", "Params_RetryPolicy", 1) + `,`,
+		`Metrics:` + repeatedStringForMetrics + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Params_RetryPolicy) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Params_RetryPolicy{`,
+		`Disabled:` + fmt.Sprintf("%v", this.Disabled) + `,`,
+		`InitialBackoff:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.InitialBackoff), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`MaxBackoff:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.MaxBackoff), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`MaxElapsedTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.MaxElapsedTime), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Params_MetricInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Params_MetricInfo{`,
+		`InstanceName:` + fmt.Sprintf("%v", this.InstanceName) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Description:` + fmt.Sprintf("%v", this.Description) + `,`,
+		`Unit:` + fmt.Sprintf("%v", this.Unit) + `,`,
+		`Kind:` + fmt.Sprintf("%v", this.Kind) + `,`,
+		`Buckets:` + fmt.Sprintf("%v", this.Buckets) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringConfig(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Insecure", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Insecure = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CaCertPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CaCertPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Headers == nil {
+				m.Headers = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipConfig(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Headers[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceAttributes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ResourceAttributes == nil {
+				m.ResourceAttributes = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipConfig(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ResourceAttributes[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBatchSize", wireType)
+			}
+			m.MaxBatchSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBatchSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxQueueSize", wireType)
+			}
+			m.MaxQueueSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxQueueSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FlushInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.FlushInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExportTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.ExportTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryPolicy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RetryPolicy == nil {
+				m.RetryPolicy = &Params_RetryPolicy{}
+			}
+			if err := m.RetryPolicy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metrics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metrics = append(m.Metrics, &Params_MetricInfo{})
+			if err := m.Metrics[len(m.Metrics)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params_RetryPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RetryPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RetryPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Disabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Disabled = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitialBackoff", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.InitialBackoff, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBackoff", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.MaxBackoff, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxElapsedTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.MaxElapsedTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params_MetricInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MetricInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MetricInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstanceName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Unit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			m.Kind = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Kind |= Params_MetricInfo_Kind(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.Buckets = append(m.Buckets, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthConfig
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthConfig
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 8
+				if elementCount != 0 && len(m.Buckets) == 0 {
+					m.Buckets = make([]float64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.Buckets = append(m.Buckets, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Buckets", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipConfig(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthConfig
+			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthConfig
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipConfig(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthConfig
+				}
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthConfig = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowConfig   = fmt.Errorf("proto: integer overflow")
+)
@@ -0,0 +1,70 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// export calls an OTLP export method, retrying with an exponential backoff while it fails with a
+// retryable error, if retry is true and the retries are enabled. The retries stop when the handler
+// is closed.
+func (h *handler) export(retry bool, fn func(ctx context.Context) error) error {
+	retry = retry && !h.retry.disabled
+	deadline := time.Now().Add(h.retry.maxElapsedTime)
+	backoff := h.retry.initialBackoff
+
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), h.exportTimeout)
+		err := fn(metadata.NewOutgoingContext(ctx, h.headers))
+		cancel()
+
+		if err == nil || !retry || !retryable(err) {
+			return err
+		}
+		if time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("giving up after %d attempts: %v", attempt, err)
+		}
+
+		h.env.Logger().Debugf("export failed, retrying in %v: %v", backoff, err)
+		t := time.NewTimer(backoff)
+		select {
+		case <-t.C:
+		case <-h.stopCh:
+			t.Stop()
+			return fmt.Errorf("handler closed after %d attempts: %v", attempt, err)
+		}
+
+		if backoff *= 2; backoff > h.retry.maxBackoff {
+			backoff = h.retry.maxBackoff
+		}
+	}
+}
+
+// retryable returns true for the gRPC statuses that the OTLP specification defines as retryable.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted,
+		codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return true
+	}
+	return false
+}
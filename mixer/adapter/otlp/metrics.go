@@ -0,0 +1,295 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"istio.io/istio/mixer/adapter/otlp/config"
+	collectormetricsv1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/collector/metrics/v1"
+	commonv1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/common/v1"
+	metricsv1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/metrics/v1"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/template/metric"
+)
+
+type (
+	// seriesKey identifies a metric series: the instance name and its encoded dimensions.
+	seriesKey struct {
+		instance   string
+		dimensions string
+	}
+
+	// series holds the value of a metric series aggregated over an export interval.
+	series struct {
+		info       *config.Params_MetricInfo
+		attributes []*commonv1.KeyValue
+
+		// the sum of a COUNTER or the last value of a GAUGE, as an integer until a non-integer
+		// value is recorded.
+		isDouble    bool
+		intValue    int64
+		doubleValue float64
+
+		// the histogram of a DISTRIBUTION.
+		count        uint64
+		sum          float64
+		bucketCounts []uint64
+	}
+)
+
+// metric.Handler#HandleMetric
+func (h *handler) HandleMetric(_ context.Context, insts []*metric.Instance) error {
+	var result *multierror.Error
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, inst := range insts {
+		info, found := h.metricInfos[inst.Name]
+		if !found {
+			h.env.Logger().Debugf("no metric configured for instance '%s'", inst.Name)
+			continue
+		}
+
+		key := seriesKey{instance: inst.Name, dimensions: encodeDimensions(inst.Dimensions)}
+		s := h.series[key]
+		if s == nil {
+			s = &series{info: info, attributes: attributes(inst.Dimensions)}
+			if info.Kind == config.DISTRIBUTION {
+				s.bucketCounts = make([]uint64, len(info.Buckets)+1)
+			}
+			h.series[key] = s
+		}
+
+		if err := s.record(inst.Value); err != nil {
+			result = multierror.Append(result, fmt.Errorf("could not record metric instance '%s': %v", inst.Name, err))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// record aggregates a value in the series.
+func (s *series) record(value interface{}) error {
+	var (
+		i       int64
+		d       float64
+		isFloat bool
+	)
+	switch v := value.(type) {
+	case int64:
+		i, d = v, float64(v)
+	case float64:
+		d, isFloat = v, true
+	case time.Duration:
+		// durations are recorded in milliseconds.
+		d, isFloat = float64(v)/float64(time.Millisecond), true
+	default:
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	switch s.info.Kind {
+	case config.GAUGE:
+		s.isDouble, s.intValue, s.doubleValue = isFloat, i, d
+	case config.COUNTER:
+		s.isDouble = s.isDouble || isFloat
+		s.intValue += i
+		s.doubleValue += d
+	case config.DISTRIBUTION:
+		s.count++
+		s.sum += d
+		s.bucketCounts[sort.SearchFloat64s(s.info.Buckets, d)]++
+	}
+	return nil
+}
+
+// dataPoint returns the data point of a GAUGE or COUNTER series.
+func (s *series) dataPoint(start, now uint64) *metricsv1.NumberDataPoint {
+	p := &metricsv1.NumberDataPoint{
+		Attributes:        s.attributes,
+		StartTimeUnixNano: start,
+		TimeUnixNano:      now,
+	}
+	if s.isDouble {
+		p.Value = &metricsv1.NumberDataPoint_AsDouble{AsDouble: s.doubleValue}
+	} else {
+		p.Value = &metricsv1.NumberDataPoint_AsInt{AsInt: s.intValue}
+	}
+	return p
+}
+
+// histogramDataPoint returns the data point of a DISTRIBUTION series.
+func (s *series) histogramDataPoint(start, now uint64) *metricsv1.HistogramDataPoint {
+	return &metricsv1.HistogramDataPoint{
+		Attributes:        s.attributes,
+		StartTimeUnixNano: start,
+		TimeUnixNano:      now,
+		Count:             s.count,
+		Sum:               s.sum,
+		BucketCounts:      s.bucketCounts,
+		ExplicitBounds:    s.info.Buckets,
+	}
+}
+
+// exportMetrics exports the metrics aggregated since the last export, and starts a new interval.
+// The metrics are sent in batches of at most maxBatchSize data points.
+func (h *handler) exportMetrics(retry bool) {
+	h.mutex.Lock()
+	all := h.series
+	start := h.intervalStart
+	h.series = make(map[seriesKey]*series)
+	h.intervalStart = time.Now()
+	h.mutex.Unlock()
+
+	if len(all) == 0 {
+		return
+	}
+
+	keys := make([]seriesKey, 0, len(all))
+	for k := range all {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].instance != keys[j].instance {
+			return keys[i].instance < keys[j].instance
+		}
+		return keys[i].dimensions < keys[j].dimensions
+	})
+
+	startNano, nowNano := uint64(start.UnixNano()), uint64(time.Now().UnixNano())
+	for len(keys) > 0 {
+		n := h.maxBatchSize
+		if n > len(keys) {
+			n = len(keys)
+		}
+		var batch []*metricsv1.Metric
+		var current *metricsv1.Metric
+		for i, k := range keys[:n] {
+			if i == 0 || k.instance != keys[i-1].instance {
+				current = newMetric(all[k].info)
+				batch = append(batch, current)
+			}
+			addDataPoint(current, all[k], startNano, nowNano)
+		}
+		keys = keys[n:]
+
+		req := &collectormetricsv1.ExportMetricsServiceRequest{
+			ResourceMetrics: []*metricsv1.ResourceMetrics{{
+				Resource: h.resource,
+				ScopeMetrics: []*metricsv1.ScopeMetrics{{
+					Scope:   &commonv1.InstrumentationScope{Name: scopeName},
+					Metrics: batch,
+				}},
+			}},
+		}
+		err := h.export(retry, func(ctx context.Context) error {
+			resp, err := h.metrics.Export(ctx, req)
+			if err == nil && resp.PartialSuccess != nil && resp.PartialSuccess.RejectedDataPoints > 0 {
+				h.env.Logger().Warningf("%d metric data points were rejected: %s",
+					resp.PartialSuccess.RejectedDataPoints, resp.PartialSuccess.ErrorMessage)
+			}
+			return err
+		})
+		if err != nil {
+			_ = h.env.Logger().Errorf("could not export %d metric data points: %v", n, err)
+		}
+	}
+}
+
+func newMetric(info *config.Params_MetricInfo) *metricsv1.Metric {
+	m := &metricsv1.Metric{
+		Name:        info.Name,
+		Description: info.Description,
+		Unit:        info.Unit,
+	}
+	if m.Name == "" {
+		m.Name = info.InstanceName
+	}
+	switch info.Kind {
+	case config.GAUGE:
+		m.Data = &metricsv1.Metric_Gauge{Gauge: &metricsv1.Gauge{}}
+	case config.COUNTER:
+		m.Data = &metricsv1.Metric_Sum{Sum: &metricsv1.Sum{
+			AggregationTemporality: metricsv1.AGGREGATION_TEMPORALITY_DELTA,
+			IsMonotonic:            true,
+		}}
+	case config.DISTRIBUTION:
+		m.Data = &metricsv1.Metric_Histogram{Histogram: &metricsv1.Histogram{
+			AggregationTemporality: metricsv1.AGGREGATION_TEMPORALITY_DELTA,
+		}}
+	}
+	return m
+}
+
+func addDataPoint(m *metricsv1.Metric, s *series, start, now uint64) {
+	switch d := m.Data.(type) {
+	case *metricsv1.Metric_Gauge:
+		// a gauge has no start time.
+		d.Gauge.DataPoints = append(d.Gauge.DataPoints, s.dataPoint(0, now))
+	case *metricsv1.Metric_Sum:
+		d.Sum.DataPoints = append(d.Sum.DataPoints, s.dataPoint(start, now))
+	case *metricsv1.Metric_Histogram:
+		d.Histogram.DataPoints = append(d.Histogram.DataPoints, s.histogramDataPoint(start, now))
+	}
+}
+
+// encodeDimensions returns a string uniquely identifying a set of dimensions.
+func encodeDimensions(dims map[string]interface{}) string {
+	keys := make([]string, 0, len(dims))
+	for k := range dims {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(adapter.Stringify(dims[k]))
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// attributes returns the attributes for instance dimensions or span tags, sorted by key. The
+// strings, integers, doubles and booleans keep their type, and the other values are stringified.
+func attributes(values map[string]interface{}) []*commonv1.KeyValue {
+	attrs := make([]*commonv1.KeyValue, 0, len(values))
+	for k, v := range values {
+		var value commonv1.AnyValue
+		switch x := v.(type) {
+		case string:
+			value.Value = &commonv1.AnyValue_StringValue{StringValue: x}
+		case int64:
+			value.Value = &commonv1.AnyValue_IntValue{IntValue: x}
+		case float64:
+			value.Value = &commonv1.AnyValue_DoubleValue{DoubleValue: x}
+		case bool:
+			value.Value = &commonv1.AnyValue_BoolValue{BoolValue: x}
+		default:
+			value.Value = &commonv1.AnyValue_StringValue{StringValue: adapter.Stringify(x)}
+		}
+		attrs = append(attrs, &commonv1.KeyValue{Key: k, Value: &value})
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+	return attrs
+}
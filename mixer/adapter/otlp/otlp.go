@@ -0,0 +1,350 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate $GOPATH/src/istio.io/istio/bin/mixer_codegen.sh -f mixer/adapter/otlp/config/config.proto -i mixer/adapter/otlp/config
+
+// Package otlp contains a metric and tracespan adapter exporting to an OpenTelemetry collector
+// over the OpenTelemetry protocol (https://opentelemetry.io/docs/specs/otlp/).
+package otlp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	descriptor "istio.io/api/policy/v1beta1"
+	pkgadapter "istio.io/istio/mixer/adapter/metadata"
+	"istio.io/istio/mixer/adapter/otlp/config"
+	collectormetricsv1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/collector/metrics/v1"
+	collectortracev1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/collector/trace/v1"
+	commonv1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/common/v1"
+	resourcev1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/resource/v1"
+	tracev1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/trace/v1"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/template/metric"
+	"istio.io/istio/mixer/template/tracespan"
+)
+
+const (
+	defaultMaxBatchSize   = 512
+	defaultMaxQueueSize   = 8192
+	defaultFlushInterval  = 5 * time.Second
+	defaultExportTimeout  = 10 * time.Second
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+	defaultMaxElapsedTime = time.Minute
+
+	// scopeName is the name of the instrumentation scope of the exported metrics and spans.
+	scopeName = "istio.io/istio/mixer/adapter/otlp"
+)
+
+type (
+	builder struct {
+		cfg         *config.Params
+		metricTypes map[string]*metric.Type
+	}
+
+	handler struct {
+		env     adapter.Env
+		conn    *grpc.ClientConn
+		traces  collectortracev1.TraceServiceClient
+		metrics collectormetricsv1.MetricsServiceClient
+
+		resource *resourcev1.Resource
+		headers  metadata.MD
+
+		maxBatchSize  int
+		maxQueueSize  int
+		flushInterval time.Duration
+		exportTimeout time.Duration
+		retry         retryPolicy
+
+		metricInfos map[string]*config.Params_MetricInfo
+
+		mutex sync.Mutex
+		spans []*tracev1.Span
+		// series are the metric series aggregated since intervalStart, by metric and dimensions.
+		series        map[seriesKey]*series
+		intervalStart time.Time
+
+		// spansReady is signaled when a full batch of spans is buffered.
+		spansReady chan struct{}
+		stopCh     chan struct{}
+		done       chan struct{}
+	}
+
+	retryPolicy struct {
+		disabled       bool
+		initialBackoff time.Duration
+		maxBackoff     time.Duration
+		maxElapsedTime time.Duration
+	}
+)
+
+// ensure our types implement the requisite interfaces
+var (
+	_ metric.HandlerBuilder    = &builder{}
+	_ metric.Handler           = &handler{}
+	_ tracespan.HandlerBuilder = &builder{}
+	_ tracespan.Handler        = &handler{}
+)
+
+///////////////// Configuration-time Methods ///////////////
+
+// adapter.HandlerBuilder#Build
+func (b *builder) Build(ctx context.Context, env adapter.Env) (adapter.Handler, error) {
+	cfg := b.cfg
+
+	var creds grpc.DialOption
+	switch {
+	case cfg.Insecure:
+		creds = grpc.WithInsecure()
+	case cfg.CaCertPath != "":
+		tc, err := credentials.NewClientTLSFromFile(cfg.CaCertPath, "")
+		if err != nil {
+			return nil, fmt.Errorf("could not load the CA certificates: %v", err)
+		}
+		creds = grpc.WithTransportCredentials(tc)
+	default:
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))
+	}
+
+	// the connection is established lazily, and re-established as needed by gRPC.
+	conn, err := grpc.Dial(cfg.Address, creds)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to '%s': %v", cfg.Address, err)
+	}
+
+	h := &handler{
+		env:           env,
+		conn:          conn,
+		traces:        collectortracev1.NewTraceServiceClient(conn),
+		metrics:       collectormetricsv1.NewMetricsServiceClient(conn),
+		resource:      &resourcev1.Resource{Attributes: stringAttributes(cfg.ResourceAttributes)},
+		headers:       metadata.New(cfg.Headers),
+		maxBatchSize:  defaultMaxBatchSize,
+		maxQueueSize:  defaultMaxQueueSize,
+		flushInterval: defaultFlushInterval,
+		exportTimeout: defaultExportTimeout,
+		retry: retryPolicy{
+			initialBackoff: defaultInitialBackoff,
+			maxBackoff:     defaultMaxBackoff,
+			maxElapsedTime: defaultMaxElapsedTime,
+		},
+		metricInfos:   make(map[string]*config.Params_MetricInfo, len(cfg.Metrics)),
+		series:        make(map[seriesKey]*series),
+		intervalStart: time.Now(),
+		spansReady:    make(chan struct{}, 1),
+		stopCh:        make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	if cfg.MaxBatchSize > 0 {
+		h.maxBatchSize = int(cfg.MaxBatchSize)
+	}
+	if cfg.MaxQueueSize > 0 {
+		h.maxQueueSize = int(cfg.MaxQueueSize)
+	}
+	if cfg.FlushInterval > 0 {
+		h.flushInterval = cfg.FlushInterval
+	}
+	if cfg.ExportTimeout > 0 {
+		h.exportTimeout = cfg.ExportTimeout
+	}
+	if rp := cfg.RetryPolicy; rp != nil {
+		h.retry.disabled = rp.Disabled
+		if rp.InitialBackoff > 0 {
+			h.retry.initialBackoff = rp.InitialBackoff
+		}
+		if rp.MaxBackoff > 0 {
+			h.retry.maxBackoff = rp.MaxBackoff
+		}
+		if rp.MaxElapsedTime > 0 {
+			h.retry.maxElapsedTime = rp.MaxElapsedTime
+		}
+	}
+	for _, m := range cfg.Metrics {
+		h.metricInfos[m.InstanceName] = m
+	}
+
+	env.ScheduleDaemon(h.run)
+
+	return h, nil
+}
+
+// adapter.HandlerBuilder#SetAdapterConfig
+func (b *builder) SetAdapterConfig(cfg adapter.Config) {
+	b.cfg = cfg.(*config.Params)
+}
+
+// adapter.HandlerBuilder#Validate
+func (b *builder) Validate() (ce *adapter.ConfigErrors) {
+	cfg := b.cfg
+
+	if cfg.Address == "" {
+		ce = ce.Appendf("address", "address is empty")
+	} else if _, _, err := net.SplitHostPort(cfg.Address); err != nil {
+		ce = ce.Appendf("address", "address is malformed: %v", err)
+	}
+	if cfg.Insecure && cfg.CaCertPath != "" {
+		ce = ce.Appendf("caCertPath", "caCertPath can't be set for an insecure connection")
+	}
+	if cfg.MaxBatchSize < 0 {
+		ce = ce.Appendf("maxBatchSize", "value must be a positive number")
+	}
+	if cfg.MaxQueueSize < 0 {
+		ce = ce.Appendf("maxQueueSize", "value must be a positive number")
+	}
+	if cfg.MaxBatchSize > 0 && cfg.MaxQueueSize > 0 && cfg.MaxQueueSize < cfg.MaxBatchSize {
+		ce = ce.Appendf("maxQueueSize", "value must be at least maxBatchSize")
+	}
+	if cfg.FlushInterval < 0 {
+		ce = ce.Appendf("flushInterval", "duration must be positive")
+	}
+	if cfg.ExportTimeout < 0 {
+		ce = ce.Appendf("exportTimeout", "duration must be positive")
+	}
+	if rp := cfg.RetryPolicy; rp != nil {
+		if rp.InitialBackoff < 0 {
+			ce = ce.Appendf("retryPolicy.initialBackoff", "duration must be positive")
+		}
+		if rp.MaxBackoff < 0 {
+			ce = ce.Appendf("retryPolicy.maxBackoff", "duration must be positive")
+		}
+		if rp.MaxElapsedTime < 0 {
+			ce = ce.Appendf("retryPolicy.maxElapsedTime", "duration must be positive")
+		}
+		if rp.InitialBackoff > 0 && rp.MaxBackoff > 0 && rp.MaxBackoff < rp.InitialBackoff {
+			ce = ce.Appendf("retryPolicy.maxBackoff", "duration must be at least initialBackoff")
+		}
+	}
+
+	seen := make(map[string]bool, len(cfg.Metrics))
+	for i, m := range cfg.Metrics {
+		field := fmt.Sprintf("metrics[%d]", i)
+		if m.InstanceName == "" {
+			ce = ce.Appendf(field+".instanceName", "instance name is empty")
+			continue
+		}
+		if seen[m.InstanceName] {
+			ce = ce.Appendf(field+".instanceName", "metric instance '%s' is configured more than once", m.InstanceName)
+		}
+		seen[m.InstanceName] = true
+
+		if t, found := b.metricTypes[m.InstanceName]; found {
+			switch t.Value {
+			case descriptor.INT64, descriptor.DOUBLE, descriptor.DURATION:
+			default:
+				ce = ce.Appendf(field, "metric instance '%s' has the unsupported value type %v", m.InstanceName, t.Value)
+			}
+		}
+
+		switch m.Kind {
+		case config.GAUGE, config.COUNTER:
+		case config.DISTRIBUTION:
+			if len(m.Buckets) == 0 {
+				ce = ce.Appendf(field+".buckets", "buckets must be provided for a DISTRIBUTION metric")
+			} else if !sort.Float64sAreSorted(m.Buckets) || hasDuplicates(m.Buckets) {
+				ce = ce.Appendf(field+".buckets", "bucket bounds must be increasing")
+			}
+		default:
+			ce = ce.Appendf(field+".kind", "metric kind must be GAUGE, COUNTER or DISTRIBUTION")
+		}
+	}
+	return
+}
+
+func hasDuplicates(sorted []float64) bool {
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return true
+		}
+	}
+	return false
+}
+
+// metric.HandlerBuilder#SetMetricTypes
+func (b *builder) SetMetricTypes(types map[string]*metric.Type) {
+	b.metricTypes = types
+}
+
+// tracespan.HandlerBuilder#SetTraceSpanTypes
+func (b *builder) SetTraceSpanTypes(map[string]*tracespan.Type) {}
+
+////////////////// Request-time Methods //////////////////////////
+
+// run exports the buffered spans and the aggregated metrics at every flush interval, and the
+// buffered spans as soon as a full batch is available. The remaining data is exported when the
+// handler is closed.
+func (h *handler) run() {
+	defer close(h.done)
+
+	tick := time.NewTicker(h.flushInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-h.stopCh:
+			h.exportSpans(false)
+			h.exportMetrics(false)
+			return
+		case <-h.spansReady:
+			h.exportSpans(true)
+		case <-tick.C:
+			h.exportSpans(true)
+			h.exportMetrics(true)
+		}
+	}
+}
+
+// adapter.Handler#Close
+func (h *handler) Close() error {
+	select {
+	case <-h.stopCh:
+		return errors.New("handler already closed")
+	default:
+	}
+	close(h.stopCh)
+	<-h.done
+	return h.conn.Close()
+}
+
+// GetInfo returns the adapter.Info specific to this adapter.
+func GetInfo() adapter.Info {
+	info := pkgadapter.GetInfo("otlp")
+	info.NewBuilder = func() adapter.HandlerBuilder { return &builder{} }
+	return info
+}
+
+// stringAttributes returns the attributes for a map of strings, sorted by key.
+func stringAttributes(m map[string]string) []*commonv1.KeyValue {
+	attrs := make([]*commonv1.KeyValue, 0, len(m))
+	for k, v := range m {
+		attrs = append(attrs, &commonv1.KeyValue{
+			Key:   k,
+			Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: v}},
+		})
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+	return attrs
+}
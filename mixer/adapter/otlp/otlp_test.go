@@ -0,0 +1,487 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	octrace "go.opencensus.io/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	descriptor "istio.io/api/policy/v1beta1"
+	"istio.io/istio/mixer/adapter/otlp/config"
+	collectormetricsv1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/collector/metrics/v1"
+	collectortracev1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/collector/trace/v1"
+	commonv1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/common/v1"
+	metricsv1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/metrics/v1"
+	tracev1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/trace/v1"
+	"istio.io/istio/mixer/pkg/adapter/opencensus"
+	"istio.io/istio/mixer/pkg/adapter/test"
+	"istio.io/istio/mixer/template/metric"
+	"istio.io/istio/mixer/template/tracespan"
+)
+
+// fakeCollector is an in-process OTLP collector recording the export requests.
+type fakeCollector struct {
+	t       *testing.T
+	address string
+
+	mutex   sync.Mutex
+	spans   []*tracev1.Span
+	metrics []*metricsv1.Metric
+	headers []metadata.MD
+	calls   int
+	// the first failures export calls fail with the status code.
+	failures int
+	code     codes.Code
+}
+
+type traceService struct{ *fakeCollector }
+type metricsService struct{ *fakeCollector }
+
+func newFakeCollector(t *testing.T) (*fakeCollector, func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %v", err)
+	}
+	c := &fakeCollector{t: t, address: l.Addr().String()}
+	s := grpc.NewServer()
+	collectortracev1.RegisterTraceServiceServer(s, traceService{c})
+	collectormetricsv1.RegisterMetricsServiceServer(s, metricsService{c})
+	go func() { _ = s.Serve(l) }()
+	return c, s.Stop
+}
+
+func (c *fakeCollector) record(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls++
+	md, _ := metadata.FromIncomingContext(ctx)
+	c.headers = append(c.headers, md)
+	if c.calls <= c.failures {
+		return status.Error(c.code, "failing as requested")
+	}
+	return nil
+}
+
+func (s traceService) Export(ctx context.Context, req *collectortracev1.ExportTraceServiceRequest) (
+	*collectortracev1.ExportTraceServiceResponse, error) {
+	if err := s.record(ctx); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, rs := range req.ResourceSpans {
+		s.checkResource(rs.Resource.Attributes)
+		for _, ss := range rs.ScopeSpans {
+			s.spans = append(s.spans, ss.Spans...)
+		}
+	}
+	return &collectortracev1.ExportTraceServiceResponse{}, nil
+}
+
+func (s metricsService) Export(ctx context.Context, req *collectormetricsv1.ExportMetricsServiceRequest) (
+	*collectormetricsv1.ExportMetricsServiceResponse, error) {
+	if err := s.record(ctx); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, rm := range req.ResourceMetrics {
+		s.checkResource(rm.Resource.Attributes)
+		for _, sm := range rm.ScopeMetrics {
+			s.metrics = append(s.metrics, sm.Metrics...)
+		}
+	}
+	return &collectormetricsv1.ExportMetricsServiceResponse{}, nil
+}
+
+func (c *fakeCollector) checkResource(attrs []*commonv1.KeyValue) {
+	if len(attrs) != 1 || attrs[0].Key != "service.name" || attrs[0].Value.GetStringValue() != "mixer" {
+		c.t.Errorf("got resource attributes %v, want the service name", attrs)
+	}
+}
+
+func (c *fakeCollector) callCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.calls
+}
+
+func (c *fakeCollector) params() *config.Params {
+	return &config.Params{
+		Address:            c.address,
+		Insecure:           true,
+		Headers:            map[string]string{"authorization": "token"},
+		ResourceAttributes: map[string]string{"service.name": "mixer"},
+		FlushInterval:      time.Hour,
+		RetryPolicy: &config.Params_RetryPolicy{
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     5 * time.Millisecond,
+			MaxElapsedTime: 10 * time.Second,
+		},
+		Metrics: []*config.Params_MetricInfo{
+			{InstanceName: "requestcount.metric.istio-system", Name: "requests", Kind: config.COUNTER},
+			{InstanceName: "connections.metric.istio-system", Kind: config.GAUGE},
+			{InstanceName: "latency.metric.istio-system", Unit: "ms", Kind: config.DISTRIBUTION, Buckets: []float64{10, 100}},
+		},
+	}
+}
+
+func build(t *testing.T, cfg *config.Params) *handler {
+	t.Helper()
+	b := GetInfo().NewBuilder().(*builder)
+	b.SetAdapterConfig(cfg)
+	b.SetMetricTypes(map[string]*metric.Type{})
+	b.SetTraceSpanTypes(map[string]*tracespan.Type{})
+	if err := b.Validate(); err != nil {
+		t.Fatalf("Validate() failed: %v", err)
+	}
+	h, err := b.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	return h.(*handler)
+}
+
+const (
+	traceID  = "463ac35c9f6413ad48485a3953bb6124"
+	spanID   = "a2fb4a1d1a96d312"
+	parentID = "0020000000000001"
+)
+
+func TestExportSpans(t *testing.T) {
+	c, stop := newFakeCollector(t)
+	defer stop()
+
+	cfg := c.params()
+	cfg.MaxBatchSize = 2
+	h := build(t, cfg)
+
+	start := time.Unix(1000, 0)
+	spans := []*tracespan.Instance{
+		{
+			TraceId: traceID, SpanId: spanID, ParentSpanId: parentID, SpanName: "/reviews",
+			StartTime: start, EndTime: start.Add(time.Second),
+			SpanTags:       map[string]interface{}{"source.name": "productpage", "request.size": int64(42)},
+			HttpStatusCode: 503,
+		},
+		{
+			TraceId: traceID, SpanId: spanID, ParentSpanId: parentID, SpanName: "/reviews",
+			ClientSpan: true, RewriteClientSpanId: true, HttpStatusCode: 404,
+		},
+		{TraceId: traceID, SpanId: spanID, SpanName: "/ratings", HttpStatusCode: 200},
+		{TraceId: "invalid", SpanId: spanID, SpanName: "/ignored"},
+	}
+	if err := h.HandleTraceSpan(context.Background(), spans); err != nil {
+		t.Fatalf("HandleTraceSpan() failed: %v", err)
+	}
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	if c.calls != 2 {
+		t.Errorf("got %d export calls, want 2 batches", c.calls)
+	}
+	if len(c.headers) == 0 || !reflect.DeepEqual(c.headers[0]["authorization"], []string{"token"}) {
+		t.Errorf("got headers %v, want the authorization header", c.headers)
+	}
+	if len(c.spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(c.spans))
+	}
+
+	server, client, root := c.spans[0], c.spans[1], c.spans[2]
+	if hex.EncodeToString(server.TraceId) != traceID || hex.EncodeToString(server.SpanId) != spanID ||
+		hex.EncodeToString(server.ParentSpanId) != parentID {
+		t.Errorf("got ids %x/%x/%x", server.TraceId, server.SpanId, server.ParentSpanId)
+	}
+	if server.Kind != tracev1.SPAN_KIND_SERVER || server.Status.Code != tracev1.STATUS_CODE_ERROR {
+		t.Errorf("got kind %v and status %v, want an erroneous server span", server.Kind, server.Status.Code)
+	}
+	if server.StartTimeUnixNano != uint64(start.UnixNano()) || server.EndTimeUnixNano != uint64(start.Add(time.Second).UnixNano()) {
+		t.Errorf("got times %d-%d", server.StartTimeUnixNano, server.EndTimeUnixNano)
+	}
+	wantAttrs := []*commonv1.KeyValue{
+		{Key: "request.size", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_IntValue{IntValue: 42}}},
+		{Key: "source.name", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: "productpage"}}},
+		{Key: "http.status_code", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_IntValue{IntValue: 503}}},
+	}
+	if !reflect.DeepEqual(server.Attributes, wantAttrs) {
+		t.Errorf("got attributes %v, want %v", server.Attributes, wantAttrs)
+	}
+
+	// the client span id is rewritten, so that the server span is its child.
+	var id octrace.SpanID
+	_, _ = hex.Decode(id[:], []byte(spanID))
+	rewritten := opencensus.RewriteSpanID(id)
+	if client.Kind != tracev1.SPAN_KIND_CLIENT || hex.EncodeToString(client.SpanId) != hex.EncodeToString(rewritten[:]) {
+		t.Errorf("got client span %v with id %x, want id %x", client.Kind, client.SpanId, rewritten)
+	}
+	if client.Status.Code != tracev1.STATUS_CODE_ERROR {
+		t.Errorf("got status %v, want an error for a 4xx client span", client.Status.Code)
+	}
+
+	if root.ParentSpanId != nil || root.Status.Code != tracev1.STATUS_CODE_UNSET {
+		t.Errorf("got parent %x and status %v, want an unset root span", root.ParentSpanId, root.Status.Code)
+	}
+}
+
+func TestSpanQueueFull(t *testing.T) {
+	c, stop := newFakeCollector(t)
+	defer stop()
+
+	cfg := c.params()
+	cfg.MaxBatchSize = 2
+	cfg.MaxQueueSize = 2
+	h := build(t, cfg)
+	defer func() { _ = h.Close() }()
+
+	span := &tracespan.Instance{TraceId: traceID, SpanId: spanID}
+	err := h.HandleTraceSpan(context.Background(), []*tracespan.Instance{span, span, span})
+	if err == nil || !strings.Contains(err.Error(), "dropped 1 spans") {
+		t.Errorf("got error %v, want the dropped span", err)
+	}
+}
+
+func TestExportMetrics(t *testing.T) {
+	c, stop := newFakeCollector(t)
+	defer stop()
+
+	h := build(t, c.params())
+	dims := map[string]interface{}{"destination": "reviews", "code": int64(200)}
+	otherDims := map[string]interface{}{"destination": "ratings", "code": int64(200)}
+	insts := []*metric.Instance{
+		{Name: "requestcount.metric.istio-system", Value: int64(1), Dimensions: dims},
+		{Name: "requestcount.metric.istio-system", Value: int64(2), Dimensions: dims},
+		{Name: "requestcount.metric.istio-system", Value: int64(5), Dimensions: otherDims},
+		{Name: "connections.metric.istio-system", Value: 3.5, Dimensions: dims},
+		{Name: "connections.metric.istio-system", Value: 1.5, Dimensions: dims},
+		{Name: "latency.metric.istio-system", Value: 5 * time.Millisecond, Dimensions: dims},
+		{Name: "latency.metric.istio-system", Value: 50 * time.Millisecond, Dimensions: dims},
+		{Name: "latency.metric.istio-system", Value: 500 * time.Millisecond, Dimensions: dims},
+		{Name: "unknown.metric.istio-system", Value: int64(1), Dimensions: dims},
+	}
+	if err := h.HandleMetric(context.Background(), insts); err != nil {
+		t.Fatalf("HandleMetric() failed: %v", err)
+	}
+	err := h.HandleMetric(context.Background(), []*metric.Instance{
+		{Name: "requestcount.metric.istio-system", Value: "invalid", Dimensions: dims},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unsupported value type string") {
+		t.Errorf("got error %v, want the unsupported value type", err)
+	}
+	if err = h.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	metrics := make(map[string]*metricsv1.Metric)
+	for _, m := range c.metrics {
+		metrics[m.Name] = m
+	}
+	if len(c.metrics) != 3 {
+		t.Fatalf("got metrics %v, want 3 metrics", c.metrics)
+	}
+
+	gauge := metrics["connections.metric.istio-system"].GetGauge()
+	if gauge == nil || len(gauge.DataPoints) != 1 || gauge.DataPoints[0].GetAsDouble() != 1.5 {
+		t.Errorf("got gauge %v, want the last value", gauge)
+	}
+
+	sum := metrics["requests"].GetSum()
+	if sum == nil || !sum.IsMonotonic || sum.AggregationTemporality != metricsv1.AGGREGATION_TEMPORALITY_DELTA {
+		t.Fatalf("got %v, want a monotonic delta sum", metrics["requests"])
+	}
+	var values []int64
+	for _, p := range sum.DataPoints {
+		values = append(values, p.GetAsInt())
+		if p.StartTimeUnixNano == 0 || p.StartTimeUnixNano > p.TimeUnixNano {
+			t.Errorf("got an invalid interval %d-%d", p.StartTimeUnixNano, p.TimeUnixNano)
+		}
+	}
+	// the series are sorted by their dimensions.
+	if !reflect.DeepEqual(values, []int64{5, 3}) {
+		t.Errorf("got sums %v, want [5 3]", values)
+	}
+
+	latency := metrics["latency.metric.istio-system"]
+	hist := latency.GetHistogram()
+	if latency.Unit != "ms" || hist == nil || len(hist.DataPoints) != 1 {
+		t.Fatalf("got %v, want a histogram", latency)
+	}
+	p := hist.DataPoints[0]
+	if p.Count != 3 || p.Sum != 555 || !reflect.DeepEqual(p.BucketCounts, []uint64{1, 1, 1}) ||
+		!reflect.DeepEqual(p.ExplicitBounds, []float64{10, 100}) {
+		t.Errorf("got histogram data point %v", p)
+	}
+}
+
+func TestExportMetricsBatches(t *testing.T) {
+	c, stop := newFakeCollector(t)
+	defer stop()
+
+	cfg := c.params()
+	cfg.MaxBatchSize = 2
+	h := build(t, cfg)
+	for _, d := range []string{"a", "b", "c"} {
+		inst := &metric.Instance{
+			Name:       "requestcount.metric.istio-system",
+			Value:      int64(1),
+			Dimensions: map[string]interface{}{"destination": d},
+		}
+		if err := h.HandleMetric(context.Background(), []*metric.Instance{inst}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = h.Close()
+
+	if c.calls != 2 || len(c.metrics) != 2 || len(c.metrics[0].GetSum().DataPoints) != 2 {
+		t.Errorf("got %d calls with metrics %v, want 2 batches", c.calls, c.metrics)
+	}
+}
+
+func TestExportRetry(t *testing.T) {
+	cases := []struct {
+		name      string
+		code      codes.Code
+		failures  int
+		disabled  bool
+		wantCalls int
+		wantSpans int
+	}{
+		{name: "Retried", code: codes.Unavailable, failures: 2, wantCalls: 3, wantSpans: 1},
+		{name: "NotRetryable", code: codes.InvalidArgument, failures: 2, wantCalls: 1},
+		{name: "Disabled", code: codes.Unavailable, failures: 2, disabled: true, wantCalls: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, stop := newFakeCollector(t)
+			defer stop()
+			c.failures, c.code = tc.failures, tc.code
+
+			cfg := c.params()
+			cfg.MaxBatchSize = 1
+			cfg.RetryPolicy.Disabled = tc.disabled
+			h := build(t, cfg)
+
+			span := &tracespan.Instance{TraceId: traceID, SpanId: spanID}
+			if err := h.HandleTraceSpan(context.Background(), []*tracespan.Instance{span}); err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 100 && c.callCount() < tc.wantCalls; i++ {
+				time.Sleep(10 * time.Millisecond)
+			}
+			_ = h.Close()
+
+			if c.calls != tc.wantCalls || len(c.spans) != tc.wantSpans {
+				t.Errorf("got %d calls and %d spans, want %d calls and %d spans", c.calls, len(c.spans), tc.wantCalls, tc.wantSpans)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *config.Params {
+		return &config.Params{
+			Address: "collector:4317",
+			Metrics: []*config.Params_MetricInfo{
+				{InstanceName: "requestcount.metric.istio-system", Kind: config.COUNTER},
+			},
+		}
+	}
+	cases := []struct {
+		name   string
+		modify func(*config.Params)
+		types  map[string]*metric.Type
+		err    string
+	}{
+		{name: "Valid", modify: func(*config.Params) {}},
+		{name: "NoAddress", modify: func(p *config.Params) { p.Address = "" }, err: "address is empty"},
+		{name: "MalformedAddress", modify: func(p *config.Params) { p.Address = "collector" }, err: "address is malformed"},
+		{
+			name:   "InsecureWithCA",
+			modify: func(p *config.Params) { p.Insecure, p.CaCertPath = true, "ca.pem" },
+			err:    "can't be set for an insecure connection",
+		},
+		{name: "NegativeBatch", modify: func(p *config.Params) { p.MaxBatchSize = -1 }, err: "maxBatchSize"},
+		{
+			name:   "QueueSmallerThanBatch",
+			modify: func(p *config.Params) { p.MaxBatchSize, p.MaxQueueSize = 10, 5 },
+			err:    "must be at least maxBatchSize",
+		},
+		{name: "NegativeFlush", modify: func(p *config.Params) { p.FlushInterval = -time.Second }, err: "flushInterval"},
+		{
+			name: "MaxBackoffBelowInitial",
+			modify: func(p *config.Params) {
+				p.RetryPolicy = &config.Params_RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Millisecond}
+			},
+			err: "must be at least initialBackoff",
+		},
+		{
+			name:   "DuplicateMetric",
+			modify: func(p *config.Params) { p.Metrics = append(p.Metrics, p.Metrics[0]) },
+			err:    "configured more than once",
+		},
+		{
+			name:   "NoKind",
+			modify: func(p *config.Params) { p.Metrics[0].Kind = config.UNSPECIFIED },
+			err:    "metric kind must be",
+		},
+		{
+			name:   "NoBuckets",
+			modify: func(p *config.Params) { p.Metrics[0].Kind = config.DISTRIBUTION },
+			err:    "buckets must be provided",
+		},
+		{
+			name:   "UnsortedBuckets",
+			modify: func(p *config.Params) { p.Metrics[0].Kind, p.Metrics[0].Buckets = config.DISTRIBUTION, []float64{2, 1} },
+			err:    "bucket bounds must be increasing",
+		},
+		{
+			name:   "UnsupportedValueType",
+			modify: func(*config.Params) {},
+			types:  map[string]*metric.Type{"requestcount.metric.istio-system": {Value: descriptor.STRING}},
+			err:    "unsupported value type",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := valid()
+			tc.modify(cfg)
+			b := &builder{}
+			b.SetAdapterConfig(cfg)
+			b.SetMetricTypes(tc.types)
+
+			err := b.Validate()
+			if tc.err == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+				t.Errorf("got error %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
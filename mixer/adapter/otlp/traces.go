@@ -0,0 +1,154 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"fmt"
+
+	octrace "go.opencensus.io/trace"
+
+	collectortracev1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/collector/trace/v1"
+	commonv1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/common/v1"
+	tracev1 "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry/proto/trace/v1"
+	"istio.io/istio/mixer/pkg/adapter/opencensus"
+	"istio.io/istio/mixer/template/tracespan"
+)
+
+const attrHTTPStatusCode = "http.status_code"
+
+// tracespan.Handler#HandleTraceSpan
+func (h *handler) HandleTraceSpan(_ context.Context, insts []*tracespan.Instance) error {
+	spans := make([]*tracev1.Span, 0, len(insts))
+	for _, inst := range insts {
+		if span := convertSpan(inst); span != nil {
+			spans = append(spans, span)
+		} else {
+			h.env.Logger().Debugf("ignoring span '%s' with invalid ids", inst.SpanName)
+		}
+	}
+
+	h.mutex.Lock()
+	dropped := len(h.spans) + len(spans) - h.maxQueueSize
+	if dropped > 0 {
+		spans = spans[:len(spans)-dropped]
+	}
+	h.spans = append(h.spans, spans...)
+	full := len(h.spans) >= h.maxBatchSize
+	h.mutex.Unlock()
+
+	if full {
+		select {
+		case h.spansReady <- struct{}{}:
+		default:
+		}
+	}
+
+	if dropped > 0 {
+		return fmt.Errorf("span queue full; dropped %d spans", dropped)
+	}
+	return nil
+}
+
+// exportSpans exports the buffered spans, in batches of at most maxBatchSize spans.
+func (h *handler) exportSpans(retry bool) {
+	h.mutex.Lock()
+	spans := h.spans
+	h.spans = nil
+	h.mutex.Unlock()
+
+	for len(spans) > 0 {
+		n := h.maxBatchSize
+		if n > len(spans) {
+			n = len(spans)
+		}
+		req := &collectortracev1.ExportTraceServiceRequest{
+			ResourceSpans: []*tracev1.ResourceSpans{{
+				Resource: h.resource,
+				ScopeSpans: []*tracev1.ScopeSpans{{
+					Scope: &commonv1.InstrumentationScope{Name: scopeName},
+					Spans: spans[:n],
+				}},
+			}},
+		}
+		spans = spans[n:]
+
+		err := h.export(retry, func(ctx context.Context) error {
+			resp, err := h.traces.Export(ctx, req)
+			if err == nil && resp.PartialSuccess != nil && resp.PartialSuccess.RejectedSpans > 0 {
+				h.env.Logger().Warningf("%d spans were rejected: %s",
+					resp.PartialSuccess.RejectedSpans, resp.PartialSuccess.ErrorMessage)
+			}
+			return err
+		})
+		if err != nil {
+			_ = h.env.Logger().Errorf("could not export %d spans: %v", n, err)
+		}
+	}
+}
+
+// convertSpan returns the OTLP span of a tracespan instance, or nil if its ids are invalid.
+func convertSpan(inst *tracespan.Instance) *tracev1.Span {
+	parentContext, ok := opencensus.ExtractParentContext(inst.TraceId, inst.ParentSpanId)
+	if !ok {
+		return nil
+	}
+	spanContext, ok := opencensus.ExtractSpanContext(inst.SpanId, parentContext)
+	if !ok {
+		return nil
+	}
+
+	span := &tracev1.Span{
+		TraceId:           spanContext.TraceID[:],
+		Name:              inst.SpanName,
+		StartTimeUnixNano: uint64(inst.StartTime.UnixNano()),
+		EndTimeUnixNano:   uint64(inst.EndTime.UnixNano()),
+		Attributes:        attributes(inst.SpanTags),
+		Status:            &tracev1.Status{},
+	}
+
+	// see opencensus.Handler for the rewriting of the client span ids.
+	spanID, parentSpanID := spanContext.SpanID, parentContext.SpanID
+	if inst.ClientSpan {
+		span.Kind = tracev1.SPAN_KIND_CLIENT
+		if inst.RewriteClientSpanId {
+			spanID = opencensus.RewriteSpanID(spanID)
+		}
+	} else {
+		span.Kind = tracev1.SPAN_KIND_SERVER
+		if inst.RewriteClientSpanId {
+			parentSpanID = opencensus.RewriteSpanID(spanID)
+		}
+	}
+	span.SpanId = spanID[:]
+	if parentSpanID != (octrace.SpanID{}) {
+		span.ParentSpanId = parentSpanID[:]
+	}
+
+	if code := inst.HttpStatusCode; code > 0 {
+		if _, found := inst.SpanTags[attrHTTPStatusCode]; !found {
+			span.Attributes = append(span.Attributes, &commonv1.KeyValue{
+				Key:   attrHTTPStatusCode,
+				Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_IntValue{IntValue: code}},
+			})
+		}
+		// the 4xx statuses are errors of the caller, not of the server.
+		if code >= 500 || (code >= 400 && inst.ClientSpan) {
+			span.Status.Code = tracev1.STATUS_CODE_ERROR
+		}
+	}
+
+	return span
+}
@@ -43,24 +43,35 @@ const (
 	FIXED_WINDOW Params_QuotaAlgorithm = 0
 	// ROLLING_WINDOW The rolling window algorithm's additional precision comes at the cost of increased redis resource usage.
 	ROLLING_WINDOW Params_QuotaAlgorithm = 1
+	// TOKEN_BUCKET The bucket holds up to `burst_size` tokens, and is refilled at the constant rate of `max_amount` tokens
+	// per `valid_duration`. Clients may burst after being idle, while the average rate is bounded.
+	TOKEN_BUCKET Params_QuotaAlgorithm = 2
+	// LEAKY_BUCKET Allocations leak out of the bucket at the constant rate of `max_amount` per `valid_duration`, and new
+	// allocations are rejected while more than `burst_size` is still in the bucket. This smooths the traffic to the average rate.
+	LEAKY_BUCKET Params_QuotaAlgorithm = 3
 )
 
 var Params_QuotaAlgorithm_name = map[int32]string{
 	0: "FIXED_WINDOW",
 	1: "ROLLING_WINDOW",
+	2: "TOKEN_BUCKET",
+	3: "LEAKY_BUCKET",
 }
 
 var Params_QuotaAlgorithm_value = map[string]int32{
 	"FIXED_WINDOW":   0,
 	"ROLLING_WINDOW": 1,
+	"TOKEN_BUCKET":   2,
+	"LEAKY_BUCKET":   3,
 }
 
 func (Params_QuotaAlgorithm) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_b4ec77e3e2f5a044, []int{0, 0}
 }
 
-// redisquota adapter supports the rate limit quota using either fixed or
-// rolling window algorithm. And it is using Redis as a shared data storage.
+// redisquota adapter supports the rate limit quota using either fixed window,
+// rolling window, token bucket or leaky bucket algorithm. And it is using Redis
+// as a shared data storage.
 //
 // Example configuration:
 //
@@ -133,6 +144,10 @@ type Params_Override struct {
 	// The upper limit for this quota override.
 	// This value should be bigger than 0
 	MaxAmount int64 `protobuf:"varint,2,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	// The burst size for this quota override, used by the `TOKEN_BUCKET` and
+	// `LEAKY_BUCKET` algorithms. Defaults to the burst size of the quota.
+	// This value should not be negative
+	BurstSize int64 `protobuf:"varint,3,opt,name=burst_size,json=burstSize,proto3" json:"burst_size,omitempty"`
 }
 
 func (m *Params_Override) Reset()      { *m = Params_Override{} }
@@ -181,6 +196,13 @@ func (m *Params_Override) GetMaxAmount() int64 {
 	return 0
 }
 
+func (m *Params_Override) GetBurstSize() int64 {
+	if m != nil {
+		return m.BurstSize
+	}
+	return 0
+}
+
 type Params_Quota struct {
 	// The name of the quota
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -198,6 +220,13 @@ type Params_Quota struct {
 	// Overrides associated with this quota.
 	// The first matching override is applied.
 	Overrides []*Params_Override `protobuf:"bytes,6,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	// `burst_size` will be ignored if `rate_limit_algorithm` is `FIXED_WINDOW` or `ROLLING_WINDOW`.
+	// For `TOKEN_BUCKET`, the capacity of the bucket, i.e. the amount that can be allocated at once
+	// after being idle. The default value is `max_amount`.
+	// For `LEAKY_BUCKET`, the amount tolerated in the bucket when a new allocation is made. The default
+	// value is 0, meaning that allocations are evenly spaced.
+	// value should be 0 <= `burst_size`
+	BurstSize int64 `protobuf:"varint,7,opt,name=burst_size,json=burstSize,proto3" json:"burst_size,omitempty"`
 }
 
 func (m *Params_Quota) Reset()      { *m = Params_Quota{} }
@@ -274,6 +303,13 @@ func (m *Params_Quota) GetOverrides() []*Params_Override {
 	return nil
 }
 
+func (m *Params_Quota) GetBurstSize() int64 {
+	if m != nil {
+		return m.BurstSize
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("adapter.redisquota.config.Params_QuotaAlgorithm", Params_QuotaAlgorithm_name, Params_QuotaAlgorithm_value)
 	proto.RegisterType((*Params)(nil), "adapter.redisquota.config.Params")
@@ -287,44 +323,46 @@ func init() {
 }
 
 var fileDescriptor_b4ec77e3e2f5a044 = []byte{
-	// 577 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xb1, 0x6f, 0xd3, 0x4e,
-	0x18, 0xf5, 0xd5, 0xa9, 0xd5, 0x5e, 0x7f, 0x3f, 0x37, 0x3a, 0x65, 0x70, 0x23, 0x71, 0x8d, 0xba,
-	0x10, 0x21, 0x64, 0x57, 0x45, 0x42, 0xa8, 0x12, 0x12, 0xad, 0x52, 0xa0, 0x28, 0x6a, 0x8a, 0x2b,
-	0x54, 0xc4, 0x62, 0x2e, 0xf1, 0xd5, 0x9c, 0x6a, 0xfb, 0xc2, 0xd9, 0x8e, 0xd2, 0x4e, 0x8c, 0x1d,
-	0x19, 0x19, 0x19, 0xf9, 0x37, 0xd8, 0x32, 0x66, 0xec, 0x04, 0xc4, 0x59, 0x18, 0xfb, 0x27, 0x20,
-	0x9f, 0xed, 0x04, 0x90, 0x50, 0x33, 0xf9, 0xf3, 0xbb, 0xf7, 0xde, 0x7d, 0xdf, 0xf7, 0x0e, 0xde,
-	0x0f, 0xd8, 0x90, 0x0a, 0x8b, 0xb8, 0xa4, 0x1f, 0x53, 0x61, 0x09, 0xea, 0xb2, 0xe8, 0x7d, 0xc2,
-	0x63, 0x62, 0xf5, 0x78, 0x78, 0xc6, 0xbc, 0xe2, 0x63, 0xf6, 0x05, 0x8f, 0x39, 0xda, 0x28, 0x78,
-	0xe6, 0x9c, 0x67, 0xe6, 0x84, 0x3a, 0xf6, 0x38, 0xf7, 0x7c, 0x6a, 0x49, 0x62, 0x37, 0x39, 0xb3,
-	0xdc, 0x44, 0x90, 0x98, 0xf1, 0x30, 0x97, 0xd6, 0x6b, 0x1e, 0xf7, 0xb8, 0x2c, 0xad, 0xac, 0xca,
-	0xd1, 0xad, 0xaf, 0x1a, 0xd4, 0x8e, 0x89, 0x20, 0x41, 0x84, 0x0e, 0xa0, 0x26, 0x0d, 0x23, 0x03,
-	0x34, 0xd4, 0xe6, 0xda, 0xce, 0x5d, 0xf3, 0x9f, 0x97, 0x99, 0xb9, 0xc4, 0x7c, 0x99, 0x61, 0xfb,
-	0x95, 0xd1, 0xb7, 0x4d, 0xc5, 0x2e, 0xc4, 0xa8, 0x09, 0xab, 0x92, 0xef, 0x44, 0x54, 0x0c, 0xa8,
-	0x70, 0x12, 0xe1, 0x1b, 0x4b, 0x0d, 0xd0, 0x5c, 0xb5, 0x75, 0x89, 0x9f, 0x48, 0xf8, 0x95, 0xf0,
-	0xd1, 0x36, 0xac, 0xf5, 0x78, 0x18, 0xd2, 0x5e, 0xd6, 0xa5, 0xd3, 0xe7, 0xdc, 0x77, 0x22, 0x76,
-	0x49, 0x0d, 0xb5, 0x01, 0x9a, 0xaa, 0x8d, 0xe6, 0x67, 0xc7, 0x9c, 0xfb, 0x27, 0xec, 0x92, 0xd6,
-	0xc7, 0x00, 0xae, 0x74, 0x06, 0x54, 0x08, 0xe6, 0x52, 0xf4, 0x16, 0x42, 0x97, 0x05, 0x34, 0x8c,
-	0x18, 0x0f, 0xcb, 0x9e, 0x77, 0x6f, 0xef, 0xb9, 0xd4, 0x9b, 0xad, 0x99, 0xf8, 0x20, 0x8c, 0xc5,
-	0x45, 0x31, 0xc6, 0x6f, 0x9e, 0xe8, 0x0e, 0x84, 0x01, 0x19, 0x3a, 0x24, 0xe0, 0x49, 0x18, 0xcb,
-	0x21, 0x54, 0x7b, 0x35, 0x20, 0xc3, 0x3d, 0x09, 0xd4, 0x1f, 0xc3, 0xf5, 0xbf, 0x3c, 0x50, 0x15,
-	0xaa, 0xe7, 0xf4, 0xc2, 0x00, 0x72, 0xde, 0xac, 0x44, 0x35, 0xb8, 0x3c, 0x20, 0x7e, 0x42, 0x8b,
-	0x1d, 0xe4, 0x3f, 0xbb, 0x4b, 0x8f, 0xc0, 0x6e, 0xe5, 0xea, 0xf3, 0x26, 0xa8, 0x5f, 0xa9, 0x70,
-	0x59, 0xae, 0x11, 0x21, 0x58, 0x09, 0x49, 0x40, 0x0b, 0xb1, 0xac, 0x6f, 0xe9, 0x00, 0xbd, 0x80,
-	0xfa, 0x80, 0xf8, 0xcc, 0x75, 0xca, 0xac, 0xe5, 0xee, 0xd6, 0x76, 0x36, 0xcc, 0xfc, 0x31, 0x98,
-	0xe5, 0x63, 0x30, 0x5b, 0x05, 0x61, 0x7f, 0x25, 0x9b, 0xf2, 0xd3, 0xf7, 0x4d, 0x60, 0xff, 0x2f,
-	0xa5, 0xe5, 0x01, 0x6a, 0xc3, 0xf5, 0x6e, 0xd2, 0x3b, 0xa7, 0xf1, 0xdc, 0xac, 0xb2, 0xb8, 0x99,
-	0x9e, 0x6b, 0x67, 0x6e, 0x5d, 0x58, 0x13, 0x24, 0xa6, 0x8e, 0xcf, 0x02, 0x16, 0x3b, 0xc4, 0xf7,
-	0xb8, 0x60, 0xf1, 0xbb, 0xc0, 0x58, 0x6e, 0x80, 0xa6, 0xbe, 0xb3, 0xbd, 0xe0, 0xd3, 0xda, 0x2b,
-	0x75, 0x36, 0xca, 0xdc, 0xda, 0x99, 0xd9, 0x0c, 0x43, 0xcf, 0xe1, 0x2a, 0x2f, 0xc2, 0x8c, 0x0c,
-	0x4d, 0xe6, 0x7f, 0x6f, 0xf1, 0xfc, 0xed, 0xb9, 0x38, 0x8f, 0x62, 0xeb, 0x21, 0xd4, 0xff, 0xbc,
-	0x15, 0x55, 0xe1, 0x7f, 0x4f, 0x0f, 0x5f, 0x1f, 0xb4, 0x9c, 0xd3, 0xc3, 0xa3, 0x56, 0xe7, 0xb4,
-	0xaa, 0x20, 0x04, 0x75, 0xbb, 0xd3, 0x6e, 0x1f, 0x1e, 0x3d, 0x2b, 0x31, 0xb0, 0xff, 0x64, 0x34,
-	0xc1, 0xca, 0x78, 0x82, 0x95, 0xeb, 0x09, 0x56, 0x6e, 0x26, 0x58, 0xf9, 0x90, 0x62, 0xf0, 0x25,
-	0xc5, 0xca, 0x28, 0xc5, 0x60, 0x9c, 0x62, 0xf0, 0x23, 0xc5, 0xe0, 0x67, 0x8a, 0x95, 0x9b, 0x14,
-	0x83, 0x8f, 0x53, 0xac, 0x8c, 0xa7, 0x58, 0xb9, 0x9e, 0x62, 0xe5, 0x8d, 0x96, 0xb7, 0xd6, 0xd5,
-	0xe4, 0x6a, 0x1f, 0xfc, 0x0a, 0x00, 0x00, 0xff, 0xff, 0xea, 0x07, 0x8f, 0x2c, 0x0d, 0x04, 0x00,
-	0x00,
+	// 619 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xc1, 0x4f, 0xd4, 0x4e,
+	0x18, 0xed, 0xd0, 0x65, 0x61, 0x87, 0xdf, 0x6f, 0xd9, 0x4c, 0xf6, 0x50, 0x36, 0x71, 0xd8, 0x70,
+	0x71, 0x63, 0x4c, 0x4b, 0xf0, 0x62, 0x48, 0x4c, 0x64, 0xdd, 0xaa, 0xc8, 0x86, 0xc5, 0x02, 0x41,
+	0xb9, 0xd4, 0xd9, 0xed, 0x50, 0x27, 0xb4, 0x1d, 0x9c, 0xb6, 0x1b, 0xe0, 0xe4, 0xd1, 0xa3, 0x47,
+	0x8f, 0x26, 0x1e, 0xf4, 0x4f, 0xe1, 0xc8, 0x91, 0x93, 0xba, 0xe5, 0xe2, 0x4d, 0xfe, 0x04, 0xd3,
+	0x69, 0xcb, 0x22, 0x89, 0x81, 0x53, 0xbf, 0xbe, 0x79, 0xef, 0xcd, 0x9b, 0xef, 0xfb, 0xe0, 0x7d,
+	0x9f, 0x1d, 0x52, 0x61, 0x10, 0x87, 0x1c, 0x44, 0x54, 0x18, 0x82, 0x3a, 0x2c, 0x7c, 0x17, 0xf3,
+	0x88, 0x18, 0x03, 0x1e, 0xec, 0x31, 0x37, 0xff, 0xe8, 0x07, 0x82, 0x47, 0x1c, 0xcd, 0xe5, 0x3c,
+	0x7d, 0xcc, 0xd3, 0x33, 0x42, 0x03, 0xbb, 0x9c, 0xbb, 0x1e, 0x35, 0x24, 0xb1, 0x1f, 0xef, 0x19,
+	0x4e, 0x2c, 0x48, 0xc4, 0x78, 0x90, 0x49, 0x1b, 0x75, 0x97, 0xbb, 0x5c, 0x96, 0x46, 0x5a, 0x65,
+	0xe8, 0xc2, 0x97, 0x29, 0x58, 0xde, 0x20, 0x82, 0xf8, 0x21, 0x32, 0x61, 0x59, 0x1a, 0x86, 0x1a,
+	0x68, 0xaa, 0xad, 0x99, 0xa5, 0xbb, 0xfa, 0x3f, 0x2f, 0xd3, 0x33, 0x89, 0xfe, 0x32, 0xc5, 0xda,
+	0xa5, 0x93, 0xef, 0xf3, 0x8a, 0x95, 0x8b, 0x51, 0x0b, 0xd6, 0x24, 0xdf, 0x0e, 0xa9, 0x18, 0x52,
+	0x61, 0xc7, 0xc2, 0xd3, 0x26, 0x9a, 0xa0, 0x55, 0xb1, 0xaa, 0x12, 0xdf, 0x94, 0xf0, 0xb6, 0xf0,
+	0xd0, 0x22, 0xac, 0x0f, 0x78, 0x10, 0xd0, 0x41, 0x9a, 0xd2, 0x3e, 0xe0, 0xdc, 0xb3, 0x43, 0x76,
+	0x4c, 0x35, 0xb5, 0x09, 0x5a, 0xaa, 0x85, 0xc6, 0x67, 0x1b, 0x9c, 0x7b, 0x9b, 0xec, 0x98, 0x36,
+	0x7e, 0x03, 0x38, 0xdd, 0x1b, 0x52, 0x21, 0x98, 0x43, 0xd1, 0x1b, 0x08, 0x1d, 0xe6, 0xd3, 0x20,
+	0x64, 0x3c, 0x28, 0x32, 0x2f, 0xdf, 0x9c, 0xb9, 0xd0, 0xeb, 0x9d, 0x4b, 0xb1, 0x19, 0x44, 0xe2,
+	0x28, 0x7f, 0xc6, 0x15, 0x4f, 0x74, 0x07, 0x42, 0x9f, 0x1c, 0xda, 0xc4, 0xe7, 0x71, 0x10, 0xc9,
+	0x47, 0xa8, 0x56, 0xc5, 0x27, 0x87, 0x2b, 0x12, 0x48, 0x8f, 0xfb, 0xb1, 0x08, 0xa3, 0xab, 0xa9,
+	0x2b, 0x12, 0x91, 0x61, 0x1f, 0xc1, 0xd9, 0x6b, 0x57, 0xa0, 0x1a, 0x54, 0xf7, 0xe9, 0x91, 0x06,
+	0x64, 0x3b, 0xd2, 0x12, 0xd5, 0xe1, 0xe4, 0x90, 0x78, 0x31, 0xcd, 0x5b, 0x94, 0xfd, 0x2c, 0x4f,
+	0x3c, 0x04, 0xcb, 0xa5, 0x0f, 0x9f, 0xe7, 0x41, 0xe3, 0xab, 0x0a, 0x27, 0x65, 0x97, 0x11, 0x82,
+	0xa5, 0x80, 0xf8, 0x34, 0x17, 0xcb, 0xfa, 0xa6, 0x80, 0x2f, 0x60, 0x75, 0x48, 0x3c, 0xe6, 0xd8,
+	0xc5, 0x2a, 0xc8, 0x90, 0x33, 0x4b, 0x73, 0x7a, 0xb6, 0x2b, 0x7a, 0xb1, 0x2b, 0x7a, 0x27, 0x27,
+	0xb4, 0xa7, 0xd3, 0x26, 0x7c, 0xfa, 0x31, 0x0f, 0xac, 0xff, 0xa5, 0xb4, 0x38, 0x40, 0x5d, 0x38,
+	0xdb, 0x8f, 0x07, 0xfb, 0x34, 0x1a, 0x9b, 0x95, 0x6e, 0x6f, 0x56, 0xcd, 0xb4, 0x97, 0x6e, 0x7d,
+	0x58, 0x17, 0x24, 0xa2, 0xb6, 0xc7, 0x7c, 0x16, 0xd9, 0xc4, 0x73, 0xb9, 0x60, 0xd1, 0x5b, 0x5f,
+	0x9b, 0x6c, 0x82, 0x56, 0x75, 0x69, 0xf1, 0x96, 0x9b, 0xb7, 0x52, 0xe8, 0x2c, 0x94, 0xba, 0x75,
+	0x53, 0xb3, 0x4b, 0x0c, 0x3d, 0x87, 0x15, 0x9e, 0xcf, 0x3a, 0xd4, 0xca, 0x72, 0x3d, 0xee, 0xdd,
+	0x7e, 0x3d, 0xac, 0xb1, 0xf8, 0xda, 0xa0, 0xa7, 0xae, 0x0d, 0x3a, 0x9b, 0xd4, 0xc2, 0x2e, 0xac,
+	0xfe, 0x1d, 0x0a, 0xd5, 0xe0, 0x7f, 0x4f, 0x57, 0x5f, 0x99, 0x1d, 0x7b, 0x67, 0x75, 0xbd, 0xd3,
+	0xdb, 0xa9, 0x29, 0x08, 0xc1, 0xaa, 0xd5, 0xeb, 0x76, 0x57, 0xd7, 0x9f, 0x15, 0x18, 0x48, 0x59,
+	0x5b, 0xbd, 0x35, 0x73, 0xdd, 0x6e, 0x6f, 0x3f, 0x59, 0x33, 0xb7, 0x6a, 0x13, 0x29, 0xd2, 0x35,
+	0x57, 0xd6, 0x5e, 0x17, 0x88, 0xda, 0x7e, 0x7c, 0x32, 0xc2, 0xca, 0xe9, 0x08, 0x2b, 0x67, 0x23,
+	0xac, 0x5c, 0x8c, 0xb0, 0xf2, 0x3e, 0xc1, 0xe0, 0x5b, 0x82, 0x95, 0x93, 0x04, 0x83, 0xd3, 0x04,
+	0x83, 0x9f, 0x09, 0x06, 0xbf, 0x12, 0xac, 0x5c, 0x24, 0x18, 0x7c, 0x3c, 0xc7, 0xca, 0xe9, 0x39,
+	0x56, 0xce, 0xce, 0xb1, 0xb2, 0x5b, 0xce, 0x5e, 0xd7, 0x2f, 0xcb, 0xe9, 0x3c, 0xf8, 0x13, 0x00,
+	0x00, 0xff, 0xff, 0x7f, 0x8c, 0xa5, 0x12, 0x6f, 0x04, 0x00, 0x00,
 }
 
 func (x Params_QuotaAlgorithm) String() string {
@@ -412,6 +450,11 @@ func (m *Params_Override) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintConfig(dAtA, i, uint64(m.MaxAmount))
 	}
+	if m.BurstSize != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.BurstSize))
+	}
 	return i, nil
 }
 
@@ -474,6 +517,11 @@ func (m *Params_Quota) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.BurstSize != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.BurstSize))
+	}
 	return i, nil
 }
 
@@ -525,6 +573,9 @@ func (m *Params_Override) Size() (n int) {
 	if m.MaxAmount != 0 {
 		n += 1 + sovConfig(uint64(m.MaxAmount))
 	}
+	if m.BurstSize != 0 {
+		n += 1 + sovConfig(uint64(m.BurstSize))
+	}
 	return n
 }
 
@@ -554,6 +605,9 @@ func (m *Params_Quota) Size() (n int) {
 			n += 1 + l + sovConfig(uint64(l))
 		}
 	}
+	if m.BurstSize != 0 {
+		n += 1 + sovConfig(uint64(m.BurstSize))
+	}
 	return n
 }
 
@@ -604,6 +658,7 @@ func (this *Params_Override) String() string {
 	s := strings.Join([]string{`&Params_Override{`,
 		`Dimensions:` + mapStringForDimensions + `,`,
 		`MaxAmount:` + fmt.Sprintf("%v", this.MaxAmount) + `,`,
+		`BurstSize:` + fmt.Sprintf("%v", this.BurstSize) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -624,6 +679,7 @@ func (this *Params_Quota) String() string {
 		`BucketDuration:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.BucketDuration), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
 		`RateLimitAlgorithm:` + fmt.Sprintf("%v", this.RateLimitAlgorithm) + `,`,
 		`Overrides:` + repeatedStringForOverrides + `,`,
+		`BurstSize:` + fmt.Sprintf("%v", this.BurstSize) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -949,6 +1005,25 @@ func (m *Params_Override) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurstSize", wireType)
+			}
+			m.BurstSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BurstSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -1172,6 +1247,25 @@ func (m *Params_Quota) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurstSize", wireType)
+			}
+			m.BurstSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BurstSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -43,6 +43,8 @@ var (
 	rateLimitingLUAScripts = map[config.Params_QuotaAlgorithm]string{
 		config.FIXED_WINDOW:   luaFixedWindow,
 		config.ROLLING_WINDOW: luaRollingWindow,
+		config.TOKEN_BUCKET:   luaTokenBucket,
+		config.LEAKY_BUCKET:   luaLeakyBucket,
 	}
 )
 
@@ -125,12 +127,30 @@ func (b *builder) Validate() (ce *adapter.ConfigErrors) {
 			}
 		}
 
+		if quotas.RateLimitAlgorithm == config.TOKEN_BUCKET || quotas.RateLimitAlgorithm == config.LEAKY_BUCKET {
+			if quotas.MaxAmount <= 0 {
+				ce = ce.Appendf("max_amount", "quotas.max_amount should be > 0 for %v algorithm", quotas.RateLimitAlgorithm)
+				continue
+			}
+
+			if quotas.BurstSize < 0 {
+				ce = ce.Appendf("burst_size", "quotas.burst_size of %v is invalid, must be >= 0", quotas.BurstSize)
+				continue
+			}
+		}
+
 		for index := range quotas.Overrides {
 			if quotas.Overrides[index].MaxAmount <= 0 {
 				ce = ce.Appendf("max_amount", "quotas.overrides.max_amount must be > 0")
 				continue
 			}
 
+			if quotas.Overrides[index].BurstSize < 0 {
+				ce = ce.Appendf("burst_size", "quotas.overrides.burst_size of %v is invalid, must be >= 0",
+					quotas.Overrides[index].BurstSize)
+				continue
+			}
+
 			if len(quotas.Overrides[index].Dimensions) == 0 {
 				ce = ce.Appendf("dimensions", "quotas.overrides.dimensions is empty")
 				continue
@@ -170,7 +190,7 @@ func (b *builder) Validate() (ce *adapter.ConfigErrors) {
 	}
 
 	// check scripts loading to redis
-	scripts := make(map[config.Params_QuotaAlgorithm]*redis.Script, 2)
+	scripts := make(map[config.Params_QuotaAlgorithm]*redis.Script, len(rateLimitingLUAScripts))
 	for algorithm, script := range rateLimitingLUAScripts {
 		scripts[algorithm] = redis.NewScript(script)
 		if _, err := scripts[algorithm].Load(client).Result(); err != nil {
@@ -228,7 +248,7 @@ func (b *builder) Build(context context.Context, env adapter.Env) (adapter.Handl
 	}
 
 	// load scripts into redis
-	scripts := make(map[config.Params_QuotaAlgorithm]*redis.Script, 2)
+	scripts := make(map[config.Params_QuotaAlgorithm]*redis.Script, len(rateLimitingLUAScripts))
 	for algorithm, script := range rateLimitingLUAScripts {
 		scripts[algorithm] = redis.NewScript(script)
 	}
@@ -286,9 +306,21 @@ func getAllocatedTokenFromResult(result *interface{}) (int64, time.Duration, err
 	return 0, 0, fmt.Errorf("invalid response from the redis server: %v", result)
 }
 
+// burstSize returns the burst size of the bucket algorithms, or its default when not configured.
+func burstSize(algorithm config.Params_QuotaAlgorithm, maxAmount int64, configured int64) int64 {
+	if configured > 0 {
+		return configured
+	}
+	if algorithm == config.TOKEN_BUCKET {
+		return maxAmount
+	}
+	return 0
+}
+
 // find override
-func (h *handler) getKeyAndQuotaAmount(instance *quota.Instance, quota *config.Params_Quota) (string, int64, error) {
+func (h *handler) getKeyAndQuotaAmount(instance *quota.Instance, quota *config.Params_Quota) (string, int64, int64, error) {
 	maxAmount := quota.MaxAmount
+	burst := quota.BurstSize
 	key := quota.Name
 
 	for idx := range quota.Overrides {
@@ -296,19 +328,22 @@ func (h *handler) getKeyAndQuotaAmount(instance *quota.Instance, quota *config.P
 			h.logger.Debugf("quota override: %v selected for %v", quota.Overrides[idx], *instance)
 
 			if hash, ok := h.dimensionHash[&quota.Overrides[idx].Dimensions]; ok {
-				// override key and max amount
+				// override key, max amount and burst size
 				key = key + "-" + hash
 				maxAmount = quota.Overrides[idx].MaxAmount
-				return key, maxAmount, nil
+				if quota.Overrides[idx].BurstSize > 0 {
+					burst = quota.Overrides[idx].BurstSize
+				}
+				return key, maxAmount, burstSize(quota.RateLimitAlgorithm, maxAmount, burst), nil
 			}
 
 			// This should not be happen
-			return "", 0, fmt.Errorf("quota override dimension hash lookup failed: %v in %v",
+			return "", 0, 0, fmt.Errorf("quota override dimension hash lookup failed: %v in %v",
 				h.limits[instance.Name].Overrides[idx].Dimensions, h.dimensionHash)
 		}
 	}
 
-	return key, maxAmount, nil
+	return key, maxAmount, burstSize(quota.RateLimitAlgorithm, maxAmount, burst), nil
 }
 
 func (h *handler) HandleQuota(context context.Context, instance *quota.Instance, args adapter.QuotaArgs) (adapter.QuotaResult, error) {
@@ -318,7 +353,7 @@ func (h *handler) HandleQuota(context context.Context, instance *quota.Instance,
 			ret := status.OK
 
 			// get overridden quotaAmount and quotaKey
-			key, maxAmount, err := h.getKeyAndQuotaAmount(instance, limit)
+			key, maxAmount, burst, err := h.getKeyAndQuotaAmount(instance, limit)
 			if err != nil {
 				_ = h.logger.Errorf("%v", err.Error())
 				return adapter.QuotaResult{}, nil
@@ -339,7 +374,8 @@ func (h *handler) HandleQuota(context context.Context, instance *quota.Instance,
 				args.BestEffort,                         // ARGV[4] best effort
 				args.QuotaAmount,                        // ARGV[5] token
 				now.UnixNano(),                          // ARGV[6] timestamp
-				args.DeduplicationID,                    // ARGS[8] deduplication id
+				args.DeduplicationID,                    // ARGV[7] deduplication id
+				burst,                                   // ARGV[8] burst size
 			).Result()
 
 			if err != nil {
@@ -240,6 +240,90 @@ func TestBuilderValidate(t *testing.T) {
 				"dimensions: quotas.overrides.dimensions is empty",
 			},
 		},
+		"Invalid max_amount for TOKEN_BUCKET": {
+			quotaTypes: map[string]*quota.Type{
+				"token-bucket": {},
+			},
+			config: &config.Params{
+				RedisServerUrl: mockRedis.Addr(),
+				Quotas: []config.Params_Quota{
+					{
+						Name:               "token-bucket",
+						ValidDuration:      time.Second,
+						RateLimitAlgorithm: config.TOKEN_BUCKET,
+					},
+				},
+			},
+			errMsg: []string{
+				"max_amount: quotas.max_amount should be > 0 for TOKEN_BUCKET algorithm",
+			},
+		},
+		"Invalid burst_size for LEAKY_BUCKET": {
+			quotaTypes: map[string]*quota.Type{
+				"leaky-bucket": {},
+			},
+			config: &config.Params{
+				RedisServerUrl: mockRedis.Addr(),
+				Quotas: []config.Params_Quota{
+					{
+						Name:               "leaky-bucket",
+						MaxAmount:          10,
+						ValidDuration:      time.Second,
+						BurstSize:          -1,
+						RateLimitAlgorithm: config.LEAKY_BUCKET,
+					},
+				},
+			},
+			errMsg: []string{
+				"burst_size: quotas.burst_size of -1 is invalid, must be >= 0",
+			},
+		},
+		"Override invalid burst_size": {
+			quotaTypes: map[string]*quota.Type{
+				"token-bucket": {},
+			},
+			config: &config.Params{
+				RedisServerUrl: mockRedis.Addr(),
+				Quotas: []config.Params_Quota{
+					{
+						Name:               "token-bucket",
+						MaxAmount:          10,
+						ValidDuration:      time.Second,
+						RateLimitAlgorithm: config.TOKEN_BUCKET,
+						Overrides: []*config.Params_Override{
+							{
+								MaxAmount: 5,
+								BurstSize: -5,
+								Dimensions: map[string]string{
+									"source": "test",
+								},
+							},
+						},
+					},
+				},
+			},
+			errMsg: []string{
+				"burst_size: quotas.overrides.burst_size of -5 is invalid, must be >= 0",
+			},
+		},
+		"Valid token bucket configuration": {
+			quotaTypes: map[string]*quota.Type{
+				"token-bucket": {},
+			},
+			config: &config.Params{
+				RedisServerUrl: mockRedis.Addr(),
+				Quotas: []config.Params_Quota{
+					{
+						Name:               "token-bucket",
+						MaxAmount:          10,
+						ValidDuration:      time.Second,
+						BurstSize:          20,
+						RateLimitAlgorithm: config.TOKEN_BUCKET,
+					},
+				},
+			},
+			errMsg: []string{},
+		},
 	}
 
 	info := GetInfo()
@@ -466,6 +550,120 @@ func TestHandleQuota(t *testing.T) {
 				{false, 1, 1, 0, 0, ""},
 			},
 		},
+		"algorithm = token bucket": {
+			quotaType: map[string]*quota.Type{
+				"token_bucket": {},
+			},
+			quotaConfig: []config.Params_Quota{
+				{
+					Name:               "token_bucket",
+					MaxAmount:          10,
+					ValidDuration:      time.Second * time.Duration(10),
+					BurstSize:          5,
+					RateLimitAlgorithm: config.TOKEN_BUCKET,
+				},
+			},
+			instance: quota.Instance{
+				Name:       "token_bucket",
+				Dimensions: map[string]interface{}{},
+			},
+			request: []RequestInfo{
+				{false, 3, 0, 3, 3, ""},
+				{false, 3, 0, 0, 0, ""},
+				{true, 3, 0, 2, 2, ""}, // the bucket only holds 5 tokens
+				{true, 1, 0, 0, 0, ""},
+				{false, 1, 1, 1, 1, ""}, // refilled with 1 token per second
+				{false, 2, 2, 0, 0, ""},
+				{false, 5, 10, 5, 5, "test"}, // record current response
+				{false, 5, 12, 5, 3, "test"}, // from the previous response
+				{true, 5, 12, 2, 2, ""},
+				{false, 6, 30, 0, 0, ""}, // more than the burst size
+			},
+		},
+		"algorithm = token bucket, default burst size, limit override": {
+			quotaType: map[string]*quota.Type{
+				"token_bucket_override": {},
+			},
+			quotaConfig: []config.Params_Quota{
+				{
+					Name:               "token_bucket_override",
+					MaxAmount:          10,
+					ValidDuration:      time.Second * time.Duration(10),
+					RateLimitAlgorithm: config.TOKEN_BUCKET,
+					Overrides: []*config.Params_Override{
+						{
+							MaxAmount: 20,
+							BurstSize: 4,
+							Dimensions: map[string]string{
+								"source": "burst",
+							},
+						},
+					},
+				},
+			},
+			instance: quota.Instance{
+				Name: "token_bucket_override",
+				Dimensions: map[string]interface{}{
+					"source": "burst",
+				},
+			},
+			request: []RequestInfo{
+				{true, 10, 0, 4, 2, ""},
+				{false, 2, 1, 2, 1, ""}, // refilled with 2 tokens per second
+				{false, 1, 1, 0, 0, ""},
+			},
+		},
+		"algorithm = leaky bucket": {
+			quotaType: map[string]*quota.Type{
+				"leaky_bucket": {},
+			},
+			quotaConfig: []config.Params_Quota{
+				{
+					Name:               "leaky_bucket",
+					MaxAmount:          10,
+					ValidDuration:      time.Second * time.Duration(10),
+					RateLimitAlgorithm: config.LEAKY_BUCKET,
+				},
+			},
+			instance: quota.Instance{
+				Name:       "leaky_bucket",
+				Dimensions: map[string]interface{}{},
+			},
+			request: []RequestInfo{
+				{false, 1, 0, 1, 1, ""},
+				{false, 1, 0, 0, 0, ""},
+				{true, 1, 0, 0, 0, ""},
+				{false, 2, 1, 2, 2, "test"}, // record current response
+				{false, 2, 2, 2, 1, "test"}, // from the previous response
+				{false, 1, 2, 0, 0, ""},
+				{false, 1, 3, 1, 1, ""}, // leaks 1 token per second
+			},
+		},
+		"algorithm = leaky bucket, burst size": {
+			quotaType: map[string]*quota.Type{
+				"leaky_bucket_burst": {},
+			},
+			quotaConfig: []config.Params_Quota{
+				{
+					Name:               "leaky_bucket_burst",
+					MaxAmount:          10,
+					ValidDuration:      time.Second * time.Duration(10),
+					BurstSize:          2,
+					RateLimitAlgorithm: config.LEAKY_BUCKET,
+				},
+			},
+			instance: quota.Instance{
+				Name:       "leaky_bucket_burst",
+				Dimensions: map[string]interface{}{},
+			},
+			request: []RequestInfo{
+				{false, 1, 0, 1, 1, ""},
+				{false, 1, 0, 1, 2, ""},
+				{false, 1, 0, 1, 3, ""},
+				{true, 1, 0, 0, 0, ""},
+				{false, 1, 1, 1, 3, ""},
+			},
+		},
 	}
 
 	for id, c := range cases {
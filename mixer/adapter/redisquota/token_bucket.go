@@ -0,0 +1,84 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisquota
+
+const (
+	// LUA script for token-bucket algorithm
+	luaTokenBucket = `
+local key_meta = KEYS[1]
+
+local credit = tonumber(ARGV[1])
+local windowLength = tonumber(ARGV[2])
+local bestEffort = tonumber(ARGV[4])
+local token = tonumber(ARGV[5])
+local timestamp = tonumber(ARGV[6])
+local deduplicationid = ARGV[7]
+local burst = tonumber(ARGV[8])
+
+-- lookup previous response for the deduplicationid and returns if it is still valid
+--------------------------------------------------------------------------------
+if (deduplicationid or '') ~= '' then
+	local previous_token = tonumber(redis.call("HGET", deduplicationid .. "-" .. key_meta, "token"))
+	local previous_expire = tonumber(redis.call("HGET", deduplicationid .. "-" .. key_meta, "expire"))
+
+	if previous_token and previous_expire then
+		if timestamp < previous_expire then
+			return {previous_token, previous_expire - timestamp}
+		end
+	end
+end
+
+-- the bucket is described by the time at which it is full again: it is refilled
+-- by one token every interval, up to burst tokens
+--------------------------------------------------------------------------------
+local interval = windowLength / credit
+local full = tonumber(redis.call("HGET", key_meta, "full"))
+if not full or full < timestamp then
+  full = timestamp
+end
+
+-- allow for rounding errors when counting the available tokens
+local available = math.floor((burst * interval - (full - timestamp)) / interval + 0.000001)
+
+if token > available then
+  if bestEffort == 1 and available > 0 then
+    -- return maximum available allocated token
+    token = available
+  else
+    -- not enough available credit
+    return {0, 0}
+  end
+end
+
+-- allocated token
+--------------------------------------------------------------------------------
+full = full + token * interval
+redis.call("HSET", key_meta, "full", string.format("%.0f", full))
+
+-- set the expiration time for automatic cleanup, once the bucket is full
+redis.call("PEXPIRE", key_meta, math.ceil((full - timestamp) / 1000000))
+
+-- the allocated tokens are refilled after this duration
+local validDuration = math.ceil(token * interval)
+
+-- save current request and set expiration time for auto cleanup
+if (deduplicationid or '') ~= '' then
+	redis.call("HMSET", deduplicationid .. "-" .. key_meta, "token", token, "expire", string.format("%.0f", timestamp + validDuration))
+	redis.call("PEXPIRE", deduplicationid .. "-" .. key_meta, math.ceil(validDuration / 1000000))
+end
+
+return {token, validDuration}
+`
+)
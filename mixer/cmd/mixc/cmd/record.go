@@ -0,0 +1,229 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	mixerpb "istio.io/api/mixer/v1"
+	"istio.io/istio/mixer/cmd/shared"
+	attr "istio.io/istio/mixer/pkg/attribute"
+	"istio.io/pkg/attribute"
+)
+
+type recordArgs struct {
+	// address the recording proxy listens on
+	listenAddress string
+
+	// mixerAddress is the full address (including port) of the mixer instance the requests are forwarded to.
+	mixerAddress string
+
+	// file the requests are recorded to
+	output string
+
+	// how long to record for, until interrupted if 0
+	duration time.Duration
+}
+
+// recorder is a Mixer API proxy recording the attributes of the requests it forwards.
+type recorder struct {
+	upstream    mixerpb.MixerClient
+	out         *recordingWriter
+	globalWords []string
+	printf      shared.FormatFn
+}
+
+func recordCmd(printf, fatalf shared.FormatFn) *cobra.Command {
+	ra := &recordArgs{}
+
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "Records the attributes of the requests sent to Mixer, for later replay.",
+		Long: "The record command runs a proxy in front of a Mixer instance. The Check and Report\n" +
+			"requests sent to the proxy are forwarded to Mixer, and their attributes are\n" +
+			"recorded to a file which can be replayed against candidate configurations with\n" +
+			"the replay command.",
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			record(ra, printf, fatalf)
+		}}
+
+	cmd.PersistentFlags().StringVarP(&ra.listenAddress, "listen", "l", ":9092",
+		"Address and port the recording proxy listens on")
+	cmd.PersistentFlags().StringVarP(&ra.mixerAddress, "mixer", "m", "localhost:9091",
+		"Address and port of the running Mixer instance the requests are forwarded to")
+	cmd.PersistentFlags().StringVarP(&ra.output, "output", "o", "",
+		"File the requests are recorded to")
+	cmd.PersistentFlags().DurationVarP(&ra.duration, "duration", "", 0,
+		"How long to record requests for, until interrupted if 0")
+
+	return cmd
+}
+
+func record(ra *recordArgs, printf, fatalf shared.FormatFn) {
+	if ra.output == "" {
+		fatalf("The file the requests are recorded to must be specified with --output")
+	}
+
+	f, err := os.Create(ra.output)
+	if err != nil {
+		fatalf("Unable to create the recording: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	out, err := newRecordingWriter(f)
+	if err != nil {
+		fatalf("Unable to write the recording: %v", err)
+	}
+
+	conn, err := grpc.Dial(ra.mixerAddress, grpc.WithInsecure())
+	if err != nil {
+		fatalf("Unable to establish connection to %s: %v", ra.mixerAddress, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	listener, err := net.Listen("tcp", ra.listenAddress)
+	if err != nil {
+		fatalf("Unable to listen on %s: %v", ra.listenAddress, err)
+	}
+
+	server := grpc.NewServer()
+	mixerpb.RegisterMixerServer(server, newRecorder(mixerpb.NewMixerClient(conn), out, printf))
+	go func() { _ = server.Serve(listener) }()
+
+	printf("Recording the requests sent to %s to %s", listener.Addr(), ra.output)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	if ra.duration > 0 {
+		select {
+		case <-stop:
+		case <-time.After(ra.duration):
+		}
+	} else {
+		<-stop
+	}
+
+	server.GracefulStop()
+
+	entries, err := out.flush()
+	if err != nil {
+		fatalf("Unable to write the recording: %v", err)
+	}
+	printf("Recorded %d requests", entries)
+}
+
+func newRecorder(upstream mixerpb.MixerClient, out *recordingWriter, printf shared.FormatFn) *recorder {
+	return &recorder{
+		upstream:    upstream,
+		out:         out,
+		globalWords: attr.GlobalList(),
+		printf:      printf,
+	}
+}
+
+// Check records the request, and forwards it to Mixer.
+func (r *recorder) Check(ctx context.Context, req *mixerpb.CheckRequest) (*mixerpb.CheckResponse, error) {
+	if err := r.recordCheck(req); err != nil {
+		r.printf("Unable to record a check request: %v", err)
+	}
+	return r.upstream.Check(ctx, req)
+}
+
+// Report records the request, and forwards it to Mixer.
+func (r *recorder) Report(ctx context.Context, req *mixerpb.ReportRequest) (*mixerpb.ReportResponse, error) {
+	if err := r.recordReport(req); err != nil {
+		r.printf("Unable to record a report request: %v", err)
+	}
+	return r.upstream.Report(ctx, req)
+}
+
+func (r *recorder) recordCheck(req *mixerpb.CheckRequest) error {
+	if err := r.checkGlobalWordCount(req.GlobalWordCount); err != nil {
+		return err
+	}
+
+	bag, err := attr.GetBagFromProto(&req.Attributes, r.globalWords)
+	if err != nil {
+		return err
+	}
+	defer bag.Done()
+
+	rec := &mixerpb.CheckRequest{
+		DeduplicationId: req.DeduplicationId,
+		Quotas:          req.Quotas,
+	}
+	attr.ToProto(bag, &rec.Attributes, nil, 0)
+	return r.out.writeCheck(rec)
+}
+
+// recordReport records each set of attributes of the request as a separate report.
+func (r *recorder) recordReport(req *mixerpb.ReportRequest) error {
+	if err := r.checkGlobalWordCount(req.GlobalWordCount); err != nil {
+		return err
+	}
+
+	// the attributes of a delta encoded request are accumulated in a single bag
+	var bag *attribute.MutableBag
+	defer func() {
+		if bag != nil {
+			bag.Done()
+		}
+	}()
+
+	for i := range req.Attributes {
+		attrs := req.Attributes[i]
+		if len(attrs.Words) == 0 {
+			attrs.Words = req.DefaultWords
+		}
+
+		if bag == nil || req.RepeatedAttributesSemantics == mixerpb.INDEPENDENT_ENCODING {
+			if bag != nil {
+				bag.Done()
+			}
+			bag = attribute.GetMutableBag(nil)
+		}
+		if err := attr.UpdateBagFromProto(bag, &attrs, r.globalWords); err != nil {
+			return err
+		}
+
+		rec := &mixerpb.ReportRequest{
+			Attributes:                  make([]mixerpb.CompressedAttributes, 1),
+			RepeatedAttributesSemantics: mixerpb.INDEPENDENT_ENCODING,
+		}
+		attr.ToProto(bag, &rec.Attributes[0], nil, 0)
+		if err := r.out.writeReport(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *recorder) checkGlobalWordCount(count uint32) error {
+	if count > uint32(len(r.globalWords)) {
+		return fmt.Errorf("inconsistent global dictionary versions used: mixc knows %d words, caller knows %d",
+			len(r.globalWords), count)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	mixerpb "istio.io/api/mixer/v1"
+	attr "istio.io/istio/mixer/pkg/attribute"
+	"istio.io/pkg/attribute"
+)
+
+type fakeMixer struct {
+	checks  int
+	reports int
+}
+
+func (m *fakeMixer) Check(ctx context.Context, in *mixerpb.CheckRequest, opts ...grpc.CallOption) (*mixerpb.CheckResponse, error) {
+	m.checks++
+	return &mixerpb.CheckResponse{}, nil
+}
+
+func (m *fakeMixer) Report(ctx context.Context, in *mixerpb.ReportRequest, opts ...grpc.CallOption) (*mixerpb.ReportResponse, error) {
+	m.reports++
+	return &mixerpb.ReportResponse{}, nil
+}
+
+// globalAttributes encodes the attributes using the global word list, as the proxies do.
+func globalAttributes(attrs map[string]interface{}) mixerpb.CompressedAttributes {
+	words := attr.GlobalList()
+	dict := make(map[string]int32, len(words))
+	for i, w := range words {
+		dict[w] = int32(i)
+	}
+
+	b := attribute.GetMutableBagForTesting(attrs)
+	var ca mixerpb.CompressedAttributes
+	attr.ToProto(b, &ca, dict, len(words))
+	return ca
+}
+
+func recordRequests(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	out, err := newRecordingWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upstream := &fakeMixer{}
+	r := newRecorder(upstream, out, t.Logf)
+	ctx := context.Background()
+
+	quotas := map[string]mixerpb.CheckRequest_QuotaParams{
+		"requestcount": {Amount: 1},
+	}
+	for _, source := range []string{"alice", "mallory", "evil", "bob"} {
+		req := &mixerpb.CheckRequest{
+			Attributes:      globalAttributes(map[string]interface{}{"source.name": source}),
+			GlobalWordCount: uint32(len(attr.GlobalList())),
+			DeduplicationId: source,
+			Quotas:          quotas,
+		}
+		if _, err := r.Check(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the second set of attributes only carries the changes to the first one.
+	report := &mixerpb.ReportRequest{
+		Attributes: []mixerpb.CompressedAttributes{
+			globalAttributes(map[string]interface{}{"source.name": "alice", "request.size": int64(10)}),
+			globalAttributes(map[string]interface{}{"request.size": int64(2000)}),
+		},
+		RepeatedAttributesSemantics: mixerpb.DELTA_ENCODING,
+		GlobalWordCount:             uint32(len(attr.GlobalList())),
+	}
+	if _, err := r.Report(ctx, report); err != nil {
+		t.Fatal(err)
+	}
+
+	if upstream.checks != 4 || upstream.reports != 1 {
+		t.Errorf("Got %d checks and %d reports forwarded, expecting 4 and 1", upstream.checks, upstream.reports)
+	}
+	if entries, err := out.flush(); err != nil || entries != 6 {
+		t.Errorf("Got %d entries recorded, %v, expecting 6", entries, err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestRecording(t *testing.T) {
+	rr, err := newRecordingReader(bytes.NewReader(recordRequests(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []map[string]interface{}{
+		{"source.name": "alice"},
+		{"source.name": "mallory"},
+		{"source.name": "evil"},
+		{"source.name": "bob"},
+		{"source.name": "alice", "request.size": int64(10)},
+		{"source.name": "alice", "request.size": int64(2000)},
+	}
+
+	var got []map[string]interface{}
+	for {
+		e, err := rr.next()
+		if err != nil {
+			break
+		}
+
+		attrs := []mixerpb.CompressedAttributes{}
+		if e.check != nil {
+			attrs = append(attrs, e.check.Attributes)
+		} else {
+			attrs = append(attrs, e.report.Attributes...)
+		}
+
+		for i := range attrs {
+			// the recorded attributes must not depend on the global word list.
+			b, err := attr.GetBagFromProto(&attrs[i], nil)
+			if err != nil {
+				t.Fatalf("Got error %v, expecting self-contained attributes", err)
+			}
+			m := make(map[string]interface{})
+			for _, name := range b.Names() {
+				m[name], _ = b.Get(name)
+			}
+			got = append(got, m)
+		}
+	}
+
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Got attributes %v, expecting %v", got, expected)
+	}
+}
+
+func TestRecordingErrors(t *testing.T) {
+	if _, err := newRecordingReader(strings.NewReader("not a recording")); err == nil {
+		t.Error("Got success, expecting an invalid recording")
+	}
+
+	rr, err := newRecordingReader(strings.NewReader(recordingMagic + "X\x01\x00"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = rr.next(); err == nil || !strings.Contains(err.Error(), "unknown recorded entry") {
+		t.Errorf("Got error %v, expecting an unknown entry", err)
+	}
+
+	rr, _ = newRecordingReader(strings.NewReader(recordingMagic + "C\x10\x00"))
+	if _, err = rr.next(); err == nil || !strings.Contains(err.Error(), "truncated recording") {
+		t.Errorf("Got error %v, expecting a truncated recording", err)
+	}
+}
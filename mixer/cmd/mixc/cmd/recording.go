@@ -0,0 +1,159 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+
+	mixerpb "istio.io/api/mixer/v1"
+)
+
+// A recording starts with recordingMagic, followed by a sequence of entries. Each entry is made of
+// its kind, the varint encoded length of the message, and the message: a CheckRequest for checks,
+// and a ReportRequest holding a single set of attributes for reports. The attributes of the
+// messages are self-contained: they don't refer to the global word list, so that a recording can
+// be replayed by a Mixer built with a different list.
+const recordingMagic = "mixc-recording-v1\n"
+
+const (
+	checkEntry  byte = 'C'
+	reportEntry byte = 'R'
+
+	// maximum size of a recorded message
+	maxEntrySize = 64 * 1024 * 1024
+)
+
+type (
+	// entry is a request read from a recording. Exactly one of check and report is set.
+	entry struct {
+		check  *mixerpb.CheckRequest
+		report *mixerpb.ReportRequest
+	}
+
+	// recordingWriter appends entries to a recording, it is safe for concurrent use.
+	recordingWriter struct {
+		mu      sync.Mutex
+		w       *bufio.Writer
+		entries int
+	}
+
+	// recordingReader reads the entries of a recording.
+	recordingReader struct {
+		r *bufio.Reader
+	}
+)
+
+func newRecordingWriter(w io.Writer) (*recordingWriter, error) {
+	rw := &recordingWriter{w: bufio.NewWriter(w)}
+	if _, err := rw.w.WriteString(recordingMagic); err != nil {
+		return nil, err
+	}
+	return rw, nil
+}
+
+func (rw *recordingWriter) writeCheck(req *mixerpb.CheckRequest) error {
+	return rw.write(checkEntry, req)
+}
+
+func (rw *recordingWriter) writeReport(req *mixerpb.ReportRequest) error {
+	return rw.write(reportEntry, req)
+}
+
+func (rw *recordingWriter) write(kind byte, msg proto.Message) error {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	var header [1 + binary.MaxVarintLen64]byte
+	header[0] = kind
+	n := 1 + binary.PutUvarint(header[1:], uint64(len(b)))
+
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if _, err = rw.w.Write(header[:n]); err != nil {
+		return err
+	}
+	if _, err = rw.w.Write(b); err != nil {
+		return err
+	}
+	rw.entries++
+	return nil
+}
+
+// flush writes the buffered entries, and returns the number of entries written so far.
+func (rw *recordingWriter) flush() (int, error) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	return rw.entries, rw.w.Flush()
+}
+
+func newRecordingReader(r io.Reader) (*recordingReader, error) {
+	rr := &recordingReader{r: bufio.NewReader(r)}
+
+	magic := make([]byte, len(recordingMagic))
+	if _, err := io.ReadFull(rr.r, magic); err != nil || string(magic) != recordingMagic {
+		return nil, errors.New("not a mixc recording")
+	}
+	return rr, nil
+}
+
+// next returns the next entry of the recording, or io.EOF at the end of the recording.
+func (rr *recordingReader) next() (*entry, error) {
+	kind, err := rr.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := binary.ReadUvarint(rr.r)
+	if err != nil {
+		return nil, fmt.Errorf("truncated recording: %v", err)
+	}
+	if size > maxEntrySize {
+		return nil, fmt.Errorf("recorded message of %d bytes is too large", size)
+	}
+
+	b := make([]byte, size)
+	if _, err = io.ReadFull(rr.r, b); err != nil {
+		return nil, fmt.Errorf("truncated recording: %v", err)
+	}
+
+	e := &entry{}
+	var msg proto.Message
+	switch kind {
+	case checkEntry:
+		e.check = &mixerpb.CheckRequest{}
+		msg = e.check
+	case reportEntry:
+		e.report = &mixerpb.ReportRequest{}
+		msg = e.report
+	default:
+		return nil, fmt.Errorf("unknown recorded entry of kind %q", kind)
+	}
+
+	if err = proto.Unmarshal(b, msg); err != nil {
+		return nil, fmt.Errorf("unable to decode recorded message: %v", err)
+	}
+	return e, nil
+}
@@ -0,0 +1,403 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	adptTmpl "istio.io/api/mixer/adapter/model/v1beta1"
+	mixerpb "istio.io/api/mixer/v1"
+	"istio.io/istio/mixer/cmd/shared"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/api"
+	"istio.io/istio/mixer/pkg/config"
+	"istio.io/istio/mixer/pkg/config/crd"
+	"istio.io/istio/mixer/pkg/config/store"
+	"istio.io/istio/mixer/pkg/loadshedding"
+	"istio.io/istio/mixer/pkg/runtime"
+	runtimeconfig "istio.io/istio/mixer/pkg/runtime/config"
+	"istio.io/istio/mixer/pkg/runtime/dispatcher"
+	"istio.io/istio/mixer/pkg/template"
+	"istio.io/pkg/pool"
+)
+
+type replayArgs struct {
+	// file the requests are replayed from
+	recording string
+
+	// configuration the candidate is compared to, as a directory or a config store URL
+	currentConfig string
+
+	// configuration under test, as a directory or a config store URL
+	candidateConfig string
+
+	// namespace of the configuration applying to all namespaces
+	configDefaultNamespace string
+
+	// how long to wait for the configurations to be loaded
+	configWaitTimeout time.Duration
+
+	// whether the quota allocations are dispatched to the adapters
+	dispatchQuotas bool
+}
+
+type (
+	// replayRuntime is an in-process Mixer built from a configuration.
+	replayRuntime struct {
+		rt     *runtime.Runtime
+		server mixerpb.MixerServer
+		gp     *pool.GoroutinePool
+		hgp    *pool.GoroutinePool
+	}
+
+	// capture collects the report and quota instances generated while serving a request.
+	capture struct {
+		mu        sync.Mutex
+		instances []string
+	}
+
+	captureKey struct{}
+
+	// outcome is the result of a replayed request.
+	outcome struct {
+		err       string
+		status    string
+		useCount  int32
+		duration  time.Duration
+		quotas    map[string]string
+		instances []string
+	}
+
+	// replayStats counts the requests replayed and the differences found.
+	replayStats struct {
+		checks      int
+		reports     int
+		differences int
+	}
+)
+
+func replayCmd(info map[string]template.Info, adapters []adapter.InfoFn, printf, fatalf shared.FormatFn) *cobra.Command {
+	ra := &replayArgs{}
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Replays recorded requests against two Mixer configurations, and reports their differences.",
+		Long: "The replay command feeds the requests recorded by the record command to two\n" +
+			"in-process Mixers, built from the current and the candidate configurations, and\n" +
+			"prints the differences between their check results, quota allocations and\n" +
+			"generated report and quota instances. The checks are dispatched to the adapters.\n" +
+			"The report instances are not, and the quota allocations are granted without\n" +
+			"being dispatched unless --dispatchQuotas is set.",
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			replay(ra, info, adapters, printf, fatalf)
+		}}
+
+	cmd.PersistentFlags().StringVarP(&ra.recording, "recording", "f", "",
+		"File the requests are replayed from")
+	cmd.PersistentFlags().StringVarP(&ra.currentConfig, "current", "", "",
+		"Directory or config store URL of the current configuration")
+	cmd.PersistentFlags().StringVarP(&ra.candidateConfig, "candidate", "", "",
+		"Directory or config store URL of the candidate configuration")
+	cmd.PersistentFlags().StringVarP(&ra.configDefaultNamespace, "configDefaultNamespace", "", "istio-system",
+		"Namespace used to store mesh wide configuration")
+	cmd.PersistentFlags().DurationVarP(&ra.configWaitTimeout, "configWaitTimeout", "", 2*time.Minute,
+		"Timeout until the configurations are loaded")
+	cmd.PersistentFlags().BoolVarP(&ra.dispatchQuotas, "dispatchQuotas", "", false,
+		"Dispatch the quota allocations to the adapters. Only use it with handlers whose state is neither "+
+			"shared with the production Mixers nor between both configurations, like memquota")
+
+	return cmd
+}
+
+func replay(ra *replayArgs, info map[string]template.Info, adapters []adapter.InfoFn, printf, fatalf shared.FormatFn) {
+	if ra.recording == "" || ra.currentConfig == "" || ra.candidateConfig == "" {
+		fatalf("The recording, and the current and candidate configurations must be specified")
+	}
+
+	f, err := os.Open(ra.recording)
+	if err != nil {
+		fatalf("Unable to open the recording: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	rr, err := newRecordingReader(f)
+	if err != nil {
+		fatalf("Unable to read %s: %v", ra.recording, err)
+	}
+
+	templates := captureTemplates(info, ra.dispatchQuotas)
+	adapterMap := config.AdapterInfoMap(adapters, template.NewRepository(info).SupportsTemplate)
+
+	current, err := newReplayRuntime(ra.currentConfig, templates, adapterMap, ra.configDefaultNamespace, ra.configWaitTimeout)
+	if err != nil {
+		fatalf("Unable to load the current configuration: %v", err)
+	}
+	defer current.close()
+
+	candidate, err := newReplayRuntime(ra.candidateConfig, templates, adapterMap, ra.configDefaultNamespace, ra.configWaitTimeout)
+	if err != nil {
+		fatalf("Unable to load the candidate configuration: %v", err)
+	}
+	defer candidate.close()
+
+	stats, err := replayRecording(rr, current, candidate, printf)
+	if err != nil {
+		fatalf("Unable to replay %s: %v", ra.recording, err)
+	}
+
+	printf("Replayed %d checks and %d reports, %d of them behaved differently with the candidate configuration",
+		stats.checks, stats.reports, stats.differences)
+}
+
+// replayRecording sends every recorded request to both runtimes, and prints the differences of their outcomes.
+func replayRecording(rr *recordingReader, current, candidate *replayRuntime, printf shared.FormatFn) (replayStats, error) {
+	var stats replayStats
+	for i := 1; ; i++ {
+		e, err := rr.next()
+		if err == io.EOF {
+			return stats, nil
+		} else if err != nil {
+			return stats, err
+		}
+
+		var kind string
+		if e.check != nil {
+			kind = "check"
+			stats.checks++
+		} else {
+			kind = "report"
+			stats.reports++
+		}
+
+		if diffs := diffOutcomes(current.replay(e), candidate.replay(e)); len(diffs) > 0 {
+			stats.differences++
+			printf("Request %d (%s):\n%s", i, kind, strings.Join(diffs, "\n"))
+		}
+	}
+}
+
+// newReplayRuntime builds an in-process Mixer from the configuration stored in the directory or at the URL.
+func newReplayRuntime(cfg string, templates map[string]*template.Info, adapters map[string]*adapter.Info,
+	defaultNamespace string, waitTimeout time.Duration) (*replayRuntime, error) {
+	configURL := cfg
+	if !strings.Contains(cfg, "://") {
+		dir, err := filepath.Abs(cfg)
+		if err != nil {
+			return nil, err
+		}
+		configURL = store.FSUrl + "://" + dir
+	}
+
+	reg := store.NewRegistry(config.StoreInventory()...)
+	groupVersion := &schema.GroupVersion{Group: crd.ConfigAPIGroup, Version: crd.ConfigAPIVersion}
+	st, err := reg.NewStore(configURL, groupVersion, nil, runtimeconfig.CriticalKinds())
+	if err != nil {
+		return nil, err
+	}
+	if err = st.Init(runtimeconfig.KindMap(adapters, templates)); err != nil {
+		return nil, err
+	}
+	if err = st.WaitForSynced(waitTimeout); err != nil {
+		st.Stop()
+		return nil, err
+	}
+
+	r := &replayRuntime{
+		gp:  pool.NewGoroutinePool(128, false),
+		hgp: pool.NewGoroutinePool(128, false),
+	}
+	r.gp.AddWorkers(128)
+	r.hgp.AddWorkers(128)
+
+	r.rt = runtime.New(st, templates, adapters, defaultNamespace, r.gp, r.hgp, false, dispatcher.DefaultCircuitBreakerOptions())
+	if err = r.rt.StartListening(); err != nil {
+		st.Stop()
+		r.close()
+		return nil, err
+	}
+
+	r.server = api.NewGRPCServer(r.rt.Dispatcher(), r.gp, nil, loadshedding.NewThrottler(loadshedding.DefaultOptions()))
+	return r, nil
+}
+
+func (r *replayRuntime) close() {
+	r.rt.StopListening()
+	_ = r.gp.Close()
+	_ = r.hgp.Close()
+}
+
+// replay serves the recorded request, and returns its outcome.
+func (r *replayRuntime) replay(e *entry) *outcome {
+	c := &capture{}
+	ctx := context.WithValue(context.Background(), captureKey{}, c)
+
+	o := &outcome{}
+	if e.check != nil {
+		// the request is copied, as the server doesn't expect it to be reused
+		req := *e.check
+		resp, err := r.server.Check(ctx, &req)
+		if err != nil {
+			o.err = decodeError(err)
+		} else {
+			o.status = decodeStatus(resp.Precondition.Status)
+			o.useCount = resp.Precondition.ValidUseCount
+			o.duration = resp.Precondition.ValidDuration
+
+			o.quotas = make(map[string]string, len(resp.Quotas))
+			for name, qr := range resp.Quotas {
+				o.quotas[name] = fmt.Sprintf("granted %d for %v", qr.GrantedAmount, qr.ValidDuration)
+			}
+		}
+	} else {
+		req := *e.report
+		req.Attributes = append([]mixerpb.CompressedAttributes(nil), e.report.Attributes...)
+		if _, err := r.server.Report(ctx, &req); err != nil {
+			o.err = decodeError(err)
+		}
+	}
+
+	o.instances = c.sorted()
+	return o
+}
+
+// captureTemplates returns the templates, with report instances captured instead of being dispatched.
+// Quota instances are captured, and the requested amounts are granted without dispatching them unless
+// dispatchQuotas is set: the quota backends may be shared with the production Mixers, and the
+// allocations of the current configuration would change the results of the candidate.
+func captureTemplates(info map[string]template.Info, dispatchQuotas bool) map[string]*template.Info {
+	templates := make(map[string]*template.Info, len(info))
+	for k, v := range info {
+		t := v
+		name := t.Name
+		switch t.Variety {
+		case adptTmpl.TEMPLATE_VARIETY_REPORT:
+			t.DispatchReport = func(ctx context.Context, handler adapter.Handler, instances []interface{}) error {
+				if c, ok := ctx.Value(captureKey{}).(*capture); ok {
+					c.add(name, instances)
+				}
+				return nil
+			}
+		case adptTmpl.TEMPLATE_VARIETY_QUOTA:
+			dispatch := t.DispatchQuota
+			t.DispatchQuota = func(ctx context.Context, handler adapter.Handler, instance interface{},
+				args adapter.QuotaArgs) (adapter.QuotaResult, error) {
+				if c, ok := ctx.Value(captureKey{}).(*capture); ok {
+					c.add(name, []interface{}{instance})
+				}
+				if dispatchQuotas {
+					return dispatch(ctx, handler, instance, args)
+				}
+				return adapter.QuotaResult{Amount: args.QuotaAmount}, nil
+			}
+		}
+		templates[k] = &t
+	}
+	return templates
+}
+
+func (c *capture) add(template string, instances []interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, inst := range instances {
+		var s string
+		if b, err := json.Marshal(inst); err == nil {
+			s = string(b)
+		} else {
+			s = fmt.Sprintf("%+v", inst)
+		}
+		c.instances = append(c.instances, template+" "+s)
+	}
+}
+
+func (c *capture) sorted() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sort.Strings(c.instances)
+	return c.instances
+}
+
+// diffOutcomes returns the differences between the outcomes of the current and the candidate configurations.
+func diffOutcomes(current, candidate *outcome) []string {
+	var diffs []string
+	field := func(name string, cur, cand interface{}) {
+		if cur != cand {
+			diffs = append(diffs, fmt.Sprintf("  %s: %v -> %v", name, cur, cand))
+		}
+	}
+
+	field("error", current.err, candidate.err)
+	field("status", current.status, candidate.status)
+	field("valid use count", current.useCount, candidate.useCount)
+	field("valid duration", current.duration, candidate.duration)
+
+	quotas := make(map[string]bool)
+	for name := range current.quotas {
+		quotas[name] = true
+	}
+	for name := range candidate.quotas {
+		quotas[name] = true
+	}
+	names := make([]string, 0, len(quotas))
+	for name := range quotas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cur, ok := current.quotas[name]
+		if !ok {
+			cur = "none"
+		}
+		cand, ok := candidate.quotas[name]
+		if !ok {
+			cand = "none"
+		}
+		field("quota "+name, cur, cand)
+	}
+
+	// both lists are sorted
+	var removed, added []string
+	i, j := 0, 0
+	for i < len(current.instances) || j < len(candidate.instances) {
+		switch {
+		case j == len(candidate.instances) || (i < len(current.instances) && current.instances[i] < candidate.instances[j]):
+			removed = append(removed, "  - "+current.instances[i])
+			i++
+		case i == len(current.instances) || candidate.instances[j] < current.instances[i]:
+			added = append(added, "  + "+candidate.instances[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+
+	diffs = append(diffs, removed...)
+	return append(diffs, added...)
+}
@@ -0,0 +1,408 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"istio.io/istio/mixer/adapter/denier"
+	"istio.io/istio/mixer/adapter/memquota"
+	"istio.io/istio/mixer/adapter/noop"
+	adptr "istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/config"
+	"istio.io/istio/mixer/pkg/template"
+	generatedTmplRepo "istio.io/istio/mixer/template"
+	"istio.io/istio/mixer/template/quota"
+)
+
+const (
+	commonCfg = `
+apiVersion: "config.istio.io/v1alpha2"
+kind: attributemanifest
+metadata:
+  name: replay
+  namespace: istio-system
+spec:
+  attributes:
+    source.name:
+      value_type: STRING
+    request.size:
+      value_type: INT64
+---
+apiVersion: "config.istio.io/v1alpha2"
+kind: handler
+metadata:
+  name: denyall
+  namespace: istio-system
+spec:
+  compiledAdapter: denier
+  params:
+    status:
+      code: 7
+      message: Not allowed
+---
+apiVersion: "config.istio.io/v1alpha2"
+kind: instance
+metadata:
+  name: denyrequest
+  namespace: istio-system
+spec:
+  compiledTemplate: checknothing
+---
+apiVersion: "config.istio.io/v1alpha2"
+kind: instance
+metadata:
+  name: requestcount
+  namespace: istio-system
+spec:
+  compiledTemplate: quota
+  params:
+    dimensions:
+      destination: '"all"'
+---
+apiVersion: "config.istio.io/v1alpha2"
+kind: rule
+metadata:
+  name: quota
+  namespace: istio-system
+spec:
+  actions:
+  - handler: memquota
+    instances:
+    - requestcount
+---
+apiVersion: "config.istio.io/v1alpha2"
+kind: handler
+metadata:
+  name: noop
+  namespace: istio-system
+spec:
+  compiledAdapter: noop
+---
+apiVersion: "config.istio.io/v1alpha2"
+kind: rule
+metadata:
+  name: metrics
+  namespace: istio-system
+spec:
+  actions:
+  - handler: noop
+    instances:
+    - requestsize
+---
+`
+
+	currentCfg = `
+apiVersion: "config.istio.io/v1alpha2"
+kind: rule
+metadata:
+  name: deny
+  namespace: istio-system
+spec:
+  match: source.name == "evil"
+  actions:
+  - handler: denyall
+    instances:
+    - denyrequest
+---
+apiVersion: "config.istio.io/v1alpha2"
+kind: handler
+metadata:
+  name: memquota
+  namespace: istio-system
+spec:
+  compiledAdapter: memquota
+  params:
+    quotas:
+    - name: requestcount.instance.istio-system
+      maxAmount: 100
+      validDuration: 10s
+---
+apiVersion: "config.istio.io/v1alpha2"
+kind: instance
+metadata:
+  name: requestsize
+  namespace: istio-system
+spec:
+  compiledTemplate: metric
+  params:
+    value: request.size | 0
+    dimensions:
+      source: source.name | "unknown"
+---
+`
+
+	candidateCfg = `
+apiVersion: "config.istio.io/v1alpha2"
+kind: rule
+metadata:
+  name: deny
+  namespace: istio-system
+spec:
+  match: source.name == "evil" || source.name == "mallory"
+  actions:
+  - handler: denyall
+    instances:
+    - denyrequest
+---
+apiVersion: "config.istio.io/v1alpha2"
+kind: handler
+metadata:
+  name: memquota
+  namespace: istio-system
+spec:
+  compiledAdapter: memquota
+  params:
+    quotas:
+    - name: requestcount.instance.istio-system
+      maxAmount: 1
+      validDuration: 10s
+---
+apiVersion: "config.istio.io/v1alpha2"
+kind: instance
+metadata:
+  name: requestsize
+  namespace: istio-system
+spec:
+  compiledTemplate: metric
+  params:
+    value: request.size | 0
+    dimensions:
+      source: source.name | "unknown"
+      large: request.size > 1000
+---
+`
+)
+
+// sharedQuotaCfg has the same quota for both configurations, whose handler shares its state.
+const sharedQuotaCfg = `
+apiVersion: "config.istio.io/v1alpha2"
+kind: handler
+metadata:
+  name: memquota
+  namespace: istio-system
+spec:
+  compiledAdapter: sharedquota
+---
+apiVersion: "config.istio.io/v1alpha2"
+kind: instance
+metadata:
+  name: requestsize
+  namespace: istio-system
+spec:
+  compiledTemplate: metric
+  params:
+    value: request.size | 0
+---
+`
+
+// sharedQuota is a quota backend shared by all its handlers, like a Redis server.
+type sharedQuota struct {
+	mu        sync.Mutex
+	max       int64
+	allocated int64
+}
+
+func (q *sharedQuota) info() adptr.Info {
+	return adptr.Info{
+		Name:               "sharedquota",
+		Description:        "Allocates a quota shared by all its handlers",
+		SupportedTemplates: []string{quota.TemplateName},
+		DefaultConfig:      &types.Empty{},
+		NewBuilder:         func() adptr.HandlerBuilder { return &sharedQuotaBuilder{q} },
+	}
+}
+
+func (q *sharedQuota) HandleQuota(_ context.Context, _ *quota.Instance, args adptr.QuotaArgs) (adptr.QuotaResult, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.allocated+args.QuotaAmount > q.max {
+		return adptr.QuotaResult{}, nil
+	}
+	q.allocated += args.QuotaAmount
+	return adptr.QuotaResult{Amount: args.QuotaAmount, ValidDuration: 10 * time.Second}, nil
+}
+
+func (q *sharedQuota) Close() error { return nil }
+
+type sharedQuotaBuilder struct {
+	q *sharedQuota
+}
+
+func (*sharedQuotaBuilder) SetQuotaTypes(map[string]*quota.Type) {}
+func (*sharedQuotaBuilder) SetAdapterConfig(adptr.Config)        {}
+func (*sharedQuotaBuilder) Validate() *adptr.ConfigErrors        { return nil }
+func (b *sharedQuotaBuilder) Build(context.Context, adptr.Env) (adptr.Handler, error) {
+	return b.q, nil
+}
+
+func writeConfig(t *testing.T, configs ...string) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "mixc-replay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, cfg := range configs {
+		if err = ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("config%d.yaml", i)), []byte(cfg), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestReplay(t *testing.T) {
+	rr, err := newRecordingReader(bytes.NewReader(recordRequests(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := generatedTmplRepo.SupportedTmplInfo
+	// the memquota handlers don't share their state.
+	templates := captureTemplates(info, true)
+	adapters := config.AdapterInfoMap([]adptr.InfoFn{denier.GetInfo, memquota.GetInfo, noop.GetInfo},
+		template.NewRepository(info).SupportsTemplate)
+
+	var runtimes []*replayRuntime
+	for _, cfg := range []string{currentCfg, candidateCfg} {
+		dir := writeConfig(t, commonCfg, cfg)
+		defer func() { _ = os.RemoveAll(dir) }()
+
+		r, err := newReplayRuntime(dir, templates, adapters, "istio-system", 10*time.Second)
+		if err != nil {
+			t.Fatalf("Got error %v, expecting the configuration to be loaded", err)
+		}
+		defer r.close()
+		runtimes = append(runtimes, r)
+	}
+
+	var out []string
+	printf := func(format string, args ...interface{}) {
+		out = append(out, fmt.Sprintf(format, args...))
+	}
+
+	stats, err := replayRecording(rr, runtimes[0], runtimes[1], printf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.checks != 4 || stats.reports != 2 || stats.differences != 4 {
+		t.Errorf("Got %+v, expecting 4 checks and 2 reports, with 4 differences", stats)
+	}
+
+	output := strings.Join(out, "\n")
+	for _, s := range []string{
+		// mallory is denied by the candidate.
+		"Request 2 (check):\n  status: OK -> PERMISSION_DENIED (denyall.istio-system:Not allowed)",
+		// bob is over the candidate quota.
+		"Request 4 (check):\n  quota requestcount: granted 1 for 10s -> granted 0 for 0s",
+		// the candidate metric has an additional dimension.
+		"Request 5 (report):\n" +
+			`  - metric {"Name":"requestsize.instance.istio-system","Value":10,"Dimensions":{"source":"alice"}` +
+			`,"MonitoredResourceType":"","MonitoredResourceDimensions":{}}` + "\n" +
+			`  + metric {"Name":"requestsize.instance.istio-system","Value":10,"Dimensions":{"large":false,"source":"alice"}`,
+		// the reported attributes were delta encoded.
+		`  + metric {"Name":"requestsize.instance.istio-system","Value":2000,"Dimensions":{"large":true,"source":"alice"}`,
+	} {
+		if !strings.Contains(output, s) {
+			t.Errorf("Got output:\n%s\nexpecting it to contain:\n%s", output, s)
+		}
+	}
+
+	// evil is denied by both configurations.
+	if strings.Contains(output, "Request 3 ") {
+		t.Errorf("Got output:\n%s\nexpecting no difference for request 3", output)
+	}
+}
+
+func TestReplaySharedQuota(t *testing.T) {
+	for _, dispatchQuotas := range []bool{false, true} {
+		t.Run(fmt.Sprintf("dispatchQuotas=%v", dispatchQuotas), func(t *testing.T) {
+			rr, err := newRecordingReader(bytes.NewReader(recordRequests(t)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			backend := &sharedQuota{max: 3}
+			info := generatedTmplRepo.SupportedTmplInfo
+			templates := captureTemplates(info, dispatchQuotas)
+			adapters := config.AdapterInfoMap([]adptr.InfoFn{denier.GetInfo, backend.info, noop.GetInfo},
+				template.NewRepository(info).SupportsTemplate)
+
+			// both configurations are the same.
+			dir := writeConfig(t, commonCfg, sharedQuotaCfg)
+			defer func() { _ = os.RemoveAll(dir) }()
+			var runtimes []*replayRuntime
+			for i := 0; i < 2; i++ {
+				r, err := newReplayRuntime(dir, templates, adapters, "istio-system", 10*time.Second)
+				if err != nil {
+					t.Fatalf("Got error %v, expecting the configuration to be loaded", err)
+				}
+				defer r.close()
+				runtimes = append(runtimes, r)
+			}
+
+			var out []string
+			printf := func(format string, args ...interface{}) {
+				out = append(out, fmt.Sprintf(format, args...))
+			}
+			stats, err := replayRecording(rr, runtimes[0], runtimes[1], printf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !dispatchQuotas {
+				if backend.allocated != 0 || stats.differences != 0 {
+					t.Errorf("Got %d allocated and output:\n%s\nexpecting no allocation and no difference",
+						backend.allocated, strings.Join(out, "\n"))
+				}
+
+				// the requested amount is granted, and the quota instance is captured.
+				rr, err = newRecordingReader(bytes.NewReader(recordRequests(t)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				e, err := rr.next()
+				if err != nil {
+					t.Fatal(err)
+				}
+				o := runtimes[0].replay(e)
+				if o.quotas["requestcount"] != "granted 1 for 0s" || len(o.instances) != 1 ||
+					!strings.HasPrefix(o.instances[0], `quota {"Name":"requestcount.instance.istio-system"`) {
+					t.Errorf("Got quotas %v and instances %v, expecting the quota to be granted and captured",
+						o.quotas, o.instances)
+				}
+				return
+			}
+
+			// the allocations of the current configuration deplete the quota of the candidate.
+			if backend.allocated != 3 || stats.differences == 0 {
+				t.Errorf("Got %d allocated and %d differences, expecting the quota to be exhausted and differences",
+					backend.allocated, stats.differences)
+			}
+		})
+	}
+}
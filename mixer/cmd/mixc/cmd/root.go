@@ -22,6 +22,8 @@ import (
 	"github.com/spf13/cobra/doc"
 
 	"istio.io/istio/mixer/cmd/shared"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/template"
 	"istio.io/istio/pkg/tracing"
 	"istio.io/pkg/collateral"
 	"istio.io/pkg/version"
@@ -112,7 +114,8 @@ func addAttributeFlags(cmd *cobra.Command, rootArgs *rootArgs) {
 }
 
 // GetRootCmd returns the root of the cobra command-tree.
-func GetRootCmd(args []string, printf, fatalf shared.FormatFn) *cobra.Command {
+func GetRootCmd(args []string, info map[string]template.Info, adapters []adapter.InfoFn,
+	printf, fatalf shared.FormatFn) *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "mixc",
 		Short: "Utility to trigger direct calls to Mixer's API.",
@@ -145,6 +148,8 @@ func GetRootCmd(args []string, printf, fatalf shared.FormatFn) *cobra.Command {
 
 	rootCmd.AddCommand(cc)
 	rootCmd.AddCommand(rc)
+	rootCmd.AddCommand(recordCmd(printf, fatalf))
+	rootCmd.AddCommand(replayCmd(info, adapters, printf, fatalf))
 	rootCmd.AddCommand(version.CobraCommand())
 	rootCmd.AddCommand(collateral.CobraCommand(rootCmd, &doc.GenManHeader{
 		Title:   "Istio Mixer Client",
@@ -17,12 +17,24 @@ package main
 import (
 	"os"
 
+	"istio.io/istio/mixer/adapter"
 	"istio.io/istio/mixer/cmd/mixc/cmd"
 	"istio.io/istio/mixer/cmd/shared"
+	adptr "istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/template"
+	generatedTmplRepo "istio.io/istio/mixer/template"
 )
 
+func supportedTemplates() map[string]template.Info {
+	return generatedTmplRepo.SupportedTmplInfo
+}
+
+func supportedAdapters() []adptr.InfoFn {
+	return adapter.Inventory()
+}
+
 func main() {
-	rootCmd := cmd.GetRootCmd(os.Args[1:], shared.Printf, shared.Fatalf)
+	rootCmd := cmd.GetRootCmd(os.Args[1:], supportedTemplates(), supportedAdapters(), shared.Printf, shared.Fatalf)
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(-1)
@@ -55,11 +55,16 @@ func serverCmd(info map[string]template.Info, adapters []adapter.InfoFn, printf,
 	serverCmd.PersistentFlags().BoolVarP(&sa.SingleThreaded, "singleThreaded", "", sa.SingleThreaded,
 		"If true, each request to Mixer will be executed in a single go routine (useful for debugging)")
 	serverCmd.PersistentFlags().Int32VarP(&sa.NumCheckCacheEntries, "numCheckCacheEntries", "", sa.NumCheckCacheEntries,
-		"Max number of entries in the check result cache")
+		"Max number of entries in the check result cache. 0 disables the cache")
+	serverCmd.PersistentFlags().Int64VarP(&sa.CheckCacheMaxBytes, "checkCacheMaxBytes", "", sa.CheckCacheMaxBytes,
+		"Max estimated memory used by the check result cache, in bytes. 0 means unbounded")
+	serverCmd.PersistentFlags().DurationVarP(&sa.CheckCacheNegativeTTL, "checkCacheNegativeTTL", "", sa.CheckCacheNegativeTTL,
+		"How long denials without a valid duration are held in the check result cache. 0 disables their caching")
 
 	serverCmd.PersistentFlags().StringVarP(&sa.ConfigStoreURL, "configStoreURL", "", sa.ConfigStoreURL,
 		"URL of the config store. Use k8s://path_to_kubeconfig, fs:// for file system, or mcps://<address> for MCP/Galley. "+
-			"If path_to_kubeconfig is empty, in-cluster kubeconfig is used.")
+			"If path_to_kubeconfig is empty, in-cluster kubeconfig is used. "+
+			"Add ?cacheDir=<dir> to the MCP address to cache the config received from Galley on disk.")
 
 	serverCmd.PersistentFlags().StringVarP(&sa.ConfigDefaultNamespace, "configDefaultNamespace", "", sa.ConfigDefaultNamespace,
 		"Namespace used to store mesh wide configuration.")
@@ -87,6 +92,7 @@ func serverCmd(info map[string]template.Info, adapters []adapter.InfoFn, printf,
 	sa.TracingOptions.AttachCobraFlags(serverCmd)
 	sa.IntrospectionOptions.AttachCobraFlags(serverCmd)
 	sa.LoadSheddingOptions.AttachCobraFlags(serverCmd)
+	sa.CircuitBreakerOptions.AttachCobraFlags(serverCmd)
 
 	return serverCmd
 }
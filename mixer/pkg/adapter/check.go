@@ -34,6 +34,9 @@ type CheckResult struct {
 	ValidUseCount int32
 	// RouteDirective represents the route directive return result
 	RouteDirective *mixerpb.RouteDirective
+	// NoCache indicates that the result must not be cached, because a rule applied to the request opted out
+	// of check caching. It is set by Mixer rather than by adapters.
+	NoCache bool
 }
 
 // IsDefault returns true if the CheckResult is in its zero state
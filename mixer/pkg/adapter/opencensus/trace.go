@@ -163,7 +163,7 @@ func buildSpanData(val *tracespan.Instance, parentContext trace.SpanContext, spa
 		// ID and rewrite span id to that one. This id should also be used as server span's parent span
 		// id.
 		if val.RewriteClientSpanId {
-			spanID = rewriteSpanID(spanID)
+			spanID = RewriteSpanID(spanID)
 		}
 	} else {
 		spanKind = trace.SpanKindServer
@@ -172,7 +172,7 @@ func buildSpanData(val *tracespan.Instance, parentContext trace.SpanContext, spa
 		// ID and rewrite parent id to that one, which makes this span attached to the client span as a
 		// child span.
 		if val.RewriteClientSpanId {
-			parentSpanID = rewriteSpanID(spanID)
+			parentSpanID = RewriteSpanID(spanID)
 		}
 	}
 
@@ -198,8 +198,8 @@ func buildSpanData(val *tracespan.Instance, parentContext trace.SpanContext, spa
 
 var pad = [8]byte{0x3f, 0x6a, 0x2e, 0xc3, 0xc8, 0x10, 0xc2, 0xab}
 
-// rewriteSpanID deterministically creates a new span id base on the given span id by XOR with a pad.
-func rewriteSpanID(spanID trace.SpanID) trace.SpanID {
+// RewriteSpanID deterministically creates a new span id base on the given span id by XOR with a pad.
+func RewriteSpanID(spanID trace.SpanID) trace.SpanID {
 	var newID trace.SpanID
 	for i, b := range spanID {
 		newID[i] = b ^ pad[i]
@@ -135,6 +135,12 @@ func (s *grpcServer) check(ctx context.Context, req *mixerpb.CheckRequest,
 
 	globalWordCount := int(req.GlobalWordCount)
 
+	var generation uint64
+	if s.cache != nil {
+		// results computed against a configuration that gets invalidated meanwhile are not cached
+		generation = s.cache.Generation()
+	}
+
 	if err := s.dispatcher.Preprocess(ctx, protoBag, checkBag); err != nil {
 		err = fmt.Errorf("preprocessing attributes failed: %v", err)
 		lg.Errora("Check failed: ", err.Error())
@@ -175,7 +181,7 @@ func (s *grpcServer) check(ctx context.Context, req *mixerpb.CheckRequest,
 		},
 	}
 
-	if s.cache != nil {
+	if s.cache != nil && !cr.NoCache {
 		// keep this for later...
 		s.cache.Set(protoBag, checkcache.Value{
 			StatusCode:           resp.Precondition.Status.Code,
@@ -184,6 +190,7 @@ func (s *grpcServer) check(ctx context.Context, req *mixerpb.CheckRequest,
 			ValidUseCount:        resp.Precondition.ValidUseCount,
 			ReferencedAttributes: *resp.Precondition.ReferencedAttributes,
 			RouteDirective:       resp.Precondition.RouteDirective,
+			Generation:           generation,
 		})
 	}
 
@@ -342,6 +342,53 @@ func TestCheckCachedAllow(t *testing.T) {
 	}
 }
 
+func TestCheckCacheBypass(t *testing.T) {
+	cases := []struct {
+		name       string
+		noCache    bool
+		invalidate bool
+		calls      int
+	}{
+		{name: "cached", calls: 1},
+		{name: "rule opted out", noCache: true, calls: 2},
+		{name: "invalidated", invalidate: true, calls: 2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ts, err := prepTestState()
+			if err != nil {
+				t.Fatalf("Unable to prep test state: %v", err)
+			}
+			defer ts.cleanupTestState()
+
+			calls := 0
+			ts.check = func(ctx context.Context, requestBag attribute.Bag) (adapter.CheckResult, error) {
+				calls++
+				return adapter.CheckResult{
+					Status:        status.OK,
+					ValidDuration: time.Hour * 1000,
+					NoCache:       c.noCache,
+				}, nil
+			}
+
+			request := mixerpb.CheckRequest{Attributes: *attr.GetProtoForTesting(map[string]interface{}{"A1": 25.0})}
+			for i := 0; i < 2; i++ {
+				if _, err := ts.client.Check(context.Background(), &request); err != nil {
+					t.Errorf("Expecting success, got %v", err)
+				}
+				if c.invalidate {
+					ts.s.cache.Invalidate()
+				}
+			}
+
+			if calls != c.calls {
+				t.Errorf("Got %d dispatched checks, expecting %d", calls, c.calls)
+			}
+		})
+	}
+}
+
 func TestCheckQuota(t *testing.T) {
 	ts, err := prepTestState()
 	if err != nil {
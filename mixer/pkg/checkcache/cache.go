@@ -29,23 +29,42 @@ package checkcache
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	rpc "github.com/gogo/googleapis/google/rpc"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 
 	mixerpb "istio.io/api/mixer/v1"
 	"istio.io/istio/mixer/pkg/attribute"
-	"istio.io/pkg/cache"
 )
 
+// Options controls the size of the check cache and what it holds.
+type Options struct {
+	// MaxEntries is the maximum number of entries held in the cache.
+	MaxEntries int32
+
+	// MaxBytes is the maximum estimated memory used by the entries held in the cache. Zero means the cache
+	// is bounded by its number of entries only.
+	MaxBytes int64
+
+	// NegativeTTL is how long denials returned without a valid duration are cached. Zero disables the caching
+	// of such denials.
+	NegativeTTL time.Duration
+}
+
 // Cache holds cached results of calls to Mixer.Check
 type Cache struct {
-	cache         cache.ExpiringCache
+	cache         *lru
 	keyShapes     []keyShape
 	keyShapesLock sync.RWMutex
 	globalWords   []string
+	negativeTTL   time.Duration
+
+	// generation is incremented each time the cache is invalidated.
+	generation uint64
 
 	// allowing patch for testing
 	getTime func() time.Time
@@ -70,6 +89,10 @@ type Value struct {
 
 	// RouteDirective for the completed Check operation
 	RouteDirective *mixerpb.RouteDirective
+
+	// Generation of the cache when the Check operation started. Values computed before the cache was last
+	// invalidated are not added to the cache.
+	Generation uint64
 }
 
 var (
@@ -82,11 +105,20 @@ var (
 		"mixer/checkcache/cache_misses_total", "The number of times a cache lookup operation failed to find an entry in the cache.", stats.UnitDimensionless)
 	evictionsTotal = stats.Int64(
 		"mixer/checkcache/cache_evictions_total", "The number of entries that have been evicted from the cache.", stats.UnitDimensionless)
-
-	writesView    = newView(writesTotal, []tag.Key{}, view.LastValue())
-	hitsView      = newView(hitsTotal, []tag.Key{}, view.LastValue())
-	missesView    = newView(missesTotal, []tag.Key{}, view.LastValue())
-	evictionsView = newView(evictionsTotal, []tag.Key{}, view.LastValue())
+	invalidationsTotal = stats.Int64(
+		"mixer/checkcache/cache_invalidations_total", "The number of entries that have been removed from the cache by an invalidation.", stats.UnitDimensionless)
+	entries = stats.Int64(
+		"mixer/checkcache/cache_entries", "The number of entries in the cache.", stats.UnitDimensionless)
+	size = stats.Int64(
+		"mixer/checkcache/cache_bytes", "The estimated memory used by the entries in the cache.", stats.UnitBytes)
+
+	writesView        = newView(writesTotal, []tag.Key{}, view.LastValue())
+	hitsView          = newView(hitsTotal, []tag.Key{}, view.LastValue())
+	missesView        = newView(missesTotal, []tag.Key{}, view.LastValue())
+	evictionsView     = newView(evictionsTotal, []tag.Key{}, view.LastValue())
+	invalidationsView = newView(invalidationsTotal, []tag.Key{}, view.LastValue())
+	entriesView       = newView(entries, []tag.Key{}, view.LastValue())
+	sizeView          = newView(size, []tag.Key{}, view.LastValue())
 )
 
 func newView(measure stats.Measure, keys []tag.Key, aggregation *view.Aggregation) *view.View {
@@ -102,23 +134,50 @@ func newView(measure stats.Measure, keys []tag.Key, aggregation *view.Aggregatio
 // New creates a new instance of a check cache with the given maximum capacity. Adding more items to the
 // cache then its capacity will cause eviction of older entries.
 func New(capacity int32) *Cache {
+	return NewWithOptions(Options{MaxEntries: capacity})
+}
+
+// NewWithOptions creates a new instance of a check cache with the given options. Adding more items to the
+// cache than its bounds allow will cause eviction of the least recently used entries.
+func NewWithOptions(o Options) *Cache {
 	cc := &Cache{
-		cache:       cache.NewLRU(time.Minute*60, 1*time.Minute, capacity),
+		cache:       newLRU(o.MaxEntries, o.MaxBytes),
 		globalWords: attribute.GlobalList(),
+		negativeTTL: o.NegativeTTL,
 		getTime:     time.Now,
 	}
 
-	_ = view.Register(writesView, hitsView, missesView, evictionsView)
+	_ = view.Register(writesView, hitsView, missesView, evictionsView, invalidationsView, entriesView, sizeView)
 
 	return cc
 }
 
 // Close releases any resources used by the check cache.
 func (cc *Cache) Close() error {
-	view.Unregister(writesView, hitsView, missesView, evictionsView)
+	view.Unregister(writesView, hitsView, missesView, evictionsView, invalidationsView, entriesView, sizeView)
 	return nil
 }
 
+// Generation returns the current generation of the cache. It should be captured before performing the Check
+// operation whose result is added to the cache, so that results computed against a configuration that has since
+// been invalidated are discarded.
+func (cc *Cache) Generation() uint64 {
+	return atomic.LoadUint64(&cc.generation)
+}
+
+// Invalidate removes all the entries from the cache, and discards the values being computed.
+func (cc *Cache) Invalidate() {
+	atomic.AddUint64(&cc.generation, 1)
+
+	// the referenced attributes of the new configuration may differ, drop the key shapes of the previous one.
+	cc.keyShapesLock.Lock()
+	cc.keyShapes = nil
+	cc.keyShapesLock.Unlock()
+
+	cc.cache.removeAll()
+	cc.recordStats()
+}
+
 // Get looks up an attribute bag in the cache.
 func (cc *Cache) Get(attrs attribute.Bag) (Value, bool) {
 	cc.keyShapesLock.RLock()
@@ -133,19 +192,10 @@ func (cc *Cache) Get(attrs attribute.Bag) (Value, bool) {
 			key := shape.makeKey(attrs)
 
 			// see if we have an entry in the cache for this key
-			if result, ok := cc.cache.Get(key); ok {
-				v := result.(Value)
-				if v.Expiration.Before(cc.getTime()) {
-					// Entry expired. This happens because the underlying ExpiringCache only lazily cleans up
-					// expired entries. Since we want to be more precise, we do our own freshness check and throw
-					// out stale results
-					cc.recordStats()
-					return Value{}, false
-				}
-
+			if result, ok := cc.cache.get(key, cc.getTime()); ok {
 				// got a match!
 				cc.recordStats()
-				return result.(Value), true
+				return result, true
 			}
 		}
 	}
@@ -154,15 +204,24 @@ func (cc *Cache) Get(attrs attribute.Bag) (Value, bool) {
 	return Value{}, false
 }
 
-// Set enters a new value in the cache.
+// Set enters a new value in the cache. Denials without a valid duration are cached for the negative TTL
+// of the cache, if any.
 func (cc *Cache) Set(attrs attribute.Bag, value Value) {
-	now := cc.getTime()
-	if value.Expiration.Before(now) {
-		// value is already expired, don't add it
-		cc.recordStats()
+	if value.Generation != cc.Generation() {
+		// the value was computed before the last invalidation, don't add it
 		return
 	}
 
+	now := cc.getTime()
+	if value.Expiration.Before(now) || value.Expiration.Equal(now) {
+		if cc.negativeTTL <= 0 || value.StatusCode == int32(rpc.OK) {
+			// value is already expired, don't add it
+			cc.recordStats()
+			return
+		}
+		value.Expiration = now.Add(cc.negativeTTL)
+	}
+
 	cc.keyShapesLock.RLock()
 	shapes := cc.keyShapes
 	cc.keyShapesLock.RUnlock()
@@ -170,7 +229,7 @@ func (cc *Cache) Set(attrs attribute.Bag, value Value) {
 	// find a matching key shape
 	for _, shape := range shapes {
 		if shape.isCompatible(attrs) {
-			cc.cache.SetWithExpiration(shape.makeKey(attrs), value, value.Expiration.Sub(now))
+			cc.cache.set(shape.makeKey(attrs), value)
 			cc.recordStats()
 			return
 		}
@@ -184,15 +243,18 @@ func (cc *Cache) Set(attrs attribute.Bag, value Value) {
 	cc.keyShapes = append(cc.keyShapes, shape)
 	cc.keyShapesLock.Unlock()
 
-	cc.cache.SetWithExpiration(shape.makeKey(attrs), value, value.Expiration.Sub(now))
+	cc.cache.set(shape.makeKey(attrs), value)
 	cc.recordStats()
 }
 
 func (cc *Cache) recordStats() {
-	s := cc.cache.Stats()
+	s := cc.cache.getStats()
 	stats.Record(context.Background(),
-		writesTotal.M(int64(s.Writes)),
-		hitsTotal.M(int64(s.Hits)),
-		missesTotal.M(int64(s.Misses)),
-		evictionsTotal.M(int64(s.Evictions)))
+		writesTotal.M(int64(s.writes)),
+		hitsTotal.M(int64(s.hits)),
+		missesTotal.M(int64(s.misses)),
+		evictionsTotal.M(int64(s.evictions)),
+		invalidationsTotal.M(int64(s.invalidations)),
+		entries.M(s.entries),
+		size.M(s.bytes))
 }
@@ -18,6 +18,7 @@ import (
 	"math/rand"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -213,10 +214,17 @@ func TestCache(t *testing.T) {
 
 	cache.getTime = func() time.Time { return time.Now().Add(time.Hour * 1000000) }
 
+	before := cache.cache.getStats()
 	if _, ok := cache.Get(attribute.GetMutableBagForTesting(tb)); ok {
 		t.Errorf("Expecting to not find entry but did")
 	}
 
+	// the expired entry is a miss
+	after := cache.cache.getStats()
+	if after.hits != before.hits || after.misses != before.misses+1 || after.entries != before.entries-1 {
+		t.Errorf("Expecting the expired entry to be removed and counted as a miss, got %+v then %+v", before, after)
+	}
+
 	// make sure our metric callbacks don't crash...
 	_, _ = prometheus.DefaultGatherer.Gather()
 
@@ -225,6 +233,141 @@ func TestCache(t *testing.T) {
 	}
 }
 
+func refAttrs(names ...string) mixerpb.ReferencedAttributes {
+	ra := mixerpb.ReferencedAttributes{Words: names}
+	for i := range names {
+		ra.AttributeMatches = append(ra.AttributeMatches, mixerpb.ReferencedAttributes_AttributeMatch{Name: int32(-i - 1), Condition: mixerpb.EXACT})
+	}
+	return ra
+}
+
+func TestNegativeCaching(t *testing.T) {
+	cases := []struct {
+		name        string
+		negativeTTL time.Duration
+		statusCode  int32
+		cached      bool
+	}{
+		{"denial", time.Minute, 7, true},
+		{"approval", time.Minute, 0, false},
+		{"disabled", 0, 7, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cache := NewWithOptions(Options{MaxEntries: 10, NegativeTTL: c.negativeTTL})
+			defer func() { _ = cache.Close() }()
+			now := time.Now()
+			cache.getTime = func() time.Time { return now }
+
+			bag := attribute.GetMutableBagForTesting(map[string]interface{}{"a": "x"})
+			cache.Set(bag, Value{StatusCode: c.statusCode, Expiration: now, ReferencedAttributes: refAttrs("a")})
+
+			value, ok := cache.Get(bag)
+			if ok != c.cached {
+				t.Fatalf("Expecting %v, got %v", c.cached, ok)
+			}
+			if ok && !value.Expiration.Equal(now.Add(c.negativeTTL)) {
+				t.Errorf("Expecting expiration %v, got %v", now.Add(c.negativeTTL), value.Expiration)
+			}
+
+			// the denial expires after the negative TTL.
+			cache.getTime = func() time.Time { return now.Add(c.negativeTTL + time.Second) }
+			if _, ok := cache.Get(bag); ok {
+				t.Errorf("Expecting to not find entry but did")
+			}
+		})
+	}
+}
+
+func TestByteBound(t *testing.T) {
+	value := Value{Expiration: time.Now().Add(time.Hour), StatusMessage: "denied", ReferencedAttributes: refAttrs("a")}
+	// room for two entries, md5 keys are 16 bytes long.
+	entrySize := 16 + value.size()
+	cache := NewWithOptions(Options{MaxEntries: 100, MaxBytes: 2*entrySize + entrySize/2})
+	defer func() { _ = cache.Close() }()
+
+	bags := make([]attribute.Bag, 3)
+	for i := range bags {
+		bags[i] = attribute.GetMutableBagForTesting(map[string]interface{}{"a": strconv.Itoa(i)})
+		cache.Set(bags[i], value)
+	}
+
+	if _, ok := cache.Get(bags[0]); ok {
+		t.Errorf("Expecting the least recently used entry to be evicted")
+	}
+	for _, bag := range bags[1:] {
+		if _, ok := cache.Get(bag); !ok {
+			t.Errorf("Expecting to find entry but didn't")
+		}
+	}
+
+	s := cache.cache.getStats()
+	if s.entries != 2 || s.bytes != 2*entrySize || s.evictions != 1 {
+		t.Errorf("Expecting 2 entries using %d bytes and 1 eviction, got %+v", 2*entrySize, s)
+	}
+
+	// values larger than the bound are never added.
+	large := value
+	large.StatusMessage = strings.Repeat("x", int(3*entrySize))
+	cache.Set(bags[0], large)
+	if _, ok := cache.Get(bags[0]); ok {
+		t.Errorf("Expecting to not find entry but did")
+	}
+}
+
+func TestEntryBound(t *testing.T) {
+	cache := New(2)
+	defer func() { _ = cache.Close() }()
+
+	value := Value{Expiration: time.Now().Add(time.Hour), ReferencedAttributes: refAttrs("a")}
+	bags := make([]attribute.Bag, 3)
+	for i := range bags {
+		bags[i] = attribute.GetMutableBagForTesting(map[string]interface{}{"a": strconv.Itoa(i)})
+		cache.Set(bags[i], value)
+		if i == 1 {
+			// make the first entry the most recently used one.
+			_, _ = cache.Get(bags[0])
+		}
+	}
+
+	for i, want := range []bool{true, false, true} {
+		if _, ok := cache.Get(bags[i]); ok != want {
+			t.Errorf("entry %d: expecting %v, got %v", i, want, ok)
+		}
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	cache := New(10)
+	defer func() { _ = cache.Close() }()
+
+	bag := attribute.GetMutableBagForTesting(map[string]interface{}{"a": "x"})
+	value := Value{Expiration: time.Now().Add(time.Hour), ReferencedAttributes: refAttrs("a"), Generation: cache.Generation()}
+	cache.Set(bag, value)
+
+	// a check started before the invalidation completes after it.
+	inflight := Value{Expiration: time.Now().Add(time.Hour), ReferencedAttributes: refAttrs("a"), Generation: cache.Generation()}
+	cache.Invalidate()
+
+	if _, ok := cache.Get(bag); ok {
+		t.Errorf("Expecting to not find entry after invalidation but did")
+	}
+	cache.Set(bag, inflight)
+	if _, ok := cache.Get(bag); ok {
+		t.Errorf("Expecting the value computed before the invalidation to be discarded")
+	}
+
+	value.Generation = cache.Generation()
+	cache.Set(bag, value)
+	if _, ok := cache.Get(bag); !ok {
+		t.Errorf("Expecting to find entry but didn't")
+	}
+	if s := cache.cache.getStats(); s.invalidations != 1 {
+		t.Errorf("Expecting 1 invalidated entry, got %d", s.invalidations)
+	}
+}
+
 const (
 	benchmarkCacheCapacity = 4096
 	benchmarkIterations    = 16000
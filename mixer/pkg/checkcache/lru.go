@@ -0,0 +1,148 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkcache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// entryOverhead approximates the memory used by an entry beyond its key and the variable parts of its value:
+// the Value struct itself, the list element and the map bucket slot.
+const entryOverhead = 192
+
+// lruStats holds the counters of the cache operations.
+type lruStats struct {
+	writes        uint64
+	hits          uint64
+	misses        uint64
+	evictions     uint64
+	invalidations uint64
+	entries       int64
+	bytes         int64
+}
+
+type lruEntry struct {
+	key   string
+	value Value
+	size  int64
+}
+
+// lru is a least-recently-used cache of Values, bounded both by its number of entries and by the
+// estimated number of bytes they use. The expiration of the values is checked by the Cache.
+type lru struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List // front is the most recently used entry
+	maxEntries int
+	maxBytes   int64
+	stats      lruStats
+}
+
+func newLRU(maxEntries int32, maxBytes int64) *lru {
+	return &lru{
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		maxEntries: int(maxEntries),
+		maxBytes:   maxBytes,
+	}
+}
+
+// get returns the value for the key, and makes it the most recently used entry. A value expired at the
+// given time is a miss: it is removed, as expired entries are only cleaned up lazily, either here or when
+// they are evicted to make room for new entries.
+func (c *lru) get(key string, now time.Time) (Value, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.stats.misses++
+		return Value{}, false
+	}
+
+	value := elem.Value.(*lruEntry).value
+	if value.Expiration.Before(now) {
+		c.removeElement(elem)
+		c.stats.entries = int64(len(c.entries))
+		c.stats.misses++
+		return Value{}, false
+	}
+
+	c.stats.hits++
+	c.order.MoveToFront(elem)
+	return value, true
+}
+
+// set adds or replaces the value for the key, then evicts the least recently used entries until the cache is
+// within its bounds. A value larger than the byte bound is not added.
+func (c *lru) set(key string, value Value) {
+	size := int64(len(key)) + value.size()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.maxBytes > 0 && size > c.maxBytes {
+		return
+	}
+
+	c.stats.writes++
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*lruEntry)
+		c.stats.bytes += size - e.size
+		e.value = value
+		e.size = size
+		c.order.MoveToFront(elem)
+	} else {
+		c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, size: size})
+		c.stats.bytes += size
+	}
+
+	for len(c.entries) > c.maxEntries || (c.maxBytes > 0 && c.stats.bytes > c.maxBytes) {
+		c.removeElement(c.order.Back())
+		c.stats.evictions++
+	}
+	c.stats.entries = int64(len(c.entries))
+}
+
+// removeAll removes all the entries.
+func (c *lru) removeAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats.invalidations += uint64(len(c.entries))
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.stats.bytes = 0
+	c.stats.entries = 0
+}
+
+func (c *lru) removeElement(elem *list.Element) {
+	e := c.order.Remove(elem).(*lruEntry)
+	delete(c.entries, e.key)
+	c.stats.bytes -= e.size
+}
+
+func (c *lru) getStats() lruStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// size returns an estimate of the memory used by the value.
+func (v *Value) size() int64 {
+	return entryOverhead + int64(len(v.StatusMessage)+v.ReferencedAttributes.Size()+v.RouteDirective.Size())
+}
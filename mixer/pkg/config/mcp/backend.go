@@ -72,6 +72,7 @@ func newStore(u *url.URL, credOptions *creds.Options, fn updateHookFn) (store.Ba
 
 	return &backend{
 		serverAddress: u.Host,
+		cacheDir:      u.Query().Get("cacheDir"),
 		insecure:      insecure,
 		credOptions:   credOptions,
 		Probe:         probe.NewProbe(),
@@ -93,6 +94,9 @@ type backend struct {
 	// address of the MCP server.
 	serverAddress string
 
+	// the directory where the resources received from the server are cached, if any
+	cacheDir string
+
 	// MCP credential options
 	credOptions *creds.Options
 
@@ -197,13 +201,10 @@ func (b *backend) Init(kinds []string) error {
 		Updater:           b,
 		ID:                mixerNodeID,
 		Reporter:          b.mcpReporter,
+		CacheDir:          b.cacheDir,
 	}
 
-	cl := mcp.NewResourceSourceClient(conn)
-	c := sink.NewClient(cl, options)
-	configz.Register(c)
-	go c.Run(ctx)
-
+	// the state is initialized first, as the cached resources are applied when the client is created.
 	b.state = &state{
 		items:  make(map[string]map[store.Key]*store.BackEndResource),
 		synced: make(map[string]bool),
@@ -212,6 +213,11 @@ func (b *backend) Init(kinds []string) error {
 		b.state.synced[collection] = false
 	}
 
+	cl := mcp.NewResourceSourceClient(conn)
+	c := sink.NewClient(cl, options)
+	configz.Register(c)
+	go c.Run(ctx)
+
 	b.cancel = cancel
 	return nil
 }
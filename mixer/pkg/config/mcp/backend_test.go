@@ -16,6 +16,7 @@ package mcp
 
 import (
 	"fmt"
+	"net/url"
 	"reflect"
 	"sort"
 	"strings"
@@ -139,6 +140,20 @@ func (s *testState) close(t *testing.T) {
 	}
 }
 
+func TestNewStore_CacheDir(t *testing.T) {
+	u, err := url.Parse("mcp://istio-galley:9901?cacheDir=/var/cache/mixer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := newStore(u, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := b.(*backend); got.serverAddress != "istio-galley:9901" || got.cacheDir != "/var/cache/mixer" {
+		t.Errorf("Got address %q and cache directory %q", got.serverAddress, got.cacheDir)
+	}
+}
+
 func TestBackend_HasSynced(t *testing.T) {
 	st := createState(t)
 	defer st.close(t)
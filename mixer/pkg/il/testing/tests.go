@@ -5220,6 +5220,164 @@ end
 		E:          `size()`,
 		CompileErr: `size() arity mismatch. Got 0 arg(s), expected 1 arg(s)`,
 	},
+	{
+		E:    `jsonPath(request.headers["x-user"], "$.roles[0]") == "admin"`,
+		Type: descriptor.BOOL,
+		I: map[string]interface{}{
+			"request.headers": map[string]string{
+				"x-user": `{"name": "alice", "roles": ["admin", "dev"]}`,
+			},
+		},
+		R:    true,
+		conf: istio06AttributeSet,
+		IL: `
+fn eval() bool
+  resolve_f "request.headers"
+  anlookup "x-user"
+  apush_s "$.roles[0]"
+  call jsonPath
+  aeq_s "admin"
+  ret
+end
+`,
+	},
+	{
+		E:    `jsonPath(as, "$.age")`,
+		Type: descriptor.STRING,
+		I: map[string]interface{}{
+			"as": `{"name": "alice", "age": 42}`,
+		},
+		R: "42",
+	},
+	{
+		E:    `jsonPath(as, "$.email")`,
+		Type: descriptor.STRING,
+		I: map[string]interface{}{
+			"as": `{"name": "alice"}`,
+		},
+		R: "",
+	},
+	{
+		E:    `jsonPath(as, "$.name")`,
+		Type: descriptor.STRING,
+		I: map[string]interface{}{
+			"as": `{"name": `,
+		},
+		Err: `error parsing JSON document '{"name": ': unexpected EOF`,
+	},
+	{
+		E:          `jsonPath(ai, "$.name")`,
+		CompileErr: `jsonPath($ai, "$.name") arg 1 ($ai) typeError got INT64, expected STRING`,
+	},
+	{
+		E:    `queryParam(request.path, "page")`,
+		Type: descriptor.STRING,
+		I: map[string]interface{}{
+			"request.path": "/books?author=dumas&page=2",
+		},
+		R:    "2",
+		conf: istio06AttributeSet,
+		IL: `
+fn eval() string
+  resolve_s "request.path"
+  apush_s "page"
+  call queryParam
+  ret
+end
+`,
+	},
+	{
+		E:    `queryParam(as, "title")`,
+		Type: descriptor.STRING,
+		I: map[string]interface{}{
+			"as": "/books?author=dumas",
+		},
+		R: "",
+	},
+	{
+		E:    `queryParam(as, "title")`,
+		Type: descriptor.STRING,
+		I: map[string]interface{}{
+			"as": "/books?title=%zz",
+		},
+		Err: `error parsing query of '/books?title=%zz': invalid URL escape "%zz"`,
+	},
+	{
+		E:    `semverCompare(as, "1.2.0") >= 0`,
+		Type: descriptor.BOOL,
+		I: map[string]interface{}{
+			"as": "v1.10.0",
+		},
+		R: true,
+		IL: `
+fn eval() bool
+  resolve_s "as"
+  apush_s "1.2.0"
+  call semverCompare
+  age_i 0
+  ret
+end
+`,
+	},
+	{
+		E:    `semverCompare(as, bs)`,
+		Type: descriptor.INT64,
+		I: map[string]interface{}{
+			"as": "1.0.0-beta.2",
+			"bs": "1.0.0-beta.11",
+		},
+		R: int64(-1),
+	},
+	{
+		E:    `semverCompare(as, "1.0.0")`,
+		Type: descriptor.INT64,
+		I: map[string]interface{}{
+			"as": "1.x",
+		},
+		Err: "error converting '1.x' to semantic version: invalid version number 'x'",
+	},
+	{
+		E:    `ipInCidrs(source.ip, "10.0.0.0/8, 192.168.0.0/16")`,
+		Type: descriptor.BOOL,
+		I: map[string]interface{}{
+			"source.ip": []byte(net.ParseIP("192.168.1.1")),
+		},
+		R:    true,
+		conf: istio06AttributeSet,
+		IL: `
+fn eval() bool
+  resolve_f "source.ip"
+  apush_s "10.0.0.0/8, 192.168.0.0/16"
+  call ipInCidrs
+  ret
+end
+`,
+	},
+	{
+		E:    `ipInCidrs(aip, "10.0.0.0/8,2001:db8::/32")`,
+		Type: descriptor.BOOL,
+		I: map[string]interface{}{
+			"aip": []byte(net.ParseIP("172.16.0.1")),
+		},
+		R: false,
+	},
+	{
+		E:    `ipInCidrs(ip("2001:db8::1"), "10.0.0.0/8,2001:db8::/32")`,
+		Type: descriptor.BOOL,
+		R:    true,
+	},
+	{
+		E:    `ipInCidrs(aip, "10.0.0.0")`,
+		Type: descriptor.BOOL,
+		I: map[string]interface{}{
+			"aip": []byte(net.ParseIP("10.0.0.1")),
+		},
+		Err: "error converting '10.0.0.0' to CIDR: invalid CIDR address: 10.0.0.0",
+	},
+	{
+		E:          `ipInCidrs(as, "10.0.0.0/8")`,
+		CompileErr: `ipInCidrs($as, "10.0.0.0/8") arg 1 ($as) typeError got STRING, expected IP_ADDRESS`,
+	},
 }
 
 // TestInfo is a structure that contains detailed test information. Depending
@@ -477,6 +477,30 @@ var (
 			text:   `toLower("Ab")`,
 			result: "ab",
 		},
+		{
+			text:   `jsonPath('{"user": {"roles": ["admin"]}}', "$.user.roles[0]")`,
+			result: "admin",
+		},
+		{
+			text:   `jsonPath("{", "$.user")`,
+			result: errors.New("error parsing JSON document '{': unexpected EOF"),
+		},
+		{
+			text:   `queryParam("/books?page=2", "page")`,
+			result: "2",
+		},
+		{
+			text:   `semverCompare("1.10.0", "1.9.0")`,
+			result: int64(1),
+		},
+		{
+			text:   `semverCompare("1.x", "1.9.0")`,
+			result: errors.New("error converting '1.x' to semantic version: invalid version number 'x'"),
+		},
+		{
+			text:   `ipInCidrs(ip("10.1.2.3"), "192.168.0.0/16,10.0.0.0/8")`,
+			result: true,
+		},
 		{
 			text:       `conditional(context.reporter.kind == "client", pick(as, "test"), "inbound")`,
 			result:     "inbound",
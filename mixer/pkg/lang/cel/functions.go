@@ -55,6 +55,18 @@ var (
 		decls.NewFunction("emptyStringMap",
 			decls.NewOverload("emptyStringMap",
 				[]*exprpb.Type{}, stringMapType)),
+		decls.NewFunction("jsonPath",
+			decls.NewOverload("jsonPath",
+				[]*exprpb.Type{decls.String, decls.String}, decls.String)),
+		decls.NewFunction("queryParam",
+			decls.NewOverload("queryParam",
+				[]*exprpb.Type{decls.String, decls.String}, decls.String)),
+		decls.NewFunction("semverCompare",
+			decls.NewOverload("semverCompare",
+				[]*exprpb.Type{decls.String, decls.String}, decls.Int)),
+		decls.NewFunction("ipInCidrs",
+			decls.NewOverload("ipInCidrs",
+				[]*exprpb.Type{decls.NewObjectType(ipAddressType), decls.String}, decls.Bool)),
 	}
 
 	standardOverloads = celgo.Functions([]*functions.Overload{
@@ -135,5 +147,51 @@ var (
 				}
 				return emptyStringMap
 			}},
+		{Operator: "jsonPath",
+			Binary: stringFunction(func(doc, path string) ref.Val {
+				out, err := lang.ExternJSONPath(doc, path)
+				if err != nil {
+					return types.NewErr(err.Error())
+				}
+				return types.String(out)
+			})},
+		{Operator: "queryParam",
+			Binary: stringFunction(func(uri, name string) ref.Val {
+				out, err := lang.ExternQueryParam(uri, name)
+				if err != nil {
+					return types.NewErr(err.Error())
+				}
+				return types.String(out)
+			})},
+		{Operator: "semverCompare",
+			Binary: stringFunction(func(v1, v2 string) ref.Val {
+				out, err := lang.ExternSemverCompare(v1, v2)
+				if err != nil {
+					return types.NewErr(err.Error())
+				}
+				return types.Int(out)
+			})},
+		{Operator: "ipInCidrs",
+			Binary: func(lhs ref.Val, rhs ref.Val) ref.Val {
+				ip, ok := lhs.(wrapperValue)
+				if !ok || ip.typ != v1beta1.IP_ADDRESS || rhs.Type() != types.StringType {
+					return types.NewErr("overload cannot be applied to argument types")
+				}
+				out, err := lang.ExternIPInCIDRs(ip.bytes, rhs.Value().(string))
+				if err != nil {
+					return types.NewErr(err.Error())
+				}
+				return types.Bool(out)
+			}},
 	}...)
 )
+
+// stringFunction adapts a function of two strings to a binary overload.
+func stringFunction(fn func(string, string) ref.Val) functions.BinaryOp {
+	return func(lhs ref.Val, rhs ref.Val) ref.Val {
+		if lhs.Type() != types.StringType || rhs.Type() != types.StringType {
+			return types.NewErr("overload cannot be applied to argument types")
+		}
+		return fn(lhs.Value().(string), rhs.Value().(string))
+	}
+}
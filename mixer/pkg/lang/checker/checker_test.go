@@ -60,9 +60,16 @@ func TestTypeCheck(t *testing.T) {
 		{"int == 2", dpb.BOOL, ""},
 		{"double == 2.0", dpb.BOOL, ""},
 		{`string | "foobar"`, dpb.STRING, ""},
+		// functions
+		{`jsonPath(string, "$.user")`, dpb.STRING, ""},
+		{`queryParam(string, "page")`, dpb.STRING, ""},
+		{`semverCompare(string, "1.2.0") >= 0`, dpb.BOOL, ""},
+		{`ipInCidrs(ip, "10.0.0.0/8")`, dpb.BOOL, ""},
 		// invalid expressions
 		{"int | bool", dpb.VALUE_TYPE_UNSPECIFIED, "typeError"},
 		{"stringmap | ", dpb.VALUE_TYPE_UNSPECIFIED, "failed to parse"},
+		{`ipInCidrs(string, "10.0.0.0/8")`, dpb.VALUE_TYPE_UNSPECIFIED, "typeError"},
+		{`semverCompare(string)`, dpb.VALUE_TYPE_UNSPECIFIED, "arity mismatch"},
 	}
 
 	for idx, tt := range tests {
@@ -15,12 +15,15 @@
 package lang
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/mail"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -55,6 +58,10 @@ var Externs = map[string]interpreter.Extern{
 	"emptyStringMap":    interpreter.ExternFromFn("emptyStringMap", externEmptyStringMap),
 	"conditionalString": interpreter.ExternFromFn("conditionalString", externConditionalString),
 	"toLower":           interpreter.ExternFromFn("toLower", ExternToLower),
+	"jsonPath":          interpreter.ExternFromFn("jsonPath", ExternJSONPath),
+	"queryParam":        interpreter.ExternFromFn("queryParam", ExternQueryParam),
+	"semverCompare":     interpreter.ExternFromFn("semverCompare", ExternSemverCompare),
+	"ipInCidrs":         interpreter.ExternFromFn("ipInCidrs", ExternIPInCIDRs),
 }
 
 // ExternFunctionMetadata is the type-metadata about externs. It gets used during compilations.
@@ -125,6 +132,26 @@ var ExternFunctionMetadata = []ast.FunctionMetadata{
 		ReturnType:    config.STRING,
 		ArgumentTypes: []config.ValueType{config.STRING},
 	},
+	{
+		Name:          "jsonPath",
+		ReturnType:    config.STRING,
+		ArgumentTypes: []config.ValueType{config.STRING, config.STRING},
+	},
+	{
+		Name:          "queryParam",
+		ReturnType:    config.STRING,
+		ArgumentTypes: []config.ValueType{config.STRING, config.STRING},
+	},
+	{
+		Name:          "semverCompare",
+		ReturnType:    config.INT64,
+		ArgumentTypes: []config.ValueType{config.STRING, config.STRING},
+	},
+	{
+		Name:          "ipInCidrs",
+		ReturnType:    config.BOOL,
+		ArgumentTypes: []config.ValueType{config.IP_ADDRESS, config.STRING},
+	},
 }
 
 // ExternIP creates an IP address
@@ -379,3 +406,211 @@ func externConditionalString(condition bool, trueStr, falseStr string) string {
 func ExternToLower(str string) string {
 	return strings.ToLower(str)
 }
+
+// ExternJSONPath extracts the value at the path from a JSON document, e.g. `$.user.roles[0]`.
+// Strings are returned as is, and other values in their JSON encoding. The empty string is
+// returned when the path doesn't exist in the document.
+func ExternJSONPath(doc string, path string) (string, error) {
+	d := json.NewDecoder(strings.NewReader(doc))
+	d.UseNumber()
+
+	var value interface{}
+	if err := d.Decode(&value); err != nil {
+		return "", fmt.Errorf("error parsing JSON document '%s': %v", doc, err)
+	}
+
+	p := path
+	if strings.HasPrefix(p, "$") {
+		p = p[1:]
+	} else if p != "" && p[0] != '.' && p[0] != '[' {
+		p = "." + p
+	}
+
+	for p != "" {
+		switch p[0] {
+		case '.':
+			end := strings.IndexAny(p[1:], ".[]") + 1
+			if end == 0 {
+				end = len(p)
+			}
+			if end == 1 {
+				return "", fmt.Errorf("error parsing JSON path '%s': empty field name", path)
+			}
+			obj, ok := value.(map[string]interface{})
+			if !ok {
+				return "", nil
+			}
+			if value, ok = obj[p[1:end]]; !ok {
+				return "", nil
+			}
+			p = p[end:]
+
+		case '[':
+			end := strings.IndexByte(p, ']')
+			if end < 0 {
+				return "", fmt.Errorf("error parsing JSON path '%s': missing ']'", path)
+			}
+			index, err := strconv.Atoi(p[1:end])
+			if err != nil {
+				return "", fmt.Errorf("error parsing JSON path '%s': invalid index '%s'", path, p[1:end])
+			}
+			arr, ok := value.([]interface{})
+			if !ok || index < 0 || index >= len(arr) {
+				return "", nil
+			}
+			value = arr[index]
+			p = p[end+1:]
+
+		default:
+			return "", fmt.Errorf("error parsing JSON path '%s': unexpected '%c'", path, p[0])
+		}
+	}
+
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	var buf bytes.Buffer
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// ExternQueryParam returns the first value of the query parameter of a URI or a request path.
+// The empty string is returned when the parameter is absent.
+func ExternQueryParam(uri string, name string) (string, error) {
+	idx := strings.IndexByte(uri, '?')
+	if idx < 0 {
+		return "", nil
+	}
+	query := uri[idx+1:]
+	if idx = strings.IndexByte(query, '#'); idx >= 0 {
+		query = query[:idx]
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return "", fmt.Errorf("error parsing query of '%s': %v", uri, err)
+	}
+	return values.Get(name), nil
+}
+
+// ExternSemverCompare compares two semantic versions, e.g. `v1.2.3-beta.1`. It returns -1, 0 or 1
+// when the first version is respectively lower than, equal to or greater than the second one.
+// Missing minor or patch numbers are considered as 0, and the build metadata is ignored.
+func ExternSemverCompare(v1 string, v2 string) (int64, error) {
+	s1, err := parseSemver(v1)
+	if err != nil {
+		return 0, err
+	}
+	s2, err := parseSemver(v2)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range s1.version {
+		if c := compareUint(s1.version[i], s2.version[i]); c != 0 {
+			return c, nil
+		}
+	}
+
+	// a version without pre-release has a higher precedence.
+	switch {
+	case len(s1.prerelease) == 0 && len(s2.prerelease) == 0:
+		return 0, nil
+	case len(s1.prerelease) == 0:
+		return 1, nil
+	case len(s2.prerelease) == 0:
+		return -1, nil
+	}
+
+	for i := 0; i < len(s1.prerelease) && i < len(s2.prerelease); i++ {
+		if c := comparePrerelease(s1.prerelease[i], s2.prerelease[i]); c != 0 {
+			return c, nil
+		}
+	}
+	return compareUint(uint64(len(s1.prerelease)), uint64(len(s2.prerelease))), nil
+}
+
+type semver struct {
+	version    [3]uint64
+	prerelease []string
+}
+
+func parseSemver(v string) (semver, error) {
+	var s semver
+
+	in := strings.TrimPrefix(v, "v")
+	if idx := strings.IndexByte(in, '+'); idx >= 0 {
+		in = in[:idx]
+	}
+	if idx := strings.IndexByte(in, '-'); idx >= 0 {
+		for _, id := range strings.Split(in[idx+1:], ".") {
+			if id == "" {
+				return s, fmt.Errorf("error converting '%s' to semantic version: empty pre-release identifier", v)
+			}
+			s.prerelease = append(s.prerelease, id)
+		}
+		in = in[:idx]
+	}
+
+	parts := strings.Split(in, ".")
+	if len(parts) > len(s.version) {
+		return s, fmt.Errorf("error converting '%s' to semantic version: too many version numbers", v)
+	}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return s, fmt.Errorf("error converting '%s' to semantic version: invalid version number '%s'", v, part)
+		}
+		s.version[i] = n
+	}
+	return s, nil
+}
+
+// comparePrerelease compares pre-release identifiers. Numeric identifiers are compared numerically,
+// and have a lower precedence than alphanumeric identifiers.
+func comparePrerelease(id1, id2 string) int64 {
+	n1, err1 := strconv.ParseUint(id1, 10, 64)
+	n2, err2 := strconv.ParseUint(id2, 10, 64)
+	switch {
+	case err1 == nil && err2 == nil:
+		return compareUint(n1, n2)
+	case err1 == nil:
+		return -1
+	case err2 == nil:
+		return 1
+	default:
+		return int64(strings.Compare(id1, id2))
+	}
+}
+
+func compareUint(a, b uint64) int64 {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ExternIPInCIDRs checks whether an IP address is contained in one of the comma separated CIDR blocks.
+func ExternIPInCIDRs(ip []byte, cidrs string) (bool, error) {
+	found := false
+	for _, cidr := range strings.Split(cidrs, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return false, fmt.Errorf("error converting '%s' to CIDR: %v", cidr, err)
+		}
+		found = found || network.Contains(net.IP(ip))
+	}
+	return found, nil
+}
@@ -494,3 +494,141 @@ func TestExternConditionalString(t *testing.T) {
 		t.Errorf("externIfElse(true, \"yes\", \"no\") => %s, wanted: yes", got)
 	}
 }
+
+func TestExternJSONPath(t *testing.T) {
+	doc := `{"user": {"id": "alice", "age": 42, "admin": true, "roles": ["dev", "ops"], "tags": {"a<b": null}}}`
+
+	var cases = []struct {
+		p string
+		e string
+	}{
+		{"$.user.id", "alice"},
+		{"user.id", "alice"},
+		{".user.id", "alice"},
+		{"$.user.age", "42"},
+		{"$.user.admin", "true"},
+		{"$.user.roles[1]", "ops"},
+		{"$.user.roles", `["dev","ops"]`},
+		{"$.user.tags", `{"a<b":null}`},
+		{"$.user.roles[2]", ""},
+		{"$.user.id[0]", ""},
+		{"$.user.name", ""},
+		{"$.user.id.first", ""},
+	}
+
+	for _, c := range cases {
+		if v, err := ExternJSONPath(doc, c.p); err != nil {
+			t.Errorf("Unexpected error: %+v, %v", c, err)
+		} else if v != c.e {
+			t.Errorf("jsonPath failure: %+v, got %s", c, v)
+		}
+	}
+}
+
+func TestExternJSONPath_Error(t *testing.T) {
+	erroneous := map[string]string{
+		"":                 "$.a",
+		"{":                "$.a",
+		`{"a": [1]}`:       "$.a[x]",
+		`{"a": [1, 2]}`:    "$.a[0",
+		`{"a": {"b": 1}}`:  "$a",
+		`{"a": {"b": 12}}`: "$.a]",
+	}
+
+	for doc, path := range erroneous {
+		if _, err := ExternJSONPath(doc, path); err == nil {
+			t.Errorf("Expected error not found for: %s in %s", path, doc)
+		}
+	}
+}
+
+func TestExternQueryParam(t *testing.T) {
+	var cases = []struct {
+		u string
+		n string
+		e string
+	}{
+		{"/books?author=dumas&title=les%20trois%20mousquetaires", "title", "les trois mousquetaires"},
+		{"/books?author=dumas&author=hugo", "author", "dumas"},
+		{"https://example.com/books?author=dumas#top", "author", "dumas"},
+		{"/books?author=dumas", "title", ""},
+		{"/books", "author", ""},
+		{"?flag", "flag", ""},
+	}
+
+	for _, c := range cases {
+		if v, err := ExternQueryParam(c.u, c.n); err != nil {
+			t.Errorf("Unexpected error: %+v, %v", c, err)
+		} else if v != c.e {
+			t.Errorf("queryParam failure: %+v, got %s", c, v)
+		}
+	}
+
+	if _, err := ExternQueryParam("/books?author=%zz", "author"); err == nil {
+		t.Error("Expected error not found for a malformed query")
+	}
+}
+
+func TestExternSemverCompare(t *testing.T) {
+	var cases = []struct {
+		v1 string
+		v2 string
+		e  int64
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.2.3+build.1", "1.2.3+build.2", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-rc.1", "1.0.0-beta.11", 1},
+	}
+
+	for _, c := range cases {
+		if v, err := ExternSemverCompare(c.v1, c.v2); err != nil {
+			t.Errorf("Unexpected error: %+v, %v", c, err)
+		} else if v != c.e {
+			t.Errorf("semverCompare failure: %+v, got %d", c, v)
+		}
+		if v, _ := ExternSemverCompare(c.v2, c.v1); v != -c.e {
+			t.Errorf("semverCompare failure: %+v reversed, got %d", c, v)
+		}
+	}
+
+	for _, v := range []string{"", "1.2.3.4", "1.a.3", "1.2.3-", "1.2.3-a..b", "-1.2.3"} {
+		if _, err := ExternSemverCompare(v, "1.0.0"); err == nil {
+			t.Errorf("Expected error not found for: %s", v)
+		}
+	}
+}
+
+func TestExternIPInCIDRs(t *testing.T) {
+	var cases = []struct {
+		ip    string
+		cidrs string
+		e     bool
+	}{
+		{"10.1.2.3", "10.0.0.0/8", true},
+		{"10.1.2.3", "192.168.0.0/16, 10.0.0.0/8", true},
+		{"172.32.0.1", "192.168.0.0/16,172.16.0.0/12", false},
+		{"2001:db8::1", "10.0.0.0/8,2001:db8::/32", true},
+		{"10.1.2.3", "", false},
+	}
+
+	for _, c := range cases {
+		if v, err := ExternIPInCIDRs(net.ParseIP(c.ip), c.cidrs); err != nil {
+			t.Errorf("Unexpected error: %+v, %v", c, err)
+		} else if v != c.e {
+			t.Errorf("ipInCidrs failure: %+v", c)
+		}
+	}
+
+	if _, err := ExternIPInCIDRs(net.ParseIP("10.1.2.3"), "10.0.0.0/8,10.0.0.0"); err == nil {
+		t.Error("Expected error not found for a malformed CIDR")
+	}
+}
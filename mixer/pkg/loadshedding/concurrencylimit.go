@@ -0,0 +1,202 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadshedding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/stats"
+)
+
+const (
+	// ConcurrencyLimitEvaluatorName is the canonical name of the ConcurrencyLimitEvaluator.
+	ConcurrencyLimitEvaluatorName = "ConcurrencyLimit"
+
+	// DefaultInitialConcurrencyLimit is the concurrency limit before any latency is observed.
+	DefaultInitialConcurrencyLimit = 100
+	// DefaultMinConcurrencyLimit is the lowest concurrency limit.
+	DefaultMinConcurrencyLimit = 10
+	// DefaultConcurrencyLimitWindow is the period over which response latencies are averaged to update the limit.
+	DefaultConcurrencyLimitWindow = 1 * time.Second
+
+	// minimum number of responses in a window to update the limit
+	minWindowSamples = 10
+
+	// number of windows over which the latency without load is averaged
+	noLoadWindows = 60
+
+	// ratio of the latency to the latency without load tolerated before the limit decreases
+	latencyTolerance = 1.5
+
+	// fraction of the computed limit applied at each update
+	limitSmoothing = 0.2
+)
+
+var (
+	_ LoadEvaluator = &ConcurrencyLimitEvaluator{}
+)
+
+// ConcurrencyLimitEvaluator limits the number of requests processed concurrently. The limit is
+// derived from the response latencies, using a gradient between the latency without load and
+// the current latency: the limit grows while the latency stays close to the latency without
+// load, and shrinks as requests start queuing and the latency increases.
+//
+// Unlike other evaluators, the requests must be admitted with Acquire and completed with
+// Release, so that the requests in flight and their latencies are tracked.
+type ConcurrencyLimitEvaluator struct {
+	minLimit float64
+	maxLimit float64
+	window   time.Duration
+
+	inFlight int64
+	limit    int64
+
+	mu sync.Mutex
+	// the precise value of limit
+	exactLimit float64
+	// latencies observed over the current window
+	windowStart       time.Time
+	windowLatency     time.Duration
+	windowSamples     int
+	windowMaxInFlight int64
+	// average latency without load, nil until the first window is complete
+	noLoadLatency *exponentialMovingAverage
+}
+
+// NewConcurrencyLimitEvaluator builds a new ConcurrencyLimitEvaluator, with the limit initialized to initialLimit,
+// and kept between minLimit and maxLimit.
+func NewConcurrencyLimitEvaluator(initialLimit, minLimit, maxLimit int, window time.Duration) *ConcurrencyLimitEvaluator {
+	if minLimit == 0 {
+		minLimit = DefaultMinConcurrencyLimit
+	}
+	if minLimit > maxLimit {
+		minLimit = maxLimit
+	}
+	if initialLimit == 0 {
+		initialLimit = DefaultInitialConcurrencyLimit
+	}
+	if window == 0 {
+		window = DefaultConcurrencyLimitWindow
+	}
+
+	c := &ConcurrencyLimitEvaluator{
+		minLimit:    float64(minLimit),
+		maxLimit:    float64(maxLimit),
+		window:      window,
+		windowStart: time.Now(),
+	}
+	c.setLimit(float64(initialLimit))
+	return c
+}
+
+// Name implements the LoadEvaluator interface.
+func (c *ConcurrencyLimitEvaluator) Name() string {
+	return ConcurrencyLimitEvaluatorName
+}
+
+// EvaluateAgainst implements the LoadEvaluator interface. It determines whether one more request can be processed
+// within the current limit, the threshold is ignored.
+func (c *ConcurrencyLimitEvaluator) EvaluateAgainst(ri RequestInfo, threshold float64) LoadEvaluation {
+	return c.evaluate(atomic.LoadInt64(&c.inFlight) + 1)
+}
+
+// Acquire admits a request, and returns whether it exceeds the limit. The request is counted as in flight
+// until Release is called, even if it exceeds the limit.
+func (c *ConcurrencyLimitEvaluator) Acquire() LoadEvaluation {
+	return c.evaluate(atomic.AddInt64(&c.inFlight, 1))
+}
+
+// Release completes a request admitted by Acquire. The latency of requests processed is used to update the limit,
+// it must be 0 for requests that were dropped.
+func (c *ConcurrencyLimitEvaluator) Release(latency time.Duration) {
+	inFlight := atomic.AddInt64(&c.inFlight, -1) + 1
+	if latency > 0 {
+		c.addSample(latency, inFlight, time.Now())
+	}
+}
+
+// Limit returns the current concurrency limit.
+func (c *ConcurrencyLimitEvaluator) Limit() int {
+	return int(atomic.LoadInt64(&c.limit))
+}
+
+func (c *ConcurrencyLimitEvaluator) evaluate(inFlight int64) LoadEvaluation {
+	limit := atomic.LoadInt64(&c.limit)
+	if inFlight <= limit {
+		return LoadEvaluation{Status: BelowThreshold}
+	}
+	return LoadEvaluation{
+		Status: ExceedsThreshold,
+		Message: fmt.Sprintf(
+			"Too many requests are being processed by this server (limit: %d). Please retry request later.", limit),
+	}
+}
+
+func (c *ConcurrencyLimitEvaluator) addSample(latency time.Duration, inFlight int64, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.windowLatency += latency
+	c.windowSamples++
+	if inFlight > c.windowMaxInFlight {
+		c.windowMaxInFlight = inFlight
+	}
+
+	if now.Sub(c.windowStart) < c.window || c.windowSamples < minWindowSamples {
+		return
+	}
+
+	latencySeconds := c.windowLatency.Seconds() / float64(c.windowSamples)
+	maxInFlight := c.windowMaxInFlight
+
+	c.windowStart = now
+	c.windowLatency = 0
+	c.windowSamples = 0
+	c.windowMaxInFlight = 0
+
+	if c.noLoadLatency == nil {
+		c.noLoadLatency = newExponentialMovingAverage(noLoadWindows*c.window, latencySeconds, now)
+	} else {
+		c.noLoadLatency.addSample(latencySeconds, now)
+	}
+	noLoad := c.noLoadLatency.currentValue(now)
+
+	// the latency without load increased over a long period of overload, it is brought back
+	// to the current latency as soon as the load is back to normal.
+	if noLoad > 2*latencySeconds {
+		c.noLoadLatency = newExponentialMovingAverage(noLoadWindows*c.window, latencySeconds, now)
+		noLoad = latencySeconds
+	}
+
+	// the server isn't using much of its limit, the latency says nothing about the limit.
+	if float64(maxInFlight)*2 < c.exactLimit {
+		return
+	}
+
+	gradient := math.Max(0.5, math.Min(1.0, latencyTolerance*noLoad/latencySeconds))
+	newLimit := c.exactLimit*gradient + math.Sqrt(c.exactLimit)
+	c.setLimit(c.exactLimit*(1-limitSmoothing) + newLimit*limitSmoothing)
+}
+
+func (c *ConcurrencyLimitEvaluator) setLimit(limit float64) {
+	c.exactLimit = math.Max(c.minLimit, math.Min(c.maxLimit, limit))
+	atomic.StoreInt64(&c.limit, int64(c.exactLimit))
+	stats.Record(context.Background(), concurrencyLimit.M(int64(c.exactLimit)))
+}
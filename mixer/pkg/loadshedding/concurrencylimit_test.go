@@ -0,0 +1,127 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadshedding
+
+import (
+	"testing"
+	"time"
+)
+
+// runWindows feeds windows of samples with the given latency and number of requests in flight to the evaluator.
+func runWindows(c *ConcurrencyLimitEvaluator, now time.Time, windows int, latency time.Duration, inFlight int64) time.Time {
+	for w := 0; w < windows; w++ {
+		for i := 0; i < minWindowSamples; i++ {
+			c.addSample(latency, inFlight, now)
+		}
+		now = now.Add(c.window)
+	}
+	return now
+}
+
+func TestConcurrencyLimitDefaults(t *testing.T) {
+	c := NewConcurrencyLimitEvaluator(0, 0, 1000, 0)
+	if c.Limit() != DefaultInitialConcurrencyLimit || c.minLimit != DefaultMinConcurrencyLimit || c.window != DefaultConcurrencyLimitWindow {
+		t.Errorf("Got limit %d, min %v and window %v, expecting the defaults", c.Limit(), c.minLimit, c.window)
+	}
+
+	c = NewConcurrencyLimitEvaluator(0, 0, 5, 0)
+	if c.Limit() != 5 || c.minLimit != 5 {
+		t.Errorf("Got limit %d and min %v, expecting 5", c.Limit(), c.minLimit)
+	}
+}
+
+func TestConcurrencyLimitAdapts(t *testing.T) {
+	c := NewConcurrencyLimitEvaluator(20, 10, 200, time.Second)
+	now := c.windowStart.Add(time.Second)
+
+	// the limit grows while the latency is stable and the limit is used.
+	now = runWindows(c, now, 20, 10*time.Millisecond, 200)
+	grown := c.Limit()
+	if grown <= 20 {
+		t.Fatalf("Got limit %d, expecting it to grow from 20", grown)
+	}
+
+	// the limit doesn't grow when the server isn't busy.
+	now = runWindows(c, now, 20, 10*time.Millisecond, 1)
+	if c.Limit() != grown {
+		t.Errorf("Got limit %d, expecting it to stay at %d", c.Limit(), grown)
+	}
+
+	// the limit shrinks as the latency increases.
+	now = runWindows(c, now, 20, 100*time.Millisecond, 200)
+	shrunk := c.Limit()
+	if shrunk >= grown {
+		t.Errorf("Got limit %d, expecting it to shrink from %d", shrunk, grown)
+	}
+
+	// the limit never goes below the minimum.
+	now = runWindows(c, now, 30, 10*time.Second, 200)
+	if c.Limit() != 10 {
+		t.Errorf("Got limit %d, expecting the minimum of 10", c.Limit())
+	}
+
+	// the limit grows back once the latency is back to normal, up to the maximum.
+	runWindows(c, now, 200, 10*time.Millisecond, 200)
+	if c.Limit() != 200 {
+		t.Errorf("Got limit %d, expecting the maximum of 200", c.Limit())
+	}
+}
+
+func TestConcurrencyLimitWindow(t *testing.T) {
+	c := NewConcurrencyLimitEvaluator(20, 10, 200, time.Second)
+	now := c.windowStart
+
+	// not enough samples.
+	for i := 0; i < minWindowSamples-1; i++ {
+		c.addSample(time.Millisecond, 20, now.Add(2*time.Second))
+	}
+	if c.noLoadLatency != nil {
+		t.Error("Got the window completed, expecting more samples to be required")
+	}
+
+	// not enough time elapsed.
+	c.addSample(time.Millisecond, 20, now.Add(500*time.Millisecond))
+	if c.noLoadLatency != nil {
+		t.Error("Got the window completed, expecting it to last a second")
+	}
+
+	c.addSample(time.Millisecond, 20, now.Add(time.Second))
+	if c.noLoadLatency == nil || c.windowSamples != 0 {
+		t.Error("Got the window in progress, expecting it to be completed")
+	}
+}
+
+func TestConcurrencyLimitAcquire(t *testing.T) {
+	c := NewConcurrencyLimitEvaluator(2, 1, 10, time.Second)
+
+	for i := 0; i < 2; i++ {
+		if eval := c.Acquire(); ThresholdExceeded(eval) {
+			t.Fatalf("Got %v for request %d, expecting it to be admitted", eval, i)
+		}
+	}
+
+	if eval := c.EvaluateAgainst(RequestInfo{PredictedCost: 1}, 0); !ThresholdExceeded(eval) {
+		t.Errorf("Got %v, expecting the limit to be exceeded", eval)
+	}
+	if eval := c.Acquire(); !ThresholdExceeded(eval) || eval.Message == "" {
+		t.Errorf("Got %v, expecting the limit to be exceeded", eval)
+	}
+	c.Release(0)
+
+	c.Release(time.Millisecond)
+	if eval := c.Acquire(); ThresholdExceeded(eval) {
+		t.Errorf("Got %v, expecting the request to be admitted after a release", eval)
+	}
+}
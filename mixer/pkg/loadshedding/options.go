@@ -57,6 +57,26 @@ type Options struct {
 	// configured maximum for a period of time. This allows for handling bursty
 	// traffic patterns. If this is set to 0, no traffic will be allowed.
 	BurstSize int
+
+	// Options for the adaptive concurrency limit evaluator
+
+	// MaxConcurrencyLimit is the upper bound of the number of requests processed
+	// concurrently, over which the server will start rejecting requests (Unavailable).
+	// The actual limit is adjusted between MinConcurrencyLimit and MaxConcurrencyLimit
+	// based on the observed response latencies. Providing a value for
+	// MaxConcurrencyLimit will enable the concurrency limit evaluator.
+	MaxConcurrencyLimit int
+
+	// MinConcurrencyLimit is the lower bound of the concurrency limit.
+	MinConcurrencyLimit int
+
+	// InitialConcurrencyLimit is the concurrency limit used until response
+	// latencies are observed.
+	InitialConcurrencyLimit int
+
+	// ConcurrencyLimitWindow controls the period over which response latencies
+	// are averaged to adjust the concurrency limit.
+	ConcurrencyLimitWindow time.Duration
 }
 
 // DefaultOptions returns a new set of options, initialized to the defaults
@@ -94,6 +114,21 @@ func (o *Options) AttachCobraFlags(cmd *cobra.Command) {
 
 	cmd.PersistentFlags().IntVarP(&o.BurstSize, "burstSize", "", 0,
 		"Number of requests that are permitted beyond the configured maximum for a period of time. Only valid when used with 'maxRequestsPerSecond'.")
+
+	cmd.PersistentFlags().IntVarP(&o.MaxConcurrencyLimit, "maxConcurrencyLimit", "", 0,
+		"Maximum number of requests processed concurrently by the server. When set, the server adapts the number of "+
+			"concurrent requests it accepts to the observed response times, and drops the requests above that limit.")
+
+	cmd.PersistentFlags().IntVarP(&o.MinConcurrencyLimit, "minConcurrencyLimit", "", 0,
+		"Minimum number of requests processed concurrently by the server, 10 if 0. Only valid when used with 'maxConcurrencyLimit'.")
+
+	cmd.PersistentFlags().IntVarP(&o.InitialConcurrencyLimit, "initialConcurrencyLimit", "", 0,
+		"Number of requests processed concurrently by the server until response times are observed, 100 if 0. "+
+			"Only valid when used with 'maxConcurrencyLimit'.")
+
+	cmd.PersistentFlags().DurationVarP(&o.ConcurrencyLimitWindow, "concurrencyLimitWindow", "", 0,
+		"Period over which response times are averaged to adjust the concurrency limit, 1s if 0. "+
+			"Only valid when used with 'maxConcurrencyLimit'.")
 }
 
 type modeValue ThrottlerMode
@@ -63,6 +63,15 @@ func TestOpts(t *testing.T) {
 			SamplesPerSecond: loadshedding.DefaultSampleFrequency,
 			SampleHalfLife:   loadshedding.DefaultHalfLife,
 		}},
+
+		{"--maxConcurrencyLimit 500 --minConcurrencyLimit 5 --initialConcurrencyLimit 50 --concurrencyLimitWindow 2s", loadshedding.Options{
+			MaxConcurrencyLimit:     500,
+			MinConcurrencyLimit:     5,
+			InitialConcurrencyLimit: 50,
+			ConcurrencyLimitWindow:  2 * time.Second,
+			SamplesPerSecond:        loadshedding.DefaultSampleFrequency,
+			SampleHalfLife:          loadshedding.DefaultHalfLife,
+		}},
 	}
 
 	for _, c := range cases {
@@ -17,9 +17,12 @@ package loadshedding
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	"istio.io/pkg/log"
 )
@@ -81,10 +84,21 @@ var (
 		Measure:     throttled,
 		Aggregation: view.Count(),
 	}
+
+	concurrencyLimit = stats.Int64(
+		"loadshedding/concurrency_limit",
+		"The number of requests the server processes concurrently before dropping requests.",
+		stats.UnitDimensionless)
+
+	concurrencyLimitView = &view.View{
+		Name:        "mixer/" + concurrencyLimit.Name(),
+		Measure:     concurrencyLimit,
+		Aggregation: view.LastValue(),
+	}
 )
 
 func init() {
-	if err := view.Register(throttledView); err != nil {
+	if err := view.Register(throttledView, concurrencyLimitView); err != nil {
 		panic(err)
 	}
 }
@@ -115,6 +129,13 @@ func NewThrottler(opts Options) *Throttler {
 		t.thresholds[e.Name()] = float64(opts.MaxRequestsPerSecond)
 	}
 
+	// the concurrency limit has no threshold, as it is enforced by the UnaryServerInterceptor.
+	if opts.MaxConcurrencyLimit > 0 {
+		e := NewConcurrencyLimitEvaluator(opts.InitialConcurrencyLimit, opts.MinConcurrencyLimit,
+			opts.MaxConcurrencyLimit, opts.ConcurrencyLimitWindow)
+		t.evaluators[e.Name()] = e
+	}
+
 	scope.Debugf("Built Throttler(%#v) from opts(%#v)", t, opts)
 	return t
 }
@@ -138,17 +159,46 @@ func (t *Throttler) Throttle(ri RequestInfo) bool {
 			continue
 		}
 		scope.Debugf("Evaluating load with %s against threshold %f", e.Name(), thres)
-		eval := e.EvaluateAgainst(ri, thres)
-		if ThresholdExceeded(eval) {
-			msg := fmt.Sprintf("Throttled (%s): '%s'", e.Name(), eval.Message)
-			if t.mode == LogOnly {
-				scope.Infoa("LogOnly - ", msg)
-				continue
-			}
-			stats.Record(context.Background(), throttled.M(1))
-			scope.Warn(msg)
+		if t.shed(e, e.EvaluateAgainst(ri, thres)) {
 			return true
 		}
 	}
 	return false
 }
+
+// UnaryServerInterceptor returns a gRPC interceptor dropping the requests exceeding the adaptive concurrency
+// limit, before they are processed. A nil value is returned if the concurrency limit is not enabled.
+func (t *Throttler) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	e, ok := t.evaluators[ConcurrencyLimitEvaluatorName].(*ConcurrencyLimitEvaluator)
+	if !ok {
+		return nil
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if t.shed(e, e.Acquire()) {
+			e.Release(0)
+			return nil, grpc.Errorf(codes.Unavailable, "Server is currently overloaded. Please try again.")
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		e.Release(time.Since(start))
+		return resp, err
+	}
+}
+
+// shed returns whether the request should be dropped based on the evaluation, according to the mode.
+func (t *Throttler) shed(e LoadEvaluator, eval LoadEvaluation) bool {
+	if !ThresholdExceeded(eval) {
+		return false
+	}
+
+	msg := fmt.Sprintf("Throttled (%s): '%s'", e.Name(), eval.Message)
+	if t.mode == LogOnly {
+		scope.Infoa("LogOnly - ", msg)
+		return false
+	}
+	stats.Record(context.Background(), throttled.M(1))
+	scope.Warn(msg)
+	return true
+}
@@ -15,11 +15,15 @@
 package loadshedding_test
 
 import (
+	"context"
 	"reflect"
 	"testing"
 	"time"
 
 	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"istio.io/istio/mixer/pkg/loadshedding"
 )
@@ -62,6 +66,12 @@ var (
 	}
 )
 
+var concurrencyLimitOpts = loadshedding.Options{
+	Mode:                    loadshedding.Enforce,
+	MaxConcurrencyLimit:     100,
+	InitialConcurrencyLimit: 50,
+}
+
 type evalComparisonFn func(got loadshedding.LoadEvaluator) bool
 type evalMap map[string]evalComparisonFn
 
@@ -76,6 +86,11 @@ func TestNewThrottler(t *testing.T) {
 		return ok
 	}
 
+	concurrencyEvalFn := func(got loadshedding.LoadEvaluator) bool {
+		c, ok := got.(*loadshedding.ConcurrencyLimitEvaluator)
+		return ok && c.Limit() == 50
+	}
+
 	cases := []struct {
 		name       string
 		opts       loadshedding.Options
@@ -86,6 +101,7 @@ func TestNewThrottler(t *testing.T) {
 		{"latency", grpcLatencyOpts, evalMap{loadshedding.GRPCLatencyEvaluatorName: latencyEvalFn}},
 		{"hybrid", hybridOpts, evalMap{loadshedding.RateLimitEvaluatorName: rateLimitEvalFn, loadshedding.GRPCLatencyEvaluatorName: latencyEvalFn}},
 		{"disabled mode", disabledOpts, evalMap{}},
+		{"concurrency limit", concurrencyLimitOpts, evalMap{loadshedding.ConcurrencyLimitEvaluatorName: concurrencyEvalFn}},
 	}
 
 	for _, v := range cases {
@@ -128,3 +144,60 @@ func TestThrottle(t *testing.T) {
 	}
 
 }
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	if i := loadshedding.NewThrottler(rateLimitOpts).UnaryServerInterceptor(); i != nil {
+		t.Error("Got an interceptor, expecting none without a concurrency limit")
+	}
+
+	cases := []struct {
+		name     string
+		mode     loadshedding.ThrottlerMode
+		wantCode codes.Code
+	}{
+		{"enforce", loadshedding.Enforce, codes.Unavailable},
+		{"log-only", loadshedding.LogOnly, codes.OK},
+	}
+
+	for _, v := range cases {
+		t.Run(v.name, func(tt *testing.T) {
+			thr := loadshedding.NewThrottler(loadshedding.Options{
+				Mode:                    v.mode,
+				MaxConcurrencyLimit:     1,
+				MinConcurrencyLimit:     1,
+				InitialConcurrencyLimit: 1,
+			})
+			interceptor := thr.UnaryServerInterceptor()
+
+			release := make(chan struct{})
+			blocked := make(chan struct{})
+			done := make(chan struct{})
+			go func() {
+				_, _ = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{},
+					func(ctx context.Context, req interface{}) (interface{}, error) {
+						close(blocked)
+						<-release
+						return nil, nil
+					})
+				close(done)
+			}()
+			<-blocked
+
+			// the limit is reached while the first request is processed.
+			_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{},
+				func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil })
+			if got := status.Code(err); got != v.wantCode {
+				tt.Errorf("Got code %v, expecting %v", got, v.wantCode)
+			}
+
+			close(release)
+			<-done
+
+			resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{},
+				func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil })
+			if err != nil || resp != "ok" {
+				tt.Errorf("Got %v, %v, expecting the request to be processed", resp, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/gogo/protobuf/proto"
+
+	tpb "istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/runtime/config"
+	"istio.io/istio/mixer/pkg/runtime/handler"
+)
+
+// checkConfigChanged returns true if the outcome of checks may differ between the old and the new configuration.
+// Only the rules dispatching to check and attribute generation templates, or carrying route directives, are compared,
+// along with the configuration of the handlers they use and of the instances of these handlers.
+func checkConfigChanged(oldSnapshot *config.Snapshot, oldHandlers *handler.Table,
+	newSnapshot *config.Snapshot, newHandlers *handler.Table) bool {
+
+	oldRules, oldOK := checkRules(oldSnapshot, oldHandlers)
+	newRules, newOK := checkRules(newSnapshot, newHandlers)
+	if !oldOK || !newOK {
+		return true
+	}
+
+	return !reflect.DeepEqual(oldRules, newRules)
+}
+
+// checkRules returns a description of the rules that affect checks, by rule name. It returns false if the
+// configuration of some handlers could not be described.
+func checkRules(snapshot *config.Snapshot, handlers *handler.Table) (map[string]string, bool) {
+	rules := make(map[string]string)
+
+	for _, rule := range snapshot.Rules {
+		var buf bytes.Buffer
+		affectsChecks := len(rule.RequestHeaderOperations) > 0 || len(rule.ResponseHeaderOperations) > 0
+
+		fmt.Fprintf(&buf, "match=%s language=%d nocache=%t\n", rule.Match, rule.Language, rule.DisableCheckCache)
+		for _, op := range rule.RequestHeaderOperations {
+			fmt.Fprintf(&buf, "request=%s\n", proto.CompactTextString(op))
+		}
+		for _, op := range rule.ResponseHeaderOperations {
+			fmt.Fprintf(&buf, "response=%s\n", proto.CompactTextString(op))
+		}
+
+		for _, action := range rule.ActionsStatic {
+			fmt.Fprintf(&buf, "action=%s handler=%s\n", action.Name, action.Handler.Name)
+			for _, instance := range action.Instances {
+				affectsChecks = affectsChecks || affectsCheck(instance.Template.Variety)
+				fmt.Fprintf(&buf, "instance=%s\n", instance.Name)
+			}
+			if !writeSignature(&buf, handlers, action.Handler.Name) {
+				return nil, false
+			}
+		}
+
+		for _, action := range rule.ActionsDynamic {
+			fmt.Fprintf(&buf, "action=%s handler=%s\n", action.Name, action.Handler.Name)
+			for _, instance := range action.Instances {
+				affectsChecks = affectsChecks || affectsCheck(instance.Template.Variety)
+				fmt.Fprintf(&buf, "instance=%s\n", instance.Name)
+			}
+			if !writeSignature(&buf, handlers, action.Handler.Name) {
+				return nil, false
+			}
+		}
+
+		if affectsChecks {
+			rules[rule.Name] = buf.String()
+		}
+	}
+
+	return rules, true
+}
+
+func affectsCheck(variety tpb.TemplateVariety) bool {
+	return variety == tpb.TEMPLATE_VARIETY_CHECK ||
+		variety == tpb.TEMPLATE_VARIETY_CHECK_WITH_OUTPUT ||
+		variety == tpb.TEMPLATE_VARIETY_ATTRIBUTE_GENERATOR
+}
+
+// writeSignature writes the signature of the configuration of the handler and its instances. A handler that
+// could not be built is skipped by the routing, which is recorded as well.
+func writeSignature(buf *bytes.Buffer, handlers *handler.Table, name string) bool {
+	e, found := handlers.Get(name)
+	if !found {
+		buf.WriteString("signature=none\n")
+		return true
+	}
+	if e.Signature == (handler.Entry{}).Signature {
+		return false
+	}
+	fmt.Fprintf(buf, "signature=%x\n", e.Signature)
+	return true
+}
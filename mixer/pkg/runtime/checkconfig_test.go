@@ -0,0 +1,94 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"testing"
+
+	"istio.io/istio/mixer/pkg/runtime/config"
+	"istio.io/istio/mixer/pkg/runtime/handler"
+	"istio.io/istio/mixer/pkg/runtime/testing/data"
+	"istio.io/pkg/pool"
+)
+
+func TestCheckConfigChanged(t *testing.T) {
+	check := []string{data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1}
+	report := []string{data.HandlerAReport1, data.InstanceReport1, data.RuleReport1}
+
+	cases := []struct {
+		name    string
+		oldCfg  []string
+		newCfg  []string
+		changed bool
+	}{
+		{
+			name:   "unchanged",
+			oldCfg: check,
+			newCfg: check,
+		},
+		{
+			name:   "report rule added",
+			oldCfg: check,
+			newCfg: append(append([]string{}, check...), report...),
+		},
+		{
+			name:    "check rule added",
+			oldCfg:  report,
+			newCfg:  append(append([]string{}, report...), check...),
+			changed: true,
+		},
+		{
+			name:    "check rule removed",
+			oldCfg:  check,
+			newCfg:  nil,
+			changed: true,
+		},
+		{
+			name:    "check instance changed",
+			oldCfg:  check,
+			newCfg:  []string{data.HandlerACheck1, data.InstanceCheck1WithSpec, data.RuleCheck1},
+			changed: true,
+		},
+		{
+			name:    "check rule match changed",
+			oldCfg:  check,
+			newCfg:  []string{data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1WithMatchClause},
+			changed: true,
+		},
+		{
+			name:    "check cache disabled",
+			oldCfg:  check,
+			newCfg:  []string{data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1NoCache},
+			changed: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			templates := data.BuildTemplates(nil)
+			adapters := data.BuildAdapters(nil)
+			gp := pool.NewGoroutinePool(1, false)
+
+			oldSnapshot, _ := config.GetSnapshotForTest(templates, adapters, data.ServiceConfig, data.JoinConfigs(c.oldCfg...))
+			oldHandlers := handler.NewTable(handler.Empty(), oldSnapshot, gp)
+			newSnapshot, _ := config.GetSnapshotForTest(templates, adapters, data.ServiceConfig, data.JoinConfigs(c.newCfg...))
+			newHandlers := handler.NewTable(oldHandlers, newSnapshot, gp)
+
+			if got := checkConfigChanged(oldSnapshot, oldHandlers, newSnapshot, newHandlers); got != c.changed {
+				t.Errorf("got %v, want %v", got, c.changed)
+			}
+		})
+	}
+}
@@ -46,10 +46,17 @@ import (
 	"istio.io/istio/mixer/pkg/runtime/lang"
 	"istio.io/istio/mixer/pkg/runtime/monitoring"
 	"istio.io/istio/mixer/pkg/template"
+	"istio.io/pkg/annotations"
 	"istio.io/pkg/attribute"
 	"istio.io/pkg/log"
 )
 
+// CheckCacheAnnotation on rules opts them out of check caching when set to "false": the results of the checks
+// that the rule applies to are neither cached by Mixer, nor by its clients.
+const CheckCacheAnnotation = "policy.istio.io/checkCache"
+
+var _ = annotations.Register(CheckCacheAnnotation, "Set to false to disable the caching of the checks matching a rule")
+
 // Ephemeral configuration state that gets updated by incoming config change events. By itself, the data contained
 // is not meaningful. BuildSnapshot must be called to create a new snapshot instance, which contains fully resolved
 // config.
@@ -840,6 +847,7 @@ func (e *Ephemeral) processRuleConfigs(
 			RequestHeaderOperations:  cfg.RequestHeaderOperations,
 			ResponseHeaderOperations: cfg.ResponseHeaderOperations,
 			Language:                 mode,
+			DisableCheckCache:        resource.Metadata.Annotations[CheckCacheAnnotation] == "false",
 		}
 
 		rules = append(rules, rule)
@@ -143,6 +143,9 @@ type (
 
 		// Language runtime to use for expressions
 		Language lang.LanguageRuntime
+
+		// DisableCheckCache prevents the results of the checks the rule applies to from being cached
+		DisableCheckCache bool
 	}
 
 	// ActionDynamic configuration. Fully resolved.
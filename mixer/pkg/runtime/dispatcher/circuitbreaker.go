@@ -0,0 +1,233 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	tpb "istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/runtime/monitoring"
+	"istio.io/istio/mixer/pkg/status"
+	"istio.io/pkg/log"
+)
+
+// CircuitBreakerOptions define the set of configuration parameters for controlling the circuit
+// breaking of the handlers.
+//
+// The circuit of a handler opens after FailureThreshold consecutive failed dispatches. While the
+// circuit is open, the dispatches to the handler are not performed, and instead fail or succeed
+// depending on the fail-open setting of the template variety. After OpenDuration, a single probe
+// dispatch is let through (half-open): the circuit closes if it succeeds, and opens again otherwise.
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failed dispatches opening the circuit of a
+	// handler. Circuit breaking is disabled if 0.
+	FailureThreshold int
+
+	// OpenDuration is the time the circuit stays open before a probe dispatch is attempted.
+	OpenDuration time.Duration
+
+	// CheckFailOpen controls whether the checks dispatched to a handler with an open circuit
+	// succeed. They are denied with an Unavailable status otherwise.
+	CheckFailOpen bool
+
+	// QuotaFailOpen controls whether the quota allocations dispatched to a handler with an open
+	// circuit are granted. They are denied with an Unavailable status otherwise.
+	QuotaFailOpen bool
+
+	// ReportFailOpen controls whether the reports dispatched to a handler with an open circuit are
+	// silently dropped. They fail with an error otherwise.
+	ReportFailOpen bool
+}
+
+// DefaultCircuitBreakerOptions returns a new set of options, initialized to the defaults.
+func DefaultCircuitBreakerOptions() CircuitBreakerOptions {
+	return CircuitBreakerOptions{
+		FailureThreshold: 0,
+		OpenDuration:     10 * time.Second,
+		CheckFailOpen:    false,
+		QuotaFailOpen:    true,
+		ReportFailOpen:   true,
+	}
+}
+
+// AttachCobraFlags attaches a set of Cobra flags to the given Cobra command.
+func (o *CircuitBreakerOptions) AttachCobraFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().IntVarP(&o.FailureThreshold, "circuitBreakerFailureThreshold", "", o.FailureThreshold,
+		"Number of consecutive failed dispatches to a handler after which its circuit opens. Circuit breaking is disabled if 0.")
+
+	cmd.PersistentFlags().DurationVarP(&o.OpenDuration, "circuitBreakerOpenDuration", "", o.OpenDuration,
+		"Time a handler circuit stays open before a probe dispatch is attempted.")
+
+	cmd.PersistentFlags().BoolVarP(&o.CheckFailOpen, "circuitBreakerCheckFailOpen", "", o.CheckFailOpen,
+		"Whether the checks dispatched to a handler with an open circuit succeed, instead of being denied.")
+
+	cmd.PersistentFlags().BoolVarP(&o.QuotaFailOpen, "circuitBreakerQuotaFailOpen", "", o.QuotaFailOpen,
+		"Whether the quotas dispatched to a handler with an open circuit are granted, instead of being denied.")
+
+	cmd.PersistentFlags().BoolVarP(&o.ReportFailOpen, "circuitBreakerReportFailOpen", "", o.ReportFailOpen,
+		"Whether the reports dispatched to a handler with an open circuit are dropped silently, instead of failing.")
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker tracks the failures of the dispatches to a handler.
+type circuitBreaker struct {
+	handler string
+
+	mutex    sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+// allow returns true if a dispatch to the handler can be performed. Only one dispatch is let
+// through while the circuit is half-open.
+func (b *circuitBreaker) allow(now time.Time, openDuration time.Duration) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if now.Sub(b.openedAt) < openDuration {
+			return false
+		}
+		b.state = circuitHalfOpen
+		log.Infof("circuit of handler '%s' is half-open, probing the handler", b.handler)
+		return true
+	case circuitHalfOpen:
+		return false
+	}
+	return true
+}
+
+// record updates the circuit with the outcome of a dispatch.
+func (b *circuitBreaker) record(failed bool, now time.Time, threshold int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !failed {
+		b.failures = 0
+		if b.state != circuitClosed {
+			b.state = circuitClosed
+			log.Infof("circuit of handler '%s' is closed", b.handler)
+			b.recordState(0)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || (b.state == circuitClosed && b.failures >= threshold) {
+		if b.state == circuitClosed {
+			log.Warnf("circuit of handler '%s' is open after %d consecutive failed dispatches", b.handler, b.failures)
+			b.recordState(1)
+		}
+		b.state = circuitOpen
+		b.openedAt = now
+	}
+}
+
+func (b *circuitBreaker) recordState(open int64) {
+	ctx, _ := tag.New(context.Background(), tag.Insert(monitoring.HandlerTag, b.handler))
+	stats.Record(ctx, monitoring.OpenCircuitsTotal.M(open))
+}
+
+// circuitBreakers keeps the circuit breakers of the handlers.
+type circuitBreakers struct {
+	options CircuitBreakerOptions
+
+	mutex    sync.RWMutex
+	breakers map[string]*circuitBreaker
+}
+
+func newCircuitBreakers(options CircuitBreakerOptions) *circuitBreakers {
+	return &circuitBreakers{
+		options:  options,
+		breakers: make(map[string]*circuitBreaker),
+	}
+}
+
+// get returns the circuit breaker of a handler, or nil if circuit breaking is disabled.
+func (c *circuitBreakers) get(handler string) *circuitBreaker {
+	if c.options.FailureThreshold <= 0 {
+		return nil
+	}
+
+	c.mutex.RLock()
+	b := c.breakers[handler]
+	c.mutex.RUnlock()
+	if b != nil {
+		return b
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if b = c.breakers[handler]; b == nil {
+		b = &circuitBreaker{handler: handler}
+		c.breakers[handler] = b
+	}
+	return b
+}
+
+// rejectOpenCircuit completes a dispatch to a handler with an open circuit, according to the
+// fail-open setting of the template variety.
+func (ds *dispatchState) rejectOpenCircuit(o CircuitBreakerOptions) {
+	msg := fmt.Sprintf("circuit of handler '%s' is open", ds.destination.HandlerName)
+
+	switch ds.destination.Template.Variety {
+	case tpb.TEMPLATE_VARIETY_CHECK, tpb.TEMPLATE_VARIETY_CHECK_WITH_OUTPUT:
+		if o.CheckFailOpen {
+			// Only cache the result until the next probe.
+			ds.checkResult.ValidDuration = o.OpenDuration
+			ds.checkResult.ValidUseCount = defaultValidUseCount
+		} else {
+			ds.checkResult.Status = status.WithUnavailable(msg)
+		}
+
+	case tpb.TEMPLATE_VARIETY_QUOTA:
+		if o.QuotaFailOpen {
+			ds.quotaResult.Amount = ds.quotaArgs.QuotaAmount
+			ds.quotaResult.ValidDuration = o.OpenDuration
+		} else {
+			ds.quotaResult.Status = status.WithUnavailable(msg)
+		}
+
+	case tpb.TEMPLATE_VARIETY_REPORT:
+		if !o.ReportFailOpen {
+			ds.err = fmt.Errorf("%s, dropped %d instances", msg, len(ds.instances))
+		}
+	}
+
+	log.Debugf("skipped dispatch: destination='%s' {err:%v}", ds.destination.FriendlyName, ds.err)
+
+	ctx, _ := tag.New(ds.ctx,
+		tag.Insert(monitoring.HandlerTag, ds.destination.HandlerName),
+		tag.Insert(monitoring.MeshFunctionTag, ds.destination.Template.Name),
+		tag.Insert(monitoring.AdapterTag, ds.destination.AdapterName),
+	)
+	stats.Record(ctx, monitoring.CircuitBreakerRejectionsTotal.M(1))
+}
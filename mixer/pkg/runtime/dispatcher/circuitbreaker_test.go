@@ -0,0 +1,203 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/googleapis/google/rpc"
+
+	tpb "istio.io/api/mixer/adapter/model/v1beta1"
+	"istio.io/istio/mixer/pkg/runtime/config"
+	"istio.io/istio/mixer/pkg/runtime/handler"
+	"istio.io/istio/mixer/pkg/runtime/routing"
+	"istio.io/istio/mixer/pkg/runtime/testing/data"
+	"istio.io/pkg/attribute"
+	"istio.io/pkg/pool"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	b := &circuitBreaker{handler: "h"}
+	now := time.Now()
+
+	b.record(true, now, 2)
+	if !b.allow(now, time.Minute) {
+		t.Fatal("the circuit opened before the threshold")
+	}
+	b.record(false, now, 2)
+	b.record(true, now, 2)
+	if !b.allow(now, time.Minute) {
+		t.Fatal("a success should reset the failures")
+	}
+	b.record(true, now, 2)
+	if b.allow(now.Add(time.Second), time.Minute) {
+		t.Fatal("the circuit should be open after 2 consecutive failures")
+	}
+
+	// A single probe is let through once the circuit has been open for the open duration.
+	if !b.allow(now.Add(time.Minute), time.Minute) {
+		t.Fatal("the probe should be let through")
+	}
+	if b.allow(now.Add(time.Minute), time.Minute) {
+		t.Fatal("only one probe should be let through")
+	}
+	b.record(true, now.Add(2*time.Minute), 2)
+	if b.allow(now.Add(2*time.Minute+time.Second), time.Minute) {
+		t.Fatal("the circuit should open again after a failed probe")
+	}
+
+	if !b.allow(now.Add(3*time.Minute), time.Minute) {
+		t.Fatal("the probe should be let through")
+	}
+	b.record(false, now.Add(3*time.Minute), 2)
+	if !b.allow(now.Add(3*time.Minute), time.Minute) || !b.allow(now.Add(3*time.Minute), time.Minute) {
+		t.Fatal("the circuit should be closed after a successful probe")
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	if b := newCircuitBreakers(DefaultCircuitBreakerOptions()).get("h"); b != nil {
+		t.Fatal("circuit breaking should be disabled by default")
+	}
+	c := newCircuitBreakers(CircuitBreakerOptions{FailureThreshold: 1})
+	if c.get("h") != c.get("h") || c.get("h") == c.get("h2") {
+		t.Fatal("there should be one circuit breaker per handler")
+	}
+}
+
+func TestCircuitBreakerDispatch(t *testing.T) {
+	var cases = []struct {
+		name      string
+		variety   tpb.TemplateVariety
+		templates []data.FakeTemplateSettings
+		config    []string
+		failOpen  bool
+		// expected error or status of the dispatches to the open circuit
+		err  string
+		code rpc.Code
+	}{
+		{
+			name:      "CheckFailClosed",
+			variety:   tpb.TEMPLATE_VARIETY_CHECK,
+			templates: []data.FakeTemplateSettings{{Name: "tcheck", ErrorOnDispatchCheck: true}},
+			config:    []string{data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1},
+			code:      rpc.UNAVAILABLE,
+		},
+		{
+			name:      "CheckFailOpen",
+			variety:   tpb.TEMPLATE_VARIETY_CHECK,
+			templates: []data.FakeTemplateSettings{{Name: "tcheck", ErrorOnDispatchCheck: true}},
+			config:    []string{data.HandlerACheck1, data.InstanceCheck1, data.RuleCheck1},
+			failOpen:  true,
+			code:      rpc.OK,
+		},
+		{
+			name:      "QuotaFailClosed",
+			variety:   tpb.TEMPLATE_VARIETY_QUOTA,
+			templates: []data.FakeTemplateSettings{{Name: "tquota", ErrorOnDispatchQuota: true}},
+			config:    []string{data.HandlerAQuota1, data.InstanceQuota1, data.RuleQuota1},
+			code:      rpc.UNAVAILABLE,
+		},
+		{
+			name:      "QuotaFailOpen",
+			variety:   tpb.TEMPLATE_VARIETY_QUOTA,
+			templates: []data.FakeTemplateSettings{{Name: "tquota", ErrorOnDispatchQuota: true}},
+			config:    []string{data.HandlerAQuota1, data.InstanceQuota1, data.RuleQuota1},
+			failOpen:  true,
+			code:      rpc.OK,
+		},
+		{
+			name:      "ReportFailClosed",
+			variety:   tpb.TEMPLATE_VARIETY_REPORT,
+			templates: []data.FakeTemplateSettings{{Name: "treport", ErrorOnDispatchReport: true}},
+			config:    []string{data.HandlerAReport1, data.InstanceReport1, data.RuleReport1},
+			err:       "is open, dropped 1 instances",
+		},
+		{
+			name:      "ReportFailOpen",
+			variety:   tpb.TEMPLATE_VARIETY_REPORT,
+			templates: []data.FakeTemplateSettings{{Name: "treport", ErrorOnDispatchReport: true}},
+			config:    []string{data.HandlerAReport1, data.InstanceReport1, data.RuleReport1},
+			failOpen:  true,
+		},
+	}
+
+	for _, tst := range cases {
+		t.Run(tst.name, func(tt *testing.T) {
+			dispatcher := New(gp, false, CircuitBreakerOptions{
+				FailureThreshold: 2,
+				OpenDuration:     time.Hour,
+				CheckFailOpen:    tst.failOpen,
+				QuotaFailOpen:    tst.failOpen,
+				ReportFailOpen:   tst.failOpen,
+			})
+
+			l := &data.Logger{}
+			templates := data.BuildTemplates(l, tst.templates...)
+			adapters := data.BuildAdapters(l)
+			s, _ := config.GetSnapshotForTest(templates, adapters, data.ServiceConfig, data.JoinConfigs(tst.config...))
+			h := handler.NewTable(handler.Empty(), s, pool.NewGoroutinePool(1, false))
+			_ = dispatcher.ChangeRoute(routing.BuildTable(h, s, "istio-system", true))
+
+			dispatch := func() (rpc.Code, error) {
+				bag := attribute.GetMutableBagForTesting(map[string]interface{}{"ident": "dest.istio-system"})
+				switch tst.variety {
+				case tpb.TEMPLATE_VARIETY_CHECK:
+					res, err := dispatcher.Check(context.TODO(), bag)
+					return rpc.Code(res.Status.Code), err
+				case tpb.TEMPLATE_VARIETY_QUOTA:
+					res, err := dispatcher.Quota(context.TODO(), bag,
+						QuotaMethodArgs{Quota: "iquota1", Amount: 10, BestEffort: true})
+					if err == nil && res.Status.Code == int32(rpc.OK) && res.Amount != 10 {
+						tt.Fatalf("got quota %d, want the requested amount", res.Amount)
+					}
+					return rpc.Code(res.Status.Code), err
+				default:
+					reporter := dispatcher.GetReporter(context.TODO())
+					defer reporter.Done()
+					if err := reporter.Report(bag); err != nil {
+						tt.Fatalf("unexpected failure from Buffer: %v", err)
+					}
+					return rpc.OK, reporter.Flush()
+				}
+			}
+
+			// The handler fails until the circuit opens.
+			for i := 0; i < 2; i++ {
+				if _, err := dispatch(); err == nil || !strings.Contains(err.Error(), "as expected") {
+					tt.Fatalf("got error %v, want the handler error", err)
+				}
+			}
+
+			l.Clear()
+			code, err := dispatch()
+			if strings.Contains(l.String(), "Dispatch") {
+				tt.Fatalf("the handler was called while its circuit is open:\n%s", l.String())
+			}
+			if tst.err == "" && err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			if tst.err != "" && (err == nil || !strings.Contains(err.Error(), tst.err)) {
+				tt.Fatalf("got error %v, want %q", err, tst.err)
+			}
+			if code != tst.code {
+				tt.Fatalf("got status %v, want %v", code, tst.code)
+			}
+		})
+	}
+}
@@ -89,15 +89,19 @@ type Impl struct {
 	gp *pool.GoroutinePool
 
 	enableTracing bool
+
+	// circuit breakers of the handlers
+	breakers *circuitBreakers
 }
 
 var _ Dispatcher = &Impl{}
 
 // New returns a new Impl instance. The Impl instance is initialized with an empty routing table.
-func New(handlerGP *pool.GoroutinePool, enableTracing bool) *Impl {
+func New(handlerGP *pool.GoroutinePool, enableTracing bool, circuitBreakerOptions CircuitBreakerOptions) *Impl {
 	d := &Impl{
 		gp:            handlerGP,
 		enableTracing: enableTracing,
+		breakers:      newCircuitBreakers(circuitBreakerOptions),
 		rc: &RoutingContext{
 			Routes: routing.Empty(),
 		},
@@ -138,6 +142,13 @@ func (d *Impl) Check(ctx context.Context, bag attribute.Bag) (adapter.CheckResul
 					ValidDuration: defaultValidDuration,
 				}
 			}
+
+			// A rule opted out of caching: neither Mixer nor its clients may reuse the result.
+			if s.noCache {
+				r.ValidUseCount = 0
+				r.ValidDuration = 0
+				r.NoCache = true
+			}
 		}
 	}
 
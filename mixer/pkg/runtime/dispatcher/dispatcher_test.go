@@ -99,6 +99,27 @@ ident                         : dest.istio-system
 `,
 	},
 
+	{
+		name: "CheckNoCache",
+		config: []string{
+			data.HandlerACheck1,
+			data.InstanceCheck1,
+			data.RuleCheck1NoCache,
+		},
+		variety:             tpb.TEMPLATE_VARIETY_CHECK,
+		expectedCheckResult: adapter.CheckResult{NoCache: true},
+		log: `
+[tcheck] InstanceBuilderFn() => name: 'tcheck', bag: '---
+ident                         : dest.istio-system
+'
+[tcheck] InstanceBuilderFn() <= (SUCCESS)
+[tcheck] DispatchCheck => context exists: 'true'
+[tcheck] DispatchCheck => handler exists: 'true'
+[tcheck] DispatchCheck => instance:       '&Struct{Fields:map[string]*Value{},XXX_unrecognized:[],}'
+[tcheck] DispatchCheck <= (SUCCESS)
+`,
+	},
+
 	{
 		name: "BasicCheckError",
 		templates: []data.FakeTemplateSettings{{
@@ -992,7 +1013,7 @@ func TestDispatcher(t *testing.T) {
 	for _, tst := range tests {
 		t.Run(tst.name, func(tt *testing.T) {
 
-			dispatcher := New(gp, true)
+			dispatcher := New(gp, true, DefaultCircuitBreakerOptions())
 
 			l := &data.Logger{}
 
@@ -1091,7 +1112,7 @@ func TestDispatcher(t *testing.T) {
 }
 
 func TestRefCount(t *testing.T) {
-	d := New(gp, true)
+	d := New(gp, true, DefaultCircuitBreakerOptions())
 	old := d.ChangeRoute(routing.Empty())
 	if old.GetRefs() != 0 {
 		t.Fatalf("%d != 0", old.GetRefs())
@@ -55,6 +55,9 @@ type dispatchState struct {
 
 	// attribute prefix for the output bag
 	outputPrefix string
+
+	// circuit breaker of the handler, recording the outcome of the dispatch
+	breaker *circuitBreaker
 }
 
 func (ds *dispatchState) clear() {
@@ -69,6 +72,7 @@ func (ds *dispatchState) clear() {
 	ds.outputPrefix = ""
 	ds.checkResult = adapter.CheckResult{}
 	ds.quotaResult = adapter.QuotaResult{}
+	ds.breaker = nil
 
 	// re-slice to change the length to 0 without changing capacity.
 	ds.instances = ds.instances[:0]
@@ -125,6 +129,7 @@ func (ds *dispatchState) invokeHandler(interface{}) {
 			log.Debugf("stack dump for handler dispatch panic:\n%s", debug.Stack())
 		}
 
+		ds.recordOutcome()
 		ds.session.completed <- ds
 	}()
 
@@ -170,7 +175,16 @@ func (ds *dispatchState) invokeHandler(interface{}) {
 	log.Debugf("complete dispatch: destination='%s' {err:%v}", ds.destination.FriendlyName, ds.err)
 
 	ds.completeSpan(ctx, span, time.Since(start), ds.err)
+	ds.recordOutcome()
 	ds.session.completed <- ds
 
 	reachedEnd = true
 }
+
+// recordOutcome updates the circuit of the handler with the outcome of the dispatch.
+func (ds *dispatchState) recordOutcome() {
+	if ds.breaker != nil {
+		options := ds.session.impl.breakers.options
+		ds.breaker.record(ds.err != nil, time.Now(), options.FailureThreshold)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"strings"
+	"time"
 
 	"github.com/gogo/googleapis/google/rpc"
 	multierror "github.com/hashicorp/go-multierror"
@@ -58,6 +59,9 @@ type session struct {
 	quotaResult adapter.QuotaResult
 	err         error
 
+	// noCache is set when a matching instance group opted out of check caching.
+	noCache bool
+
 	// The current number of activeDispatches handler dispatches.
 	activeDispatches int
 
@@ -82,6 +86,7 @@ func (s *session) clear() {
 	s.err = nil
 	s.quotaResult = adapter.QuotaResult{}
 	s.checkResult = adapter.CheckResult{}
+	s.noCache = false
 
 	// Drain the channel
 	exit := false
@@ -133,6 +138,9 @@ func (s *session) dispatch() error { //nolint: unparam
 
 			if groupMatched {
 				ndestinations++
+				if group.DisableCheckCache && s.variety == tpb.TEMPLATE_VARIETY_CHECK {
+					s.noCache = true
+				}
 			}
 
 			for j, input := range group.Builders {
@@ -283,6 +291,19 @@ func (s *session) dispatchBufferedReports() {
 func (s *session) dispatchToHandler(ds *dispatchState) {
 	s.activeDispatches++
 	ds.session = s
+
+	// The attribute generators are local and not subject to circuit breaking.
+	if ds.destination.Template.Variety != tpb.TEMPLATE_VARIETY_ATTRIBUTE_GENERATOR {
+		if ds.breaker = s.impl.breakers.get(ds.destination.HandlerName); ds.breaker != nil {
+			if !ds.breaker.allow(time.Now(), s.impl.breakers.options.OpenDuration) {
+				ds.breaker = nil
+				ds.rejectOpenCircuit(s.impl.breakers.options)
+				s.completed <- ds
+				return
+			}
+		}
+	}
+
 	s.impl.gp.ScheduleWork(ds.invokeHandler, nil)
 }
 
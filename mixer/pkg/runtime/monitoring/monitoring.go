@@ -189,6 +189,18 @@ var (
 		"Number of instances created per request by Mixer",
 		stats.UnitDimensionless)
 
+	// OpenCircuitsTotal is a measure of whether the circuit of a handler is open.
+	OpenCircuitsTotal = stats.Int64(
+		"mixer/runtime/open_circuits_total",
+		"Whether the circuit of a handler is open (1) or closed (0).",
+		stats.UnitDimensionless)
+
+	// CircuitBreakerRejectionsTotal is a measure of the number of dispatches skipped because of an open circuit.
+	CircuitBreakerRejectionsTotal = stats.Int64(
+		"mixer/runtime/circuit_breaker_rejections_total",
+		"Total number of adapter dispatches skipped by Mixer because the circuit of the handler is open.",
+		stats.UnitDimensionless)
+
 	DestinationsPerVarietyTotal = stats.Int64(
 		"mixer/dispatcher/destinations_per_variety_total",
 		"Number of Mixer adapter destinations by template variety type",
@@ -257,6 +269,10 @@ func init() {
 		newView(DispatchesTotal, dispatchKeys, view.Count()),
 		newView(DispatchDurationsSeconds, dispatchKeys, view.Distribution(durationBuckets...)),
 
+		// circuit breaker views
+		newView(OpenCircuitsTotal, envConfigKeys, view.LastValue()),
+		newView(CircuitBreakerRejectionsTotal, []tag.Key{MeshFunctionTag, HandlerTag, AdapterTag}, view.Count()),
+
 		// others
 		newView(DestinationsPerRequest, []tag.Key{}, view.Distribution(countBuckets...)),
 		newView(InstancesPerRequest, []tag.Key{}, view.Distribution(countBuckets...)),
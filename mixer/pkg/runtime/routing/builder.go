@@ -194,7 +194,7 @@ func (b *builder) build(snapshot *config.Snapshot) {
 				}
 
 				b.add(rule.Namespace, buildTemplateInfo(instance.Template), entry, condition, builder, mapper,
-					entry.Name, instance.Name, rule.Match, action.Name)
+					entry.Name, instance.Name, rule.Match, action.Name, rule.DisableCheckCache)
 			}
 		}
 
@@ -220,7 +220,7 @@ func (b *builder) build(snapshot *config.Snapshot) {
 				builder, mapper := b.getBuilderAndMapperDynamic(instance)
 
 				b.add(rule.Namespace, b.templateInfo(instance.Template), entry, condition, builder, mapper,
-					entry.Name, instance.Name, rule.Match, action.Name)
+					entry.Name, instance.Name, rule.Match, action.Name, rule.DisableCheckCache)
 			}
 		}
 
@@ -364,7 +364,8 @@ func (b *builder) add(
 	handlerName string,
 	instanceName string,
 	matchText string,
-	actionName string) {
+	actionName string,
+	disableCheckCache bool) {
 
 	// CHECK_WITH_OUTPUT is grouped into CHECK variety table
 	variety := t.Variety
@@ -419,8 +420,8 @@ func (b *builder) add(
 	for _, set := range byHandler.InstanceGroups {
 		// Try to find an input set to place the entry by comparing the compiled expression and resource type.
 		// This doesn't flatten across all actions, but only for actions coming from the same rule. We can
-		// flatten based on the expression text as well.
-		if set.Condition == condition {
+		// flatten based on the expression text as well. Rules opting out of check caching are kept apart.
+		if set.Condition == condition && set.DisableCheckCache == disableCheckCache {
 			instanceGroup = set
 			break
 		}
@@ -428,10 +429,11 @@ func (b *builder) add(
 
 	if instanceGroup == nil {
 		instanceGroup = &InstanceGroup{
-			id:        b.nextID(),
-			Condition: condition,
-			Builders:  []NamedBuilder{},
-			Mappers:   []template.OutputMapperFn{},
+			id:                b.nextID(),
+			Condition:         condition,
+			Builders:          []NamedBuilder{},
+			Mappers:           []template.OutputMapperFn{},
+			DisableCheckCache: disableCheckCache,
 		}
 		byHandler.InstanceGroups = append(byHandler.InstanceGroups, instanceGroup)
 
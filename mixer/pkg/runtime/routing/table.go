@@ -153,6 +153,9 @@ type InstanceGroup struct {
 
 	// Mappers for attribute-generating adapters that map output attributes into the main attribute set.
 	Mappers []template.OutputMapperFn
+
+	// DisableCheckCache indicates that the results of the checks this group applies to must not be cached.
+	DisableCheckCache bool
 }
 
 var emptyTable = &Table{id: -1}
@@ -52,6 +52,9 @@ type Runtime struct {
 
 	handlerPool *pool.GoroutinePool
 
+	// checkConfigListener is called when a configuration that may change the outcome of checks comes into effect.
+	checkConfigListener func()
+
 	*probe.Probe
 
 	stateLock            sync.Mutex
@@ -67,7 +70,8 @@ func New(
 	defaultConfigNamespace string,
 	executorPool *pool.GoroutinePool,
 	handlerPool *pool.GoroutinePool,
-	enableTracing bool) *Runtime {
+	enableTracing bool,
+	circuitBreakerOptions dispatcher.CircuitBreakerOptions) *Runtime {
 
 	// Ignoring the errors for bad configuration that has already made it to the store.
 	// during snapshot creation the bad configuration errors are already logged.
@@ -77,7 +81,7 @@ func New(
 		ephemeral:              e,
 		snapshot:               config.Empty(),
 		handlers:               handler.Empty(),
-		dispatcher:             dispatcher.New(executorPool, enableTracing),
+		dispatcher:             dispatcher.New(executorPool, enableTracing, circuitBreakerOptions),
 		handlerPool:            handlerPool,
 		Probe:                  probe.NewProbe(),
 		store:                  s,
@@ -96,6 +100,12 @@ func (c *Runtime) Dispatcher() dispatcher.Dispatcher {
 	return c.dispatcher
 }
 
+// OnCheckConfigChange registers a function called whenever a configuration that may change the outcome of checks
+// comes into effect, e.g. to invalidate the cached check results. It must be called before StartListening.
+func (c *Runtime) OnCheckConfigChange(fn func()) {
+	c.checkConfigListener = fn
+}
+
 // StartListening directs Runtime to start listening to configuration changes. As config changes, runtime processes
 // the confguration and creates a dispatcher.
 func (c *Runtime) StartListening() error {
@@ -163,6 +173,11 @@ func (c *Runtime) processNewConfig() {
 
 	oldContext := c.dispatcher.ChangeRoute(newRoutes)
 
+	if c.checkConfigListener != nil && checkConfigChanged(c.snapshot, oldHandlers, newSnapshot, newHandlers) {
+		log.Infof("Check configuration changed: id='%d'", newSnapshot.ID)
+		c.checkConfigListener()
+	}
+
 	c.handlers = newHandlers
 	c.snapshot = newSnapshot
 
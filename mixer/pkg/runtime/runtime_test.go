@@ -29,6 +29,7 @@ import (
 	dpb "istio.io/api/policy/v1beta1"
 	"istio.io/istio/mixer/pkg/config/store"
 	"istio.io/istio/mixer/pkg/runtime/config/constant"
+	"istio.io/istio/mixer/pkg/runtime/dispatcher"
 	"istio.io/istio/mixer/pkg/runtime/testing/data"
 	"istio.io/pkg/attribute"
 	"istio.io/pkg/pool"
@@ -49,7 +50,8 @@ func TestRuntime_Basic(t *testing.T) {
 		adapters, "istio-system",
 		egp,
 		hgp,
-		true)
+		true,
+		dispatcher.DefaultCircuitBreakerOptions())
 
 	d := rt.Dispatcher()
 	if d == nil {
@@ -95,7 +97,8 @@ func TestRuntime_ErrorDuringWatch(t *testing.T) {
 		adapters, "istio-system",
 		egp,
 		hgp,
-		true)
+		true,
+		dispatcher.DefaultCircuitBreakerOptions())
 
 	err := rt.StartListening()
 	if err == nil {
@@ -114,7 +117,8 @@ func TestRuntime_OnConfigChange(t *testing.T) {
 		adapters, "istio-system",
 		egp,
 		hgp,
-		true)
+		true,
+		dispatcher.DefaultCircuitBreakerOptions())
 
 	err := rt.StartListening()
 	if err != nil {
@@ -190,7 +194,8 @@ func TestRuntime_InFlightRequestsDuringConfigChange(t *testing.T) {
 		adapters, "istio-system",
 		egp,
 		hgp,
-		true)
+		true,
+		dispatcher.DefaultCircuitBreakerOptions())
 
 	err := rt.StartListening()
 	if err != nil {
@@ -289,6 +289,22 @@ spec:
     - icheck1.tcheck.istio-system
 `
 
+// RuleCheck1NoCache is a standard testing rule config with name rcheck1, opting out of check caching.
+var RuleCheck1NoCache = `
+apiVersion: "config.istio.io/v1alpha2"
+kind: rule
+metadata:
+  name: rcheck1
+  namespace: istio-system
+  annotations:
+    policy.istio.io/checkCache: "false"
+spec:
+  actions:
+  - handler: hcheck1.acheck
+    instances:
+    - icheck1.tcheck.istio-system
+`
+
 // RuleCheck1TrueCondition is a standard testing instance config with name R1. It references I1 and H1.
 var RuleCheck1TrueCondition = `
 apiVersion: "config.istio.io/v1alpha2"
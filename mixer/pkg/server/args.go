@@ -23,6 +23,7 @@ import (
 	"istio.io/istio/mixer/pkg/config/store"
 	"istio.io/istio/mixer/pkg/loadshedding"
 	"istio.io/istio/mixer/pkg/runtime/config/constant"
+	"istio.io/istio/mixer/pkg/runtime/dispatcher"
 	"istio.io/istio/mixer/pkg/template"
 	"istio.io/istio/pkg/mcp/creds"
 	"istio.io/istio/pkg/tracing"
@@ -53,6 +54,7 @@ type Args struct {
 
 	// URL of the config store. Use k8s://path_to_kubeconfig, fs:// for file system, or mcps://<host> to
 	// connect to Galley. If path_to_kubeconfig is empty, in-cluster kubeconfig is used.")
+	// The config received from Galley is cached on disk if the MCP URL has a cacheDir query parameter.
 	// If this is empty (and ConfigStore isn't specified), "k8s://" will be used.
 	ConfigStoreURL string
 
@@ -93,9 +95,16 @@ type Args struct {
 	// Port to use for exposing mixer self-monitoring information
 	MonitoringPort uint16
 
-	// Maximum number of entries in the check cache
+	// Maximum number of entries in the check cache. Zero, the default, disables the cache
+	// (see issue https://github.com/istio/istio/issues/9596).
 	NumCheckCacheEntries int32
 
+	// Maximum estimated memory used by the check cache, in bytes. Zero means unbounded.
+	CheckCacheMaxBytes int64
+
+	// How long denials without a valid duration are cached. Zero disables their caching.
+	CheckCacheNegativeTTL time.Duration
+
 	// Enable profiling via web interface host:port/debug/pprof
 	EnableProfiling bool
 
@@ -112,6 +121,9 @@ type Args struct {
 	UseTemplateCRDs bool
 
 	LoadSheddingOptions loadshedding.Options
+
+	// The circuit breaking options of the handlers
+	CircuitBreakerOptions dispatcher.CircuitBreakerOptions
 }
 
 // DefaultArgs allocates an Args struct initialized with Mixer's default configuration.
@@ -132,10 +144,10 @@ func DefaultArgs() *Args {
 		ReadinessProbeOptions:  &probe.Options{},
 		IntrospectionOptions:   ctrlz.DefaultOptions(),
 		EnableProfiling:        true,
-		NumCheckCacheEntries:   5000 * 5 * 60, // 5000 QPS with average TTL of 5 minutes
 		UseAdapterCRDs:         true,
 		UseTemplateCRDs:        true,
 		LoadSheddingOptions:    loadshedding.DefaultOptions(),
+		CircuitBreakerOptions:  dispatcher.DefaultCircuitBreakerOptions(),
 	}
 }
 
@@ -160,6 +172,14 @@ func (a *Args) validate() error {
 		return fmt.Errorf("# check cache entries must be >= 0 and <= 2^31-1, got %d", a.NumCheckCacheEntries)
 	}
 
+	if a.CheckCacheMaxBytes < 0 {
+		return fmt.Errorf("check cache size must be >= 0, got %d", a.CheckCacheMaxBytes)
+	}
+
+	if a.CheckCacheNegativeTTL < 0 {
+		return fmt.Errorf("check cache negative TTL must be >= 0, got %v", a.CheckCacheNegativeTTL)
+	}
+
 	if a.ConfigStore != nil && a.ConfigStoreURL != "" {
 		return fmt.Errorf("invalid arguments: both ConfigStore and ConfigStoreURL are specified")
 	}
@@ -181,6 +201,8 @@ func (a *Args) String() string {
 	fmt.Fprintln(buf, "EnableProfiling: ", a.EnableProfiling)
 	fmt.Fprintln(buf, "SingleThreaded: ", a.SingleThreaded)
 	fmt.Fprintln(buf, "NumCheckCacheEntries: ", a.NumCheckCacheEntries)
+	fmt.Fprintln(buf, "CheckCacheMaxBytes: ", a.CheckCacheMaxBytes)
+	fmt.Fprintln(buf, "CheckCacheNegativeTTL: ", a.CheckCacheNegativeTTL)
 	fmt.Fprintln(buf, "ConfigStoreURL: ", a.ConfigStoreURL)
 	fmt.Fprintln(buf, "CertificateFile: ", a.CredentialOptions.CertificateFile)
 	fmt.Fprintln(buf, "KeyFile: ", a.CredentialOptions.KeyFile)
@@ -193,6 +215,7 @@ func (a *Args) String() string {
 	fmt.Fprintf(buf, "UseTemplateCRDs: %#v\n", a.UseTemplateCRDs)
 	fmt.Fprintf(buf, "LoadSheddingOptions: %#v\n", a.LoadSheddingOptions)
 	fmt.Fprintf(buf, "UseAdapterCRDs: %#v\n", a.UseAdapterCRDs)
+	fmt.Fprintf(buf, "CircuitBreakerOptions: %#v\n", a.CircuitBreakerOptions)
 
 	return buf.String()
 }
@@ -100,6 +100,20 @@ func TracingServerInterceptor(tracer opentracing.Tracer) grpc.UnaryServerInterce
 	}
 }
 
+// chainUnaryServerInterceptors combines the interceptors into one, the first interceptor being the outermost.
+func chainUnaryServerInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
+
 func isSampled(md metadata.MD) bool {
 	for _, val := range md.Get("x-b3-sampled") {
 		if val == "1" || strings.EqualFold(val, "true") {
@@ -76,7 +76,7 @@ type listenFunc func(network string, address string) (net.Listener, error)
 type patchTable struct {
 	newRuntime func(s store.Store, templates map[string]*template.Info, adapters map[string]*adapter.Info,
 		defaultConfigNamespace string, executorPool *pool.GoroutinePool,
-		handlerPool *pool.GoroutinePool, enableTracing bool) *runtime.Runtime
+		handlerPool *pool.GoroutinePool, enableTracing bool, circuitBreakerOptions dispatcher.CircuitBreakerOptions) *runtime.Runtime
 	configTracing func(serviceName string, options *tracing.Options) (io.Closer, error)
 	startMonitor  func(port uint16, enableProfiling bool, lf listenFunc) (*monitor, error)
 	listen        listenFunc
@@ -147,6 +147,7 @@ func newServer(a *Args, p *patchTable) (server *Server, err error) {
 	// construct the gRPC options
 
 	var grpcOptions []grpc.ServerOption
+	var interceptors []grpc.UnaryServerInterceptor
 	grpcOptions = append(grpcOptions, grpc.MaxConcurrentStreams(uint32(a.MaxConcurrentStreams)), grpc.MaxRecvMsgSize(int(a.MaxMessageSize)))
 
 	if a.TracingOptions.TracingEnabled() {
@@ -154,7 +155,7 @@ func newServer(a *Args, p *patchTable) (server *Server, err error) {
 		if err != nil {
 			return nil, fmt.Errorf("unable to setup tracing")
 		}
-		grpcOptions = append(grpcOptions, grpc.UnaryInterceptor(TracingServerInterceptor(ot.GlobalTracer())))
+		interceptors = append(interceptors, TracingServerInterceptor(ot.GlobalTracer()))
 	}
 
 	// get the network stuff setup
@@ -215,7 +216,18 @@ func newServer(a *Args, p *patchTable) (server *Server, err error) {
 	s.configStore = st
 	log.Info("Starting runtime config watch...")
 	rt := p.newRuntime(st, templateMap, adapterMap, a.ConfigDefaultNamespace,
-		s.gp, s.adapterGP, a.TracingOptions.TracingEnabled())
+		s.gp, s.adapterGP, a.TracingOptions.TracingEnabled(), a.CircuitBreakerOptions)
+
+	if a.NumCheckCacheEntries > 0 {
+		s.checkCache = checkcache.NewWithOptions(checkcache.Options{
+			MaxEntries:  a.NumCheckCacheEntries,
+			MaxBytes:    a.CheckCacheMaxBytes,
+			NegativeTTL: a.CheckCacheNegativeTTL,
+		})
+
+		// cached results may no longer hold once a new configuration is in effect
+		rt.OnCheckConfigChange(s.checkCache.Invalidate)
+	}
 
 	if err = p.runtimeListen(rt); err != nil {
 		return nil, fmt.Errorf("unable to listen: %v", err)
@@ -223,13 +235,6 @@ func newServer(a *Args, p *patchTable) (server *Server, err error) {
 
 	s.dispatcher = rt.Dispatcher()
 
-	// see issue https://github.com/istio/istio/issues/9596
-	a.NumCheckCacheEntries = 0
-
-	if a.NumCheckCacheEntries > 0 {
-		s.checkCache = checkcache.New(a.NumCheckCacheEntries)
-	}
-
 	// get the grpc server wired up
 	grpc.EnableTracing = a.EnableGRPCTracing
 
@@ -251,6 +256,14 @@ func newServer(a *Args, p *patchTable) (server *Server, err error) {
 		grpcOptions = append(grpcOptions, grpc.StatsHandler(&ocgrpc.ServerHandler{}))
 	}
 
+	// excess requests are dropped before anything else is done with them
+	if interceptor := throttler.UnaryServerInterceptor(); interceptor != nil {
+		interceptors = append([]grpc.UnaryServerInterceptor{interceptor}, interceptors...)
+	}
+	if len(interceptors) > 0 {
+		grpcOptions = append(grpcOptions, grpc.UnaryInterceptor(chainUnaryServerInterceptors(interceptors...)))
+	}
+
 	s.server = grpc.NewServer(grpcOptions...)
 	mixerpb.RegisterMixerServer(s.server, api.NewGRPCServer(s.dispatcher, s.gp, s.checkCache, throttler))
 
@@ -117,6 +117,8 @@ func init() {
 		"Max message size received by MCP's grpc client")
 	discoveryCmd.PersistentFlags().IntVar(&serverArgs.MCPInitialConnWindowSize, "mcpInitialConnWindowSize", bootstrap.DefaultMCPInitialConnWindowSize,
 		"Max message size received by MCP's grpc client")
+	discoveryCmd.PersistentFlags().StringVar(&serverArgs.MCPCacheDir, "mcpCacheDir", "",
+		"Directory where the config received from MCP servers is cached, and applied on startup if the servers are unreachable")
 
 	// Config Controller options
 	discoveryCmd.PersistentFlags().BoolVar(&serverArgs.Config.DisableInstallCRDs, "disable-install-crds", false,
@@ -136,7 +138,7 @@ func init() {
 	discoveryCmd.PersistentFlags().StringVar(&serverArgs.Service.Consul.ServerURL, "consulserverURL", "",
 		"URL for the Consul server")
 	discoveryCmd.PersistentFlags().DurationVar(&serverArgs.Service.Consul.Interval, "consulserverInterval", 2*time.Second,
-		"Interval (in seconds) for polling the Consul service registry")
+		"Maximum wait time of the blocking queries watching the Consul service registry")
 
 	// using address, so it can be configured as localhost:.. (possibly UDS in future)
 	discoveryCmd.PersistentFlags().StringVar(&serverArgs.DiscoveryOptions.HTTPAddr, "httpAddr", ":8080",
@@ -52,10 +52,9 @@ import (
 	configaggregate "istio.io/istio/pilot/pkg/config/aggregate"
 	"istio.io/istio/pilot/pkg/config/clusterregistry"
 	"istio.io/istio/pilot/pkg/config/coredatamodel"
+	configfile "istio.io/istio/pilot/pkg/config/file"
 	"istio.io/istio/pilot/pkg/config/kube/crd/controller"
 	"istio.io/istio/pilot/pkg/config/kube/ingress"
-	"istio.io/istio/pilot/pkg/config/memory"
-	configmonitor "istio.io/istio/pilot/pkg/config/monitor"
 	"istio.io/istio/pilot/pkg/model"
 	istio_networking "istio.io/istio/pilot/pkg/networking/core"
 	"istio.io/istio/pilot/pkg/networking/plugin"
@@ -104,8 +103,8 @@ const (
 )
 
 var (
-	// FilepathWalkInterval dictates how often the file system is walked for config
-	FilepathWalkInterval = 100 * time.Millisecond
+	// FileDebounce is the delay used to batch the changes of config files
+	FileDebounce = configfile.DefaultDebounce
 
 	// PilotCertDir is the default location for mTLS certificates used by pilot
 	// Visible for tests - at runtime can be set by PILOT_CERT_DIR environment variable.
@@ -144,8 +143,8 @@ type MeshArgs struct {
 }
 
 // ConfigArgs provide configuration options for the configuration controller. If FileDir is set, that directory will
-// be monitored for CRD yaml files and will update the controller as those files change (This is used for testing
-// purposes). Otherwise, a CRD client is created based on the configuration.
+// be watched for CRD yaml files and will update the controller as those files change (This is used for deployments
+// without Kubernetes and for testing). Otherwise, a CRD client is created based on the configuration.
 type ConfigArgs struct {
 	ClusterRegistriesNamespace string
 	KubeConfig                 string
@@ -184,7 +183,9 @@ type PilotArgs struct {
 	MCPMaxMessageSize        int
 	MCPInitialWindowSize     int
 	MCPInitialConnWindowSize int
-	KeepaliveOptions         *istiokeepalive.Options
+	// MCPCacheDir is the directory where the resources received from the MCP config sources are cached
+	MCPCacheDir      string
+	KeepaliveOptions *istiokeepalive.Options
 	// ForceStop is set as true when used for testing to make the server stop quickly
 	ForceStop bool
 }
@@ -399,7 +400,7 @@ func (s *Server) initMesh(args *PilotArgs) error {
 				s.mesh = mesh
 				if s.EnvoyXdsServer != nil {
 					s.EnvoyXdsServer.Env.Mesh = mesh
-					s.EnvoyXdsServer.ConfigUpdate(true)
+					s.EnvoyXdsServer.ConfigUpdate(&model.PushRequest{Full: true})
 				}
 			}
 		})
@@ -467,7 +468,7 @@ func (s *Server) initMeshNetworks(args *PilotArgs) error { //nolint: unparam
 			}
 			if s.EnvoyXdsServer != nil {
 				s.EnvoyXdsServer.Env.MeshNetworks = meshNetworks
-				s.EnvoyXdsServer.ConfigUpdate(true)
+				s.EnvoyXdsServer.ConfigUpdate(&model.PushRequest{Full: true})
 			}
 		}
 	})
@@ -514,8 +515,8 @@ func (s *Server) initMCPConfigController(args *PilotArgs) error {
 
 	options := coredatamodel.Options{
 		DomainSuffix: args.Config.ControllerOptions.DomainSuffix,
-		ClearDiscoveryServerCache: func() {
-			s.EnvoyXdsServer.ConfigUpdate(true)
+		ClearDiscoveryServerCache: func(configsUpdated map[model.ConfigKey]struct{}) {
+			s.EnvoyXdsServer.ConfigUpdate(&model.PushRequest{Full: true, ConfigsUpdated: configsUpdated})
 		},
 	}
 
@@ -538,10 +539,7 @@ func (s *Server) initMCPConfigController(args *PilotArgs) error {
 					cancel()
 					return fmt.Errorf("invalid fs config URL %s, contains no file path", configSource.Address)
 				}
-				store := memory.Make(model.IstioConfigTypes)
-				configController := memory.NewController(store)
-
-				err := s.makeFileMonitor(url.Path, configController)
+				configController, err := s.makeFileController(url.Path, args)
 				if err != nil {
 					cancel()
 					return err
@@ -632,6 +630,10 @@ func (s *Server) initMCPConfigController(args *PilotArgs) error {
 			ID:                clientNodeID,
 			Reporter:          reporter,
 		}
+		if args.MCPCacheDir != "" {
+			// each config source is cached separately
+			sinkOptions.CacheDir = path.Join(args.MCPCacheDir, url.PathEscape(configSource.Address))
+		}
 
 		cl := mcpapi.NewResourceSourceClient(conn)
 		mcpClient := sink.NewClient(cl, sinkOptions)
@@ -691,10 +693,7 @@ func (s *Server) initConfigController(args *PilotArgs) error {
 	} else if args.Config.Controller != nil {
 		s.configController = args.Config.Controller
 	} else if args.Config.FileDir != "" {
-		store := memory.Make(model.IstioConfigTypes)
-		configController := memory.NewController(store)
-
-		err := s.makeFileMonitor(args.Config.FileDir, configController)
+		configController, err := s.makeFileController(args.Config.FileDir, args)
 		if err != nil {
 			return err
 		}
@@ -762,17 +761,15 @@ func (s *Server) makeKubeConfigController(args *PilotArgs) (model.ConfigStoreCac
 	return controller.NewController(configClient, args.Config.ControllerOptions), nil
 }
 
-func (s *Server) makeFileMonitor(fileDir string, configController model.ConfigStore) error {
-	fileSnapshot := configmonitor.NewFileSnapshot(fileDir, model.IstioConfigTypes)
-	fileMonitor := configmonitor.NewMonitor("file-monitor", configController, FilepathWalkInterval, fileSnapshot.ReadConfigFiles)
-
-	// Defer starting the file monitor until after the service is created.
-	s.addStartFunc(func(stop <-chan struct{}) error {
-		fileMonitor.Start(stop)
-		return nil
+func (s *Server) makeFileController(fileDir string, args *PilotArgs) (model.ConfigStoreCache, error) {
+	configController, err := configfile.NewController(fileDir, model.IstioConfigTypes, configfile.Options{
+		DomainSuffix: args.Config.ControllerOptions.DomainSuffix,
+		Debounce:     FileDebounce,
 	})
-
-	return nil
+	if err != nil {
+		return nil, multierror.Prefix(err, "failed to read config files.")
+	}
+	return configController, nil
 }
 
 // createK8sServiceControllers creates all the k8s service controllers under this pilot
@@ -105,8 +105,8 @@ func (m *Multicluster) AddMemberCluster(clientset kubernetes.Interface, clusterI
 
 	m.remoteKubeControllers[clusterID] = &remoteKubeController
 	m.m.Unlock()
-	_ = kubectl.AppendServiceHandler(func(*model.Service, model.Event) { m.XDSUpdater.ConfigUpdate(true) })
-	_ = kubectl.AppendInstanceHandler(func(*model.ServiceInstance, model.Event) { m.XDSUpdater.ConfigUpdate(true) })
+	_ = kubectl.AppendServiceHandler(func(svc *model.Service, _ model.Event) { m.serviceUpdate(svc) })
+	_ = kubectl.AppendInstanceHandler(func(si *model.ServiceInstance, _ model.Event) { m.serviceUpdate(si.Service) })
 	go kubectl.Run(stopCh)
 	return nil
 }
@@ -126,8 +126,18 @@ func (m *Multicluster) DeleteMemberCluster(clusterID string) error {
 	close(m.remoteKubeControllers[clusterID].stopCh)
 	delete(m.remoteKubeControllers, clusterID)
 	if m.XDSUpdater != nil {
-		m.XDSUpdater.ConfigUpdate(true)
+		m.XDSUpdater.ConfigUpdate(&model.PushRequest{Full: true})
 	}
 
 	return nil
 }
+
+// serviceUpdate requests a full push for a change of a service of a remote cluster, or of
+// its instances.
+func (m *Multicluster) serviceUpdate(svc *model.Service) {
+	req := &model.PushRequest{Full: true}
+	if svc != nil {
+		req.ConfigsUpdated = map[model.ConfigKey]struct{}{model.ServiceConfigKey(svc): {}}
+	}
+	m.XDSUpdater.ConfigUpdate(req)
+}
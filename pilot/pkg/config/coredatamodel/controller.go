@@ -39,8 +39,10 @@ type CoreDataModel interface {
 
 // Options stores the configurable attributes of a Control
 type Options struct {
-	DomainSuffix              string
-	ClearDiscoveryServerCache func()
+	DomainSuffix string
+	// ClearDiscoveryServerCache is called with the keys of the added, updated and deleted
+	// configs when a change is applied, except for service entries which trigger events.
+	ClearDiscoveryServerCache func(configsUpdated map[model.ConfigKey]struct{})
 }
 
 // Controller is a temporary storage for the changes received
@@ -188,8 +190,8 @@ func (c *Controller) Apply(change *sink.Change) error {
 
 	if descriptor.Type == model.ServiceEntry.Type {
 		c.serviceEntryEvents(innerStore, prevStore)
-	} else {
-		c.options.ClearDiscoveryServerCache()
+	} else if updated := configsUpdated(descriptor.Type, innerStore, prevStore); len(updated) > 0 {
+		c.options.ClearDiscoveryServerCache(updated)
 	}
 
 	return nil
@@ -285,6 +287,28 @@ func (c *Controller) serviceEntryEvents(currentStore, prevStore map[string]map[s
 	}
 }
 
+// configsUpdated returns the keys of the configs added, updated or deleted between two
+// versions of the store of a type. Configs without a version are assumed to be updated.
+func configsUpdated(typ string, currentStore, prevStore map[string]map[string]*model.Config) map[model.ConfigKey]struct{} {
+	out := map[model.ConfigKey]struct{}{}
+	for namespace, byName := range currentStore {
+		for name, config := range byName {
+			prevConfig, ok := prevStore[namespace][name]
+			if !ok || config.ResourceVersion == "" || config.ResourceVersion != prevConfig.ResourceVersion {
+				out[model.ConfigKey{Type: typ, Name: name, Namespace: namespace}] = struct{}{}
+			}
+		}
+	}
+	for namespace, prevByName := range prevStore {
+		for name := range prevByName {
+			if _, ok := currentStore[namespace][name]; !ok {
+				out[model.ConfigKey{Type: typ, Name: name, Namespace: namespace}] = struct{}{}
+			}
+		}
+	}
+	return out
+}
+
 func extractNameNamespace(metadataName string) (string, string) {
 	segments := strings.Split(metadataName, "/")
 	if len(segments) == 2 {
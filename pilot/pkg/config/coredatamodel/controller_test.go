@@ -111,14 +111,14 @@ var (
 
 	testControllerOptions = coredatamodel.Options{
 		DomainSuffix:              "cluster.local",
-		ClearDiscoveryServerCache: func() {},
+		ClearDiscoveryServerCache: func(map[model.ConfigKey]struct{}) {},
 	}
 )
 
 func TestOptions(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	var cacheCleared bool
-	testControllerOptions.ClearDiscoveryServerCache = func() {
+	testControllerOptions.ClearDiscoveryServerCache = func(map[model.ConfigKey]struct{}) {
 		cacheCleared = true
 	}
 	controller := coredatamodel.NewController(testControllerOptions)
@@ -156,6 +156,82 @@ func TestOptions(t *testing.T) {
 	g.Expect(cacheCleared).To(gomega.Equal(true))
 }
 
+func TestConfigsUpdated(t *testing.T) {
+	var gotConfigs map[model.ConfigKey]struct{}
+	options := testControllerOptions
+	options.ClearDiscoveryServerCache = func(configsUpdated map[model.ConfigKey]struct{}) {
+		gotConfigs = configsUpdated
+	}
+	controller := coredatamodel.NewController(options)
+
+	makeVirtualService := func(name, version string) *sink.Object {
+		return &sink.Object{
+			TypeURL: "type.googleapis.com/istio.networking.v1alpha3.VirtualService",
+			Metadata: &mcpapi.Metadata{
+				Name:    fmt.Sprintf("default/%s", name),
+				Version: version,
+			},
+			Body: &networking.VirtualService{
+				Hosts: []string{name + ".com"},
+				Tcp: []*networking.TCPRoute{{
+					Route: []*networking.RouteDestination{{
+						Destination: &networking.Destination{Host: name + ".com"},
+					}},
+				}},
+			},
+		}
+	}
+	key := func(name string) model.ConfigKey {
+		return model.ConfigKey{Type: model.VirtualService.Type, Name: name, Namespace: "default"}
+	}
+
+	// Note: these tests steps are cumulative
+	steps := []struct {
+		name    string
+		objects []*sink.Object
+		want    map[model.ConfigKey]struct{}
+	}{
+		{
+			name:    "initial add",
+			objects: []*sink.Object{makeVirtualService("foo", "v0"), makeVirtualService("bar", "v0")},
+			want:    map[model.ConfigKey]struct{}{key("foo"): {}, key("bar"): {}},
+		},
+		{
+			name:    "update",
+			objects: []*sink.Object{makeVirtualService("foo", "v1"), makeVirtualService("bar", "v0")},
+			want:    map[model.ConfigKey]struct{}{key("foo"): {}},
+		},
+		{
+			name:    "unchanged",
+			objects: []*sink.Object{makeVirtualService("foo", "v1"), makeVirtualService("bar", "v0")},
+			want:    nil,
+		},
+		{
+			name:    "delete and add",
+			objects: []*sink.Object{makeVirtualService("foo", "v1"), makeVirtualService("baz", "v0")},
+			want:    map[model.ConfigKey]struct{}{key("bar"): {}, key("baz"): {}},
+		},
+		{
+			name:    "no version",
+			objects: []*sink.Object{makeVirtualService("foo", ""), makeVirtualService("baz", "v0")},
+			want:    map[model.ConfigKey]struct{}{key("foo"): {}},
+		},
+	}
+
+	for i, s := range steps {
+		t.Run(fmt.Sprintf("[%v] %s", i, s.name), func(tt *testing.T) {
+			gotConfigs = nil
+			change := &sink.Change{Collection: model.VirtualService.Collection, Objects: s.objects}
+			if err := controller.Apply(change); err != nil {
+				tt.Fatalf("Apply() failed: %v", err)
+			}
+			if !reflect.DeepEqual(gotConfigs, s.want) {
+				tt.Fatalf("wrong configs updated: \n got %+v \nwant %+v", gotConfigs, s.want)
+			}
+		})
+	}
+}
+
 func TestHasSynced(t *testing.T) {
 	t.Skip("Pending: https://github.com/istio/istio/issues/7947")
 	g := gomega.NewGomegaWithT(t)
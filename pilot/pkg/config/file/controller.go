@@ -0,0 +1,425 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package file provides a config store backed by the YAML files of a directory, for
+// deployments of Pilot without Kubernetes. Files are watched with fsnotify, and each change
+// is turned into add, update and delete events of the affected resources.
+package file
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gogo/protobuf/proto"
+
+	"istio.io/istio/pilot/pkg/config/memory"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/pkg/log"
+)
+
+var (
+	errReadOnly = errors.New("file config store is read-only")
+
+	supportedExtensions = map[string]bool{
+		".yaml": true,
+		".yml":  true,
+	}
+)
+
+const (
+	// DefaultDebounce is the default delay used to batch the changes of a file.
+	DefaultDebounce = 100 * time.Millisecond
+
+	// DefaultRetryInterval is the default delay between two reads of a missing root directory.
+	DefaultRetryInterval = 5 * time.Second
+)
+
+// Options configures a controller.
+type Options struct {
+	// DomainSuffix is set on the resources read from files.
+	DomainSuffix string
+
+	// Debounce is the delay used to batch the changes of a file, as editors and tools often
+	// write a file several times in a row. DefaultDebounce is used if zero.
+	Debounce time.Duration
+
+	// RetryInterval is the delay between two reads of the root directory while it doesn't
+	// exist, as it can't be watched. DefaultRetryInterval is used if zero.
+	RetryInterval time.Duration
+}
+
+// Controller is a read-only model.ConfigStoreCache with the resources of the files of a
+// directory and its subdirectories. Resources failing validation are reported by Errors,
+// and the last valid version of each of them is kept.
+type Controller struct {
+	model.ConfigStoreCache
+
+	root       string
+	descriptor model.ConfigDescriptor
+	options    Options
+	watcher    *fsnotify.Watcher
+
+	// rootMissing is true if the root directory didn't exist when last read. Only accessed by
+	// the event loop once the controller runs.
+	rootMissing bool
+
+	// files has the resources of each file, by key. Only accessed by the event loop once
+	// the controller runs.
+	files map[string]map[string]model.Config
+	// owners has the file of each resource key, and shadowed the other files defining the
+	// same key, which are reloaded if the owner drops it.
+	owners   map[string]string
+	shadowed map[string]map[string]struct{}
+
+	errorsMu sync.RWMutex
+	errors   map[string][]*Error
+}
+
+var _ model.ConfigStoreCache = &Controller{}
+
+// NewController creates a controller for the files of the root directory, and loads them.
+// Events for the initial resources are delivered once the controller runs. A missing root
+// directory is not an error: the controller has no resources until it is created.
+func NewController(root string, descriptor model.ConfigDescriptor, options Options) (*Controller, error) {
+	if options.Debounce == 0 {
+		options.Debounce = DefaultDebounce
+	}
+	if options.RetryInterval == 0 {
+		options.RetryInterval = DefaultRetryInterval
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	c := &Controller{
+		ConfigStoreCache: memory.NewController(memory.Make(descriptor)),
+		root:             root,
+		descriptor:       descriptor,
+		options:          options,
+		watcher:          watcher,
+		files:            map[string]map[string]model.Config{},
+		owners:           map[string]string{},
+		shadowed:         map[string]map[string]struct{}{},
+		errors:           map[string][]*Error{},
+	}
+	if err = c.resync(); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Create is not supported, the files are the source of truth.
+func (c *Controller) Create(model.Config) (string, error) {
+	return "", errReadOnly
+}
+
+// Update is not supported, the files are the source of truth.
+func (c *Controller) Update(model.Config) (string, error) {
+	return "", errReadOnly
+}
+
+// Delete is not supported, the files are the source of truth.
+func (c *Controller) Delete(string, string, string) error {
+	return errReadOnly
+}
+
+// Errors returns the current problems with the files, sorted by file and line.
+func (c *Controller) Errors() []*Error {
+	c.errorsMu.RLock()
+	defer c.errorsMu.RUnlock()
+	var out []*Error
+	for _, errs := range c.errors {
+		out = append(out, errs...)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].File != out[j].File {
+			return out[i].File < out[j].File
+		}
+		return out[i].Line < out[j].Line
+	})
+	return out
+}
+
+// Run watches the files until the stop channel is closed.
+func (c *Controller) Run(stop <-chan struct{}) {
+	go c.ConfigStoreCache.Run(stop)
+	defer c.watcher.Close() // nolint: errcheck
+
+	retry := time.NewTicker(c.options.RetryInterval)
+	defer retry.Stop()
+
+	pending := map[string]struct{}{}
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-stop:
+			return
+		case ev := <-c.watcher.Events:
+			pending[ev.Name] = struct{}{}
+			if timer == nil {
+				timer = time.After(c.options.Debounce)
+			}
+		case err := <-c.watcher.Errors:
+			log.Warnf("Error watching config files in %s: %v", c.root, err)
+		case <-timer:
+			timer = nil
+			c.processChanges(pending)
+			pending = map[string]struct{}{}
+		case <-retry.C:
+			if c.rootMissing {
+				if err := c.resync(); err != nil {
+					log.Warnf("Failed to read config files in %s: %v", c.root, err)
+				}
+			}
+		}
+	}
+}
+
+// processChanges reloads the changed files. Changes to directories and to other files, such
+// as the symlinks swapped by Kubernetes when a mounted ConfigMap changes, trigger a full
+// resync, since the set of files may have changed.
+func (c *Controller) processChanges(paths map[string]struct{}) {
+	for path := range paths {
+		if !c.isConfigFile(path) {
+			if err := c.resync(); err != nil {
+				log.Warnf("Failed to read config files in %s: %v", c.root, err)
+			}
+			return
+		}
+	}
+	for path := range paths {
+		c.reloadFile(path)
+	}
+}
+
+// isConfigFile returns true if the path is a YAML file, as opposed to a directory or a file
+// which isn't read by the controller.
+func (c *Controller) isConfigFile(path string) bool {
+	if !supportedExtensions[filepath.Ext(path)] || strings.HasPrefix(filepath.Base(path), ".") {
+		return false
+	}
+	info, err := os.Stat(path)
+	return (err != nil && c.isKnown(path)) || (err == nil && info.Mode().IsRegular())
+}
+
+// isKnown returns true if the file had resources or errors when last read.
+func (c *Controller) isKnown(path string) bool {
+	if _, f := c.files[path]; f {
+		return true
+	}
+	c.errorsMu.RLock()
+	defer c.errorsMu.RUnlock()
+	_, f := c.errors[path]
+	return f
+}
+
+// resync watches all the directories, reloads all the files and removes the resources of
+// the files which no longer exist. A missing root directory is handled as an empty one.
+func (c *Controller) resync() error {
+	found := map[string]struct{}{}
+	missing := false
+	err := filepath.Walk(c.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == c.root && os.IsNotExist(err) {
+				missing = true
+				return nil
+			}
+			return err
+		}
+		if path != c.root && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return c.watcher.Add(path)
+		}
+		if !supportedExtensions[filepath.Ext(path)] {
+			return nil
+		}
+		// Follow symlinks to files, as used by mounted ConfigMaps.
+		if info.Mode()&os.ModeSymlink != 0 {
+			if info, err = os.Stat(path); err != nil || !info.Mode().IsRegular() {
+				return nil
+			}
+		} else if !info.Mode().IsRegular() {
+			return nil
+		}
+		found[path] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if missing && !c.rootMissing {
+		log.Warnf("Config directory %s does not exist, retrying every %v", c.root, c.options.RetryInterval)
+	}
+	c.rootMissing = missing
+
+	var known []string
+	for path := range c.files {
+		known = append(known, path)
+	}
+	c.errorsMu.RLock()
+	for path := range c.errors {
+		known = append(known, path)
+	}
+	c.errorsMu.RUnlock()
+	for _, path := range known {
+		if _, f := found[path]; !f && c.isKnown(path) {
+			c.reloadFile(path)
+		}
+	}
+	paths := make([]string, 0, len(found))
+	for path := range found {
+		paths = append(paths, path)
+	}
+	// Sort so that the first file defining a resource consistently wins.
+	sort.Strings(paths)
+	for _, path := range paths {
+		c.reloadFile(path)
+	}
+	return nil
+}
+
+// reloadFile reads a file, and updates the store with the changes of its resources.
+func (c *Controller) reloadFile(path string) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warnf("Failed to read config file %s: %v", path, err)
+			return
+		}
+		data = nil
+	}
+
+	result := parseFile(path, data, c.descriptor, c.options.DomainSuffix)
+	errs := result.errors
+	old := c.files[path]
+	for key, paths := range c.shadowed {
+		delete(paths, path)
+		if len(paths) == 0 {
+			delete(c.shadowed, key)
+		}
+	}
+	configs := map[string]model.Config{}
+	for _, config := range result.configs {
+		key := config.Key()
+		if owner, f := c.owners[key]; f && owner != path {
+			errs = append(errs, &Error{File: path, Err: errors.New(key + " is already defined in " + owner)})
+			if c.shadowed[key] == nil {
+				c.shadowed[key] = map[string]struct{}{}
+			}
+			c.shadowed[key][path] = struct{}{}
+			continue
+		}
+		if _, f := configs[key]; f {
+			errs = append(errs, &Error{File: path, Err: errors.New(key + " is defined more than once")})
+			continue
+		}
+		configs[key] = config
+	}
+	// Keep the last valid version of invalid resources.
+	for key := range result.invalid {
+		if prev, f := old[key]; f {
+			if _, f := configs[key]; !f {
+				configs[key] = prev
+			}
+		}
+	}
+
+	var released []string
+	for key, prev := range old {
+		if _, f := configs[key]; !f {
+			c.deleteConfig(prev)
+			delete(c.owners, key)
+			released = append(released, key)
+		}
+	}
+	for key, config := range configs {
+		prev, f := old[key]
+		switch {
+		case !f:
+			c.createConfig(config)
+			c.owners[key] = path
+		case !sameConfig(prev, config):
+			c.updateConfig(config)
+		}
+	}
+	if len(configs) == 0 {
+		delete(c.files, path)
+	} else {
+		c.files[path] = configs
+	}
+
+	c.setErrors(path, errs)
+
+	// Let other files define the resources dropped by this one.
+	for _, key := range released {
+		for other := range c.shadowed[key] {
+			c.reloadFile(other)
+		}
+	}
+}
+
+func (c *Controller) setErrors(path string, errs []*Error) {
+	for _, err := range errs {
+		log.Warnf("Invalid config file: %v", err)
+	}
+	c.errorsMu.Lock()
+	defer c.errorsMu.Unlock()
+	if len(errs) == 0 {
+		delete(c.errors, path)
+	} else {
+		c.errors[path] = errs
+	}
+}
+
+func (c *Controller) createConfig(config model.Config) {
+	if _, err := c.ConfigStoreCache.Create(config); err != nil {
+		log.Warnf("Failed to create config %s: %v", config.Key(), err)
+	}
+}
+
+func (c *Controller) updateConfig(config model.Config) {
+	if prev := c.ConfigStoreCache.Get(config.Type, config.Name, config.Namespace); prev != nil {
+		config.ResourceVersion = prev.ResourceVersion
+	}
+	if _, err := c.ConfigStoreCache.Update(config); err != nil {
+		log.Warnf("Failed to update config %s: %v", config.Key(), err)
+	}
+}
+
+func (c *Controller) deleteConfig(config model.Config) {
+	if err := c.ConfigStoreCache.Delete(config.Type, config.Name, config.Namespace); err != nil {
+		log.Warnf("Failed to delete config %s: %v", config.Key(), err)
+	}
+}
+
+// sameConfig returns true if the resources have the same content.
+func sameConfig(a, b model.Config) bool {
+	return reflect.DeepEqual(a.Labels, b.Labels) &&
+		reflect.DeepEqual(a.Annotations, b.Annotations) &&
+		proto.Equal(a.Spec, b.Spec)
+}
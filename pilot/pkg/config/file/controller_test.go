@@ -0,0 +1,242 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	networking "istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+type event struct {
+	name  string
+	host  string
+	event model.Event
+}
+
+func (e event) String() string {
+	return fmt.Sprintf("%s %s %s", e.event, e.name, e.host)
+}
+
+type fixture struct {
+	t      *testing.T
+	dir    string
+	c      *Controller
+	events chan event
+	stop   chan struct{}
+}
+
+func newFixture(t *testing.T, files map[string]string) *fixture {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "file-controller")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fixture{t: t, dir: dir, events: make(chan event, 100), stop: make(chan struct{})}
+	for name, content := range files {
+		f.write(name, content)
+	}
+	f.start(dir, Options{Debounce: 10 * time.Millisecond})
+	return f
+}
+
+func (f *fixture) start(root string, options Options) {
+	f.t.Helper()
+	var err error
+	f.c, err = NewController(root, model.IstioConfigTypes, options)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	f.c.RegisterEventHandler(model.Gateway.Type, func(config model.Config, ev model.Event) {
+		f.events <- event{
+			name:  config.Name,
+			host:  config.Spec.(*networking.Gateway).Servers[0].Hosts[0],
+			event: ev,
+		}
+	})
+	go f.c.Run(f.stop)
+}
+
+func (f *fixture) close() {
+	close(f.stop)
+	_ = os.RemoveAll(f.dir)
+}
+
+func (f *fixture) write(name, content string) {
+	f.t.Helper()
+	path := filepath.Join(f.dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		f.t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+func (f *fixture) remove(name string) {
+	f.t.Helper()
+	if err := os.RemoveAll(filepath.Join(f.dir, name)); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// expect waits for the events, in any order, and fails on unexpected events.
+func (f *fixture) expect(want ...event) {
+	f.t.Helper()
+	pending := map[event]int{}
+	for _, e := range want {
+		pending[e]++
+	}
+	for i := 0; i < len(want); i++ {
+		select {
+		case e := <-f.events:
+			if pending[e] == 0 {
+				f.t.Fatalf("unexpected event %v, want %v", e, want)
+			}
+			pending[e]--
+		case <-time.After(5 * time.Second):
+			f.t.Fatalf("timed out waiting for events %v", want)
+		}
+	}
+	select {
+	case e := <-f.events:
+		f.t.Fatalf("unexpected event %v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func (f *fixture) expectErrors(n int) {
+	f.t.Helper()
+	if errs := f.c.Errors(); len(errs) != n {
+		f.t.Fatalf("got errors %v, want %d", errs, n)
+	}
+}
+
+func TestController(t *testing.T) {
+	f := newFixture(t, map[string]string{
+		"a.yaml":        gateway("a", "a.com"),
+		"sub/b.yml":     gateway("b", "b.com"),
+		"ignored.txt":   gateway("ignored", "ignored.com"),
+		".hidden.yaml":  gateway("hidden", "hidden.com"),
+		"sub/other.txt": "not yaml",
+	})
+	defer f.close()
+
+	f.expect(event{"a", "a.com", model.EventAdd}, event{"b", "b.com", model.EventAdd})
+	if configs, _ := f.c.List(model.Gateway.Type, ""); len(configs) != 2 {
+		t.Fatalf("got %d gateways, want 2", len(configs))
+	}
+
+	// Rewriting a file with the same content has no effect.
+	f.write("a.yaml", gateway("a", "a.com"))
+	f.expect()
+
+	f.write("a.yaml", gateway("a", "a2.com")+"---\n"+gateway("c", "c.com"))
+	f.expect(event{"a", "a2.com", model.EventUpdate}, event{"c", "c.com", model.EventAdd})
+
+	f.write("a.yaml", gateway("c", "c.com"))
+	f.expect(event{"a", "a2.com", model.EventDelete})
+
+	f.write("sub/new/d.yaml", gateway("d", "d.com"))
+	f.expect(event{"d", "d.com", model.EventAdd})
+
+	f.remove("sub")
+	f.expect(event{"b", "b.com", model.EventDelete}, event{"d", "d.com", model.EventDelete})
+	f.expectErrors(0)
+}
+
+func TestControllerInvalidResources(t *testing.T) {
+	f := newFixture(t, map[string]string{"a.yaml": gateway("a", "a.com")})
+	defer f.close()
+	f.expect(event{"a", "a.com", model.EventAdd})
+
+	// The last valid version of an invalid resource is kept.
+	f.write("a.yaml", gateway("a", "")+"---\n"+gateway("b", "b.com"))
+	f.expect(event{"b", "b.com", model.EventAdd})
+	f.expectErrors(1)
+	if err := f.c.Errors()[0]; err.File != filepath.Join(f.dir, "a.yaml") || err.Line != 1 {
+		t.Errorf("got error %v, want an error on line 1 of a.yaml", err)
+	}
+
+	f.write("a.yaml", gateway("a", "a2.com"))
+	f.expect(event{"a", "a2.com", model.EventUpdate}, event{"b", "b.com", model.EventDelete})
+	f.expectErrors(0)
+
+	f.write("b.yaml", "kind: [\n")
+	f.expect()
+	f.expectErrors(1)
+	f.remove("b.yaml")
+	f.expect()
+	f.expectErrors(0)
+}
+
+func TestControllerDuplicateResources(t *testing.T) {
+	f := newFixture(t, map[string]string{
+		"a.yaml": gateway("gw", "a.com"),
+		"b.yaml": gateway("gw", "b.com"),
+	})
+	defer f.close()
+
+	// Files are loaded in order, so the first one wins.
+	f.expect(event{"gw", "a.com", model.EventAdd})
+	f.expectErrors(1)
+
+	// The resource of the other file is used once the first one drops it.
+	f.remove("a.yaml")
+	f.expect(event{"gw", "a.com", model.EventDelete}, event{"gw", "b.com", model.EventAdd})
+	f.expectErrors(0)
+}
+
+func TestControllerReadOnly(t *testing.T) {
+	f := newFixture(t, nil)
+	defer f.close()
+
+	if _, err := f.c.Create(model.Config{}); err != errReadOnly {
+		t.Errorf("Create() got error %v, want %v", err, errReadOnly)
+	}
+	if _, err := f.c.Update(model.Config{}); err != errReadOnly {
+		t.Errorf("Update() got error %v, want %v", err, errReadOnly)
+	}
+	if err := f.c.Delete(model.Gateway.Type, "gw", "default"); err != errReadOnly {
+		t.Errorf("Delete() got error %v, want %v", err, errReadOnly)
+	}
+}
+
+func TestControllerMissingDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-controller")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fixture{t: t, dir: dir, events: make(chan event, 100), stop: make(chan struct{})}
+	defer f.close()
+	f.start(filepath.Join(f.dir, "config"), Options{Debounce: 10 * time.Millisecond, RetryInterval: 10 * time.Millisecond})
+
+	f.write("config/a.yaml", gateway("a", "a.com"))
+	f.expect(event{"a", "a.com", model.EventAdd})
+
+	f.remove("config")
+	f.expect(event{"a", "a.com", model.EventDelete})
+
+	f.write("config/b.yaml", gateway("b", "b.com"))
+	f.expect(event{"b", "b.com", model.EventAdd})
+	f.expectErrors(0)
+}
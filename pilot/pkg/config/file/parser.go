@@ -0,0 +1,128 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	kubeyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"istio.io/istio/pilot/pkg/config/kube/crd"
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// Error is a problem with a resource of a file.
+type Error struct {
+	File string
+	// Line is the first line of the YAML document of the resource, starting at 1.
+	Line int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+}
+
+// document is a YAML document of a file.
+type document struct {
+	line int
+	data []byte
+}
+
+// splitDocuments splits a YAML stream into documents, keeping track of the line where each
+// of them starts.
+func splitDocuments(data []byte) []document {
+	var docs []document
+	current := document{line: 1}
+	line := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if strings.HasPrefix(text, "---") && strings.TrimSpace(strings.TrimPrefix(text, "---")) == "" {
+			docs = append(docs, current)
+			current = document{line: line + 1}
+			continue
+		}
+		current.data = append(current.data, text...)
+		current.data = append(current.data, '\n')
+	}
+	return append(docs, current)
+}
+
+// isEmpty returns true if the document has only blank lines and comments.
+func (d document) isEmpty() bool {
+	for _, l := range strings.Split(string(d.data), "\n") {
+		l = strings.TrimSpace(l)
+		if l != "" && !strings.HasPrefix(l, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// parseResult is the outcome of parsing a file.
+type parseResult struct {
+	configs []model.Config
+	// invalid has the keys of the resources that failed validation. The key of resources
+	// that can't be decoded is unknown.
+	invalid map[string]struct{}
+	errors  []*Error
+}
+
+// parseFile parses and validates the resources of a file. Resources of types missing from
+// the descriptor are ignored, as are non Istio resources.
+func parseFile(path string, data []byte, descriptor model.ConfigDescriptor, domainSuffix string) parseResult {
+	result := parseResult{invalid: map[string]struct{}{}}
+	fail := func(doc document, format string, args ...interface{}) {
+		result.errors = append(result.errors, &Error{File: path, Line: doc.line, Err: fmt.Errorf(format, args...)})
+	}
+
+	for _, doc := range splitDocuments(data) {
+		if doc.isEmpty() {
+			continue
+		}
+
+		obj := crd.IstioKind{}
+		if err := kubeyaml.NewYAMLOrJSONDecoder(bytes.NewReader(doc.data), 512*1024).Decode(&obj); err != nil {
+			fail(doc, "cannot parse resource: %v", err)
+			continue
+		}
+		schema, exists := descriptor.GetByType(crd.CamelCaseToKebabCase(obj.Kind))
+		if !exists {
+			continue
+		}
+
+		config, err := crd.ConvertObject(schema, &obj, domainSuffix)
+		if err != nil {
+			fail(doc, "cannot parse %s %s/%s: %v", obj.Kind, obj.Namespace, obj.Name, err)
+			if obj.Name != "" {
+				result.invalid[model.Key(schema.Type, obj.Name, obj.Namespace)] = struct{}{}
+			}
+			continue
+		}
+		if err := schema.Validate(config.Name, config.Namespace, config.Spec); err != nil {
+			fail(doc, "invalid %s %s/%s: %v", obj.Kind, config.Namespace, config.Name, err)
+			result.invalid[config.Key()] = struct{}{}
+			continue
+		}
+		result.configs = append(result.configs, *config)
+	}
+	return result
+}
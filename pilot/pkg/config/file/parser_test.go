@@ -0,0 +1,94 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"strings"
+	"testing"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+func gateway(name, host string) string {
+	return `apiVersion: networking.istio.io/v1alpha3
+kind: Gateway
+metadata:
+  name: ` + name + `
+  namespace: default
+spec:
+  selector:
+    istio: ingressgateway
+  servers:
+  - port:
+      number: 80
+      name: http
+      protocol: HTTP
+    hosts:
+    - "` + host + `"
+`
+}
+
+func TestSplitDocuments(t *testing.T) {
+	docs := splitDocuments([]byte("a: 1\n---\n# comment\n---\nb: 2\nc: 3\n--- \nd: 4\n"))
+	want := []struct {
+		line  int
+		empty bool
+	}{{1, false}, {3, true}, {5, false}, {8, false}}
+	if len(docs) != len(want) {
+		t.Fatalf("got %d documents, want %d", len(docs), len(want))
+	}
+	for i, w := range want {
+		if docs[i].line != w.line || docs[i].isEmpty() != w.empty {
+			t.Errorf("document %d: got line %d empty %v, want line %d empty %v",
+				i, docs[i].line, docs[i].isEmpty(), w.line, w.empty)
+		}
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	data := strings.Join([]string{
+		gateway("valid", "*"),
+		// Not an Istio resource.
+		"apiVersion: v1\nkind: Service\nmetadata:\n  name: svc\n",
+		gateway("invalid", ""),
+		"kind: [\n",
+	}, "---\n")
+
+	result := parseFile("test.yaml", []byte(data), model.IstioConfigTypes, "cluster.local")
+
+	if len(result.configs) != 1 || result.configs[0].Name != "valid" || result.configs[0].Domain != "cluster.local" {
+		t.Errorf("got configs %v, want the valid gateway", result.configs)
+	}
+	if _, f := result.invalid[model.Key(model.Gateway.Type, "invalid", "default")]; !f || len(result.invalid) != 1 {
+		t.Errorf("got invalid keys %v, want the invalid gateway", result.invalid)
+	}
+
+	if len(result.errors) != 2 {
+		t.Fatalf("got errors %v, want 2", result.errors)
+	}
+	for i, want := range []string{"test.yaml:22: invalid Gateway default/invalid", "test.yaml:38: cannot parse resource"} {
+		if got := result.errors[i].Error(); !strings.HasPrefix(got, want) {
+			t.Errorf("got error %q, want prefix %q", got, want)
+		}
+	}
+}
+
+func TestParseFileFiltersTypes(t *testing.T) {
+	descriptor := model.ConfigDescriptor{model.VirtualService}
+	result := parseFile("test.yaml", []byte(gateway("gw", "*")), descriptor, "")
+	if len(result.configs) != 0 || len(result.errors) != 0 {
+		t.Errorf("got %v, want gateways to be ignored", result)
+	}
+}
@@ -71,6 +71,12 @@ type PushContext struct {
 
 	// sidecars for each namespace
 	sidecarsByNamespace map[string][]*SidecarScope
+
+	// configScopes has the visibility and the hosts of the virtual services, destination rules
+	// and service entries. previousConfigScopes are the ones of the push context this one
+	// replaced: a proxy may depend on the previous version of a changed config.
+	configScopes         map[ConfigKey]*configScope
+	previousConfigScopes map[ConfigKey]*configScope
 	////////// END ////////
 
 	// The following data is either a global index or used in the inbound path.
@@ -95,6 +101,10 @@ type PushContext struct {
 	// ServiceAccounts contains a map of hostname and port to service accounts.
 	ServiceAccounts map[Hostname]map[int][]string `json:"-"`
 
+	// ProxiesSkipped counts the proxies that were not pushed, since the config changes that
+	// triggered the push did not affect them.
+	ProxiesSkipped int `json:"proxiesSkipped,omitempty"`
+
 	initDone bool
 }
 
@@ -142,7 +152,91 @@ type XDSUpdater interface {
 	// ConfigUpdate is called to notify the XDS server of config updates and request a push.
 	// The requests may be collapsed and throttled.
 	// This replaces the 'cache invalidation' model.
-	ConfigUpdate(full bool)
+	ConfigUpdate(req *PushRequest)
+}
+
+// configScope has what determines the proxies using a virtual service, destination rule or
+// service entry.
+type configScope struct {
+	// public is true if the config is visible from all namespaces.
+	public bool
+	hosts  []Hostname
+}
+
+// ConfigKey identifies a config object.
+type ConfigKey struct {
+	// Type is the config type, for example "virtual-service".
+	Type      string
+	Name      string
+	Namespace string
+}
+
+// ServiceConfigType is the type of the config keys of services changed in a service registry.
+// Their name is the hostname of the service.
+const ServiceConfigType = "service"
+
+// ServiceConfigKey returns the config key of a service changed in a service registry.
+func ServiceConfigKey(svc *Service) ConfigKey {
+	return ConfigKey{Type: ServiceConfigType, Name: string(svc.Hostname), Namespace: svc.Attributes.Namespace}
+}
+
+// PushRequest describes a request to push config to proxies, and the changes that
+// triggered it. Requests are merged while debouncing.
+type PushRequest struct {
+	// Full is set if the request requires recomputing the full config. Otherwise only
+	// endpoints are pushed.
+	Full bool
+
+	// ConfigsUpdated has the config objects that changed, for a full push. It is empty
+	// if the push was triggered by a change that may affect any proxy, for example a
+	// service registry or mesh config change, in which case all proxies are pushed.
+	ConfigsUpdated map[ConfigKey]struct{}
+
+	// EdsUpdates has the hostnames of the services whose endpoints changed.
+	EdsUpdates map[string]struct{}
+}
+
+// Merge two push requests. A full push is targeted to the changed configs only if
+// both requests are targeted.
+func (pr *PushRequest) Merge(other *PushRequest) *PushRequest {
+	if pr == nil {
+		return other
+	}
+	if other == nil {
+		return pr
+	}
+
+	merged := &PushRequest{
+		Full: pr.Full || other.Full,
+	}
+
+	switch {
+	case pr.Full && other.Full:
+		if len(pr.ConfigsUpdated) > 0 && len(other.ConfigsUpdated) > 0 {
+			merged.ConfigsUpdated = make(map[ConfigKey]struct{}, len(pr.ConfigsUpdated)+len(other.ConfigsUpdated))
+			for k := range pr.ConfigsUpdated {
+				merged.ConfigsUpdated[k] = struct{}{}
+			}
+			for k := range other.ConfigsUpdated {
+				merged.ConfigsUpdated[k] = struct{}{}
+			}
+		}
+	case pr.Full:
+		merged.ConfigsUpdated = pr.ConfigsUpdated
+	case other.Full:
+		merged.ConfigsUpdated = other.ConfigsUpdated
+	}
+
+	if len(pr.EdsUpdates) > 0 || len(other.EdsUpdates) > 0 {
+		merged.EdsUpdates = make(map[string]struct{}, len(pr.EdsUpdates)+len(other.EdsUpdates))
+		for k := range pr.EdsUpdates {
+			merged.EdsUpdates[k] = struct{}{}
+		}
+		for k := range other.EdsUpdates {
+			merged.EdsUpdates[k] = struct{}{}
+		}
+	}
+	return merged
 }
 
 // ProxyPushStatus represents an event captured during config push to proxies.
@@ -304,6 +398,7 @@ func NewPushContext() *PushContext {
 			destRule: map[Hostname]*combinedDestinationRule{},
 		},
 		sidecarsByNamespace: map[string][]*SidecarScope{},
+		configScopes:        map[ConfigKey]*configScope{},
 
 		ServiceByHostname:     map[Hostname]*Service{},
 		ProxyStatus:           map[string]map[string]ProxyPushStatus{},
@@ -323,6 +418,68 @@ func (ps *PushContext) JSON() ([]byte, error) {
 	return json.MarshalIndent(ps, "", "    ")
 }
 
+// AddSkippedProxies records proxies that were not pushed, since they are not affected by
+// the config changes.
+func (ps *PushContext) AddSkippedProxies(n int) {
+	ps.proxyStatusMutex.Lock()
+	ps.ProxiesSkipped += n
+	ps.proxyStatusMutex.Unlock()
+}
+
+// SetPreviousContext keeps the visibility and the hosts of the configs of the push context
+// replaced by this one, to find the proxies depending on the previous version of a changed
+// config.
+func (ps *PushContext) SetPreviousContext(previous *PushContext) {
+	if previous == nil {
+		return
+	}
+	ps.previousConfigScopes = previous.configScopes
+}
+
+// DependsOnConfig returns true if a proxy of the namespace, using the sidecar scope, may be
+// affected by a change of a virtual service, destination rule, service entry or service. The
+// proxy depends on a config if either its previous or its current version is visible from the
+// namespace and has a host imported by the scope. Unknown configs are assumed to affect
+// the proxy.
+func (ps *PushContext) DependsOnConfig(namespace string, sc *SidecarScope, key ConfigKey) bool {
+	if key.Type == ServiceConfigType {
+		// The instances of the service, in its namespace, have inbound listeners for it.
+		if key.Namespace == namespace {
+			return true
+		}
+		return sc.DependsOnHosts(key.Type, key.Namespace, []Hostname{Hostname(key.Name)})
+	}
+
+	previous, current := ps.previousConfigScopes[key], ps.configScopes[key]
+	if previous == nil && current == nil {
+		return true
+	}
+
+	for _, scope := range []*configScope{previous, current} {
+		if scope == nil {
+			continue
+		}
+		if !scope.public && key.Namespace != namespace {
+			continue
+		}
+		if sc.DependsOnHosts(key.Type, key.Namespace, scope.hosts) {
+			return true
+		}
+	}
+	return false
+}
+
+// addConfigScope records the visibility and the hosts of a config.
+func (ps *PushContext) addConfigScope(c *Config, public bool, hosts []Hostname) {
+	if ps.configScopes == nil {
+		ps.configScopes = map[ConfigKey]*configScope{}
+	}
+	ps.configScopes[ConfigKey{Type: c.Type, Name: c.Name, Namespace: c.Namespace}] = &configScope{
+		public: public,
+		hosts:  hosts,
+	}
+}
+
 // OnConfigChange is called when a config change is detected.
 func (ps *PushContext) OnConfigChange() {
 	LastPushMutex.Lock()
@@ -584,6 +741,10 @@ func (ps *PushContext) InitContext(env *Environment) error {
 		return err
 	}
 
+	if err = ps.initServiceEntries(env); err != nil {
+		return err
+	}
+
 	if err = ps.initAuthorizationPolicies(env); err != nil {
 		rbacLog.Errorf("failed to initialize authorization policies: %v", err)
 		return err
@@ -730,9 +891,10 @@ func (ps *PushContext) initVirtualServices(env *Environment) error {
 		}
 	}
 
-	for _, virtualService := range vservices {
+	for i, virtualService := range vservices {
 		ns := virtualService.Namespace
 		rule := virtualService.Spec.(*networking.VirtualService)
+		public := false
 		if len(rule.ExportTo) == 0 {
 			// No exportTo in virtualService. Use the global default
 			// TODO: We currently only honor ., * and ~
@@ -741,6 +903,7 @@ func (ps *PushContext) initVirtualServices(env *Environment) error {
 				ps.privateVirtualServicesByNamespace[ns] = append(ps.privateVirtualServicesByNamespace[ns], virtualService)
 			} else if ps.defaultVirtualServiceExportTo[VisibilityPublic] {
 				ps.publicVirtualServices = append(ps.publicVirtualServices, virtualService)
+				public = true
 			}
 		} else {
 			// TODO: we currently only process the first element in the array
@@ -752,8 +915,15 @@ func (ps *PushContext) initVirtualServices(env *Environment) error {
 				// ~ is not valid in the exportTo fields in virtualServices, services, destination rules
 				// and we currently only allow . or *. So treat this as public export
 				ps.publicVirtualServices = append(ps.publicVirtualServices, virtualService)
+				public = true
 			}
 		}
+
+		hosts := make([]Hostname, 0, len(rule.Hosts))
+		for _, h := range rule.Hosts {
+			hosts = append(hosts, Hostname(h))
+		}
+		ps.addConfigScope(&vservices[i], public, hosts)
 	}
 
 	return nil
@@ -922,6 +1092,8 @@ func (ps *PushContext) SetDestinationRules(configs []Config) {
 			}
 		}
 
+		ps.addConfigScope(&configs[i], isPubliclyExported, []Hostname{Hostname(rule.Host)})
+
 		if isPubliclyExported {
 			if _, exist := namespaceExportedDestRules[configs[i].Namespace]; !exist {
 				namespaceExportedDestRules[configs[i].Namespace] = &processedDestRules{
@@ -958,6 +1130,33 @@ func (ps *PushContext) SetDestinationRules(configs []Config) {
 	ps.allExportedDestRules = allExportedDestRules
 }
 
+// Caches the visibility and the hosts of the service entries. Their services are part of
+// the service registry.
+func (ps *PushContext) initServiceEntries(env *Environment) error {
+	serviceEntries, err := env.List(ServiceEntry.Type, NamespaceAll)
+	if err != nil {
+		return err
+	}
+
+	for i := range serviceEntries {
+		c := &serviceEntries[i]
+		se := c.Spec.(*networking.ServiceEntry)
+		public := false
+		if len(se.ExportTo) == 0 {
+			public = !ps.defaultServiceExportTo[VisibilityPrivate] && ps.defaultServiceExportTo[VisibilityPublic]
+		} else {
+			public = Visibility(se.ExportTo[0]) != VisibilityPrivate
+		}
+
+		hosts := make([]Hostname, 0, len(se.Hosts))
+		for _, h := range se.Hosts {
+			hosts = append(hosts, ResolveShortnameToFQDN(h, c.ConfigMeta))
+		}
+		ps.addConfigScope(c, public, hosts)
+	}
+	return nil
+}
+
 func (ps *PushContext) initAuthorizationPolicies(env *Environment) error {
 	var err error
 	if ps.AuthzPolicies, err = NewAuthzPolicies(env); err != nil {
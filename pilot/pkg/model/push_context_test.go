@@ -0,0 +1,82 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPushRequestMerge(t *testing.T) {
+	vs := ConfigKey{Type: VirtualService.Type, Name: "vs", Namespace: "ns"}
+	dr := ConfigKey{Type: DestinationRule.Type, Name: "dr", Namespace: "ns"}
+
+	tests := []struct {
+		name     string
+		left     *PushRequest
+		right    *PushRequest
+		expected *PushRequest
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"nil left",
+			nil,
+			&PushRequest{Full: true},
+			&PushRequest{Full: true},
+		},
+		{
+			"targeted full",
+			&PushRequest{Full: true, ConfigsUpdated: map[ConfigKey]struct{}{vs: {}}},
+			&PushRequest{Full: true, ConfigsUpdated: map[ConfigKey]struct{}{dr: {}}},
+			&PushRequest{Full: true, ConfigsUpdated: map[ConfigKey]struct{}{vs: {}, dr: {}}},
+		},
+		{
+			"untargeted full",
+			&PushRequest{Full: true, ConfigsUpdated: map[ConfigKey]struct{}{vs: {}}},
+			&PushRequest{Full: true},
+			&PushRequest{Full: true},
+		},
+		{
+			"incremental keeps target",
+			&PushRequest{Full: true, ConfigsUpdated: map[ConfigKey]struct{}{vs: {}}},
+			&PushRequest{EdsUpdates: map[string]struct{}{"a": {}}},
+			&PushRequest{
+				Full:           true,
+				ConfigsUpdated: map[ConfigKey]struct{}{vs: {}},
+				EdsUpdates:     map[string]struct{}{"a": {}},
+			},
+		},
+		{
+			"incremental",
+			&PushRequest{EdsUpdates: map[string]struct{}{"a": {}}},
+			&PushRequest{EdsUpdates: map[string]struct{}{"b": {}}},
+			&PushRequest{EdsUpdates: map[string]struct{}{"a": {}, "b": {}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.left.Merge(tt.right)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Merge => got %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -248,6 +248,40 @@ func (sc *SidecarScope) Services() []*Service {
 	return sc.services
 }
 
+// DependsOnHosts returns true if the sidecar scope imports any of the hosts of a config of
+// the given type and namespace. Virtual services, service entries and services are imported
+// through the hosts of their own namespace. Destination rules apply to the imported services
+// matching their host, whatever namespace they are defined in.
+func (sc *SidecarScope) DependsOnHosts(configType, namespace string, hosts []Hostname) bool {
+	if sc == nil {
+		return true
+	}
+
+	if configType == DestinationRule.Type {
+		for _, s := range sc.services {
+			for _, h := range hosts {
+				if h.Matches(s.Hostname) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for _, l := range sc.EgressListeners {
+		for _, ns := range []string{namespace, wildcardNamespace} {
+			for _, importedHost := range l.listenerHosts[ns] {
+				for _, h := range hosts {
+					if importedHost.Matches(h) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
 // DestinationRule returns the destination rule applicable for a given hostname
 // used by CDS code
 func (sc *SidecarScope) DestinationRule(hostname Hostname) *Config {
@@ -26,6 +26,7 @@ import (
 
 	adminapi "github.com/envoyproxy/go-control-plane/envoy/admin/v2alpha"
 	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	ads "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
 	"github.com/gogo/protobuf/types"
 	"github.com/prometheus/client_golang/prometheus"
@@ -181,6 +182,20 @@ var (
 		Help: "Total number of updates received by pilot.",
 	}, []string{"type"})
 
+	proxiesSkipped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "pilot_xds_proxies_skipped",
+		Help: "Number of proxies not pushed, since the config changes did not affect them.",
+	})
+
+	deltaResources = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pilot_xds_delta_resources",
+		Help: "Resources sent, removed or skipped as unchanged on incremental xDS connections.",
+	}, []string{"type"})
+
+	deltaSentResources      = deltaResources.WithLabelValues("sent")
+	deltaRemovedResources   = deltaResources.WithLabelValues("removed")
+	deltaUnchangedResources = deltaResources.WithLabelValues("unchanged")
+
 	inboundConfigUpdates   = inboundUpdates.WithLabelValues("config")
 	inboundEDSUpdates      = inboundUpdates.WithLabelValues("eds")
 	inboundServiceUpdates  = inboundUpdates.WithLabelValues("svc")
@@ -207,6 +222,8 @@ func init() {
 	prometheus.MustRegister(pushContextErrors)
 	prometheus.MustRegister(totalXDSInternalErrors)
 	prometheus.MustRegister(inboundUpdates)
+	prometheus.MustRegister(proxiesSkipped)
+	prometheus.MustRegister(deltaResources)
 }
 
 // DiscoveryStream is a common interface for EDS and ADS. It also has a
@@ -260,6 +277,14 @@ type XdsConnection struct {
 	// Both ADS and EDS streams implement this interface
 	stream DiscoveryStream
 
+	// deltaStream is set instead of stream for connections using the incremental xDS
+	// protocol. Responses generated by the push functions are converted to deltas.
+	deltaStream DeltaDiscoveryStream
+
+	// deltaResources tracks, for incremental xDS connections, the resources sent to and
+	// ACKed by the client, keyed by type URL.
+	deltaResources map[string]*deltaResourceState
+
 	// Routes is the list of watched Routes.
 	Routes []string
 
@@ -434,7 +459,7 @@ func (s *DiscoveryServer) StreamAggregatedResources(stream ads.AggregatedDiscove
 				// Remote side closed connection.
 				return receiveError
 			}
-			err = s.initConnectionNode(discReq, con)
+			err = s.initConnectionNode(discReq.Node, con)
 			if err != nil {
 				return err
 			}
@@ -635,7 +660,7 @@ func (s *DiscoveryServer) StreamAggregatedResources(stream ads.AggregatedDiscove
 }
 
 // update the node associated with the connection, after receiving a a packet from envoy.
-func (s *DiscoveryServer) initConnectionNode(discReq *xdsapi.DiscoveryRequest, con *XdsConnection) error {
+func (s *DiscoveryServer) initConnectionNode(node *core.Node, con *XdsConnection) error {
 	con.mu.RLock() // may not be needed - once per connection, but locking for consistency.
 	if con.modelNode != nil {
 		con.mu.RUnlock()
@@ -643,10 +668,10 @@ func (s *DiscoveryServer) initConnectionNode(discReq *xdsapi.DiscoveryRequest, c
 	}
 	con.mu.RUnlock()
 
-	if discReq.Node == nil || discReq.Node.Id == "" {
+	if node == nil || node.Id == "" {
 		return errors.New("missing node id")
 	}
-	nt, err := model.ParseServiceNodeWithMetadata(discReq.Node.Id, model.ParseMetadata(discReq.Node.Metadata))
+	nt, err := model.ParseServiceNodeWithMetadata(node.Id, model.ParseMetadata(node.Metadata))
 	if err != nil {
 		return err
 	}
@@ -667,7 +692,7 @@ func (s *DiscoveryServer) initConnectionNode(discReq *xdsapi.DiscoveryRequest, c
 	// This is not preferable as only the connected Pilot is aware of this proxies location, but it
 	// can still help provide some client-side Envoy context when load balancing based on location.
 	if util.IsLocalityEmpty(nt.Locality) {
-		nt.Locality = discReq.Node.Locality
+		nt.Locality = node.Locality
 	}
 
 	if err := nt.SetWorkloadLabels(s.Env); err != nil {
@@ -689,18 +714,13 @@ func (s *DiscoveryServer) initConnectionNode(discReq *xdsapi.DiscoveryRequest, c
 	con.modelNode = nt
 	if con.ConID == "" {
 		// first request
-		con.ConID = connectionID(discReq.Node.Id)
+		con.ConID = connectionID(node.Id)
 	}
 	con.mu.Unlock()
 
 	return nil
 }
 
-// DeltaAggregatedResources is not implemented.
-func (s *DiscoveryServer) DeltaAggregatedResources(stream ads.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) error {
-	return status.Errorf(codes.Unimplemented, "not implemented")
-}
-
 // Compute and send the new configuration for a connection. This is blocking and may be slow
 // for large configs. The method will hold a lock on con.pushMutex.
 func (s *DiscoveryServer) pushConnection(con *XdsConnection, pushEv *XdsEvent) error {
@@ -806,16 +826,15 @@ func adsClientCount() int {
 
 // AdsPushAll will send updates to all nodes, for a full config or incremental EDS.
 func AdsPushAll(s *DiscoveryServer) {
-	s.AdsPushAll(versionInfo(), s.globalPushContext(), true, nil)
+	s.AdsPushAll(versionInfo(), s.globalPushContext(), &model.PushRequest{Full: true})
 }
 
 // AdsPushAll implements old style invalidation, generated when any rule or endpoint changes.
 // Primary code path is from v1 discoveryService.clearCache(), which is added as a handler
 // to the model ConfigStorageCache and Controller.
-func (s *DiscoveryServer) AdsPushAll(version string, push *model.PushContext,
-	full bool, edsUpdates map[string]struct{}) {
-	if !full {
-		s.edsIncremental(version, push, edsUpdates)
+func (s *DiscoveryServer) AdsPushAll(version string, push *model.PushContext, req *model.PushRequest) {
+	if !req.Full {
+		s.edsIncremental(version, push, req)
 		return
 	}
 
@@ -845,12 +864,11 @@ func (s *DiscoveryServer) AdsPushAll(version string, push *model.PushContext,
 		}
 	}
 	adsLog.Infof("Cluster init time %v %s", time.Since(t0), version)
-	s.startPush(version, push, true, nil)
+	s.startPush(version, push, req)
 }
 
 // Send a signal to all connections, with a push event.
-func (s *DiscoveryServer) startPush(version string, push *model.PushContext, full bool,
-	edsUpdates map[string]struct{}) {
+func (s *DiscoveryServer) startPush(version string, push *model.PushContext, req *model.PushRequest) {
 
 	// Push config changes, iterating over connected envoys. This cover ADS and EDS(0.7), both share
 	// the same connection table
@@ -867,6 +885,29 @@ func (s *DiscoveryServer) startPush(version string, push *model.PushContext, ful
 	// TODO: get service, serviceinstances, configs once, to avoid repeated redundant calls.
 	// TODO: indicate the specific events, to only push what changed.
 
+	// A full push targeted to specific configs skips the proxies that don't depend on them.
+	// Skipped proxies still get the pending endpoint updates, if any.
+	var edsOnlyClients map[*XdsConnection]bool
+	if req.Full && len(req.ConfigsUpdated) > 0 {
+		skipped := 0
+		edsOnlyClients = map[*XdsConnection]bool{}
+		affected := pending[:0]
+		for _, client := range pending {
+			if proxyNeedsPush(client.modelNode, push, req.ConfigsUpdated) {
+				affected = append(affected, client)
+				continue
+			}
+			skipped++
+			if len(req.EdsUpdates) > 0 {
+				edsOnlyClients[client] = true
+				affected = append(affected, client)
+			}
+		}
+		pending = affected
+		push.AddSkippedProxies(skipped)
+		proxiesSkipped.Add(float64(skipped))
+	}
+
 	pendingPush := int32(len(pending))
 
 	tstart := time.Now()
@@ -882,8 +923,8 @@ func (s *DiscoveryServer) startPush(version string, push *model.PushContext, ful
 		pending = pending[1:]
 
 		// indicates whether to do a full push for the proxy
-		proxyFull := full
-		if !full {
+		proxyFull := req.Full && !edsOnlyClients[client]
+		if !proxyFull {
 			s.proxyUpdatesMutex.Lock()
 			if _, ok := s.proxyUpdates[client.modelNode.IPAddresses[0]]; ok {
 				proxyFull = true
@@ -900,7 +941,7 @@ func (s *DiscoveryServer) startPush(version string, push *model.PushContext, ful
 				wg.Done()
 			}()
 
-			edsOnly := edsUpdates
+			edsOnly := req.EdsUpdates
 			if proxyFull {
 				edsOnly = nil
 			}
@@ -924,7 +965,7 @@ func (s *DiscoveryServer) startPush(version string, push *model.PushContext, ful
 				if !timer.Stop() {
 					<-timer.C
 				}
-			case <-client.stream.Context().Done(): // grpc stream was closed
+			case <-client.streamContext().Done(): // grpc stream was closed
 				adsLog.Infof("Client closed connection %v", client.ConID)
 			case <-timer.C:
 				// This may happen to some clients if the other side is in a bad state and can't receive.
@@ -992,8 +1033,19 @@ func (s *DiscoveryServer) removeCon(conID string, con *XdsConnection) {
 	}
 }
 
+// streamContext returns the context of the gRPC stream backing the connection.
+func (conn *XdsConnection) streamContext() context.Context {
+	if conn.deltaStream != nil {
+		return conn.deltaStream.Context()
+	}
+	return conn.stream.Context()
+}
+
 // Send with timeout
 func (conn *XdsConnection) send(res *xdsapi.DiscoveryResponse) error {
+	if conn.deltaStream != nil {
+		return conn.sendDelta(res, true)
+	}
 	done := make(chan error)
 	// hardcoded for now - not sure if we need a setting
 	t := time.NewTimer(SendTimeout)
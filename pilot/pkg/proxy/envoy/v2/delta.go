@@ -0,0 +1,442 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	ads "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	"github.com/gogo/protobuf/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Incremental (delta) xDS.
+//
+// Config generation is shared with the state-of-the-world protocol: the push functions
+// build the complete set of resources for a type and call XdsConnection.send. For
+// connections using the delta protocol the response is converted, before sending, into
+// a DeltaDiscoveryResponse holding only the resources whose version changed since the
+// previous response, and the names of the resources that are no longer present.
+//
+// The version of a resource is a hash of its serialization, so unchanged resources are
+// detected across pushes without keeping a copy of the config per proxy. The generated
+// envoy types don't support deterministic marshaling: a resource including a map with
+// more than one entry may occasionally be resent even if it did not change.
+
+// DeltaDiscoveryStream is the server side of an incremental xDS stream.
+type DeltaDiscoveryStream interface {
+	Send(*xdsapi.DeltaDiscoveryResponse) error
+	Recv() (*xdsapi.DeltaDiscoveryRequest, error)
+	grpc.ServerStream
+}
+
+// deltaResourceState tracks the resources of one type known to an incremental xDS client.
+type deltaResourceState struct {
+	// sent has the versions of the resources, keyed by name, the client will have after
+	// applying the last response that was sent.
+	sent map[string]string
+
+	// acked has the versions of the resources, keyed by name, as of the last ACK. On NACK
+	// the client keeps the previous config, and sent is reset to this.
+	acked map[string]string
+
+	// nonceSent is the nonce of the last response for this type.
+	nonceSent string
+
+	// forceResponse is set when a request must be answered even if no resource changed,
+	// so the client is not left waiting for the initial response.
+	forceResponse bool
+}
+
+func newDeltaResourceState() *deltaResourceState {
+	return &deltaResourceState{
+		sent:  map[string]string{},
+		acked: map[string]string{},
+	}
+}
+
+func copyVersions(in map[string]string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+func newDeltaXdsConnection(peerAddr string, stream DeltaDiscoveryStream) *XdsConnection {
+	con := newXdsConnection(peerAddr, nil)
+	con.deltaStream = stream
+	con.deltaResources = map[string]*deltaResourceState{}
+	return con
+}
+
+// deltaState returns the tracked state for a type, creating it if needed. Must be called
+// with con.mu held.
+func (conn *XdsConnection) deltaState(typeURL string) *deltaResourceState {
+	st, f := conn.deltaResources[typeURL]
+	if !f {
+		st = newDeltaResourceState()
+		conn.deltaResources[typeURL] = st
+	}
+	return st
+}
+
+// resourceVersion returns the name of an xDS resource and a version derived from its content.
+func resourceVersion(r *types.Any) (string, string, error) {
+	// TODO: avoid decoding the resource just to get the name.
+	var msg types.DynamicAny
+	if err := types.UnmarshalAny(r, &msg); err != nil {
+		return "", "", err
+	}
+	var name string
+	switch m := msg.Message.(type) {
+	case *xdsapi.Cluster:
+		name = m.Name
+	case *xdsapi.Listener:
+		name = m.Name
+	case *xdsapi.RouteConfiguration:
+		name = m.Name
+	case *xdsapi.ClusterLoadAssignment:
+		name = m.ClusterName
+	default:
+		return "", "", fmt.Errorf("unsupported resource type %s", r.TypeUrl)
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write(r.Value)
+	return name, strconv.FormatUint(h.Sum64(), 16), nil
+}
+
+// sendDelta converts a state-of-the-world response to an incremental response, and sends it
+// if any resource was changed or removed. If full is false, the response is a partial update
+// and resources missing from it are not removed.
+func (conn *XdsConnection) sendDelta(res *xdsapi.DiscoveryResponse, full bool) error {
+	out := &xdsapi.DeltaDiscoveryResponse{
+		SystemVersionInfo: res.VersionInfo,
+		Nonce:             res.Nonce,
+	}
+
+	conn.mu.Lock()
+	st := conn.deltaState(res.TypeUrl)
+	next := copyVersions(st.sent)
+	seen := make(map[string]struct{}, len(res.Resources))
+	unchanged := 0
+	for i := range res.Resources {
+		r := &res.Resources[i]
+		name, version, err := resourceVersion(r)
+		if err != nil {
+			conn.mu.Unlock()
+			totalXDSInternalErrors.Add(1)
+			return err
+		}
+		seen[name] = struct{}{}
+		if st.sent[name] == version {
+			unchanged++
+			continue
+		}
+		next[name] = version
+		out.Resources = append(out.Resources, xdsapi.Resource{
+			Name:     name,
+			Version:  version,
+			Resource: r,
+		})
+	}
+	if full {
+		for name := range st.sent {
+			if _, f := seen[name]; !f {
+				out.RemovedResources = append(out.RemovedResources, name)
+				delete(next, name)
+			}
+		}
+		sort.Strings(out.RemovedResources)
+	}
+	deltaUnchangedResources.Add(float64(unchanged))
+
+	if len(out.Resources) == 0 && len(out.RemovedResources) == 0 && !st.forceResponse {
+		conn.mu.Unlock()
+		adsLog.Debugf("ADS:DELTA: no changes for %s type:%s", conn.ConID, res.TypeUrl)
+		return nil
+	}
+	st.forceResponse = false
+	st.sent = next
+	st.nonceSent = out.Nonce
+	switch res.TypeUrl {
+	case ClusterType:
+		conn.ClusterNonceSent = out.Nonce
+	case ListenerType:
+		conn.ListenerNonceSent = out.Nonce
+	case RouteType:
+		conn.RouteNonceSent = out.Nonce
+		conn.RouteVersionInfoSent = out.SystemVersionInfo
+	case EndpointType:
+		conn.EndpointNonceSent = out.Nonce
+	}
+	conn.mu.Unlock()
+
+	deltaSentResources.Add(float64(len(out.Resources)))
+	deltaRemovedResources.Add(float64(len(out.RemovedResources)))
+	return conn.sendDeltaResponse(out)
+}
+
+// Send a delta response with timeout.
+func (conn *XdsConnection) sendDeltaResponse(res *xdsapi.DeltaDiscoveryResponse) error {
+	done := make(chan error, 1)
+	t := time.NewTimer(SendTimeout)
+	go func() {
+		done <- conn.deltaStream.Send(res)
+	}()
+	select {
+	case <-t.C:
+		adsLog.Infof("Timeout writing %s", conn.ConID)
+		xdsResponseWriteTimeouts.Add(1)
+		return errors.New("timeout sending")
+	case err := <-done:
+		t.Stop()
+		return err
+	}
+}
+
+func receiveDeltaThread(con *XdsConnection, reqChannel chan *xdsapi.DeltaDiscoveryRequest, errP *error) {
+	defer close(reqChannel) // indicates close of the remote side.
+	for {
+		req, err := con.deltaStream.Recv()
+		if err != nil {
+			if status.Code(err) == codes.Canceled || err == io.EOF {
+				con.mu.RLock()
+				adsLog.Infof("ADS:DELTA: %q %s terminated %v", con.PeerAddr, con.ConID, err)
+				con.mu.RUnlock()
+				return
+			}
+			*errP = err
+			adsLog.Errorf("ADS:DELTA: %q %s terminated with error: %v", con.PeerAddr, con.ConID, err)
+			totalXDSInternalErrors.Add(1)
+			return
+		}
+		select {
+		case reqChannel <- req:
+		case <-con.deltaStream.Context().Done():
+			adsLog.Errorf("ADS:DELTA: %q %s terminated with stream closed", con.PeerAddr, con.ConID)
+			return
+		}
+	}
+}
+
+// DeltaAggregatedResources implements the incremental ADS interface. Pushes are triggered the
+// same way as for StreamAggregatedResources, but only changed and removed resources are sent.
+func (s *DiscoveryServer) DeltaAggregatedResources(stream ads.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) error {
+	peerInfo, ok := peer.FromContext(stream.Context())
+	peerAddr := "0.0.0.0"
+	if ok {
+		peerAddr = peerInfo.Addr.String()
+	}
+
+	t0 := time.Now()
+	_ = s.initRateLimiter.Wait(context.TODO())
+
+	err := s.globalPushContext().InitContext(s.Env)
+	if err != nil {
+		adsLog.Warnf("Error reading config %v", err)
+		return err
+	}
+	con := newDeltaXdsConnection(peerAddr, stream)
+
+	var receiveError error
+	reqChannel := make(chan *xdsapi.DeltaDiscoveryRequest, 1)
+	go receiveDeltaThread(con, reqChannel, &receiveError)
+
+	for {
+		select {
+		case req, ok := <-reqChannel:
+			if !ok {
+				// Remote side closed connection.
+				return receiveError
+			}
+			err = s.initConnectionNode(req.Node, con)
+			if err != nil {
+				return err
+			}
+			adsLog.Debugf("ADS:DELTA: REQ %s %s %s %v subscribe:%d unsubscribe:%d", peerAddr, con.ConID, req.TypeUrl,
+				time.Since(t0), len(req.ResourceNamesSubscribe), len(req.ResourceNamesUnsubscribe))
+			err = s.processDeltaRequest(con, req)
+			if err != nil {
+				return err
+			}
+
+			con.mu.Lock()
+			if !con.added {
+				con.added = true
+				con.mu.Unlock()
+				s.addCon(con.ConID, con)
+				defer s.removeCon(con.ConID, con)
+			} else {
+				con.mu.Unlock()
+			}
+		case pushEv := <-con.pushChannel:
+			err := s.pushConnection(con, pushEv)
+			if err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// processDeltaRequest handles ACKs, NACKs and subscription changes sent by an incremental
+// xDS client, pushing the requested resources if needed.
+func (s *DiscoveryServer) processDeltaRequest(con *XdsConnection, req *xdsapi.DeltaDiscoveryRequest) error {
+	if req.ResponseNonce != "" {
+		s.processDeltaAck(con, req)
+		if len(req.ResourceNamesSubscribe) == 0 && len(req.ResourceNamesUnsubscribe) == 0 {
+			return nil
+		}
+	}
+
+	con.mu.Lock()
+	st := con.deltaState(req.TypeUrl)
+	// The client already has these resources, from a previous connection. Only resources
+	// with a different version will be sent.
+	for name, version := range req.InitialResourceVersions {
+		st.sent[name] = version
+		st.acked[name] = version
+	}
+	// Unsubscribed resources are dropped from the tracked state without sending a removal.
+	for _, name := range req.ResourceNamesUnsubscribe {
+		delete(st.sent, name)
+		delete(st.acked, name)
+	}
+	wildcard := req.TypeUrl == ClusterType || req.TypeUrl == ListenerType
+	if wildcard || len(req.ResourceNamesSubscribe) > 0 {
+		st.forceResponse = true
+	}
+	con.mu.Unlock()
+
+	switch req.TypeUrl {
+	case ClusterType:
+		con.CDSWatch = true
+		return s.pushCds(con, s.globalPushContext(), versionInfo())
+
+	case ListenerType:
+		con.LDSWatch = true
+		return s.pushLds(con, s.globalPushContext(), versionInfo())
+
+	case RouteType:
+		con.Routes = updateSubscriptions(con.Routes, req.ResourceNamesSubscribe, req.ResourceNamesUnsubscribe)
+		if len(req.ResourceNamesSubscribe) == 0 || len(con.Routes) == 0 {
+			return nil
+		}
+		return s.pushRoute(con, s.globalPushContext(), versionInfo())
+
+	case EndpointType:
+		for _, cn := range req.ResourceNamesUnsubscribe {
+			s.removeEdsCon(cn, con.ConID)
+		}
+		for _, cn := range req.ResourceNamesSubscribe {
+			s.addEdsCon(cn, con.ConID, con)
+		}
+		con.Clusters = updateSubscriptions(con.Clusters, req.ResourceNamesSubscribe, req.ResourceNamesUnsubscribe)
+		if len(req.ResourceNamesSubscribe) == 0 || len(con.Clusters) == 0 {
+			return nil
+		}
+		return s.pushEds(s.globalPushContext(), con, versionInfo(), nil)
+
+	default:
+		adsLog.Warnf("ADS:DELTA: Unknown watched resources %s", req.String())
+	}
+	return nil
+}
+
+// processDeltaAck records an ACK or NACK for the last response of a type.
+func (s *DiscoveryServer) processDeltaAck(con *XdsConnection, req *xdsapi.DeltaDiscoveryRequest) {
+	con.mu.Lock()
+	defer con.mu.Unlock()
+	st := con.deltaState(req.TypeUrl)
+
+	if req.ErrorDetail != nil {
+		adsLog.Warnf("ADS:DELTA: ACK ERROR %v %s (%s) %v", con.PeerAddr, con.ConID, con.modelNode.ID, req.String())
+		errCode := codes.Code(req.ErrorDetail.Code)
+		if reject := rejectMetric(req.TypeUrl); reject != nil {
+			reject.With(prometheus.Labels{"node": con.modelNode.ID, "err": errCode.String()}).Add(1)
+		}
+		totalXDSRejects.Add(1)
+		// The client keeps the last config it accepted. The next push will resend all the
+		// resources that differ from it.
+		st.sent = copyVersions(st.acked)
+		return
+	}
+
+	if req.ResponseNonce != st.nonceSent {
+		adsLog.Debugf("ADS:DELTA: Expired nonce received %s %s, sent %s, received %s",
+			con.ConID, req.TypeUrl, st.nonceSent, req.ResponseNonce)
+		return
+	}
+	adsLog.Debugf("ADS:DELTA: ACK %s %s (%s) %s", con.PeerAddr, con.ConID, req.TypeUrl, req.ResponseNonce)
+	st.acked = copyVersions(st.sent)
+	switch req.TypeUrl {
+	case ClusterType:
+		con.ClusterNonceAcked = req.ResponseNonce
+	case ListenerType:
+		con.ListenerNonceAcked = req.ResponseNonce
+	case RouteType:
+		con.RouteNonceAcked = req.ResponseNonce
+	case EndpointType:
+		con.EndpointNonceAcked = req.ResponseNonce
+	}
+}
+
+// rejectMetric returns the reject gauge for a type URL.
+func rejectMetric(typeURL string) *prometheus.GaugeVec {
+	switch typeURL {
+	case ClusterType:
+		return cdsReject
+	case ListenerType:
+		return ldsReject
+	case RouteType:
+		return rdsReject
+	case EndpointType:
+		return edsReject
+	}
+	return nil
+}
+
+// updateSubscriptions applies subscribe and unsubscribe lists to a sorted list of names.
+func updateSubscriptions(current, subscribe, unsubscribe []string) []string {
+	names := make(map[string]struct{}, len(current)+len(subscribe))
+	for _, n := range current {
+		names[n] = struct{}{}
+	}
+	for _, n := range subscribe {
+		names[n] = struct{}{}
+	}
+	for _, n := range unsubscribe {
+		delete(names, n)
+	}
+	out := make([]string, 0, len(names))
+	for n := range names {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
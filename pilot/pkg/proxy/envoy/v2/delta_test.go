@@ -0,0 +1,325 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package v2_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	ads "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	"github.com/gogo/googleapis/google/rpc"
+	"google.golang.org/grpc"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/proxy/envoy/v2"
+	"istio.io/istio/pkg/adsc"
+	"istio.io/istio/tests/util"
+)
+
+const (
+	deltaSvc     = "delta.test.svc.cluster.local"
+	deltaCluster = "outbound|8080||delta.test.svc.cluster.local"
+
+	deltaAckSvc      = "delta-ack.test.svc.cluster.local"
+	deltaAckCluster  = "outbound|8080||delta-ack.test.svc.cluster.local"
+	deltaNackSvc     = "delta-nack.test.svc.cluster.local"
+	deltaNackCluster = "outbound|8080||delta-nack.test.svc.cluster.local"
+	deltaEdsSvc      = "delta-eds.test.svc.cluster.local"
+	deltaEdsCluster  = "outbound|8080||delta-eds.test.svc.cluster.local"
+)
+
+func TestDeltaAds(t *testing.T) {
+	server, tearDown := initLocalPilotTestEnv(t)
+	defer tearDown()
+
+	server.EnvoyXdsServer.MemRegistry.AddHTTPService(deltaSvc, "10.10.1.50", 8080)
+	server.EnvoyXdsServer.MemRegistry.SetEndpoints(deltaSvc,
+		newEndpointWithAccount("127.0.0.10", "hello-sa", "v1"))
+	server.EnvoyXdsServer.Push(&model.PushRequest{Full: true})
+
+	client, err := adsc.Dial(util.MockPilotGrpcAddr, "", &adsc.Config{
+		IP:    testIP(0x0a0a0a0b),
+		Delta: true,
+	})
+	if err != nil {
+		t.Fatal("Error connecting ", err)
+	}
+	defer client.Close()
+
+	client.Watch()
+	if _, err = client.Wait("rds", 10*time.Second); err != nil {
+		t.Fatal("Error getting initial config ", err)
+	}
+	if len(client.EDS) == 0 || len(client.HTTPListeners) == 0 {
+		t.Fatal("Missing initial config")
+	}
+	testEndpoints("127.0.0.10", deltaCluster, client, t)
+
+	t.Run("unchanged", func(t *testing.T) {
+		client.WaitClear()
+		updates := client.DeltaUpdates[v2.ClusterType]
+
+		v2.AdsPushAll(server.EnvoyXdsServer)
+
+		if upd, err := client.Wait("cds", 2*time.Second); err != adsc.ErrTimeout {
+			t.Errorf("Expecting no CDS update for an unchanged config, got %s %v", upd, err)
+		}
+		if client.DeltaUpdates[v2.ClusterType] != updates {
+			t.Errorf("Expecting no clusters to be resent, got %d", client.DeltaUpdates[v2.ClusterType]-updates)
+		}
+	})
+
+	t.Run("endpoints", func(t *testing.T) {
+		client.WaitClear()
+		updates := client.DeltaUpdates[v2.EndpointType]
+
+		server.EnvoyXdsServer.MemRegistry.SetEndpoints(deltaSvc,
+			newEndpointWithAccount("127.0.0.11", "hello-sa", "v1"))
+
+		upd, err := client.Wait("", 5*time.Second)
+		if err != nil {
+			t.Fatal("Incremental push failed", err)
+		}
+		if upd != "eds" {
+			t.Error("Expecting EDS only update, got", upd)
+		}
+		testEndpoints("127.0.0.11", deltaCluster, client, t)
+		// Other services may have pending endpoint updates, but unchanged clusters must not be resent.
+		if n := client.DeltaUpdates[v2.EndpointType] - updates; n == 0 || n >= len(client.EDS) {
+			t.Errorf("Expecting only the updated clusters to be sent, got %d of %d", n, len(client.EDS))
+		}
+	})
+}
+
+// deltaStream is a raw incremental xDS client, used to control the ACKs and subscriptions
+// sent to Pilot.
+type deltaStream struct {
+	t         *testing.T
+	node      *core.Node
+	stream    ads.AggregatedDiscoveryService_DeltaAggregatedResourcesClient
+	responses chan *xdsapi.DeltaDiscoveryResponse
+}
+
+func connectDeltaADS(t *testing.T, url string, node string) (*deltaStream, util.TearDownFunc) {
+	conn, err := grpc.Dial(url, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatal("GRPC dial failed ", err)
+	}
+	stream, err := ads.NewAggregatedDiscoveryServiceClient(conn).DeltaAggregatedResources(context.Background())
+	if err != nil {
+		t.Fatal("Stream resources failed ", err)
+	}
+	d := &deltaStream{
+		t:         t,
+		node:      &core.Node{Id: node, Metadata: nodeMetadata},
+		stream:    stream,
+		responses: make(chan *xdsapi.DeltaDiscoveryResponse, 100),
+	}
+	go func() {
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				close(d.responses)
+				return
+			}
+			d.responses <- res
+		}
+	}()
+	return d, func() {
+		_ = stream.CloseSend()
+		_ = conn.Close()
+	}
+}
+
+func (d *deltaStream) send(req *xdsapi.DeltaDiscoveryRequest) {
+	d.t.Helper()
+	req.Node = d.node
+	if err := d.stream.Send(req); err != nil {
+		d.t.Fatal("Delta request failed ", err)
+	}
+}
+
+// ack sends an ACK for a response, and waits for Pilot to record it. Responses sent before
+// the ACK is processed would make its nonce expire.
+func (d *deltaStream) ack(typeURL string, res *xdsapi.DeltaDiscoveryResponse) {
+	d.t.Helper()
+	d.send(&xdsapi.DeltaDiscoveryRequest{TypeUrl: typeURL, ResponseNonce: res.Nonce})
+	for i := 0; i < 50; i++ {
+		for _, s := range getSyncStatus(d.t) {
+			if s.ClusterAcked == res.Nonce || s.EndpointAcked == res.Nonce {
+				return
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	d.t.Fatal("Timeout waiting for the ACK of ", res.Nonce)
+}
+
+// wait returns the next response with a resource named name, or removing it. Other
+// responses are skipped.
+func (d *deltaStream) wait(name string) *xdsapi.DeltaDiscoveryResponse {
+	d.t.Helper()
+	res := d.next(name, 5*time.Second)
+	if res == nil {
+		d.t.Fatal("Timeout waiting for ", name)
+	}
+	return res
+}
+
+// next is like wait, but returns nil on timeout.
+func (d *deltaStream) next(name string, to time.Duration) *xdsapi.DeltaDiscoveryResponse {
+	d.t.Helper()
+	t := time.NewTimer(to)
+	defer t.Stop()
+	for {
+		select {
+		case res, ok := <-d.responses:
+			if !ok {
+				d.t.Fatal("Stream closed")
+			}
+			if deltaResponseHas(res, name) || deltaResponseRemoves(res, name) {
+				return res
+			}
+		case <-t.C:
+			return nil
+		}
+	}
+}
+
+// expectNone fails if a response including the resource named name is received in the
+// next second.
+func (d *deltaStream) expectNone(name string) {
+	d.t.Helper()
+	t := time.NewTimer(time.Second)
+	defer t.Stop()
+	for {
+		select {
+		case res, ok := <-d.responses:
+			if ok && deltaResponseHas(res, name) {
+				d.t.Fatal("Unexpected push of ", name)
+			}
+		case <-t.C:
+			return
+		}
+	}
+}
+
+func deltaResponseHas(res *xdsapi.DeltaDiscoveryResponse, name string) bool {
+	for _, r := range res.Resources {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func deltaResponseRemoves(res *xdsapi.DeltaDiscoveryResponse, name string) bool {
+	for _, n := range res.RemovedResources {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDeltaAdsAckTracking(t *testing.T) {
+	server, tearDown := initLocalPilotTestEnv(t)
+	defer tearDown()
+
+	registry := server.EnvoyXdsServer.MemRegistry
+	registry.AddHTTPService(deltaAckSvc, "10.10.1.51", 8080)
+	registry.SetEndpoints(deltaAckSvc, newEndpointWithAccount("127.0.0.20", "hello-sa", "v1"))
+	registry.AddHTTPService(deltaEdsSvc, "10.10.1.53", 8080)
+	registry.SetEndpoints(deltaEdsSvc, newEndpointWithAccount("127.0.0.30", "hello-sa", "v1"))
+	server.EnvoyXdsServer.Push(&model.PushRequest{Full: true})
+
+	d, closeStream := connectDeltaADS(t, util.MockPilotGrpcAddr, sidecarID(testIP(0x0a0a0a0c), "delta"))
+	defer closeStream()
+
+	d.send(&xdsapi.DeltaDiscoveryRequest{TypeUrl: v2.ClusterType})
+	d.ack(v2.ClusterType, d.wait(deltaAckCluster))
+
+	t.Run("nack", func(t *testing.T) {
+		d.t = t
+		registry.AddHTTPService(deltaNackSvc, "10.10.1.52", 8080)
+		server.EnvoyXdsServer.Push(&model.PushRequest{Full: true})
+		res := d.wait(deltaNackCluster)
+		d.send(&xdsapi.DeltaDiscoveryRequest{
+			TypeUrl:       v2.ClusterType,
+			ResponseNonce: res.Nonce,
+			ErrorDetail:   &rpc.Status{Message: "NOPE!"},
+		})
+
+		// The client kept the config it had before the rejected response: once the NACK is
+		// processed, a push resends the cluster even though the config did not change.
+		res = nil
+		for i := 0; i < 50 && res == nil; i++ {
+			server.EnvoyXdsServer.Push(&model.PushRequest{Full: true})
+			res = d.next(deltaNackCluster, 100*time.Millisecond)
+		}
+		if res == nil || !deltaResponseHas(res, deltaNackCluster) {
+			t.Fatal("Expecting the rejected cluster to be resent")
+		}
+		if deltaResponseHas(res, deltaAckCluster) {
+			t.Error("Expecting the accepted clusters not to be resent")
+		}
+		d.ack(v2.ClusterType, res)
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		d.t = t
+		registry.RemoveService(deltaNackSvc)
+		server.EnvoyXdsServer.Push(&model.PushRequest{Full: true})
+		res := d.wait(deltaNackCluster)
+		if !deltaResponseRemoves(res, deltaNackCluster) || deltaResponseHas(res, deltaNackCluster) {
+			t.Fatalf("Expecting %s in the removed resources, got %v", deltaNackCluster, res.RemovedResources)
+		}
+		d.ack(v2.ClusterType, res)
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		d.t = t
+		d.send(&xdsapi.DeltaDiscoveryRequest{
+			TypeUrl:                v2.EndpointType,
+			ResourceNamesSubscribe: []string{deltaAckCluster, deltaEdsCluster},
+		})
+		d.ack(v2.EndpointType, d.wait(deltaAckCluster))
+
+		// Subscribing to another cluster in the same request makes Pilot answer once the
+		// unsubscribe is processed.
+		d.send(&xdsapi.DeltaDiscoveryRequest{
+			TypeUrl:                  v2.EndpointType,
+			ResourceNamesSubscribe:   []string{deltaCluster},
+			ResourceNamesUnsubscribe: []string{deltaAckCluster},
+		})
+		res := d.wait(deltaCluster)
+		if deltaResponseHas(res, deltaAckCluster) {
+			t.Error("Expecting the unsubscribed cluster not to be pushed")
+		}
+		d.ack(v2.EndpointType, res)
+
+		registry.SetEndpoints(deltaAckSvc, newEndpointWithAccount("127.0.0.21", "hello-sa", "v1"))
+		d.expectNone(deltaAckCluster)
+
+		// The other subscribed cluster is still pushed.
+		registry.SetEndpoints(deltaEdsSvc, newEndpointWithAccount("127.0.0.31", "hello-sa", "v1"))
+		res = d.wait(deltaEdsCluster)
+		if deltaResponseHas(res, deltaAckCluster) {
+			t.Error("Expecting the unsubscribed cluster not to be pushed")
+		}
+	})
+}
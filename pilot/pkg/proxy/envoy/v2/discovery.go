@@ -125,7 +125,7 @@ type DiscoveryServer struct {
 	// pushes between the 2 packages.
 	edsUpdates map[string]struct{}
 
-	updateChannel chan *updateReq
+	updateChannel chan *model.PushRequest
 
 	// mutex used for config update scheduling (former cache update mutex)
 	updateMutex sync.RWMutex
@@ -137,11 +137,6 @@ type DiscoveryServer struct {
 	proxyUpdates map[string]struct{}
 }
 
-// updateReq includes info about the requested update.
-type updateReq struct {
-	full bool
-}
-
 // EndpointShards holds the set of endpoint shards of a service. Registries update
 // individual shards incrementally. The shards are aggregated and split into
 // clusters when a push for the specific cluster is needed.
@@ -186,16 +181,16 @@ func NewDiscoveryServer(
 		edsUpdates:              map[string]struct{}{},
 		proxyUpdates:            map[string]struct{}{},
 		concurrentPushLimit:     make(chan struct{}, 20), // TODO(hzxuzhonghu): support configuration
-		updateChannel:           make(chan *updateReq, 10),
+		updateChannel:           make(chan *model.PushRequest, 10),
 	}
 
 	// Flush cached discovery responses whenever services, service
 	// instances, or routing configuration changes.
-	serviceHandler := func(*model.Service, model.Event) { out.clearCache() }
+	serviceHandler := func(svc *model.Service, _ model.Event) { out.serviceUpdate(svc) }
 	if err := ctl.AppendServiceHandler(serviceHandler); err != nil {
 		return nil
 	}
-	instanceHandler := func(*model.ServiceInstance, model.Event) { out.clearCache() }
+	instanceHandler := func(si *model.ServiceInstance, _ model.Event) { out.serviceUpdate(si.Service) }
 	if err := ctl.AppendInstanceHandler(instanceHandler); err != nil {
 		return nil
 	}
@@ -206,7 +201,14 @@ func NewDiscoveryServer(
 	if configCache != nil {
 		// TODO: changes should not trigger a full recompute of LDS/RDS/CDS/EDS
 		// (especially mixerclient HTTP and quota)
-		configHandler := func(model.Config, model.Event) { out.clearCache() }
+		configHandler := func(c model.Config, _ model.Event) {
+			out.ConfigUpdate(&model.PushRequest{
+				Full: true,
+				ConfigsUpdated: map[model.ConfigKey]struct{}{
+					{Type: c.Type, Name: c.Name, Namespace: c.Namespace}: {},
+				},
+			})
+		}
 		for _, descriptor := range model.IstioConfigTypes {
 			configCache.RegisterEventHandler(descriptor.Type, configHandler)
 		}
@@ -251,7 +253,7 @@ func (s *DiscoveryServer) periodicRefresh(stopCh <-chan struct{}) {
 		select {
 		case <-ticker.C:
 			adsLog.Debugf("ADS: Periodic push of envoy configs version:%s", versionInfo())
-			s.AdsPushAll(versionInfo(), s.globalPushContext(), true, nil)
+			s.AdsPushAll(versionInfo(), s.globalPushContext(), &model.PushRequest{Full: true})
 		case <-stopCh:
 			return
 		}
@@ -282,9 +284,9 @@ func (s *DiscoveryServer) periodicRefreshMetrics(stopCh <-chan struct{}) {
 
 // Push is called to push changes on config updates using ADS. This is set in DiscoveryService.Push,
 // to avoid direct dependencies.
-func (s *DiscoveryServer) Push(full bool, edsUpdates map[string]struct{}) {
-	if !full {
-		go s.AdsPushAll(versionInfo(), s.globalPushContext(), false, edsUpdates)
+func (s *DiscoveryServer) Push(req *model.PushRequest) {
+	if !req.Full {
+		go s.AdsPushAll(versionInfo(), s.globalPushContext(), req)
 		return
 	}
 	// Reset the status during the push.
@@ -303,6 +305,7 @@ func (s *DiscoveryServer) Push(full bool, edsUpdates map[string]struct{}) {
 		pushContextErrors.Inc()
 		return
 	}
+	push.SetPreviousContext(pc)
 
 	if err := s.updateServiceShards(push); err != nil {
 		return
@@ -321,7 +324,7 @@ func (s *DiscoveryServer) Push(full bool, edsUpdates map[string]struct{}) {
 	version = versionLocal
 	versionMutex.Unlock()
 
-	go s.AdsPushAll(versionLocal, push, true, nil)
+	go s.AdsPushAll(versionLocal, push, req)
 }
 
 func nonce() string {
@@ -348,7 +351,7 @@ func (s *DiscoveryServer) ClearCache() {
 }
 
 // Start the actual push. Called from a timer.
-func (s *DiscoveryServer) doPush(full bool) {
+func (s *DiscoveryServer) doPush(req *model.PushRequest) {
 	// more config update events may happen while doPush is processing.
 	// we don't want to lose updates.
 	s.mutex.Lock()
@@ -359,20 +362,33 @@ func (s *DiscoveryServer) doPush(full bool) {
 	s.edsUpdates = map[string]struct{}{}
 	s.mutex.Unlock()
 
-	s.Push(full, edsUpdates)
+	s.Push(req.Merge(&model.PushRequest{EdsUpdates: edsUpdates}))
 }
 
 // clearCache will clear all envoy caches. Called by service, instance and config handlers.
 // This will impact the performance, since envoy will need to recalculate.
 func (s *DiscoveryServer) clearCache() {
-	s.ConfigUpdate(true)
+	s.ConfigUpdate(&model.PushRequest{Full: true})
+}
+
+// serviceUpdate requests a full push for a change of a service or of its instances. Only the
+// proxies that import the service, or are in its namespace, are pushed.
+func (s *DiscoveryServer) serviceUpdate(svc *model.Service) {
+	if svc == nil {
+		s.clearCache()
+		return
+	}
+	s.ConfigUpdate(&model.PushRequest{
+		Full:           true,
+		ConfigsUpdated: map[model.ConfigKey]struct{}{model.ServiceConfigKey(svc): {}},
+	})
 }
 
 // ConfigUpdate implements ConfigUpdater interface, used to request pushes.
 // It replaces the 'clear cache' from v1.
-func (s *DiscoveryServer) ConfigUpdate(full bool) {
+func (s *DiscoveryServer) ConfigUpdate(req *model.PushRequest) {
 	inboundConfigUpdates.Add(1)
-	s.updateChannel <- &updateReq{full: full}
+	s.updateChannel <- req
 }
 
 // Debouncing and update request happens in a separate thread, it uses locks
@@ -388,7 +404,7 @@ func (s *DiscoveryServer) handleUpdates(stopCh <-chan struct{}) {
 	pushCounter := 0
 
 	debouncedEvents := 0
-	fullPush := false
+	var req *model.PushRequest
 
 	for {
 		select {
@@ -399,10 +415,9 @@ func (s *DiscoveryServer) handleUpdates(stopCh <-chan struct{}) {
 				startDebounce = lastConfigUpdateTime
 			}
 			debouncedEvents++
-			// fullPush is sticky if any debounced event requires a fullPush
-			if r.full {
-				fullPush = true
-			}
+			// Full push is sticky if any debounced event requires it, and it is targeted
+			// only if all debounced events are.
+			req = req.Merge(r)
 
 		case now := <-timeChan:
 			timeChan = nil
@@ -412,12 +427,12 @@ func (s *DiscoveryServer) handleUpdates(stopCh <-chan struct{}) {
 			// it has been too long or quiet enough
 			if eventDelay >= DebounceMax || quietTime >= DebounceAfter {
 				pushCounter++
-				adsLog.Infof("Push debounce stable[%d] %d: %v since last change, %v since last push, full=%v",
+				adsLog.Infof("Push debounce stable[%d] %d: %v since last change, %v since last push, full=%v configs=%d",
 					pushCounter, debouncedEvents,
-					quietTime, eventDelay, fullPush)
+					quietTime, eventDelay, req.Full, len(req.ConfigsUpdated))
 
-				go s.doPush(fullPush)
-				fullPush = false
+				go s.doPush(req)
+				req = nil
 				debouncedEvents = 0
 				continue
 			}
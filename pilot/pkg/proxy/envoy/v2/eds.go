@@ -403,7 +403,8 @@ func (s *DiscoveryServer) SvcUpdate(cluster, hostname string, ports map[string]u
 
 // Update clusters for an incremental EDS push, and initiate the push.
 // Only clusters that changed are updated/pushed.
-func (s *DiscoveryServer) edsIncremental(version string, push *model.PushContext, edsUpdates map[string]struct{}) {
+func (s *DiscoveryServer) edsIncremental(version string, push *model.PushContext, req *model.PushRequest) {
+	edsUpdates := req.EdsUpdates
 	adsLog.Infof("XDS:EDSInc Pushing:%s Services:%v ConnectedEndpoints:%d",
 		version, edsUpdates, adsClientCount())
 	t0 := time.Now()
@@ -433,7 +434,7 @@ func (s *DiscoveryServer) edsIncremental(version string, push *model.PushContext
 	}
 	adsLog.Infof("Cluster init time %v %s", time.Since(t0), version)
 
-	s.startPush(version, push, false, edsUpdates)
+	s.startPush(version, push, req)
 }
 
 // WorkloadUpdate is called when workload labels/annotations are updated.
@@ -490,7 +491,7 @@ func (s *DiscoveryServer) WorkloadUpdate(id string, labels map[string]string, _
 	// no other workload can be affected. Safer option is to fallback to full push.
 
 	adsLog.Infof("Label change, full push %s ", id)
-	s.ConfigUpdate(true)
+	s.ConfigUpdate(&model.PushRequest{Full: true})
 }
 
 // EDSUpdate computes destination address membership across all clusters and networks.
@@ -578,11 +579,7 @@ func (s *DiscoveryServer) edsUpdate(shard, serviceName string,
 	// no need to trigger push here.
 	// It is done in DiscoveryServer.Push --> AdsPushAll
 	if !internal {
-		if requireFull {
-			s.ConfigUpdate(true)
-		} else {
-			s.ConfigUpdate(false)
-		}
+		s.ConfigUpdate(&model.PushRequest{Full: requireFull})
 	}
 }
 
@@ -761,7 +758,14 @@ func (s *DiscoveryServer) pushEds(push *model.PushContext, con *XdsConnection, v
 	}
 
 	response := endpointDiscoveryResponse(loadAssignments, version)
-	err := con.send(response)
+	var err error
+	if edsUpdatedServices != nil && con.deltaStream != nil {
+		// Incremental EDS pushes only include the updated clusters - the clusters that are
+		// missing from the response were not removed.
+		err = con.sendDelta(response, false)
+	} else {
+		err = con.send(response)
+	}
 	if err != nil {
 		adsLog.Warnf("EDS: Send failure %s: %v", con.ConID, err)
 		edsSendErrPushes.Add(1)
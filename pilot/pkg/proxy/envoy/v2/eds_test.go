@@ -206,7 +206,7 @@ func addTestClientEndpoints(server *bootstrap.Server) {
 			Locality: asdc2Locality,
 		},
 	})
-	server.EnvoyXdsServer.Push(true, nil)
+	server.EnvoyXdsServer.Push(&model.PushRequest{Full: true})
 }
 
 // Verify server sends the endpoint. This check for a single endpoint with the given
@@ -253,9 +253,9 @@ func testOverlappingPorts(server *bootstrap.Server, adsc *adsc.ADSC, t *testing.
 	// Test initial state
 	testEndpoints("10.0.0.53", "outbound|53||overlapping.cluster.local", adsc, t)
 
-	server.EnvoyXdsServer.Push(false, map[string]struct{}{
+	server.EnvoyXdsServer.Push(&model.PushRequest{EdsUpdates: map[string]struct{}{
 		"overlapping.cluster.local": {},
-	})
+	}})
 	_, _ = adsc.Wait("", 5*time.Second)
 
 	// After the incremental push, we should still see the endpoint
@@ -529,7 +529,7 @@ func multipleRequest(server *bootstrap.Server, inc bool, nclients,
 			updates := map[string]struct{}{
 				edsIncSvc: {},
 			}
-			server.EnvoyXdsServer.AdsPushAll(strconv.Itoa(j), server.EnvoyXdsServer.Env.PushContext, false, updates)
+			server.EnvoyXdsServer.AdsPushAll(strconv.Itoa(j), server.EnvoyXdsServer.Env.PushContext, &model.PushRequest{EdsUpdates: updates})
 		} else {
 			v2.AdsPushAll(server.EnvoyXdsServer)
 		}
@@ -596,7 +596,7 @@ func addUdsEndpoint(server *bootstrap.Server) {
 		Labels: map[string]string{"socket": "unix"},
 	})
 
-	server.EnvoyXdsServer.Push(true, nil)
+	server.EnvoyXdsServer.Push(&model.PushRequest{Full: true})
 }
 
 func addLocalityEndpoints(server *bootstrap.Server, hostname model.Hostname) {
@@ -633,7 +633,7 @@ func addLocalityEndpoints(server *bootstrap.Server, hostname model.Hostname) {
 			},
 		})
 	}
-	server.EnvoyXdsServer.Push(true, nil)
+	server.EnvoyXdsServer.Push(&model.PushRequest{Full: true})
 }
 
 func addOverlappingEndpoints(server *bootstrap.Server) {
@@ -663,7 +663,7 @@ func addOverlappingEndpoints(server *bootstrap.Server) {
 			},
 		},
 	})
-	server.EnvoyXdsServer.Push(true, nil)
+	server.EnvoyXdsServer.Push(&model.PushRequest{Full: true})
 }
 
 // Verify the endpoint debug interface is installed and returns some string.
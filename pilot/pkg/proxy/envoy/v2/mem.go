@@ -131,6 +131,13 @@ func (sd *MemServiceDiscovery) AddService(name model.Hostname, svc *model.Servic
 	// TODO: notify listeners
 }
 
+// RemoveService removes an in-memory service.
+func (sd *MemServiceDiscovery) RemoveService(name model.Hostname) {
+	sd.mutex.Lock()
+	delete(sd.services, name)
+	sd.mutex.Unlock()
+}
+
 // AddInstance adds an in-memory instance.
 func (sd *MemServiceDiscovery) AddInstance(service model.Hostname, instance *model.ServiceInstance) {
 	// WIP: add enough code to allow tests and load tests to work
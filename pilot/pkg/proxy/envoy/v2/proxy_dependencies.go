@@ -0,0 +1,60 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// proxyNeedsPush returns true if the proxy may be affected by any of the updated configs.
+// It errs on the side of pushing: config types that are not known to be scoped are
+// assumed to affect all proxies.
+func proxyNeedsPush(proxy *model.Proxy, push *model.PushContext, configs map[model.ConfigKey]struct{}) bool {
+	if proxy == nil || len(configs) == 0 {
+		return true
+	}
+
+	for key := range configs {
+		if configAffectsProxy(proxy, push, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func configAffectsProxy(proxy *model.Proxy, push *model.PushContext, key model.ConfigKey) bool {
+	rootNamespace := ""
+	if push != nil && push.Env != nil && push.Env.Mesh != nil {
+		rootNamespace = push.Env.Mesh.RootNamespace
+	}
+
+	switch key.Type {
+	case model.Gateway.Type:
+		// Gateways are only used to build the config of routers.
+		return proxy.Type == model.Router
+	case model.Sidecar.Type:
+		// Sidecar resources apply to proxies in their own namespace. The ones in the root
+		// namespace are the default for all namespaces.
+		return key.Namespace == proxy.ConfigNamespace || key.Namespace == rootNamespace
+	case model.VirtualService.Type, model.DestinationRule.Type, model.ServiceEntry.Type, model.ServiceConfigType:
+		// Routers are not scoped by Sidecar resources.
+		if proxy.Type == model.Router || push == nil {
+			return true
+		}
+		return push.DependsOnConfig(proxy.ConfigNamespace, proxy.SidecarScope, key)
+	default:
+		return true
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+
+	mcpapi "istio.io/api/mcp/v1alpha1"
+	networking "istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/pilot/pkg/config/coredatamodel"
+	"istio.io/istio/pilot/pkg/config/memory"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/mcp/sink"
+)
+
+var (
+	dependenciesServices = []*model.Service{
+		dependenciesService("c", "app"),
+		dependenciesService("a", "imported"),
+		dependenciesService("z", "imported"),
+		dependenciesService("b", "other"),
+	}
+
+	// dependenciesSidecar imports the services of its namespace, and a single service of
+	// the imported namespace.
+	dependenciesSidecar = &model.Config{
+		ConfigMeta: model.ConfigMeta{
+			Type:      model.Sidecar.Type,
+			Name:      "default",
+			Namespace: "app",
+		},
+		Spec: &networking.Sidecar{
+			Egress: []*networking.IstioEgressListener{
+				{
+					Hosts: []string{"./*", "imported/a.imported.svc.cluster.local"},
+				},
+			},
+		},
+	}
+)
+
+func dependenciesService(name, namespace string) *model.Service {
+	return &model.Service{
+		Hostname: model.Hostname(name + "." + namespace + ".svc.cluster.local"),
+		Address:  "10.10.0.1",
+		Ports: model.PortList{
+			{Name: "http", Port: 80, Protocol: model.ProtocolHTTP},
+		},
+		Attributes: model.ServiceAttributes{Name: name, Namespace: namespace},
+	}
+}
+
+func dependenciesVirtualService(name, namespace, host string) model.Config {
+	return model.Config{
+		ConfigMeta: model.ConfigMeta{
+			Type:      model.VirtualService.Type,
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: &networking.VirtualService{
+			Hosts: []string{host},
+			Tcp: []*networking.TCPRoute{{
+				Route: []*networking.RouteDestination{{
+					Destination: &networking.Destination{Host: host},
+				}},
+			}},
+		},
+	}
+}
+
+func dependenciesDestinationRule(name, namespace, host string, exportTo ...string) model.Config {
+	return model.Config{
+		ConfigMeta: model.ConfigMeta{
+			Type:      model.DestinationRule.Type,
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: &networking.DestinationRule{
+			Host:     host,
+			ExportTo: exportTo,
+		},
+	}
+}
+
+func dependenciesServiceEntry(name, namespace, host string) model.Config {
+	return model.Config{
+		ConfigMeta: model.ConfigMeta{
+			Type:      model.ServiceEntry.Type,
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: &networking.ServiceEntry{
+			Hosts:      []string{host},
+			Ports:      []*networking.Port{{Number: 80, Name: "http", Protocol: "HTTP"}},
+			Location:   networking.ServiceEntry_MESH_EXTERNAL,
+			Resolution: networking.ServiceEntry_NONE,
+		},
+	}
+}
+
+// initDependenciesContext returns the push context of the test services and of the
+// configs of the store, following the previous one.
+func initDependenciesContext(t *testing.T, store model.ConfigStore, previous *model.PushContext) *model.PushContext {
+	t.Helper()
+	services := map[model.Hostname]*model.Service{}
+	for _, s := range dependenciesServices {
+		services[s.Hostname] = s
+	}
+	meshConfig := model.DefaultMeshConfig()
+	env := &model.Environment{
+		ServiceDiscovery: NewMemServiceDiscovery(services, 0),
+		IstioConfigStore: model.MakeIstioStore(store),
+		Mesh:             &meshConfig,
+	}
+
+	push := model.NewPushContext()
+	if err := push.InitContext(env); err != nil {
+		t.Fatalf("InitContext() failed: %v", err)
+	}
+	push.SetPreviousContext(previous)
+	return push
+}
+
+func newDependenciesStore(t *testing.T, configs ...model.Config) model.ConfigStore {
+	t.Helper()
+	store := memory.Make(model.IstioConfigTypes)
+	for _, c := range configs {
+		if _, err := store.Create(c); err != nil {
+			t.Fatalf("Create(%s/%s) failed: %v", c.Namespace, c.Name, err)
+		}
+	}
+	return store
+}
+
+func TestProxyNeedsPush(t *testing.T) {
+	previous := initDependenciesContext(t, newDependenciesStore(t,
+		dependenciesVirtualService("vs-moved", "imported", "a.imported.svc.cluster.local"),
+		dependenciesVirtualService("vs-z", "imported", "z.imported.svc.cluster.local"),
+	), nil)
+	push := initDependenciesContext(t, newDependenciesStore(t,
+		dependenciesVirtualService("vs-a", "imported", "a.imported.svc.cluster.local"),
+		dependenciesVirtualService("vs-moved", "imported", "z.imported.svc.cluster.local"),
+		dependenciesVirtualService("vs-z", "imported", "z.imported.svc.cluster.local"),
+		dependenciesVirtualService("vs-b", "other", "b.other.svc.cluster.local"),
+		dependenciesVirtualService("vs-wildcard", "other", "*.imported.svc.cluster.local"),
+		dependenciesDestinationRule("dr-a", "other", "a.imported.svc.cluster.local"),
+		dependenciesDestinationRule("dr-b", "other", "b.other.svc.cluster.local"),
+		dependenciesDestinationRule("dr-private", "imported", "a.imported.svc.cluster.local", "."),
+		dependenciesServiceEntry("se", "other", "se.other.com"),
+	), previous)
+	meshConfig := push.Env.Mesh
+
+	scoped := &model.Proxy{
+		Type:            model.SidecarProxy,
+		ConfigNamespace: "app",
+		SidecarScope:    model.ConvertToSidecarScope(push, dependenciesSidecar, "app"),
+	}
+	unscoped := &model.Proxy{
+		Type:            model.SidecarProxy,
+		ConfigNamespace: "app",
+		SidecarScope:    model.ConvertToSidecarScope(push, nil, "app"),
+	}
+	gateway := &model.Proxy{
+		Type:            model.Router,
+		ConfigNamespace: "istio-system",
+	}
+
+	key := func(typ, name, namespace string) model.ConfigKey {
+		return model.ConfigKey{Type: typ, Name: name, Namespace: namespace}
+	}
+	serviceKey := func(name, namespace string) model.ConfigKey {
+		return model.ServiceConfigKey(dependenciesService(name, namespace))
+	}
+
+	tests := []struct {
+		name     string
+		proxy    *model.Proxy
+		configs  []model.ConfigKey
+		expected bool
+	}{
+		{"no configs", scoped, nil, true},
+		{"gateway for sidecar", scoped, []model.ConfigKey{key(model.Gateway.Type, "name", "app")}, false},
+		{"gateway for router", gateway, []model.ConfigKey{key(model.Gateway.Type, "name", "other")}, true},
+		{"sidecar same namespace", scoped, []model.ConfigKey{key(model.Sidecar.Type, "name", "app")}, true},
+		{"sidecar root namespace", scoped, []model.ConfigKey{key(model.Sidecar.Type, "name", meshConfig.RootNamespace)}, true},
+		{"sidecar other namespace", scoped, []model.ConfigKey{key(model.Sidecar.Type, "name", "other")}, false},
+		{"virtual service imported", scoped, []model.ConfigKey{key(model.VirtualService.Type, "vs-a", "imported")}, true},
+		{"virtual service not imported", scoped, []model.ConfigKey{key(model.VirtualService.Type, "vs-b", "other")}, false},
+		{"virtual service host not imported", scoped, []model.ConfigKey{key(model.VirtualService.Type, "vs-z", "imported")}, false},
+		{"virtual service previously imported", scoped, []model.ConfigKey{key(model.VirtualService.Type, "vs-moved", "imported")}, true},
+		{"virtual service wildcard not imported", scoped, []model.ConfigKey{key(model.VirtualService.Type, "vs-wildcard", "other")}, false},
+		{"virtual service unknown", scoped, []model.ConfigKey{key(model.VirtualService.Type, "deleted", "other")}, true},
+		{"virtual service default scope", unscoped, []model.ConfigKey{key(model.VirtualService.Type, "vs-b", "other")}, true},
+		{"virtual service for router", gateway, []model.ConfigKey{key(model.VirtualService.Type, "vs-b", "other")}, true},
+		{"destination rule imported host", scoped, []model.ConfigKey{key(model.DestinationRule.Type, "dr-a", "other")}, true},
+		{"destination rule not imported", scoped, []model.ConfigKey{key(model.DestinationRule.Type, "dr-b", "other")}, false},
+		{"destination rule private", scoped, []model.ConfigKey{key(model.DestinationRule.Type, "dr-private", "imported")}, false},
+		{"destination rule default scope", unscoped, []model.ConfigKey{key(model.DestinationRule.Type, "dr-b", "other")}, true},
+		{"service entry not imported", scoped, []model.ConfigKey{key(model.ServiceEntry.Type, "se", "other")}, false},
+		{"service entry default scope", unscoped, []model.ConfigKey{key(model.ServiceEntry.Type, "se", "other")}, true},
+		{"service same namespace", scoped, []model.ConfigKey{serviceKey("c", "app")}, true},
+		{"service imported", scoped, []model.ConfigKey{serviceKey("a", "imported")}, true},
+		{"service not imported", scoped, []model.ConfigKey{serviceKey("b", "other")}, false},
+		{"unknown type", scoped, []model.ConfigKey{key(model.EnvoyFilter.Type, "name", "other")}, true},
+		{
+			"any affected",
+			scoped,
+			[]model.ConfigKey{key(model.Sidecar.Type, "name", "other"), key(model.VirtualService.Type, "vs-a", "imported")},
+			true,
+		},
+		{
+			"none affected",
+			scoped,
+			[]model.ConfigKey{key(model.Sidecar.Type, "name", "other"), key(model.DestinationRule.Type, "dr-b", "other")},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs := map[model.ConfigKey]struct{}{}
+			for _, c := range tt.configs {
+				configs[c] = struct{}{}
+			}
+			if got := proxyNeedsPush(tt.proxy, push, configs); got != tt.expected {
+				t.Errorf("proxyNeedsPush => got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProxyNeedsPushMCP(t *testing.T) {
+	var configsUpdated map[model.ConfigKey]struct{}
+	controller := coredatamodel.NewController(coredatamodel.Options{
+		DomainSuffix: "cluster.local",
+		ClearDiscoveryServerCache: func(updated map[model.ConfigKey]struct{}) {
+			configsUpdated = updated
+		},
+	})
+
+	virtualService := func(name, namespace, host string) *sink.Object {
+		return &sink.Object{
+			TypeURL:  "type.googleapis.com/istio.networking.v1alpha3.VirtualService",
+			Metadata: &mcpapi.Metadata{Name: namespace + "/" + name, Version: "v0"},
+			Body:     dependenciesVirtualService(name, namespace, host).Spec,
+		}
+	}
+
+	var push *model.PushContext
+	steps := []struct {
+		name     string
+		objects  []*sink.Object
+		expected bool
+	}{
+		{
+			"not imported",
+			[]*sink.Object{virtualService("vs-b", "other", "b.other.svc.cluster.local")},
+			false,
+		},
+		{
+			"imported",
+			[]*sink.Object{
+				virtualService("vs-b", "other", "b.other.svc.cluster.local"),
+				virtualService("vs-a", "imported", "a.imported.svc.cluster.local"),
+			},
+			true,
+		},
+		{
+			"imported deleted",
+			[]*sink.Object{virtualService("vs-b", "other", "b.other.svc.cluster.local")},
+			true,
+		},
+		{
+			"not imported deleted",
+			nil,
+			false,
+		},
+	}
+
+	for _, s := range steps {
+		t.Run(s.name, func(t *testing.T) {
+			configsUpdated = nil
+			change := &sink.Change{Collection: model.VirtualService.Collection, Objects: s.objects}
+			if err := controller.Apply(change); err != nil {
+				t.Fatalf("Apply() failed: %v", err)
+			}
+			if len(configsUpdated) == 0 {
+				t.Fatal("Apply() did not request a push of the changed configs")
+			}
+
+			push = initDependenciesContext(t, controller, push)
+			scoped := &model.Proxy{
+				Type:            model.SidecarProxy,
+				ConfigNamespace: "app",
+				SidecarScope:    model.ConvertToSidecarScope(push, dependenciesSidecar, "app"),
+			}
+			if got := proxyNeedsPush(scoped, push, configsUpdated); got != s.expected {
+				t.Errorf("proxyNeedsPush(%v) => got %v, want %v", configsUpdated, got, s.expected)
+			}
+		})
+	}
+}
@@ -31,7 +31,8 @@ type Controller struct {
 	monitor Monitor
 }
 
-// NewController creates a new Consul controller
+// NewController creates a new Consul controller. The interval bounds how long the blocking
+// queries watching for changes wait.
 func NewController(addr string, interval time.Duration) (*Controller, error) {
 	conf := api.DefaultConfig()
 	conf.Address = addr
@@ -100,6 +101,24 @@ func (c *Controller) getCatalogService(name string, q *api.QueryOptions) ([]*api
 	return endpoints, nil
 }
 
+// getHealthyInstances returns the instances of a service, excluding the ones failing
+// Consul health checks.
+func (c *Controller) getHealthyInstances(name string, q *api.QueryOptions) ([]*api.CatalogService, error) {
+	entries, _, err := c.client.Health().Service(name, "", false, q)
+	if err != nil {
+		log.Warnf("Could not retrieve service health from consul: %v", err)
+		return nil, err
+	}
+
+	endpoints := make([]*api.CatalogService, 0, len(entries))
+	for _, entry := range entries {
+		if isHealthy(entry) {
+			endpoints = append(endpoints, convertServiceEntry(entry))
+		}
+	}
+	return endpoints, nil
+}
+
 // ManagementPorts retrieves set of health check ports by instance IP.
 // This does not apply to Consul service registry, as Consul does not
 // manage the service instances. In future, when we integrate Nomad, we
@@ -127,7 +146,7 @@ func (c *Controller) InstancesByPort(hostname model.Hostname, port int,
 		return nil, err
 	}
 
-	endpoints, err := c.getCatalogService(name, nil)
+	endpoints, err := c.getHealthyInstances(name, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +217,7 @@ func (c *Controller) GetProxyWorkloadLabels(proxy *model.Proxy) (model.LabelsCol
 			if len(proxy.IPAddresses) > 0 {
 				for _, ipAddress := range proxy.IPAddresses {
 					if ipAddress == addr {
-						labels := convertLabels(endpoint.ServiceTags)
+						labels := convertInstanceLabels(endpoint)
 						out = append(out, labels)
 						break
 					}
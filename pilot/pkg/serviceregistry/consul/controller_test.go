@@ -20,6 +20,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -100,7 +102,20 @@ type mockServer struct {
 	Productpage []*api.CatalogService
 	Reviews     []*api.CatalogService
 	Rating      []*api.CatalogService
-	Lock        sync.Mutex
+	// Health has the aggregated check status of instances, by service address. Instances
+	// are passing by default.
+	Health map[string]string
+	Lock   sync.Mutex
+
+	// index and responses implement blocking queries: the index is bumped each time the
+	// response for a path changes.
+	index     uint64
+	responses map[string]*mockResponse
+}
+
+type mockResponse struct {
+	data  string
+	index uint64
 }
 
 func newServer() *mockServer {
@@ -109,6 +124,8 @@ func newServer() *mockServer {
 		Reviews:     make([]*api.CatalogService, len(reviews)),
 		Rating:      make([]*api.CatalogService, len(rating)),
 		Services:    make(map[string][]string),
+		Health:      make(map[string]string),
+		responses:   make(map[string]*mockResponse),
 	}
 
 	copy(m.Reviews, reviews)
@@ -119,41 +136,104 @@ func newServer() *mockServer {
 	}
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/v1/catalog/services" {
-			m.Lock.Lock()
-			data, _ := json.Marshal(&m.Services)
-			m.Lock.Unlock()
-			w.Header().Set("Content-Type", "application/json")
-			fmt.Fprintln(w, string(data))
-		} else if r.URL.Path == "/v1/catalog/service/reviews" {
-			m.Lock.Lock()
-			data, _ := json.Marshal(&m.Reviews)
-			m.Lock.Unlock()
-			w.Header().Set("Content-Type", "application/json")
-			fmt.Fprintln(w, string(data))
-		} else if r.URL.Path == "/v1/catalog/service/productpage" {
-			m.Lock.Lock()
-			data, _ := json.Marshal(&m.Productpage)
-			m.Lock.Unlock()
-			w.Header().Set("Content-Type", "application/json")
-			fmt.Fprintln(w, string(data))
-		} else if r.URL.Path == "/v1/catalog/service/rating" {
-			m.Lock.Lock()
-			data, _ := json.Marshal(&m.Rating)
-			m.Lock.Unlock()
-			w.Header().Set("Content-Type", "application/json")
-			fmt.Fprintln(w, string(data))
-		} else {
-			data, _ := json.Marshal(&[]*api.CatalogService{})
-			w.Header().Set("Content-Type", "application/json")
-			fmt.Fprintln(w, string(data))
+		var index uint64
+		if i := r.URL.Query().Get("index"); i != "" {
+			index, _ = strconv.ParseUint(i, 10, 64)
 		}
+		wait, _ := time.ParseDuration(r.URL.Query().Get("wait"))
+		deadline := time.Now().Add(wait)
+
+		// Block until the response changes, or the wait time elapses.
+		resp := m.response(r.URL.Path)
+		for index > 0 && resp.index <= index && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+			resp = m.response(r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Consul-Index", strconv.FormatUint(resp.index, 10))
+		w.Header().Set("X-Consul-LastContact", "0")
+		fmt.Fprintln(w, resp.data)
 	}))
 
 	m.Server = server
 	return &m
 }
 
+// response returns the current response for a path, and the index of its last change.
+func (m *mockServer) response(path string) *mockResponse {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	var data []byte
+	switch {
+	case path == "/v1/catalog/services":
+		data, _ = json.Marshal(&m.Services)
+	case strings.HasPrefix(path, "/v1/catalog/service/"):
+		data, _ = json.Marshal(m.instances(strings.TrimPrefix(path, "/v1/catalog/service/")))
+	case strings.HasPrefix(path, "/v1/health/service/"):
+		entries := []*api.ServiceEntry{}
+		for _, inst := range m.instances(strings.TrimPrefix(path, "/v1/health/service/")) {
+			checkID, status := "service:"+inst.ServiceID, m.Health[inst.ServiceAddress]
+			switch status {
+			case "":
+				status = api.HealthPassing
+			case api.HealthMaint:
+				// Consul reports maintenance mode as a critical check with a reserved ID.
+				checkID, status = api.ServiceMaintPrefix+inst.ServiceID, api.HealthCritical
+			}
+			entries = append(entries, &api.ServiceEntry{
+				Node: &api.Node{
+					ID:         inst.ID,
+					Node:       inst.Node,
+					Address:    inst.Address,
+					Datacenter: inst.Datacenter,
+				},
+				Service: &api.AgentService{
+					ID:      inst.ServiceID,
+					Service: inst.ServiceName,
+					Tags:    inst.ServiceTags,
+					Meta:    inst.ServiceMeta,
+					Port:    inst.ServicePort,
+					Address: inst.ServiceAddress,
+				},
+				Checks: api.HealthChecks{
+					{
+						CheckID:     checkID,
+						ServiceID:   inst.ServiceID,
+						ServiceName: inst.ServiceName,
+						Status:      status,
+					},
+				},
+			})
+		}
+		data, _ = json.Marshal(&entries)
+	default:
+		data, _ = json.Marshal(&[]*api.CatalogService{})
+	}
+
+	resp := m.responses[path]
+	if resp == nil || resp.data != string(data) {
+		m.index++
+		resp = &mockResponse{data: string(data), index: m.index}
+		m.responses[path] = resp
+	}
+	return resp
+}
+
+func (m *mockServer) instances(name string) []*api.CatalogService {
+	switch name {
+	case "reviews":
+		return m.Reviews
+	case "productpage":
+		return m.Productpage
+	case "rating":
+		return m.Rating
+	default:
+		return []*api.CatalogService{}
+	}
+}
+
 func TestInstances(t *testing.T) {
 	ts := newServer()
 	defer ts.Server.Close()
@@ -496,3 +576,68 @@ func TestGetProxyWorkloadLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestInstancesHealth(t *testing.T) {
+	ts := newServer()
+	defer ts.Server.Close()
+	controller, err := NewController(ts.Server.URL, 3*time.Second)
+	if err != nil {
+		t.Errorf("could not create Consul Controller: %v", err)
+	}
+
+	ts.Lock.Lock()
+	ts.Health["172.19.0.6"] = api.HealthCritical
+	ts.Health["172.19.0.7"] = api.HealthWarning
+	ts.Health["172.19.0.8"] = api.HealthMaint
+	ts.Lock.Unlock()
+
+	instances, err := controller.InstancesByPort(serviceHostname("reviews"), 0, model.LabelsCollection{})
+	if err != nil {
+		t.Errorf("client encountered error during Instances(): %v", err)
+	}
+	if len(instances) != 1 {
+		t.Fatalf("Instances() did not filter unhealthy instances => %d, want 1", len(instances))
+	}
+	if instances[0].Endpoint.Address != "172.19.0.7" {
+		t.Errorf("Instances() returned wrong instance => %s, want %s", instances[0].Endpoint.Address, "172.19.0.7")
+	}
+}
+
+func TestInstancesServiceMeta(t *testing.T) {
+	ts := newServer()
+	defer ts.Server.Close()
+	controller, err := NewController(ts.Server.URL, 3*time.Second)
+	if err != nil {
+		t.Errorf("could not create Consul Controller: %v", err)
+	}
+
+	ts.Lock.Lock()
+	ts.Rating = []*api.CatalogService{
+		{
+			Node:           "istio-node",
+			Address:        "172.19.0.6",
+			ID:             "istio-node-id",
+			ServiceID:      "rating-id",
+			ServiceName:    "rating",
+			ServiceTags:    []string{"version|v1", "protocol|http"},
+			ServiceAddress: "172.19.0.12",
+			ServicePort:    9080,
+			ServiceMeta:    map[string]string{"zone": "prod", "version": "v2"},
+		},
+	}
+	ts.Lock.Unlock()
+
+	instances, err := controller.InstancesByPort(serviceHostname("rating"), 0, model.LabelsCollection{{"zone": "prod"}})
+	if err != nil {
+		t.Errorf("client encountered error during Instances(): %v", err)
+	}
+	if len(instances) != 1 {
+		t.Fatalf("Instances() did not match service meta => %d, want 1", len(instances))
+	}
+	if instances[0].Labels["version"] != "v1" {
+		t.Errorf("Instances() tags should take precedence over service meta => %q", instances[0].Labels)
+	}
+	if instances[0].Endpoint.ServicePort.Protocol != model.ProtocolHTTP {
+		t.Errorf("Instances() wrong protocol => %v, want %v", instances[0].Endpoint.ServicePort.Protocol, model.ProtocolHTTP)
+	}
+}
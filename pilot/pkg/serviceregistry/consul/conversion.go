@@ -43,6 +43,36 @@ func convertLabels(labels []string) model.Labels {
 	return out
 }
 
+// convertInstanceLabels returns the labels of a service instance. Labels are read from
+// tags of form "key|value" and from the service meta, except for the meta keys that
+// configure the port. Tags take precedence.
+func convertInstanceLabels(instance *api.CatalogService) model.Labels {
+	out := convertLabels(instance.ServiceTags)
+	for k, v := range instance.ServiceMeta {
+		if k == protocolTagName || k == externalTagName {
+			continue
+		}
+		if _, f := out[k]; !f {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// instanceProtocol returns the protocol of a service instance, from the "protocol" service
+// meta or, if not set, from a "protocol|<name>" tag.
+func instanceProtocol(instance *api.CatalogService) string {
+	if protocol := instance.ServiceMeta[protocolTagName]; protocol != "" {
+		return protocol
+	}
+	for _, tag := range instance.ServiceTags {
+		if vals := strings.Split(tag, "|"); len(vals) > 1 && vals[0] == protocolTagName {
+			return vals[1]
+		}
+	}
+	return ""
+}
+
 func convertPort(port int, name string) *model.Port {
 	if name == "" {
 		name = "tcp"
@@ -65,7 +95,7 @@ func convertService(endpoints []*api.CatalogService) *model.Service {
 	for _, endpoint := range endpoints {
 		name = endpoint.ServiceName
 
-		port := convertPort(endpoint.ServicePort, endpoint.ServiceMeta[protocolTagName])
+		port := convertPort(endpoint.ServicePort, instanceProtocol(endpoint))
 
 		if svcPort, exists := ports[port.Port]; exists && svcPort.Protocol != port.Protocol {
 			log.Warnf("Service %v has two instances on same port %v but different protocols (%v, %v)",
@@ -104,8 +134,8 @@ func convertService(endpoints []*api.CatalogService) *model.Service {
 }
 
 func convertInstance(instance *api.CatalogService) *model.ServiceInstance {
-	labels := convertLabels(instance.ServiceTags)
-	port := convertPort(instance.ServicePort, instance.ServiceMeta[protocolTagName])
+	labels := convertInstanceLabels(instance)
+	port := convertPort(instance.ServicePort, instanceProtocol(instance))
 
 	addr := instance.ServiceAddress
 	if addr == "" {
@@ -143,6 +173,41 @@ func convertInstance(instance *api.CatalogService) *model.ServiceInstance {
 	}
 }
 
+// convertServiceEntry converts an entry of the health endpoint to the catalog representation,
+// so instances are converted the same way regardless of the endpoint they are read from.
+func convertServiceEntry(entry *api.ServiceEntry) *api.CatalogService {
+	out := &api.CatalogService{}
+	if entry.Node != nil {
+		out.ID = entry.Node.ID
+		out.Node = entry.Node.Node
+		out.Address = entry.Node.Address
+		out.Datacenter = entry.Node.Datacenter
+		out.TaggedAddresses = entry.Node.TaggedAddresses
+		out.NodeMeta = entry.Node.Meta
+	}
+	if entry.Service != nil {
+		out.ServiceID = entry.Service.ID
+		out.ServiceName = entry.Service.Service
+		out.ServiceAddress = entry.Service.Address
+		out.ServiceTags = entry.Service.Tags
+		out.ServiceMeta = entry.Service.Meta
+		out.ServicePort = entry.Service.Port
+	}
+	return out
+}
+
+// isHealthy returns true if the instance should receive traffic. Instances with a critical
+// node or service check, or in maintenance mode, are excluded. Instances with warnings still
+// receive traffic, same as in Consul DNS.
+func isHealthy(entry *api.ServiceEntry) bool {
+	switch entry.Checks.AggregatedStatus() {
+	case api.HealthCritical, api.HealthMaint:
+		return false
+	default:
+		return true
+	}
+}
+
 // serviceHostname produces FQDN for a consul service
 func serviceHostname(name string) model.Hostname {
 	// TODO include datacenter in Hostname?
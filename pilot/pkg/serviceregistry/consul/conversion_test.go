@@ -147,6 +147,63 @@ func TestConvertInstance(t *testing.T) {
 	}
 }
 
+func TestConvertServiceEntry(t *testing.T) {
+	entry := &api.ServiceEntry{
+		Node: &api.Node{
+			ID:         "1111-22-3333-444",
+			Node:       "istio-node",
+			Address:    "172.19.0.5",
+			Datacenter: "dc1",
+		},
+		Service: &api.AgentService{
+			ID:      "productpage-id",
+			Service: "productpage",
+			Tags:    []string{"version|v1"},
+			Meta:    map[string]string{protocolTagName: "grpc", "zone": "prod"},
+			Port:    9080,
+			Address: "172.19.0.11",
+		},
+	}
+
+	out := convertInstance(convertServiceEntry(entry))
+
+	if out.Service.Hostname != serviceHostname("productpage") {
+		t.Errorf("convertServiceEntry() bad service hostname => %q, want %q",
+			out.Service.Hostname, serviceHostname("productpage"))
+	}
+	if out.Endpoint.Address != "172.19.0.11" || out.Endpoint.Port != 9080 {
+		t.Errorf("convertServiceEntry() bad endpoint => %v:%v", out.Endpoint.Address, out.Endpoint.Port)
+	}
+	if out.Endpoint.Locality != "dc1" {
+		t.Errorf("convertServiceEntry() bad locality => %v, want %v", out.Endpoint.Locality, "dc1")
+	}
+	if out.Endpoint.ServicePort.Protocol != model.ProtocolGRPC {
+		t.Errorf("convertServiceEntry() bad protocol => %v, want %v", out.Endpoint.ServicePort.Protocol, model.ProtocolGRPC)
+	}
+	if len(out.Labels) != 2 || out.Labels["version"] != "v1" || out.Labels["zone"] != "prod" {
+		t.Errorf("convertServiceEntry() bad labels => %q", out.Labels)
+	}
+}
+
+func TestIsHealthy(t *testing.T) {
+	tests := []struct {
+		checks   api.HealthChecks
+		expected bool
+	}{
+		{nil, true},
+		{api.HealthChecks{{Status: api.HealthPassing}}, true},
+		{api.HealthChecks{{Status: api.HealthPassing}, {Status: api.HealthWarning}}, true},
+		{api.HealthChecks{{Status: api.HealthPassing}, {Status: api.HealthCritical}}, false},
+		{api.HealthChecks{{CheckID: api.NodeMaint, Status: api.HealthCritical}}, false},
+	}
+
+	for _, tt := range tests {
+		if got := isHealthy(&api.ServiceEntry{Checks: tt.checks}); got != tt.expected {
+			t.Errorf("isHealthy(%v) => %v, want %v", tt.checks, got, tt.expected)
+		}
+	}
+}
+
 func TestServiceHostname(t *testing.T) {
 	out := serviceHostname("productpage")
 
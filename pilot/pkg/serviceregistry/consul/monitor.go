@@ -15,8 +15,10 @@
 package consul
 
 import (
+	"context"
 	"reflect"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/consul/api"
@@ -25,6 +27,9 @@ import (
 	"istio.io/pkg/log"
 )
 
+// retryDelay is the delay before retrying a failed blocking query.
+const retryDelay = time.Second
+
 type consulServices map[string][]string
 type consulServiceInstances []*api.CatalogService
 
@@ -42,111 +47,188 @@ type InstanceHandler func(instance *api.CatalogService, event model.Event) error
 type ServiceHandler func(instances []*api.CatalogService, event model.Event) error
 
 type consulMonitor struct {
-	discovery            *api.Client
-	instanceCachedRecord consulServiceInstances
-	serviceCachedRecord  consulServices
-	instanceHandlers     []InstanceHandler
-	serviceHandlers      []ServiceHandler
-	period               time.Duration
+	discovery           *api.Client
+	serviceCachedRecord consulServices
+	instanceHandlers    []InstanceHandler
+	serviceHandlers     []ServiceHandler
+	waitTime            time.Duration
+
+	// watchers has the cancel function of the instance watch of each service.
+	watchers map[string]context.CancelFunc
+	wg       sync.WaitGroup
 }
 
-// NewConsulMonitor polls for changes in Consul Services and CatalogServices
-func NewConsulMonitor(client *api.Client, period time.Duration) Monitor {
+// NewConsulMonitor watches for changes in Consul Services and their healthy instances, using
+// blocking queries. The wait time bounds how long each query blocks waiting for a change.
+func NewConsulMonitor(client *api.Client, waitTime time.Duration) Monitor {
 	return &consulMonitor{
-		discovery:            client,
-		period:               period,
-		instanceCachedRecord: make(consulServiceInstances, 0),
-		serviceCachedRecord:  make(consulServices),
-		instanceHandlers:     make([]InstanceHandler, 0),
-		serviceHandlers:      make([]ServiceHandler, 0),
+		discovery:           client,
+		waitTime:            waitTime,
+		serviceCachedRecord: make(consulServices),
+		instanceHandlers:    make([]InstanceHandler, 0),
+		serviceHandlers:     make([]ServiceHandler, 0),
+		watchers:            make(map[string]context.CancelFunc),
 	}
 }
 
 func (m *consulMonitor) Start(stop <-chan struct{}) {
-	m.run(stop)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
+	}()
+	m.run(ctx)
+	m.wg.Wait()
 }
 
-func (m *consulMonitor) run(stop <-chan struct{}) {
-	ticker := time.NewTicker(m.period)
+// run watches the list of services, and starts a watch for the instances of each service.
+func (m *consulMonitor) run(ctx context.Context) {
+	var index uint64
 	for {
-		select {
-		case <-stop:
-			ticker.Stop()
+		svcs, meta, err := m.discovery.Catalog().Services(m.queryOptions(ctx, index))
+		if ctx.Err() != nil {
+			m.stopWatchers()
 			return
-		case <-ticker.C:
-			m.updateServiceRecord()
-			m.updateInstanceRecord()
 		}
+		if err != nil {
+			log.Warnf("Could not fetch services: %v", err)
+			index = 0
+			if !sleep(ctx, retryDelay) {
+				m.stopWatchers()
+				return
+			}
+			continue
+		}
+		index = nextIndex(index, meta.LastIndex)
+		m.updateServiceRecord(ctx, svcs)
 	}
 }
 
-func (m *consulMonitor) updateServiceRecord() {
-	svcs, _, err := m.discovery.Catalog().Services(nil)
-	if err != nil {
-		log.Warnf("Could not fetch services: %v", err)
-		return
-	}
-
+func (m *consulMonitor) updateServiceRecord(ctx context.Context, svcs map[string][]string) {
 	// The order of service tags may change even there is no service change
 	// Sort the service tags to avoid unnecessary pushes to envoy
 	for _, tags := range svcs {
 		sort.Strings(tags)
 	}
 	newRecord := consulServices(svcs)
-	if !reflect.DeepEqual(newRecord, m.serviceCachedRecord) {
-		// This is only a work-around solution currently
-		// Since Handler functions generally act as a refresher
-		// regardless of the input, thus passing in meaningless
-		// input should make functionalities work
-		//TODO
-		obj := []*api.CatalogService{}
-		var event model.Event
-		for _, f := range m.serviceHandlers {
-			go func(handler ServiceHandler) {
-				if err := handler(obj, event); err != nil {
-					log.Warnf("Error executing service handler function: %v", err)
-				}
-			}(f)
-		}
-		m.serviceCachedRecord = newRecord
+	if reflect.DeepEqual(newRecord, m.serviceCachedRecord) {
+		return
 	}
-}
 
-func (m *consulMonitor) updateInstanceRecord() {
-	svcs, _, err := m.discovery.Catalog().Services(nil)
-	if err != nil {
-		log.Warnf("Could not fetch instances: %v", err)
-		return
+	// This is only a work-around solution currently
+	// Since Handler functions generally act as a refresher
+	// regardless of the input, thus passing in meaningless
+	// input should make functionalities work
+	//TODO
+	obj := []*api.CatalogService{}
+	var event model.Event
+	for _, f := range m.serviceHandlers {
+		go func(handler ServiceHandler) {
+			if err := handler(obj, event); err != nil {
+				log.Warnf("Error executing service handler function: %v", err)
+			}
+		}(f)
 	}
+	m.serviceCachedRecord = newRecord
 
-	instances := make([]*api.CatalogService, 0)
+	for name, cancel := range m.watchers {
+		if _, f := svcs[name]; !f {
+			cancel()
+			delete(m.watchers, name)
+			m.notifyInstanceHandlers(&api.CatalogService{ServiceName: name}, model.EventDelete)
+		}
+	}
 	for name := range svcs {
-		endpoints, _, err := m.discovery.Catalog().Service(name, "", nil)
-		if err != nil {
-			log.Warnf("Could not retrieve service catalogue from consul: %v", err)
+		if _, f := m.watchers[name]; f {
 			continue
 		}
-		instances = append(instances, endpoints...)
+		watchCtx, cancel := context.WithCancel(ctx)
+		m.watchers[name] = cancel
+		m.wg.Add(1)
+		go func(name string) {
+			defer m.wg.Done()
+			m.watchInstances(watchCtx, name)
+		}(name)
 	}
+}
+
+// watchInstances watches the healthy instances of a service, until the context is done.
+func (m *consulMonitor) watchInstances(ctx context.Context, name string) {
+	var index uint64
+	cachedRecord := make(consulServiceInstances, 0)
+	for {
+		entries, meta, err := m.discovery.Health().Service(name, "", false, m.queryOptions(ctx, index))
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Warnf("Could not fetch instances of service %s: %v", name, err)
+			index = 0
+			if !sleep(ctx, retryDelay) {
+				return
+			}
+			continue
+		}
+		index = nextIndex(index, meta.LastIndex)
 
-	newRecord := consulServiceInstances(instances)
-	sort.Sort(newRecord)
-	if !reflect.DeepEqual(newRecord, m.instanceCachedRecord) {
-		// This is only a work-around solution currently
-		// Since Handler functions generally act as a refresher
-		// regardless of the input, thus passing in meaningless
-		// input should make functionalities work
-		// TODO
-		obj := &api.CatalogService{}
-		var event model.Event
-		for _, f := range m.instanceHandlers {
-			go func(handler InstanceHandler) {
-				if err := handler(obj, event); err != nil {
-					log.Warnf("Error executing instance handler function: %v", err)
-				}
-			}(f)
+		newRecord := make(consulServiceInstances, 0, len(entries))
+		for _, entry := range entries {
+			if isHealthy(entry) {
+				newRecord = append(newRecord, convertServiceEntry(entry))
+			}
+		}
+		sort.Sort(newRecord)
+		if reflect.DeepEqual(newRecord, cachedRecord) {
+			continue
 		}
-		m.instanceCachedRecord = newRecord
+		cachedRecord = newRecord
+		m.notifyInstanceHandlers(&api.CatalogService{ServiceName: name}, model.EventUpdate)
+	}
+}
+
+func (m *consulMonitor) notifyInstanceHandlers(obj *api.CatalogService, event model.Event) {
+	for _, f := range m.instanceHandlers {
+		go func(handler InstanceHandler) {
+			if err := handler(obj, event); err != nil {
+				log.Warnf("Error executing instance handler function: %v", err)
+			}
+		}(f)
+	}
+}
+
+func (m *consulMonitor) stopWatchers() {
+	for name, cancel := range m.watchers {
+		cancel()
+		delete(m.watchers, name)
+	}
+}
+
+func (m *consulMonitor) queryOptions(ctx context.Context, index uint64) *api.QueryOptions {
+	q := &api.QueryOptions{
+		WaitIndex: index,
+		WaitTime:  m.waitTime,
+	}
+	return q.WithContext(ctx)
+}
+
+// nextIndex returns the index for the next blocking query. The index is reset if it goes
+// backwards, for example after a Consul snapshot restore, as recommended by the Consul docs.
+func nextIndex(prev, last uint64) uint64 {
+	if last < prev {
+		return 0
+	}
+	return last
+}
+
+// sleep waits for the duration, and returns false if the context is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
 	}
 }
 
@@ -171,6 +253,12 @@ func (a consulServiceInstances) Swap(i, j int) {
 // Less i and j
 func (a consulServiceInstances) Less(i, j int) bool {
 	// ID is the node ID
-	// ServiceID is a unique service instance identifier
-	return a[i].ID+a[i].ServiceID < a[j].ID+a[j].ServiceID
+	// ServiceID is a unique service instance identifier, but only within a node
+	if a[i].ID+a[i].ServiceID != a[j].ID+a[j].ServiceID {
+		return a[i].ID+a[i].ServiceID < a[j].ID+a[j].ServiceID
+	}
+	if a[i].ServiceAddress != a[j].ServiceAddress {
+		return a[i].ServiceAddress < a[j].ServiceAddress
+	}
+	return a[i].ServicePort < a[j].ServicePort
 }
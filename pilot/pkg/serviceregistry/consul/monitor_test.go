@@ -81,7 +81,7 @@ func TestController(t *testing.T) {
 
 	// re-ordering of service instances -> does not trigger update
 	ts.Lock.Lock()
-	reviews[0], reviews[len(reviews)-1] = reviews[len(reviews)-1], reviews[0]
+	ts.Reviews[0], ts.Reviews[len(ts.Reviews)-1] = ts.Reviews[len(ts.Reviews)-1], ts.Reviews[0]
 	ts.Lock.Unlock()
 
 	time.Sleep(notifyThreshold)
@@ -98,9 +98,27 @@ func TestController(t *testing.T) {
 		t.Errorf("got %d notifications from controller, want %d", i, 2)
 	}
 
+	// failing health check -> trigger instance update
+	ts.Lock.Lock()
+	ts.Health[ts.Reviews[1].ServiceAddress] = api.HealthCritical
+	ts.Lock.Unlock()
+	time.Sleep(notifyThreshold)
+	if i := getCountAndReset(); i != 1 {
+		t.Errorf("got %d notifications from controller, want %d", i, 1)
+	}
+
+	// warning health check -> instance still healthy, does not trigger update
+	ts.Lock.Lock()
+	ts.Health[ts.Reviews[0].ServiceAddress] = api.HealthWarning
+	ts.Lock.Unlock()
+	time.Sleep(notifyThreshold)
+	if i := getCountAndReset(); i != 0 {
+		t.Errorf("got %d notifications from controller, want %d", i, 0)
+	}
+
 	// delete a service instance -> trigger instance update
 	ts.Lock.Lock()
-	ts.Reviews = reviews[0:1]
+	ts.Reviews = ts.Reviews[0:1]
 	ts.Lock.Unlock()
 	time.Sleep(notifyThreshold)
 	if i := getCountAndReset(); i != 1 {
@@ -63,7 +63,7 @@ const (
 	domainSuffix = "company.com"
 )
 
-func (*FakeXdsUpdater) ConfigUpdate(bool) {
+func (*FakeXdsUpdater) ConfigUpdate(*model.PushRequest) {
 
 }
 
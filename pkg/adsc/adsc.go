@@ -55,6 +55,9 @@ type Config struct {
 	// IP is currently the primary key used to locate inbound configs. It is sent by client,
 	// must match a known endpoint IP. Tests can use a ServiceEntry to register fake IPs.
 	IP string
+
+	// Delta enables the incremental xDS protocol, using DeltaAggregatedResources.
+	Delta bool
 }
 
 // ADSC implements a basic client for ADS, for use in stress tests and tools
@@ -64,8 +67,26 @@ type ADSC struct {
 	// Set after Dial is called.
 	stream ads.AggregatedDiscoveryService_StreamAggregatedResourcesClient
 
+	// deltaStream is used instead of stream if the incremental protocol is enabled.
+	deltaStream ads.AggregatedDiscoveryService_DeltaAggregatedResourcesClient
+
 	conn *grpc.ClientConn
 
+	delta bool
+
+	// deltaResources holds the resources received using the incremental protocol, keyed by
+	// type URL and resource name. Each response updates it, and the complete set is then
+	// processed as for a state-of-the-world response.
+	deltaResources map[string]map[string]proto.Message
+
+	// deltaSubscriptions has the names of the subscribed resources for each type URL.
+	deltaSubscriptions map[string]map[string]struct{}
+
+	// deltaPending has the type URLs of the incremental requests waiting for a response, in
+	// the order they were sent. Responses without resources don't carry their type URL, and
+	// are ACKed for the oldest pending request.
+	deltaPending []string
+
 	// NodeID is the node identity sent to Pilot.
 	nodeID string
 
@@ -108,6 +129,11 @@ type ADSC struct {
 	Updates     chan string
 	VersionInfo map[string]string
 
+	// DeltaUpdates counts, for each type URL, the resources received in incremental responses.
+	// Removed resources are counted in DeltaRemovals.
+	DeltaUpdates  map[string]int
+	DeltaRemovals map[string]int
+
 	mutex sync.Mutex
 }
 
@@ -139,6 +165,12 @@ func Dial(url string, certDir string, opts *Config) (*ADSC, error) {
 		VersionInfo: map[string]string{},
 		certDir:     certDir,
 		url:         url,
+		delta:       opts.Delta,
+
+		deltaResources:     map[string]map[string]proto.Message{},
+		deltaSubscriptions: map[string]map[string]struct{}{},
+		DeltaUpdates:       map[string]int{},
+		DeltaRemovals:      map[string]int{},
 	}
 	if opts.Namespace == "" {
 		opts.Namespace = "default"
@@ -213,6 +245,9 @@ func (a *ADSC) Close() {
 	if a.stream != nil {
 		_ = a.stream.CloseSend()
 	}
+	if a.deltaStream != nil {
+		_ = a.deltaStream.CloseSend()
+	}
 	a.conn.Close()
 	a.mutex.Unlock()
 }
@@ -245,6 +280,15 @@ func (a *ADSC) Run() error {
 	}
 
 	xds := ads.NewAggregatedDiscoveryServiceClient(a.conn)
+	if a.delta {
+		deltastr, err := xds.DeltaAggregatedResources(context.Background())
+		if err != nil {
+			return err
+		}
+		a.deltaStream = deltastr
+		go a.handleDeltaRecv()
+		return nil
+	}
 	edsstr, err := xds.StreamAggregatedResources(context.Background())
 	if err != nil {
 		return err
@@ -361,11 +405,12 @@ func (a *ADSC) handleLDS(ll []*xdsapi.Listener) {
 		b, _ := json.MarshalIndent(ll, " ", " ")
 		log.Println(string(b))
 	}
-	a.mutex.Lock()
-	defer a.mutex.Unlock()
 	if len(routes) > 0 {
 		a.sendRsc(routeType, routes)
 	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
 	a.HTTPListeners = lh
 	a.TCPListeners = lt
 
@@ -542,11 +587,15 @@ func (a *ADSC) handleEDS(eds []*xdsapi.ClusterLoadAssignment) {
 	}
 	if a.InitialLoad == 0 {
 		// first load - Envoy loads listeners after endpoints
-		_ = a.stream.Send(&xdsapi.DiscoveryRequest{
-			ResponseNonce: time.Now().String(),
-			Node:          a.node(),
-			TypeUrl:       listenerType,
-		})
+		if a.delta {
+			a.sendDeltaRsc(listenerType, nil)
+		} else {
+			_ = a.stream.Send(&xdsapi.DiscoveryRequest{
+				ResponseNonce: time.Now().String(),
+				Node:          a.node(),
+				TypeUrl:       listenerType,
+			})
+		}
 	}
 
 	a.mutex.Lock()
@@ -640,6 +689,10 @@ func (a *ADSC) EndpointsJSON() string {
 // it will start watching RDS and CDS.
 func (a *ADSC) Watch() {
 	a.watchTime = time.Now()
+	if a.delta {
+		a.sendDeltaRsc(clusterType, nil)
+		return
+	}
 	_ = a.stream.Send(&xdsapi.DiscoveryRequest{
 		ResponseNonce: time.Now().String(),
 		Node:          a.node(),
@@ -648,6 +701,10 @@ func (a *ADSC) Watch() {
 }
 
 func (a *ADSC) sendRsc(typeurl string, rsc []string) {
+	if a.delta {
+		a.sendDeltaRsc(typeurl, rsc)
+		return
+	}
 	_ = a.stream.Send(&xdsapi.DiscoveryRequest{
 		ResponseNonce: "",
 		Node:          a.node(),
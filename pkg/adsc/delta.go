@@ -0,0 +1,212 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adsc
+
+import (
+	"log"
+	"sort"
+
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/gogo/protobuf/proto"
+)
+
+// sendDeltaRsc updates the subscriptions for a type, using the incremental protocol. A nil
+// list of resources is a wildcard subscription, used for clusters and listeners.
+func (a *ADSC) sendDeltaRsc(typeurl string, rsc []string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	subs, requested := a.deltaSubscriptions[typeurl]
+	if !requested {
+		subs = map[string]struct{}{}
+		a.deltaSubscriptions[typeurl] = subs
+	}
+
+	req := &xdsapi.DeltaDiscoveryRequest{
+		Node:    a.node(),
+		TypeUrl: typeurl,
+	}
+	want := map[string]struct{}{}
+	for _, n := range rsc {
+		want[n] = struct{}{}
+		if _, f := subs[n]; !f {
+			subs[n] = struct{}{}
+			req.ResourceNamesSubscribe = append(req.ResourceNamesSubscribe, n)
+		}
+	}
+	for n := range subs {
+		if _, f := want[n]; !f {
+			delete(subs, n)
+			delete(a.deltaResources[typeurl], n)
+			req.ResourceNamesUnsubscribe = append(req.ResourceNamesUnsubscribe, n)
+		}
+	}
+	if requested && len(req.ResourceNamesSubscribe) == 0 && len(req.ResourceNamesUnsubscribe) == 0 {
+		return
+	}
+	// The server answers wildcard subscriptions and new subscriptions, even if it has no
+	// resource to send. Requests that only unsubscribe get no response.
+	if (!requested && rsc == nil) || len(req.ResourceNamesSubscribe) > 0 {
+		a.deltaPending = append(a.deltaPending, typeurl)
+	}
+	_ = a.deltaStream.Send(req)
+}
+
+// responseType finds the type URL of a response, removing the request it answers from the
+// pending ones. Must be called with a.mutex held.
+func (a *ADSC) responseType(msg *xdsapi.DeltaDiscoveryResponse) string {
+	typeURL := ""
+	for _, rsc := range msg.Resources {
+		if rsc.Resource != nil {
+			typeURL = rsc.Resource.TypeUrl
+			break
+		}
+	}
+	if typeURL == "" {
+		typeURL = a.removedType(msg.RemovedResources)
+	}
+	if typeURL == "" {
+		if len(a.deltaPending) == 0 {
+			return ""
+		}
+		typeURL = a.deltaPending[0]
+		a.deltaPending = a.deltaPending[1:]
+		return typeURL
+	}
+	for i, t := range a.deltaPending {
+		if t == typeURL {
+			a.deltaPending = append(a.deltaPending[:i], a.deltaPending[i+1:]...)
+			break
+		}
+	}
+	return typeURL
+}
+
+// removedType finds the type of removed resources, for responses that don't include any
+// resource. The response doesn't carry the type URL, but resource names of different
+// types don't overlap in practice. Must be called with a.mutex held.
+func (a *ADSC) removedType(names []string) string {
+	for typeURL, rsc := range a.deltaResources {
+		for _, n := range names {
+			if _, f := rsc[n]; f {
+				return typeURL
+			}
+		}
+	}
+	return ""
+}
+
+func (a *ADSC) handleDeltaRecv() {
+	for {
+		msg, err := a.deltaStream.Recv()
+		if err != nil {
+			log.Println("Connection closed ", err, a.nodeID)
+			a.Close()
+			a.WaitClear()
+			a.Updates <- "close"
+			return
+		}
+
+		a.mutex.Lock()
+		typeURL := a.responseType(msg)
+		if typeURL == "" {
+			a.mutex.Unlock()
+			log.Println("Ignoring response without pending request ", msg.Nonce)
+			continue
+		}
+		for _, rsc := range msg.Resources {
+			if rsc.Resource == nil || rsc.Resource.TypeUrl != typeURL {
+				continue
+			}
+			var m proto.Message
+			switch typeURL {
+			case listenerType:
+				m = &xdsapi.Listener{}
+			case clusterType:
+				m = &xdsapi.Cluster{}
+			case endpointType:
+				m = &xdsapi.ClusterLoadAssignment{}
+			case routeType:
+				m = &xdsapi.RouteConfiguration{}
+			default:
+				continue
+			}
+			if err := proto.Unmarshal(rsc.Resource.Value, m); err != nil {
+				log.Println("Failed to decode ", rsc.Name, err)
+				continue
+			}
+			if a.deltaResources[typeURL] == nil {
+				a.deltaResources[typeURL] = map[string]proto.Message{}
+			}
+			a.deltaResources[typeURL][rsc.Name] = m
+		}
+		for _, n := range msg.RemovedResources {
+			delete(a.deltaResources[typeURL], n)
+		}
+
+		a.VersionInfo[typeURL] = msg.SystemVersionInfo
+		a.DeltaUpdates[typeURL] += len(msg.Resources)
+		a.DeltaRemovals[typeURL] += len(msg.RemovedResources)
+		_ = a.deltaStream.Send(&xdsapi.DeltaDiscoveryRequest{
+			Node:          a.node(),
+			TypeUrl:       typeURL,
+			ResponseNonce: msg.Nonce,
+		})
+
+		if len(msg.Resources) == 0 && len(msg.RemovedResources) == 0 {
+			a.mutex.Unlock()
+			continue
+		}
+
+		// Process the complete set of resources for the type, same as a state-of-the-world response.
+		names := make([]string, 0, len(a.deltaResources[typeURL]))
+		for n := range a.deltaResources[typeURL] {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		rsc := make([]proto.Message, 0, len(names))
+		for _, n := range names {
+			rsc = append(rsc, a.deltaResources[typeURL][n])
+		}
+		a.mutex.Unlock()
+
+		switch typeURL {
+		case listenerType:
+			ll := make([]*xdsapi.Listener, 0, len(rsc))
+			for _, m := range rsc {
+				ll = append(ll, m.(*xdsapi.Listener))
+			}
+			a.handleLDS(ll)
+		case clusterType:
+			ll := make([]*xdsapi.Cluster, 0, len(rsc))
+			for _, m := range rsc {
+				ll = append(ll, m.(*xdsapi.Cluster))
+			}
+			a.handleCDS(ll)
+		case endpointType:
+			ll := make([]*xdsapi.ClusterLoadAssignment, 0, len(rsc))
+			for _, m := range rsc {
+				ll = append(ll, m.(*xdsapi.ClusterLoadAssignment))
+			}
+			a.handleEDS(ll)
+		case routeType:
+			ll := make([]*xdsapi.RouteConfiguration, 0, len(rsc))
+			for _, m := range rsc {
+				ll = append(ll, m.(*xdsapi.RouteConfiguration))
+			}
+			a.handleRDS(ll)
+		}
+	}
+}
@@ -15,8 +15,12 @@
 package configz
 
 import (
+	"fmt"
 	"html/template"
 	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
 
 	"istio.io/istio/pkg/mcp/configz/server/assets"
 	"istio.io/istio/pkg/mcp/sink"
@@ -41,6 +45,15 @@ type SnapshotTopic interface {
 	GetResource(group string, collection string, name string) *sink.Object
 }
 
+// HistoryTopic defines the expected interface for listing, comparing and pinning the snapshots
+// recorded in the history of a group.
+type HistoryTopic interface {
+	History(group string) []snapshot.HistoryVersion
+	Diff(group string, from, to int64) (*snapshot.Diff, error)
+	Pin(group string, version int64) error
+	Unpin(group string) error
+}
+
 // Register the Configz topic for the snapshots.
 func Register(topic SnapshotTopic) {
 	ctrlz.RegisterTopic(CreateTopic(topic))
@@ -114,6 +127,68 @@ func (c *configzTopic) Activate(context fw.TopicContext) {
 			fw.RenderJSON(w, http.StatusOK, d)
 		}
 	})
+
+	if h, ok := c.topic.(HistoryTopic); ok {
+		c.activateHistory(context.JSONRouter(), h)
+	}
+}
+
+// activateHistory registers the routes listing, comparing and pinning the snapshots in the history of a group.
+// The first group is used if none is specified.
+func (c *configzTopic) activateHistory(router *mux.Router, h HistoryTopic) {
+	_ = router.StrictSlash(true).NewRoute().Methods("GET").Path("/history").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fw.RenderJSON(w, http.StatusOK, h.History(c.group(req)))
+	})
+
+	_ = router.StrictSlash(true).NewRoute().Methods("GET").Path("/diff").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		from, err := versionParam(req, "from")
+		if err != nil {
+			fw.RenderError(w, http.StatusBadRequest, err)
+			return
+		}
+		to, err := versionParam(req, "to")
+		if err != nil {
+			fw.RenderError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		d, err := h.Diff(c.group(req), from, to)
+		if err != nil {
+			fw.RenderError(w, http.StatusNotFound, err)
+			return
+		}
+		fw.RenderJSON(w, http.StatusOK, d)
+	})
+
+	_ = router.StrictSlash(true).NewRoute().Methods("POST").Path("/pin").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		version, err := versionParam(req, "version")
+		if err != nil {
+			fw.RenderError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err = h.Pin(c.group(req), version); err != nil {
+			fw.RenderError(w, http.StatusNotFound, err)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	_ = router.StrictSlash(true).NewRoute().Methods("POST").Path("/unpin").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := h.Unpin(c.group(req)); err != nil {
+			fw.RenderError(w, http.StatusNotFound, err)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func versionParam(req *http.Request, name string) (int64, error) {
+	v, err := strconv.ParseInt(req.URL.Query().Get(name), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s version %q", name, req.URL.Query().Get(name))
+	}
+	return v, nil
 }
 
 func (c *configzTopic) collectData(group string) *data {
@@ -126,3 +201,13 @@ func (c *configzTopic) collectData(group string) *data {
 func (c *configzTopic) getResource(group string, collection string, name string) *sink.Object {
 	return c.topic.GetResource(group, collection, name)
 }
+
+func (c *configzTopic) group(req *http.Request) string {
+	if group := req.URL.Query().Get("group"); group != "" {
+		return group
+	}
+	if groups := c.topic.GetGroups(); len(groups) > 0 {
+		return groups[0]
+	}
+	return ""
+}
@@ -25,8 +25,6 @@ import (
 	"github.com/gogo/protobuf/types"
 
 	"istio.io/istio/pkg/mcp/snapshot"
-	"istio.io/istio/pkg/mcp/source"
-	mcptest "istio.io/istio/pkg/mcp/testing"
 	"istio.io/istio/pkg/mcp/testing/groups"
 	"istio.io/pkg/ctrlz"
 	"istio.io/pkg/ctrlz/fw"
@@ -35,24 +33,20 @@ import (
 const testK8sCollection = "k8s/core/v1/nodes"
 
 func TestConfigZ(t *testing.T) {
-	s, err := mcptest.NewServer(0, []source.CollectionOptions{{Name: testK8sCollection}})
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer func() { _ = s.Close() }()
-
 	b := snapshot.NewInMemoryBuilder()
 	b.SetVersion(testK8sCollection, "23")
-	err = b.SetEntry(testK8sCollection, "foo", "v0", time.Time{}, nil, nil, &types.Empty{})
+	err := b.SetEntry(testK8sCollection, "foo", "v0", time.Time{}, nil, nil, &types.Empty{})
 	if err != nil {
 		t.Fatalf("Setting an entry should not have failed: %v", err)
 	}
 
-	s.Cache.SetSnapshot(groups.Default, b.Build())
+	// the topics registered with ControlZ are global, the cache with history is used for all the tests.
+	cache := snapshot.NewWithHistory(groups.DefaultIndexFn, 5)
+	cache.SetSnapshot(groups.Default, b.Build())
 
 	o := ctrlz.DefaultOptions()
 	o.Port = 0
-	cz, err := ctrlz.Run(o, []fw.Topic{CreateTopic(s.Cache)})
+	cz, err := ctrlz.Run(o, []fw.Topic{CreateTopic(cache)})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -63,6 +57,8 @@ func TestConfigZ(t *testing.T) {
 	t.Run("configj with 1 request", func(tt *testing.T) { testConfigJWithOneRequest(tt, baseURL) })
 
 	t.Run("configj mcp resource with 1 request", func(tt *testing.T) { testConfigJResourceWithOneRequest(tt, baseURL) })
+
+	t.Run("configj history", func(tt *testing.T) { testConfigJHistory(tt, baseURL, cache) })
 }
 
 func testConfigJWithOneRequest(t *testing.T, baseURL string) {
@@ -125,6 +121,75 @@ func testConfigJResourceWithOneRequest(t *testing.T, baseURL string) {
 
 }
 
+func testConfigJHistory(t *testing.T, baseURL string, c *snapshot.Cache) {
+	t.Helper()
+
+	b := snapshot.NewInMemoryBuilder()
+	b.SetVersion(testK8sCollection, "24")
+	if err := b.SetEntry(testK8sCollection, "foo", "v1", time.Time{}, nil, nil, &types.Empty{}); err != nil {
+		t.Fatalf("Setting an entry should not have failed: %v", err)
+	}
+	c.SetSnapshot(groups.Default, b.Build())
+
+	baseURL += "/configj"
+
+	var history []snapshot.HistoryVersion
+	if err := json.Unmarshal([]byte(request(t, baseURL+"/history")), &history); err != nil {
+		t.Fatalf("Should have unmarshalled json: %v", err)
+	}
+	if len(history) != 2 || history[0].ID != 1 || history[1].Versions[testK8sCollection] != "24" {
+		t.Fatalf("Should have listed both versions: %+v", history)
+	}
+
+	var d snapshot.Diff
+	if err := json.Unmarshal([]byte(request(t, baseURL+"/diff?from=1&to=2")), &d); err != nil {
+		t.Fatalf("Should have unmarshalled json: %v", err)
+	}
+	if len(d.Changes) != 1 || d.Changes[0].Type != snapshot.Modified || d.Changes[0].Name != "foo" {
+		t.Fatalf("Should have listed foo as modified: %+v", d)
+	}
+
+	for _, tc := range []struct {
+		method string
+		path   string
+		status int
+	}{
+		{"GET", "/diff?from=1&to=x", http.StatusBadRequest},
+		{"GET", "/diff?from=1&to=3", http.StatusNotFound},
+		{"POST", "/pin?version=3", http.StatusNotFound},
+		{"POST", "/pin?group=" + groups.Default + "&version=1", http.StatusAccepted},
+	} {
+		req, err := http.NewRequest(tc.method, baseURL+tc.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s %s: got status %d, expecting %d", tc.method, tc.path, resp.StatusCode, tc.status)
+		}
+	}
+
+	if o := c.GetResource(groups.Default, testK8sCollection, "foo"); o == nil || o.Metadata.Version != "v0" {
+		t.Fatalf("Should have served the pinned version v0: %v", o)
+	}
+
+	resp, err := http.Post(baseURL+"/unpin", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("Should have unpinned the version: %d", resp.StatusCode)
+	}
+	if o := c.GetResource(groups.Default, testK8sCollection, "foo"); o == nil || o.Metadata.Version != "v1" {
+		t.Fatalf("Should have served the latest version v1: %v", o)
+	}
+}
+
 func request(t *testing.T, url string) string {
 	var e error
 	for i := 1; i < 10; i++ {
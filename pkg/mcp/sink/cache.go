@@ -0,0 +1,109 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+
+	mcp "istio.io/api/mcp/v1alpha1"
+)
+
+// diskCache persists the last resources applied for each collection, so that a sink restarting while its
+// source is unreachable can start from them. Each collection is stored in its own file, as a full-state
+// mcp.Resources message.
+type diskCache struct {
+	dir string
+}
+
+func newDiskCache(dir string) (*diskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("unable to create the MCP cache directory %s: %v", dir, err)
+	}
+	return &diskCache{dir: dir}, nil
+}
+
+func (c *diskCache) path(collection string) string {
+	return filepath.Join(c.dir, url.PathEscape(collection))
+}
+
+// load returns the cached resources of a collection, or nil if none are cached.
+func (c *diskCache) load(collection string) (*mcp.Resources, error) {
+	b, err := ioutil.ReadFile(c.path(collection))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resources := &mcp.Resources{}
+	if err = proto.Unmarshal(b, resources); err != nil {
+		return nil, fmt.Errorf("unable to decode the cached resources of %v: %v", collection, err)
+	}
+	if resources.Collection != collection {
+		return nil, fmt.Errorf("cached resources of %v are for collection %v", collection, resources.Collection)
+	}
+	return resources, nil
+}
+
+// store replaces the cached resources of a collection. The file is written to a temporary file first and
+// renamed, so that it is never left partially written.
+func (c *diskCache) store(collection, version string, resources map[string]*mcp.Resource) error {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	msg := &mcp.Resources{
+		Collection:        collection,
+		SystemVersionInfo: version,
+		Resources:         make([]mcp.Resource, 0, len(names)),
+	}
+	for _, name := range names {
+		msg.Resources = append(msg.Resources, *resources[name])
+	}
+
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(c.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err = f.Write(b); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, c.path(collection))
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+	}
+	return err
+}
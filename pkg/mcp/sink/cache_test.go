@@ -0,0 +1,170 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	mcp "istio.io/api/mcp/v1alpha1"
+	"istio.io/istio/pkg/mcp/internal/test"
+	"istio.io/istio/pkg/mcp/testing/monitoring"
+)
+
+func newCacheDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "mcp-sink-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func newCachedSink(dir string, updater Updater) *Sink {
+	return New(&Options{
+		CollectionOptions: []CollectionOptions{
+			{Name: test.FakeType0Collection, Incremental: true},
+			{Name: test.FakeType1Collection},
+		},
+		Updater:  updater,
+		ID:       test.NodeID,
+		Metadata: test.NodeMetadata,
+		Reporter: monitoring.NewInMemoryStatsContext(),
+		CacheDir: dir,
+	})
+}
+
+// versions returns the versions of the objects applied for a collection, by name.
+func versions(u *InMemoryUpdater, collection string) map[string]string {
+	v := make(map[string]string)
+	for _, o := range u.Get(collection) {
+		v[o.Metadata.Name] = o.Metadata.Version
+	}
+	return v
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := newCacheDir(t)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	c, err := newDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := c.load(test.FakeType0Collection); got != nil || err != nil {
+		t.Fatalf("Got %v, %v, expecting nothing cached", got, err)
+	}
+
+	resources := map[string]*mcp.Resource{
+		"b": test.Type0B[0].Resource,
+		"a": test.Type0A[0].Resource,
+	}
+	if err = c.store(test.FakeType0Collection, "v1", resources); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.load(test.FakeType0Collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := test.MakeResources(false, test.FakeType0Collection, "v1", "", nil, test.Type0A[0], test.Type0B[0])
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %v, expecting %v", got, want)
+	}
+
+	// only the collection file is left in the directory.
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Got %d files in the cache directory, expecting 1", len(files))
+	}
+
+	if err = ioutil.WriteFile(c.path(test.FakeType1Collection), []byte("invalid"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.load(test.FakeType1Collection); err == nil {
+		t.Error("Got no error, expecting the cached resources to be invalid")
+	}
+}
+
+func TestSinkBootstrapsFromCache(t *testing.T) {
+	dir := newCacheDir(t)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	s := newCachedSink(dir, NewInMemoryUpdater())
+	for _, resources := range []*mcp.Resources{
+		test.MakeResources(false, test.FakeType0Collection, "1", "n0", nil, test.Type0A[0], test.Type0B[0]),
+		test.MakeResources(true, test.FakeType0Collection, "2", "n1", []string{"b"}, test.Type0A[1], test.Type0C[0]),
+		test.MakeResources(false, test.FakeType1Collection, "1", "n2", nil, test.Type1A[0]),
+		// resources which are NACK'd aren't cached.
+		test.MakeResources(false, test.FakeType1Collection, "2", "n3", nil, test.BadUnmarshal),
+	} {
+		s.handleResponse(resources)
+	}
+
+	// a sink restarting while the source is unreachable applies the cached resources.
+	u := NewInMemoryUpdater()
+	s = newCachedSink(dir, u)
+
+	if got, want := versions(u, test.FakeType0Collection), map[string]string{"a": "v1", "c": "v0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %v applied, expecting %v", got, want)
+	}
+	if got, want := versions(u, test.FakeType1Collection), map[string]string{"a": "v0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %v applied, expecting %v", got, want)
+	}
+
+	// the source only sends the changes since the cached versions once reachable.
+	for _, req := range s.createInitialRequests() {
+		var want map[string]string
+		if req.Collection == test.FakeType0Collection {
+			want = map[string]string{"a": "v1", "c": "v0"}
+		}
+		if !reflect.DeepEqual(req.InitialResourceVersions, want) {
+			t.Errorf("Got initial versions %v for %v, expecting %v", req.InitialResourceVersions, req.Collection, want)
+		}
+	}
+
+	// the cache is reconciled with the source.
+	s.handleResponse(test.MakeResources(true, test.FakeType0Collection, "3", "n4", []string{"c"}, test.Type0A[2]))
+	s.handleResponse(test.MakeResources(false, test.FakeType1Collection, "3", "n5", nil))
+
+	u = NewInMemoryUpdater()
+	newCachedSink(dir, u)
+	if got, want := versions(u, test.FakeType0Collection), map[string]string{"a": "v2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %v applied, expecting %v", got, want)
+	}
+	if got := versions(u, test.FakeType1Collection); len(got) != 0 {
+		t.Errorf("Got %v applied, expecting nothing", got)
+	}
+}
+
+func TestSinkWithoutCache(t *testing.T) {
+	s := New(&Options{
+		CollectionOptions: CollectionOptionsFromSlice(test.SupportedCollections),
+		Updater:           NewInMemoryUpdater(),
+		Reporter:          monitoring.NewInMemoryStatsContext(),
+	})
+	s.handleResponse(test.MakeResources(false, test.FakeType0Collection, "1", "n0", nil, test.Type0A[0]))
+
+	if s.cache != nil || s.state[test.FakeType0Collection].resources != nil {
+		t.Error("Got resources tracked, expecting no cache")
+	}
+}
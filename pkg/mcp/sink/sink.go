@@ -38,6 +38,9 @@ type perCollectionState struct {
 
 	// determines when incremental delivery is enabled for this collection
 	requestIncremental bool
+
+	// the resources that we've successfully ACK'd, only tracked when they are cached on disk
+	resources map[string]*mcp.Resource
 }
 
 // Sink implements the resource sink message exchange for MCP. It can be instantiated by client and server
@@ -51,6 +54,7 @@ type Sink struct {
 	journal  *RecentRequestsJournal
 	metadata map[string]string
 	reporter monitoring.Reporter
+	cache    *diskCache
 }
 
 // New creates a new resource sink.
@@ -68,7 +72,7 @@ func New(options *Options) *Sink { // nolint: lll
 		}
 	}
 
-	return &Sink{
+	sink := &Sink{
 		state:    state,
 		nodeInfo: nodeInfo,
 		updater:  options.Updater,
@@ -76,37 +80,71 @@ func New(options *Options) *Sink { // nolint: lll
 		reporter: options.Reporter,
 		journal:  NewRequestJournal(),
 	}
+
+	if options.CacheDir != "" {
+		cache, err := newDiskCache(options.CacheDir)
+		if err != nil {
+			scope.Errorf("MCP: resources are not cached on disk: %v", err)
+		} else {
+			sink.cache = cache
+			sink.bootstrap()
+		}
+	}
+
+	return sink
 }
 
-// Probe point for test code to determine when the node is finished processing responses.
-var handleResponseDoneProbe = func() {}
+// bootstrap applies the resources cached on disk, so that they are served until the source is reachable.
+// The versions of the cached resources are sent in the initial incremental requests, so that the source only
+// sends the resources which changed since.
+func (sink *Sink) bootstrap() {
+	for collection, state := range sink.state {
+		state.resources = make(map[string]*mcp.Resource)
 
-func (sink *Sink) sendNACKRequest(response *mcp.Resources, err error) *mcp.RequestResources {
-	errorDetails, _ := status.FromError(err)
+		resources, err := sink.cache.load(collection)
+		if err != nil {
+			scope.Errorf("MCP: ignoring the cached resources of %v: %v", collection, err)
+			continue
+		}
+		if resources == nil {
+			continue
+		}
 
-	scope.Errorf("MCP: sending NACK for nonce=%v: error=%q", response.Nonce, err)
-	sink.reporter.RecordRequestNack(response.Collection, 0, errorDetails.Code())
+		change, err := toChange(resources)
+		if err == nil {
+			err = sink.updater.Apply(change)
+		}
+		if err != nil {
+			scope.Errorf("MCP: unable to apply the cached resources of %v: %v", collection, err)
+			continue
+		}
 
-	req := &mcp.RequestResources{
-		SinkNode:      sink.nodeInfo,
-		Collection:    response.Collection,
-		ResponseNonce: response.Nonce,
-		ErrorDetail:   errorDetails.Proto(),
+		internal.UpdateResourceVersionTracking(state.versions, resources)
+		updateResources(state.resources, resources)
+		scope.Infof("MCP: applied %d cached resources of %v at version %q",
+			len(resources.Resources), collection, resources.SystemVersionInfo)
 	}
-	return req
 }
 
-func (sink *Sink) handleResponse(resources *mcp.Resources) *mcp.RequestResources {
-	if handleResponseDoneProbe != nil {
-		defer handleResponseDoneProbe()
+// updateResources applies a response to the resources tracked for a collection.
+func updateResources(tracked map[string]*mcp.Resource, resources *mcp.Resources) {
+	if !resources.Incremental {
+		for name := range tracked {
+			delete(tracked, name)
+		}
 	}
-
-	state, ok := sink.state[resources.Collection]
-	if !ok {
-		errDetails := status.Errorf(codes.Unimplemented, "unsupported collection %v", resources.Collection)
-		return sink.sendNACKRequest(resources, errDetails)
+	for i := range resources.Resources {
+		r := &resources.Resources[i]
+		tracked[r.Metadata.Name] = r
+	}
+	if resources.Incremental {
+		for _, name := range resources.RemovedResources {
+			delete(tracked, name)
+		}
 	}
+}
 
+func toChange(resources *mcp.Resources) (*Change, error) {
 	change := &Change{
 		Collection:        resources.Collection,
 		Objects:           make([]*Object, 0, len(resources.Resources)),
@@ -118,7 +156,7 @@ func (sink *Sink) handleResponse(resources *mcp.Resources) *mcp.RequestResources
 	for _, resource := range resources.Resources {
 		var dynamicAny types.DynamicAny
 		if err := types.UnmarshalAny(resource.Body, &dynamicAny); err != nil {
-			return sink.sendNACKRequest(resources, err)
+			return nil, err
 		}
 
 		// TODO - use galley metadata to verify collection and type_url match?
@@ -129,6 +167,42 @@ func (sink *Sink) handleResponse(resources *mcp.Resources) *mcp.RequestResources
 		}
 		change.Objects = append(change.Objects, object)
 	}
+	return change, nil
+}
+
+// Probe point for test code to determine when the node is finished processing responses.
+var handleResponseDoneProbe = func() {}
+
+func (sink *Sink) sendNACKRequest(response *mcp.Resources, err error) *mcp.RequestResources {
+	errorDetails, _ := status.FromError(err)
+
+	scope.Errorf("MCP: sending NACK for nonce=%v: error=%q", response.Nonce, err)
+	sink.reporter.RecordRequestNack(response.Collection, 0, errorDetails.Code())
+
+	req := &mcp.RequestResources{
+		SinkNode:      sink.nodeInfo,
+		Collection:    response.Collection,
+		ResponseNonce: response.Nonce,
+		ErrorDetail:   errorDetails.Proto(),
+	}
+	return req
+}
+
+func (sink *Sink) handleResponse(resources *mcp.Resources) *mcp.RequestResources {
+	if handleResponseDoneProbe != nil {
+		defer handleResponseDoneProbe()
+	}
+
+	state, ok := sink.state[resources.Collection]
+	if !ok {
+		errDetails := status.Errorf(codes.Unimplemented, "unsupported collection %v", resources.Collection)
+		return sink.sendNACKRequest(resources, errDetails)
+	}
+
+	change, err := toChange(resources)
+	if err != nil {
+		return sink.sendNACKRequest(resources, err)
+	}
 
 	if err := sink.updater.Apply(change); err != nil {
 		errDetails := status.Error(codes.InvalidArgument, err.Error())
@@ -139,6 +213,12 @@ func (sink *Sink) handleResponse(resources *mcp.Resources) *mcp.RequestResources
 	sink.mu.Lock()
 	internal.UpdateResourceVersionTracking(state.versions, resources)
 	useIncremental := state.requestIncremental
+	if sink.cache != nil {
+		updateResources(state.resources, resources)
+		if err := sink.cache.store(resources.Collection, resources.SystemVersionInfo, state.resources); err != nil {
+			scope.Errorf("MCP: unable to cache the resources of %v on disk: %v", resources.Collection, err)
+		}
+	}
 	sink.mu.Unlock()
 
 	// ACK
@@ -351,6 +431,10 @@ type Options struct {
 	ID                string
 	Metadata          map[string]string
 	Reporter          monitoring.Reporter
+
+	// CacheDir is the directory where the last applied resources are cached, so that they are applied
+	// on startup if the source is unreachable. Resources are not cached if empty.
+	CacheDir string
 }
 
 // Stream is for sending RequestResources messages and receiving Resource messages.
@@ -0,0 +1,147 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"hash/fnv"
+	"time"
+
+	mcp "istio.io/api/mcp/v1alpha1"
+	"istio.io/istio/pkg/mcp/source"
+)
+
+// CanaryOptions configures the staged rollout of new snapshots: a new snapshot is first served to the
+// selected sinks only, and to all the sinks once it has been served for the soak time without being NACK'd.
+// It is rolled back as soon as one of the selected sinks NACKs it.
+type CanaryOptions struct {
+	// SinkAnnotations selects the sinks with all these annotations.
+	SinkAnnotations map[string]string
+
+	// Percentage of the sinks selected, based on their ids.
+	Percentage int
+
+	// SoakTime is how long a new snapshot is served to the selected sinks before all the sinks are served.
+	SoakTime time.Duration
+}
+
+// canary is the rollout of a new snapshot to a group.
+type canary struct {
+	snapshot Snapshot
+	// the snapshot served to the sinks which aren't selected
+	stable Snapshot
+	timer  *time.Timer
+}
+
+// selects returns whether a new snapshot is served to a sink before it is promoted.
+func (o *CanaryOptions) selects(node *mcp.SinkNode) bool {
+	if node == nil {
+		return false
+	}
+
+	if len(o.SinkAnnotations) > 0 {
+		selected := true
+		for k, v := range o.SinkAnnotations {
+			if node.Annotations[k] != v {
+				selected = false
+				break
+			}
+		}
+		if selected {
+			return true
+		}
+	}
+
+	if o.Percentage > 0 {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(node.Id))
+		return int(h.Sum32()%100) < o.Percentage
+	}
+	return false
+}
+
+// SetCanaryOptions enables the staged rollout of new snapshots, or disables it if options is nil. Only the
+// snapshots set afterwards are rolled out in stages.
+func (c *Cache) SetCanaryOptions(options *CanaryOptions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.canaryOptions = options
+	if options == nil {
+		for group := range c.canaries {
+			c.stopCanary(group)
+			c.respond(group)
+		}
+	}
+}
+
+// startCanary starts the rollout of a new snapshot to a group. The rollout in progress, if any,
+// is superseded: the sinks which aren't selected are still served its stable snapshot.
+func (c *Cache) startCanary(group string, previous, snapshot Snapshot) {
+	stable := previous
+	if cn, ok := c.canaries[group]; ok {
+		stable = cn.stable
+		cn.timer.Stop()
+	}
+
+	cn := &canary{
+		snapshot: snapshot,
+		stable:   stable,
+	}
+	cn.timer = time.AfterFunc(c.canaryOptions.SoakTime, func() { c.promote(group, cn) })
+	c.canaries[group] = cn
+
+	scope.Infof("Rolling out a new snapshot of group %q to the selected sinks for %v", group, c.canaryOptions.SoakTime)
+}
+
+// promote serves the snapshot of a rollout to all the sinks of a group, unless the rollout was superseded.
+func (c *Cache) promote(group string, cn *canary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.canaries[group] != cn {
+		return
+	}
+	delete(c.canaries, group)
+
+	scope.Infof("Promoting the new snapshot of group %q to all the sinks", group)
+	c.respond(group)
+}
+
+// nack rolls back the snapshot of the rollout in progress for a group if a selected sink NACK'd it.
+func (c *Cache) nack(group string, request *source.Request) {
+	cn, ok := c.canaries[group]
+	if !ok || !c.canaryOptions.selects(request.SinkNode) {
+		return
+	}
+
+	version := cn.snapshot.Version(request.Collection)
+	if request.VersionInfo != version || version == cn.stable.Version(request.Collection) {
+		return
+	}
+
+	scope.Warnf("Rolling back the new snapshot of group %q: version %q of %v was NACK'd by %v: %v",
+		group, version, request.Collection, request.SinkNode.GetId(), request.ErrorDetail)
+
+	c.stopCanary(group)
+	c.snapshots[group] = cn.stable
+	c.respond(group)
+}
+
+func (c *Cache) stopCanary(group string) {
+	if cn, ok := c.canaries[group]; ok {
+		cn.timer.Stop()
+		delete(c.canaries, group)
+	}
+}
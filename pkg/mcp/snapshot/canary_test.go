@@ -0,0 +1,140 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogo/googleapis/google/rpc"
+
+	mcp "istio.io/api/mcp/v1alpha1"
+	"istio.io/istio/pkg/mcp/source"
+	"istio.io/istio/pkg/mcp/testing/groups"
+)
+
+var (
+	canaryNode = &mcp.SinkNode{Id: "canary", Annotations: map[string]string{"rollout": "canary"}}
+	stableNode = &mcp.SinkNode{Id: "stable"}
+)
+
+// watch opens a watch for a sink, and returns the channel its responses are pushed to.
+func watch(c *Cache, node *mcp.SinkNode, version string, errorDetail *rpc.Status) chan *source.WatchResponse {
+	responseC := make(chan *source.WatchResponse, 1)
+	c.Watch(&source.Request{
+		Collection:  historyCollection,
+		VersionInfo: version,
+		SinkNode:    node,
+		ErrorDetail: errorDetail,
+	}, func(response *source.WatchResponse) {
+		responseC <- response
+	}, node.Id)
+	return responseC
+}
+
+func expectVersion(t *testing.T, responseC chan *source.WatchResponse, version string) {
+	t.Helper()
+
+	got, _ := getAsyncResponse(responseC)
+	switch {
+	case version == "" && got != nil:
+		t.Fatalf("Got version %q, expecting no response", got.Version)
+	case version != "" && got == nil:
+		t.Fatalf("Got no response, expecting version %q", version)
+	case version != "" && got.Version != version:
+		t.Fatalf("Got version %q, expecting %q", got.Version, version)
+	}
+}
+
+func TestCanarySelects(t *testing.T) {
+	for i, tc := range []struct {
+		options CanaryOptions
+		node    *mcp.SinkNode
+		want    bool
+	}{
+		{CanaryOptions{SinkAnnotations: map[string]string{"rollout": "canary"}}, canaryNode, true},
+		{CanaryOptions{SinkAnnotations: map[string]string{"rollout": "canary"}}, stableNode, false},
+		{CanaryOptions{SinkAnnotations: map[string]string{"rollout": "canary", "zone": "a"}}, canaryNode, false},
+		{CanaryOptions{Percentage: 100}, stableNode, true},
+		{CanaryOptions{Percentage: 0}, stableNode, false},
+		{CanaryOptions{SinkAnnotations: map[string]string{"zone": "a"}, Percentage: 100}, canaryNode, true},
+		{CanaryOptions{Percentage: 100}, nil, false},
+	} {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			if got := tc.options.selects(tc.node); got != tc.want {
+				t.Errorf("Got %v, expecting %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCanaryPromote(t *testing.T) {
+	c := New(groups.DefaultIndexFn)
+	c.SetCanaryOptions(&CanaryOptions{
+		SinkAnnotations: map[string]string{"rollout": "canary"},
+		SoakTime:        100 * time.Millisecond,
+	})
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "1", nil))
+
+	canaryC := watch(c, canaryNode, "1", nil)
+	stableC := watch(c, stableNode, "1", nil)
+
+	// the new snapshot is only served to the canary.
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "2", nil))
+	expectVersion(t, canaryC, "2")
+	expectVersion(t, watch(c, stableNode, "", nil), "1")
+
+	// all the sinks are served the new snapshot once it soaked.
+	expectVersion(t, stableC, "2")
+	expectVersion(t, watch(c, stableNode, "", nil), "2")
+}
+
+func TestCanaryRollback(t *testing.T) {
+	c := New(groups.DefaultIndexFn)
+	c.SetCanaryOptions(&CanaryOptions{
+		SinkAnnotations: map[string]string{"rollout": "canary"},
+		SoakTime:        time.Hour,
+	})
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "1", nil))
+
+	stableC := watch(c, stableNode, "1", nil)
+	otherCanaryC := watch(c, &mcp.SinkNode{Id: "other", Annotations: canaryNode.Annotations}, "1", nil)
+
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "2", nil))
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "3", nil))
+	expectVersion(t, otherCanaryC, "2")
+	otherCanaryC = watch(c, &mcp.SinkNode{Id: "other", Annotations: canaryNode.Annotations}, "2", nil)
+	expectVersion(t, otherCanaryC, "3")
+	otherCanaryC = watch(c, &mcp.SinkNode{Id: "other", Annotations: canaryNode.Annotations}, "3", nil)
+
+	// a NACK from a sink which isn't selected is ignored.
+	expectVersion(t, watch(c, stableNode, "1", &rpc.Status{Code: 3}), "")
+
+	// a NACK from a canary rolls the new snapshot back to the sinks which were served it.
+	expectVersion(t, watch(c, canaryNode, "3", &rpc.Status{Code: 3, Message: "invalid"}), "1")
+	expectVersion(t, otherCanaryC, "1")
+	expectVersion(t, stableC, "")
+	expectVersion(t, watch(c, canaryNode, "", nil), "1")
+
+	// the next snapshot is rolled out again.
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "4", nil))
+	expectVersion(t, watch(c, canaryNode, "1", nil), "4")
+	expectVersion(t, stableC, "")
+
+	// disabling the rollout serves the latest snapshot to all the sinks.
+	c.SetCanaryOptions(nil)
+	expectVersion(t, stableC, "4")
+}
@@ -0,0 +1,258 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	mcp "istio.io/api/mcp/v1alpha1"
+	"istio.io/istio/galley/pkg/metadata"
+)
+
+// HistoryVersion describes a snapshot recorded in the history of a group.
+type HistoryVersion struct {
+	// ID of the snapshot in the history of the group, increasing with each snapshot set.
+	ID int64
+	// Created is the time the snapshot was set.
+	Created time.Time
+	// Versions of the collections of the snapshot.
+	Versions map[string]string
+	// Pinned is true if the snapshot is served instead of the latest one.
+	Pinned bool
+}
+
+// ChangeType is the type of change of a resource between two snapshots.
+type ChangeType string
+
+const (
+	// Added resources are only in the newer snapshot.
+	Added ChangeType = "added"
+	// Removed resources are only in the older snapshot.
+	Removed ChangeType = "removed"
+	// Modified resources are in both snapshots, with different versions.
+	Modified ChangeType = "modified"
+)
+
+// Change of a resource between two snapshots.
+type Change struct {
+	Collection  string
+	Name        string
+	Type        ChangeType
+	FromVersion string `json:",omitempty"`
+	ToVersion   string `json:",omitempty"`
+}
+
+// Diff lists the resources changed between two snapshots of a group.
+type Diff struct {
+	From    int64
+	To      int64
+	Changes []Change
+}
+
+type historyEntry struct {
+	id       int64
+	created  time.Time
+	snapshot Snapshot
+}
+
+// history is a bounded ring of the latest snapshots set for a group.
+type history struct {
+	lastID  int64
+	entries []*historyEntry
+	// the snapshot served instead of the latest one, if any. It is kept even
+	// once it is evicted from the entries.
+	pinned *historyEntry
+}
+
+func (h *history) add(snapshot Snapshot, size int) {
+	h.lastID++
+	h.entries = append(h.entries, &historyEntry{
+		id:       h.lastID,
+		created:  time.Now(),
+		snapshot: snapshot,
+	})
+	if len(h.entries) > size {
+		evicted := len(h.entries) - size
+		copy(h.entries, h.entries[evicted:])
+		for i := size; i < len(h.entries); i++ {
+			h.entries[i] = nil
+		}
+		h.entries = h.entries[:size]
+	}
+}
+
+func (h *history) get(id int64) *historyEntry {
+	for _, e := range h.entries {
+		if e.id == id {
+			return e
+		}
+	}
+	if h.pinned != nil && h.pinned.id == id {
+		return h.pinned
+	}
+	return nil
+}
+
+// collections returns the sorted collections of the given snapshots. Snapshots which don't
+// list their collections are assumed to hold the collections known to Galley.
+func collections(snapshots ...Snapshot) []string {
+	seen := make(map[string]bool)
+	for _, s := range snapshots {
+		if l, ok := s.(interface{ Collections() []string }); ok {
+			for _, c := range l.Collections() {
+				seen[c] = true
+			}
+		} else {
+			for _, info := range metadata.Types.All() {
+				seen[info.Collection.String()] = true
+			}
+		}
+	}
+
+	result := make([]string, 0, len(seen))
+	for c := range seen {
+		result = append(result, c)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// History returns the snapshots recorded for a group, from the oldest to the latest.
+func (c *Cache) History(group string) []HistoryVersion {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	h, ok := c.histories[group]
+	if !ok {
+		return nil
+	}
+
+	entries := h.entries
+	if h.pinned != nil && (len(entries) == 0 || h.pinned.id < entries[0].id) {
+		// the pinned snapshot was evicted, it is still listed as it is being served.
+		entries = append([]*historyEntry{h.pinned}, entries...)
+	}
+
+	versions := make([]HistoryVersion, 0, len(entries))
+	for _, e := range entries {
+		v := HistoryVersion{
+			ID:       e.id,
+			Created:  e.created,
+			Versions: make(map[string]string),
+			Pinned:   e == h.pinned,
+		}
+		for _, collection := range collections(e.snapshot) {
+			if version := e.snapshot.Version(collection); version != "" {
+				v.Versions[collection] = version
+			}
+		}
+		versions = append(versions, v)
+	}
+	return versions
+}
+
+// Diff returns the resources changed from one snapshot in the history of a group to another.
+func (c *Cache) Diff(group string, from, to int64) (*Diff, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	h, ok := c.histories[group]
+	if !ok {
+		return nil, fmt.Errorf("no snapshot history for group %q", group)
+	}
+	fromEntry := h.get(from)
+	if fromEntry == nil {
+		return nil, fmt.Errorf("version %d of group %q is not in the history", from, group)
+	}
+	toEntry := h.get(to)
+	if toEntry == nil {
+		return nil, fmt.Errorf("version %d of group %q is not in the history", to, group)
+	}
+
+	d := &Diff{From: from, To: to, Changes: []Change{}}
+	for _, collection := range collections(fromEntry.snapshot, toEntry.snapshot) {
+		d.Changes = append(d.Changes,
+			diffResources(collection, fromEntry.snapshot.Resources(collection), toEntry.snapshot.Resources(collection))...)
+	}
+	return d, nil
+}
+
+func diffResources(collection string, from, to []*mcp.Resource) []Change {
+	versions := make(map[string]string, len(from))
+	for _, r := range from {
+		versions[r.Metadata.Name] = r.Metadata.Version
+	}
+
+	var changes []Change
+	for _, r := range to {
+		name := r.Metadata.Name
+		fromVersion, ok := versions[name]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Collection: collection, Name: name, Type: Added, ToVersion: r.Metadata.Version})
+		case fromVersion != r.Metadata.Version:
+			changes = append(changes, Change{
+				Collection: collection, Name: name, Type: Modified, FromVersion: fromVersion, ToVersion: r.Metadata.Version})
+		}
+		delete(versions, name)
+	}
+	for name, version := range versions {
+		changes = append(changes, Change{Collection: collection, Name: name, Type: Removed, FromVersion: version})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// Pin serves a snapshot from the history of a group to its clients, instead of the latest one,
+// until Unpin is called. Snapshots set in the meantime are still recorded in the history.
+func (c *Cache) Pin(group string, id int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h, ok := c.histories[group]
+	if !ok {
+		return fmt.Errorf("no snapshot history for group %q", group)
+	}
+	e := h.get(id)
+	if e == nil {
+		return fmt.Errorf("version %d of group %q is not in the history", id, group)
+	}
+
+	h.pinned = e
+	scope.Infof("Pin(): serving version %d of group %q", id, group)
+	c.respond(group)
+	return nil
+}
+
+// Unpin serves the latest snapshot of a group to its clients again.
+func (c *Cache) Unpin(group string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h, ok := c.histories[group]
+	if !ok || h.pinned == nil {
+		return fmt.Errorf("group %q is not pinned", group)
+	}
+
+	h.pinned = nil
+	scope.Infof("Unpin(): serving the latest version %d of group %q", h.lastID, group)
+	c.respond(group)
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/google/go-cmp/cmp"
+
+	"istio.io/istio/pkg/mcp/source"
+	"istio.io/istio/pkg/mcp/testing/groups"
+)
+
+const historyCollection = "k8s/core/v1/nodes"
+
+// buildHistorySnapshot builds a snapshot with entries named after the keys of versions.
+func buildHistorySnapshot(t *testing.T, version string, versions map[string]string) *InMemory {
+	t.Helper()
+
+	b := NewInMemoryBuilder()
+	b.SetVersion(historyCollection, version)
+	for name, v := range versions {
+		if err := b.SetEntry(historyCollection, name, v, time.Time{}, nil, nil, &types.Empty{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return b.Build()
+}
+
+func historyIDs(c *Cache) []int64 {
+	var ids []int64
+	for _, v := range c.History(groups.Default) {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+func TestHistoryIsBounded(t *testing.T) {
+	c := NewWithHistory(groups.DefaultIndexFn, 2)
+	for _, v := range []string{"1", "2", "3"} {
+		c.SetSnapshot(groups.Default, buildHistorySnapshot(t, v, nil))
+	}
+
+	if diff := cmp.Diff(historyIDs(c), []int64{2, 3}); diff != "" {
+		t.Errorf("Unexpected history: %s", diff)
+	}
+	if got := c.History(groups.Default)[1].Versions[historyCollection]; got != "3" {
+		t.Errorf("Got collection version %q, expecting 3", got)
+	}
+
+	if _, err := c.Diff(groups.Default, 1, 3); err == nil {
+		t.Error("Got no error, expecting the evicted version to be unknown")
+	}
+
+	c.ClearSnapshot(groups.Default)
+	if h := c.History(groups.Default); h != nil {
+		t.Errorf("Got history %v, expecting it to be cleared", h)
+	}
+}
+
+func TestHistoryDisabled(t *testing.T) {
+	c := New(groups.DefaultIndexFn)
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "1", nil))
+
+	if h := c.History(groups.Default); h != nil {
+		t.Errorf("Got history %v, expecting none", h)
+	}
+	if err := c.Pin(groups.Default, 1); err == nil {
+		t.Error("Got no error, expecting pinning to fail without history")
+	}
+}
+
+func TestHistoryDiff(t *testing.T) {
+	c := NewWithHistory(groups.DefaultIndexFn, 10)
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "1", map[string]string{"a": "v1", "b": "v1", "c": "v1"}))
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "2", map[string]string{"a": "v1", "b": "v2", "d": "v1"}))
+
+	d, err := c.Diff(groups.Default, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Diff{
+		From: 1,
+		To:   2,
+		Changes: []Change{
+			{Collection: historyCollection, Name: "b", Type: Modified, FromVersion: "v1", ToVersion: "v2"},
+			{Collection: historyCollection, Name: "c", Type: Removed, FromVersion: "v1"},
+			{Collection: historyCollection, Name: "d", Type: Added, ToVersion: "v1"},
+		},
+	}
+	if diff := cmp.Diff(d, want); diff != "" {
+		t.Errorf("Unexpected diff: %s", diff)
+	}
+
+	if d, err = c.Diff(groups.Default, 2, 2); err != nil || len(d.Changes) != 0 {
+		t.Errorf("Got %v, %v, expecting no change", d, err)
+	}
+	if _, err = c.Diff(groups.Default, 1, 3); err == nil {
+		t.Error("Got no error, expecting version 3 to be unknown")
+	}
+	if _, err = c.Diff("unknown", 1, 2); err == nil {
+		t.Error("Got no error, expecting the group to be unknown")
+	}
+}
+
+func TestPin(t *testing.T) {
+	c := NewWithHistory(groups.DefaultIndexFn, 2)
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "1", nil))
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "2", nil))
+
+	responseC := make(chan *source.WatchResponse, 1)
+	if _, _, err := createTestWatch(c, historyCollection, "2", responseC, false, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// the clients are served the pinned version.
+	if err := c.Pin(groups.Default, 1); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := getAsyncResponse(responseC); got == nil || got.Version != "1" {
+		t.Fatalf("Got response %v, expecting version 1", got)
+	}
+	if got, _, err := createTestWatch(c, historyCollection, "", responseC, true, false); err != nil || got.Version != "1" {
+		t.Fatalf("Got response %v (%v), expecting version 1", got, err)
+	}
+
+	// the new snapshots are recorded, but not served.
+	if _, _, err := createTestWatch(c, historyCollection, "1", responseC, false, true); err != nil {
+		t.Fatal(err)
+	}
+	c.SetSnapshot(groups.Default, buildHistorySnapshot(t, "3", nil))
+	if got, _ := getAsyncResponse(responseC); got != nil {
+		t.Fatalf("Got response %v, expecting none while pinned", got)
+	}
+
+	// the pinned version is listed even once evicted from the history.
+	h := c.History(groups.Default)
+	if len(h) != 3 || h[0].ID != 1 || !h[0].Pinned || h[1].Pinned || h[2].Pinned {
+		t.Errorf("Got history %+v, expecting the pinned version 1 followed by 2 and 3", h)
+	}
+
+	// the latest version is served once unpinned.
+	if err := c.Unpin(groups.Default); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := getAsyncResponse(responseC); got == nil || got.Version != "3" {
+		t.Fatalf("Got response %v, expecting version 3", got)
+	}
+	if diff := cmp.Diff(historyIDs(c), []int64{2, 3}); diff != "" {
+		t.Errorf("Unexpected history: %s", diff)
+	}
+
+	if err := c.Unpin(groups.Default); err == nil {
+		t.Error("Got no error, expecting the group not to be pinned")
+	}
+	if err := c.Pin(groups.Default, 1); err == nil {
+		t.Error("Got no error, expecting the evicted version to be unknown")
+	}
+}
@@ -146,6 +146,21 @@ func (s *InMemory) Version(collection string) string {
 	return s.versions[collection]
 }
 
+// Collections returns the sorted collections of the snapshot.
+func (s *InMemory) Collections() []string {
+	collections := make([]string, 0, len(s.versions))
+	for collection := range s.versions {
+		collections = append(collections, collection)
+	}
+	for collection := range s.resources {
+		if _, ok := s.versions[collection]; !ok {
+			collections = append(collections, collection)
+		}
+	}
+	sort.Strings(collections)
+	return collections
+}
+
 // Clone this snapshot.
 func (s *InMemory) Clone() *InMemory {
 	c := &InMemory{
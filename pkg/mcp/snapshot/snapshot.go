@@ -50,13 +50,22 @@ type Info struct {
 
 // Cache is a snapshot-based cache that maintains a single versioned
 // snapshot of responses per group of clients. Cache consistently replies with the
-// latest snapshot.
+// latest snapshot, unless an older snapshot from the history of the group is pinned,
+// or a new snapshot is being rolled out to a subset of the clients (see CanaryOptions).
 type Cache struct {
 	mu         sync.RWMutex
 	snapshots  map[string]Snapshot
 	status     map[string]*StatusInfo
 	watchCount int64
 
+	// the number of snapshots kept in the history of each group
+	historySize int
+	histories   map[string]*history
+
+	// the staged rollout of new snapshots, disabled if nil
+	canaryOptions *CanaryOptions
+	canaries      map[string]*canary
+
 	groupIndex GroupIndexFn
 }
 
@@ -68,10 +77,19 @@ type GroupIndexFn func(collection string, node *mcp.SinkNode) string
 
 // New creates a new cache of resource snapshots.
 func New(groupIndex GroupIndexFn) *Cache {
+	return NewWithHistory(groupIndex, 0)
+}
+
+// NewWithHistory creates a new cache of resource snapshots, which keeps the latest historySize
+// snapshots of each group so that they can be compared and pinned.
+func NewWithHistory(groupIndex GroupIndexFn, historySize int) *Cache {
 	return &Cache{
-		snapshots:  make(map[string]Snapshot),
-		status:     make(map[string]*StatusInfo),
-		groupIndex: groupIndex,
+		snapshots:   make(map[string]Snapshot),
+		status:      make(map[string]*StatusInfo),
+		historySize: historySize,
+		histories:   make(map[string]*history),
+		canaries:    make(map[string]*canary),
+		groupIndex:  groupIndex,
 	}
 }
 
@@ -117,9 +135,13 @@ func (c *Cache) Watch(request *source.Request, pushResponse source.PushResponseF
 
 	collection := request.Collection
 
+	if request.ErrorDetail != nil {
+		c.nack(group, request)
+	}
+
 	// return an immediate response if a snapshot is available and the
 	// requested version doesn't match.
-	if snapshot, ok := c.snapshots[group]; ok {
+	if snapshot, ok := c.served(group, request.SinkNode); ok {
 
 		version := snapshot.Version(request.Collection)
 		scope.Debugf("Found snapshot for group: %q for %v @ version: %q",
@@ -213,17 +235,54 @@ func (c *Cache) SetSnapshot(group string, snapshot Snapshot) {
 	defer c.mu.Unlock()
 
 	// update the existing entry
+	previous, hasPrevious := c.snapshots[group]
 	c.snapshots[group] = snapshot
 
-	// trigger existing watches for which version changed
+	if c.canaryOptions != nil && hasPrevious {
+		c.startCanary(group, previous, snapshot)
+	}
+
+	if c.historySize > 0 {
+		h, ok := c.histories[group]
+		if !ok {
+			h = &history{}
+			c.histories[group] = h
+		}
+		h.add(snapshot, c.historySize)
+
+		if h.pinned != nil {
+			scope.Infof("SetSnapshot(): version %d of group %q is pinned, holding version %d",
+				h.pinned.id, group, h.lastID)
+			return
+		}
+	}
+
+	c.respond(group)
+}
+
+// Snapshot returns the latest snapshot set for a group, which may not be served to all the clients yet,
+// or nil if none is set.
+func (c *Cache) Snapshot(group string) Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.snapshots[group]
+}
+
+// respond triggers the existing watches of a group for which the version of the served snapshot changed.
+func (c *Cache) respond(group string) {
 	if info, ok := c.status[group]; ok {
 		info.mu.Lock()
 		defer info.mu.Unlock()
 
 		for id, watch := range info.watches {
+			snapshot, ok := c.served(group, watch.request.SinkNode)
+			if !ok {
+				continue
+			}
 			version := snapshot.Version(watch.request.Collection)
 			if version != watch.request.VersionInfo {
-				scope.Infof("SetSnapshot(): respond to watch %d for %v @ version %q",
+				scope.Infof("respond(): respond to watch %d for %v @ version %q",
 					id, watch.request.Collection, version)
 
 				response := &source.WatchResponse{
@@ -237,20 +296,35 @@ func (c *Cache) SetSnapshot(group string, snapshot Snapshot) {
 				// discard the responseWatch
 				delete(info.watches, id)
 
-				scope.Debugf("SetSnapshot(): watch %d for %v @ version %q complete",
+				scope.Debugf("respond(): watch %d for %v @ version %q complete",
 					id, watch.request.Collection, version)
 			}
 		}
 	}
 }
 
-// ClearSnapshot clears snapshot for a group. This does not cancel any open
+// ClearSnapshot clears snapshot and history for a group. This does not cancel any open
 // watches already created (see ClearStatus).
 func (c *Cache) ClearSnapshot(group string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	delete(c.snapshots, group)
+	delete(c.histories, group)
+	c.stopCanary(group)
+}
+
+// served returns the snapshot served to a client of a group: the pinned snapshot if any, the previous
+// snapshot for the clients not selected for the rollout of a new snapshot, the latest snapshot otherwise.
+func (c *Cache) served(group string, node *mcp.SinkNode) (Snapshot, bool) {
+	if h, ok := c.histories[group]; ok && h.pinned != nil {
+		return h.pinned.snapshot, true
+	}
+	if cn, ok := c.canaries[group]; ok && !c.canaryOptions.selects(node) {
+		return cn.stable, true
+	}
+	snapshot, ok := c.snapshots[group]
+	return snapshot, ok
 }
 
 // ClearStatus clears status for a group. This has the effect of canceling
@@ -303,7 +377,7 @@ func (c *Cache) GetSnapshotInfo(group string) []Info {
 		group = c.GetGroups()[0]
 	}
 
-	if snapshot, ok := c.snapshots[group]; ok {
+	if snapshot, ok := c.served(group, nil); ok {
 
 		snapshots := make([]Info, 0, len(metadata.Types.All()))
 		collections := make([]string, 0, len(metadata.Types.All()))
@@ -351,7 +425,7 @@ func (c *Cache) GetResource(group string, collection string, resourceName string
 		return nil
 	}
 
-	if snapshot, ok := c.snapshots[group]; ok {
+	if snapshot, ok := c.served(group, nil); ok {
 		for _, resource := range snapshot.Resources(collection) {
 			if resource.Metadata.Name == resourceName {
 				var dynamicAny types.DynamicAny
@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"sync/atomic"
 
+	rpc "github.com/gogo/googleapis/google/rpc"
 	"github.com/gogo/status"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
@@ -44,6 +45,9 @@ type Request struct {
 	VersionInfo string
 	SinkNode    *mcp.SinkNode
 
+	// ErrorDetail is set when the sink NACK'd the version
+	ErrorDetail *rpc.Status
+
 	// hidden
 	incremental bool
 }
@@ -411,6 +415,7 @@ func (con *connection) processClientRequest(req *mcp.RequestResources) error {
 	// nonces can be reused across streams; we verify nonce only if it initialized
 	if req.ResponseNonce == "" || w.pending.GetNonce() == req.ResponseNonce {
 		versionInfo := ""
+		var errorDetail *rpc.Status
 
 		if w.pending == nil {
 			scope.Infof("MCP: connection %v: inc=%v WATCH for %v", con, req.Incremental, collection)
@@ -420,6 +425,7 @@ func (con *connection) processClientRequest(req *mcp.RequestResources) error {
 				scope.Warnf("MCP: connection %v: NACK collection=%v version=%q with nonce=%q error=%#v inc=%v", // nolint: lll
 					con, collection, req.ResponseNonce, versionInfo, req.ErrorDetail, req.Incremental)
 				con.reporter.RecordRequestNack(collection, con.id, codes.Code(req.ErrorDetail.Code))
+				errorDetail = req.ErrorDetail
 			} else {
 				scope.Infof("MCP: connection %v ACK collection=%v with version=%q nonce=%q inc=%v",
 					con, collection, versionInfo, req.ResponseNonce, req.Incremental)
@@ -440,6 +446,7 @@ func (con *connection) processClientRequest(req *mcp.RequestResources) error {
 			SinkNode:    req.SinkNode,
 			Collection:  collection,
 			VersionInfo: versionInfo,
+			ErrorDetail: errorDetail,
 			incremental: req.Incremental,
 		}
 		w.cancel = con.watcher.Watch(sr, con.queueResponse, con.peerAddr)
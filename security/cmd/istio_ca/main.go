@@ -33,6 +33,7 @@ import (
 	"istio.io/istio/security/pkg/cmd"
 	"istio.io/istio/security/pkg/k8s/controller"
 	"istio.io/istio/security/pkg/pki/ca"
+	"istio.io/istio/security/pkg/pki/ca/external"
 	probecontroller "istio.io/istio/security/pkg/probe"
 	"istio.io/istio/security/pkg/registry"
 	"istio.io/istio/security/pkg/registry/kube"
@@ -46,6 +47,13 @@ import (
 	"istio.io/pkg/version"
 )
 
+// The signers of the certificates.
+const (
+	localSigner = "local"
+	httpSigner  = "http"
+	acmeSigner  = "acme"
+)
+
 type cliOptions struct { // nolint: maligned
 	// Comma separated string containing all listened namespaces
 	listenedNamespaces      string
@@ -57,6 +65,8 @@ type cliOptions struct { // nolint: maligned
 	signingCertFile string
 	signingKeyFile  string
 	rootCertFile    string
+	// The secret of the plugged certificates, watched for root rotations if set.
+	pluggedCertSecret string
 
 	selfSignedCA        bool
 	selfSignedCACertTTL time.Duration
@@ -110,6 +120,27 @@ type cliOptions struct { // nolint: maligned
 
 	// Enable dual-use certs - SPIFFE in SAN and in CommonName
 	dualUse bool
+
+	// Whether Citadel supports certificate revocation.
+	enableRevocation bool
+	// Comma separated string containing the identities allowed to revoke certificates.
+	revocationAdmins string
+	// The validity of the certificate revocation lists.
+	crlTTL time.Duration
+
+	// The interval of checking the CA secret for root rotations.
+	caSecretCheckInterval time.Duration
+
+	// The signer of the certificates: local, http or acme.
+	signer string
+	// The external CA with an HTTP API.
+	externalCAURL       string
+	externalCATokenFile string
+	externalCARootCert  string
+	// The ACME CA.
+	acmeDirectoryURL string
+	acmeAccountKey   string
+	acmeEmail        string
 }
 
 var (
@@ -177,6 +208,10 @@ func init() {
 	flags.StringVar(&opts.certChainFile, "cert-chain", "", "Path to the certificate chain file.")
 	flags.StringVar(&opts.signingCertFile, "signing-cert", "", "Path to the CA signing certificate file.")
 	flags.StringVar(&opts.signingKeyFile, "signing-key", "", "Path to the CA signing key file.")
+	flags.StringVar(&opts.pluggedCertSecret, "plugged-cert-secret", "", "The secret in the Citadel storage "+
+		"namespace with the plugged certificates, usually mounted as the '--signing-cert', '--signing-key', "+
+		"'--cert-chain' and '--root-cert' files. When set, Citadel reloads them from the secret and supports "+
+		"root and signing cert rotations.")
 
 	// Both self-signed or non-self-signed Citadel may take a root certificate file with a list of root certificates.
 	flags.StringVar(&opts.rootCertFile, "root-cert", "", "Path to the root certificate file.")
@@ -187,6 +222,8 @@ func init() {
 			"When set to true, the '--signing-cert' and '--signing-key' options are ignored.")
 	flags.DurationVar(&opts.selfSignedCACertTTL, "self-signed-ca-cert-ttl", cmd.DefaultSelfSignedCACertTTL,
 		"The TTL of self-signed CA root certificate.")
+	flags.DurationVar(&opts.caSecretCheckInterval, "ca-secret-check-interval", cmd.DefaultCASecretCheckInterval,
+		"The interval of checking the secret of the self-signed CA, or of the plugged certificates, for root rotations.")
 	flags.StringVar(&opts.trustDomain, "trust-domain", "",
 		"The domain serves to identify the system with SPIFFE.")
 	// Upstream CA configuration if Citadel interacts with upstream CA.
@@ -241,6 +278,30 @@ func init() {
 	flags.BoolVar(&opts.dualUse, "experimental-dual-use",
 		false, "Enable dual-use mode. Generates certificates with a CommonName identical to the SAN.")
 
+	// Certificate revocation
+	flags.BoolVar(&opts.enableRevocation, "enable-revocation", false, "Whether Citadel supports certificate "+
+		"revocation. The revoked certificates are stored in the "+ca.RevocationListConfigMap+" ConfigMap of the "+
+		"Citadel storage namespace, and distributed to the node agents in a certificate revocation list.")
+	flags.StringVar(&opts.revocationAdmins, "revocation-admin-identities", "",
+		"The list of identities allowed to revoke certificates, separated by comma.")
+	flags.DurationVar(&opts.crlTTL, "crl-ttl", ca.DefaultCRLTTL, "The TTL of the certificate revocation lists.")
+
+	// External signing
+	flags.StringVar(&opts.signer, "signer", localSigner, "The signer of the certificates. '"+localSigner+
+		"' signs with the self-signed or plugged-in CA key. '"+httpSigner+"' and '"+acmeSigner+"' delegate the "+
+		"signing to an external CA, whose root certificates are read from '--root-cert'.")
+	flags.StringVar(&opts.externalCAURL, "external-ca-url", "",
+		"The endpoint of the external CA signing the CSRs, with the "+httpSigner+" signer.")
+	flags.StringVar(&opts.externalCATokenFile, "external-ca-token-file", "",
+		"Path to the bearer token sent to the external CA, with the "+httpSigner+" signer.")
+	flags.StringVar(&opts.externalCARootCert, "external-ca-root-cert", "", "Path to the root certificates "+
+		"verifying the server of the external CA, instead of the system roots.")
+	flags.StringVar(&opts.acmeDirectoryURL, "acme-directory-url", "",
+		"The directory URL of the ACME CA, with the "+acmeSigner+" signer.")
+	flags.StringVar(&opts.acmeAccountKey, "acme-account-key", "", "Path to the ECDSA P-256 key of the ACME "+
+		"account. An ephemeral account is used if not set.")
+	flags.StringVar(&opts.acmeEmail, "acme-email", "", "The contact email of the ACME account.")
+
 	rootCmd.AddCommand(version.CobraCommand())
 
 	rootCmd.AddCommand(collateral.CobraCommand(rootCmd, &doc.GenManHeader{
@@ -250,6 +311,7 @@ func init() {
 	}))
 
 	rootCmd.AddCommand(cmd.NewProbeCmd())
+	rootCmd.AddCommand(rootRotationCmd())
 
 	opts.loggingOptions.AttachCobraFlags(rootCmd)
 	opts.ctrlzOptions.AttachCobraFlags(rootCmd)
@@ -301,9 +363,24 @@ func runCA() {
 	if err != nil {
 		fatalf("Could not create k8s clientset: %v", err)
 	}
+	// The secret of the CA picked up for the root rotations.
+	caSecret := ""
+	if opts.signer != localSigner {
+		log.Info("Root rotations are not supported with an external CA")
+	} else if opts.selfSignedCA {
+		caSecret = ca.CASecret
+	} else if opts.pluggedCertSecret != "" {
+		caSecret = opts.pluggedCertSecret
+	} else {
+		log.Info("Root rotations are disabled, '--plugged-cert-secret' is not set")
+	}
 	ca := createCA(cs.CoreV1())
 
 	stopCh := make(chan struct{})
+	if caSecret != "" {
+		go ca.WatchCASecret(cs.CoreV1(), opts.istioCaStorageNamespace, caSecret, opts.rootCertFile,
+			opts.caSecretCheckInterval, stopCh)
+	}
 	if !opts.serverOnly {
 		log.Infof("Creating Kubernetes controller to write issued keys and certs into secret ...")
 		// For workloads in K8s, we apply the configured workload cert TTL.
@@ -345,7 +422,12 @@ func runCA() {
 
 		// The CA API uses cert with the max workload cert TTL.
 		hostnames := append(strings.Split(opts.grpcHosts, ","), fqdn())
-		caServer, startErr := caserver.New(ca, opts.maxWorkloadCertTTL, opts.signCACerts, hostnames, opts.grpcPort, spiffe.GetTrustDomain())
+		var revocationAdmins []string
+		if opts.revocationAdmins != "" {
+			revocationAdmins = strings.Split(opts.revocationAdmins, ",")
+		}
+		caServer, startErr := caserver.New(ca, opts.maxWorkloadCertTTL, opts.signCACerts, hostnames, opts.grpcPort,
+			spiffe.GetTrustDomain(), revocationAdmins)
 		if startErr != nil {
 			fatalf("Failed to create istio ca server: %v", startErr)
 		}
@@ -409,7 +491,14 @@ func createCA(client corev1.CoreV1Interface) *ca.IstioCA {
 	var caOpts *ca.IstioCAOptions
 	var err error
 
-	if opts.selfSignedCA {
+	if opts.signer != localSigner {
+		log.Infof("Use the %s signer of an external CA", opts.signer)
+		caOpts, err = ca.NewExternalIstioCAOptions(createSigner(), opts.rootCertFile, opts.workloadCertTTL,
+			opts.maxWorkloadCertTTL, opts.istioCaStorageNamespace, client)
+		if err != nil {
+			fatalf("Failed to create an Citadel (error: %v)", err)
+		}
+	} else if opts.selfSignedCA {
 		log.Info("Use self-signed certificate as the CA certificate")
 		spiffe.SetTrustDomain(spiffe.DetermineTrustDomain(opts.trustDomain, true))
 		// Abort after 20 minutes.
@@ -438,6 +527,12 @@ func createCA(client corev1.CoreV1Interface) *ca.IstioCA {
 
 	caOpts.LivenessProbeOptions = opts.LivenessProbeOptions
 	caOpts.ProbeCheckInterval = opts.probeCheckInterval
+	if opts.enableRevocation && opts.signer != localSigner {
+		log.Warn("Certificate revocation is not supported with an external CA, and is disabled")
+	} else if opts.enableRevocation {
+		caOpts.RevocationStore = ca.NewConfigMapRevocationStore(client, opts.istioCaStorageNamespace)
+		caOpts.CRLTTL = opts.crlTTL
+	}
 
 	istioCA, err := ca.NewIstioCA(caOpts)
 	if err != nil {
@@ -458,7 +553,52 @@ func createCA(client corev1.CoreV1Interface) *ca.IstioCA {
 	return istioCA
 }
 
+func createSigner() ca.Signer {
+	var signer ca.Signer
+	var err error
+	switch opts.signer {
+	case httpSigner:
+		signer, err = external.NewHTTPSigner(external.HTTPConfig{
+			URL:          opts.externalCAURL,
+			TokenFile:    opts.externalCATokenFile,
+			RootCertFile: opts.externalCARootCert,
+		})
+	case acmeSigner:
+		signer, err = external.NewACMESigner(external.ACMEConfig{
+			DirectoryURL:   opts.acmeDirectoryURL,
+			AccountKeyFile: opts.acmeAccountKey,
+			Email:          opts.acmeEmail,
+			RootCertFile:   opts.externalCARootCert,
+		})
+	}
+	if err != nil {
+		fatalf("Failed to create the %s signer (error: %v)", opts.signer, err)
+	}
+	return signer
+}
+
 func verifyCommandLineOptions() {
+	switch opts.signer {
+	case localSigner:
+	case httpSigner, acmeSigner:
+		if opts.selfSignedCA {
+			fatalf("'-self-signed-ca' can't be used with the %s signer", opts.signer)
+		}
+		if opts.signer == httpSigner && opts.externalCAURL == "" {
+			fatalf("No external CA has been specified. Specify its endpoint via '-external-ca-url' option")
+		}
+		if opts.signer == acmeSigner && opts.acmeDirectoryURL == "" {
+			fatalf("No ACME CA has been specified. Specify its directory via '-acme-directory-url' option")
+		}
+		if opts.rootCertFile == "" {
+			fatalf("No root cert has been specified. Specify the root cert file of the external CA via " +
+				"'-root-cert' option")
+		}
+		return
+	default:
+		fatalf("Unknown signer %q, it should be %s, %s or %s", opts.signer, localSigner, httpSigner, acmeSigner)
+	}
+
 	if opts.selfSignedCA {
 		return
 	}
@@ -0,0 +1,167 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	kubelib "istio.io/istio/pkg/kube"
+	"istio.io/istio/security/pkg/cmd"
+	"istio.io/istio/security/pkg/pki/ca"
+)
+
+// rootRotationCmd returns the commands rotating the root certificate of a self-signed Citadel, or
+// the plugged certificates of Citadel.
+func rootRotationCmd() *cobra.Command {
+	var (
+		kubeConfigFile    string
+		namespace         string
+		pluggedCertSecret string
+		minWait           time.Duration
+
+		// The new plugged certificates.
+		signingCertFile string
+		signingKeyFile  string
+		certChainFile   string
+		rootCertFile    string
+	)
+	client := func() (corev1.CoreV1Interface, error) {
+		cs, err := kubelib.CreateClientset(kubeConfigFile, "")
+		if err != nil {
+			return nil, fmt.Errorf("could not create k8s clientset: %v", err)
+		}
+		return cs.CoreV1(), nil
+	}
+
+	secretName := func() string {
+		if pluggedCertSecret != "" {
+			return pluggedCertSecret
+		}
+		return ca.CASecret
+	}
+
+	rotationCmd := &cobra.Command{
+		Use:   "root-rotation",
+		Short: "Rotate the root certificate of Citadel.",
+		Long: "Rotate the root certificate of Citadel without breaking the trust between workloads. " +
+			"'start' generates a new root for a self-signed Citadel, or stages the new plugged certificates of " +
+			"'--plugged-cert-secret', and the new root is trusted by the workloads once their certificates are " +
+			"refreshed. 'advance' makes the new root, or the new plugged signing cert, sign the certificates. " +
+			"'finish' stops trusting the old root, once all the certificates it signed have expired. Running " +
+			"Citadels pick up each step within --ca-secret-check-interval. Citadels with plugged certificates " +
+			"must be started with the same '--plugged-cert-secret'.",
+	}
+	rotationCmd.PersistentFlags().StringVar(&kubeConfigFile, "kube-config", "",
+		"Specifies path to kubeconfig file. This must be specified when not running inside a Kubernetes pod.")
+	rotationCmd.PersistentFlags().StringVar(&namespace, "citadel-storage-namespace", "istio-system",
+		"Namespace where the Citadel pod is running.")
+	rotationCmd.PersistentFlags().StringVar(&pluggedCertSecret, "plugged-cert-secret", "",
+		"The secret with the plugged certificates of Citadel. The self-signed root is rotated if not set.")
+
+	step := func(use, short string, run func(corev1.CoreV1Interface) error) *cobra.Command {
+		return &cobra.Command{
+			Use:   use,
+			Short: short,
+			Args:  cobra.ExactArgs(0),
+			RunE: func(c *cobra.Command, args []string) error {
+				cl, err := client()
+				if err != nil {
+					return err
+				}
+				if err = run(cl); err != nil {
+					return err
+				}
+				return printRootRotationStatus(c, cl, namespace, secretName())
+			},
+		}
+	}
+
+	startCmd := step("start", "Trust a new root certificate, generated or plugged.",
+		func(cl corev1.CoreV1Interface) error {
+			if pluggedCertSecret == "" {
+				if signingCertFile != "" || signingKeyFile != "" || certChainFile != "" || rootCertFile != "" {
+					return fmt.Errorf("the new certificates can only be given with '--plugged-cert-secret'")
+				}
+				return ca.StartRootRotation(cl, namespace)
+			}
+			if signingCertFile == "" || signingKeyFile == "" || certChainFile == "" {
+				return fmt.Errorf("'--signing-cert', '--signing-key' and '--cert-chain' are required with " +
+					"'--plugged-cert-secret'")
+			}
+			pems := map[string][]byte{}
+			for _, file := range []string{signingCertFile, signingKeyFile, certChainFile, rootCertFile} {
+				if file == "" {
+					continue
+				}
+				data, err := ioutil.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("failed to read %s (%v)", file, err)
+				}
+				pems[file] = data
+			}
+			return ca.StartPluggedCertRotation(cl, namespace, pluggedCertSecret, pems[signingCertFile],
+				pems[signingKeyFile], pems[certChainFile], pems[rootCertFile])
+		})
+	startCmd.Flags().StringVar(&signingCertFile, "signing-cert", "",
+		"Path to the new plugged signing certificate file.")
+	startCmd.Flags().StringVar(&signingKeyFile, "signing-key", "", "Path to the new plugged signing key file.")
+	startCmd.Flags().StringVar(&certChainFile, "cert-chain", "",
+		"Path to the certificate chain file of the new plugged signing certificate.")
+	startCmd.Flags().StringVar(&rootCertFile, "root-cert", "",
+		"Path to the new plugged root certificate file. The current root is kept if not set.")
+	rotationCmd.AddCommand(startCmd)
+	rotationCmd.AddCommand(step("advance", "Sign the certificates with the new root or plugged signing cert.",
+		func(cl corev1.CoreV1Interface) error {
+			return ca.AdvanceRootRotation(cl, namespace, secretName())
+		}))
+	finishCmd := step("finish", "Stop trusting the old root certificate.",
+		func(cl corev1.CoreV1Interface) error {
+			return ca.FinishRootRotation(cl, namespace, secretName(), minWait)
+		})
+	finishCmd.Flags().DurationVar(&minWait, "min-wait", cmd.DefaultMaxWorkloadCertTTL,
+		"The minimum time between 'advance' and 'finish', which should be at least the max TTL of the "+
+			"workload certificates.")
+	rotationCmd.AddCommand(finishCmd)
+	rotationCmd.AddCommand(step("status", "Show the status of the root rotation.",
+		func(corev1.CoreV1Interface) error {
+			return nil
+		}))
+	return rotationCmd
+}
+
+func printRootRotationStatus(c *cobra.Command, client corev1.CoreV1Interface, namespace, secretName string) error {
+	status, err := ca.GetRootRotationStatus(client, namespace, secretName)
+	if err != nil {
+		return err
+	}
+	switch status.Phase {
+	case ca.RootRotationNone:
+		c.Println("No root rotation in progress.")
+	case ca.RootRotationNewRootTrusted:
+		c.Println("The new root is trusted, the old root signs the certificates. Next step: advance.")
+	case ca.RootRotationNewRootSigning:
+		c.Printf("The new root signs the certificates since %s, the old root is still trusted. Next step: finish.\n",
+			status.Advanced.Format(time.RFC3339))
+	}
+	for _, root := range status.TrustedRoots {
+		c.Printf("Trusted root: %v\n", root)
+	}
+	return nil
+}
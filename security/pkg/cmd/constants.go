@@ -49,6 +49,10 @@ const (
 	// DefaultCSRMaxRetries is the default value of CSR retries for Citadel to send CSR to upstream CA.
 	DefaultCSRMaxRetries = 10
 
+	// DefaultCASecretCheckInterval is the default interval of checking the secret of the self-signed
+	// CA, or of the plugged certificates, for root rotations.
+	DefaultCASecretCheckInterval = time.Minute
+
 	// ListenedNamespaceKey is the key for the environment variable that specifies the namespace.
 	ListenedNamespaceKey = "NAMESPACE"
 )
@@ -33,6 +33,7 @@ import (
 	"github.com/gogo/status"
 	"google.golang.org/grpc/codes"
 
+	caClientInterface "istio.io/istio/security/pkg/nodeagent/caclient/interface"
 	"istio.io/istio/security/pkg/nodeagent/model"
 	"istio.io/istio/security/pkg/nodeagent/plugin"
 	"istio.io/istio/security/pkg/nodeagent/secretfetcher"
@@ -169,6 +170,12 @@ type SecretCache struct {
 	rootCertMutex      *sync.Mutex
 	rootCert           []byte
 	rootCertExpireTime time.Time
+
+	// crl is the certificate revocation list of the CA, pushed to proxies together with the root
+	// cert. crlToken is the last token accepted by the CA, used to refresh the CRL. Both are
+	// guarded by rootCertMutex.
+	crl      []byte
+	crlToken string
 }
 
 // NewSecretCache creates a new secret cache.
@@ -240,9 +247,13 @@ func (sc *SecretCache) GenerateSecret(ctx context.Context, connectionID, resourc
 	}
 
 	t := time.Now()
+	sc.rootCertMutex.Lock()
+	crl := sc.crl
+	sc.rootCertMutex.Unlock()
 	ns = &model.SecretItem{
 		ResourceName: resourceName,
 		RootCert:     sc.rootCert,
+		CRL:          crl,
 		ExpireTime:   sc.rootCertExpireTime,
 		Token:        token,
 		CreatedTime:  t,
@@ -353,6 +364,7 @@ func (sc *SecretCache) keyCertRotationJob() {
 		select {
 		case <-sc.rotationTicker.C:
 			sc.rotate(false /*updateRootFlag*/)
+			sc.refreshCRL()
 		case <-sc.closing:
 			if sc.rotationTicker != nil {
 				sc.rotationTicker.Stop()
@@ -467,9 +479,13 @@ func (sc *SecretCache) rotate(updateRootFlag bool) {
 
 			atomic.AddUint64(&sc.rootCertChangedCount, 1)
 			t := time.Now()
+			sc.rootCertMutex.Lock()
+			crl := sc.crl
+			sc.rootCertMutex.Unlock()
 			ns := &model.SecretItem{
 				ResourceName: resourceName,
 				RootCert:     sc.rootCert,
+				CRL:          crl,
 				ExpireTime:   sc.rootCertExpireTime,
 				Token:        e.Token,
 				CreatedTime:  t,
@@ -686,8 +702,15 @@ func (sc *SecretCache) generateSecret(ctx context.Context, token, resourceName s
 		sc.rootCertMutex.Unlock()
 	}
 
+	crlChanged := sc.updateCRL(ctx, exchangedToken)
+
 	if rootCertChanged {
 		cacheLog.Info("Root cert has changed")
+	}
+	if crlChanged {
+		cacheLog.Info("Certificate revocation list has changed")
+	}
+	if rootCertChanged || crlChanged {
 		sc.rotate(true /*updateRootFlag*/)
 	}
 
@@ -702,6 +725,48 @@ func (sc *SecretCache) generateSecret(ctx context.Context, token, resourceName s
 	}, nil
 }
 
+// updateCRL fetches the certificate revocation list from the CA, if supported by the CA client,
+// and returns true if it has changed.
+func (sc *SecretCache) updateCRL(ctx context.Context, token string) bool {
+	crlClient, ok := sc.fetcher.CaClient.(caClientInterface.CRLClient)
+	if !ok {
+		return false
+	}
+	crl, err := crlClient.GetCRL(ctx, token)
+	if err != nil {
+		// Keep the last CRL, the CA may not have revocation enabled.
+		cacheLog.Debugf("Failed to get certificate revocation list: %v", err)
+		return false
+	}
+
+	sc.rootCertMutex.Lock()
+	defer sc.rootCertMutex.Unlock()
+	sc.crlToken = token
+	if bytes.Equal(sc.crl, crl) {
+		return false
+	}
+	sc.crl = crl
+	return true
+}
+
+// refreshCRL fetches the certificate revocation list with the last token accepted by the CA, and
+// pushes it to the proxies if it has changed.
+func (sc *SecretCache) refreshCRL() {
+	if !sc.fetcher.UseCaClient {
+		return
+	}
+	sc.rootCertMutex.Lock()
+	token := sc.crlToken
+	sc.rootCertMutex.Unlock()
+	if token == "" {
+		return
+	}
+	if sc.updateCRL(context.Background(), token) {
+		cacheLog.Info("Certificate revocation list has changed")
+		sc.rotate(true /*updateRootFlag*/)
+	}
+}
+
 // parseCertAndGetExpiryTimestamp parses certificate and returns cert expire time, or return error
 // if fails to parse certificate.
 func parseCertAndGetExpiryTimestamp(certByte []byte) (time.Time, error) {
@@ -245,6 +245,53 @@ func TestWorkloadAgentRefreshSecret(t *testing.T) {
 	}
 }
 
+func TestWorkloadAgentRefreshCRL(t *testing.T) {
+	fakeCACli := &mockCRLCAClient{mockCAClient: newMockCAClient()}
+	fakeCACli.crl.Store([]byte("crl1"))
+	opt := Options{
+		SecretTTL:        time.Minute,
+		RotationInterval: 10 * time.Millisecond,
+		EvictionDuration: 10 * time.Second,
+		InitialBackoff:   10,
+		SkipValidateCert: true,
+	}
+	fetcher := &secretfetcher.SecretFetcher{
+		UseCaClient: true,
+		CaClient:    fakeCACli,
+	}
+	pushed := make(chan []byte, 10)
+	sc := NewSecretCache(fetcher, func(_ string, resourceName string, secret *model.SecretItem) error {
+		if resourceName == RootCertReqResourceName && secret != nil {
+			pushed <- secret.CRL
+		}
+		return nil
+	}, opt)
+	defer sc.Close()
+
+	conID := "proxy1-id"
+	if _, err := sc.GenerateSecret(context.Background(), conID, testResourceName, "jwtToken1"); err != nil {
+		t.Fatalf("Failed to get secrets: %v", err)
+	}
+	gotSecretRoot, err := sc.GenerateSecret(context.Background(), conID, RootCertReqResourceName, "jwtToken1")
+	if err != nil {
+		t.Fatalf("Failed to get secrets: %v", err)
+	}
+	if got, want := gotSecretRoot.CRL, []byte("crl1"); !bytes.Equal(got, want) {
+		t.Errorf("CRL: got: %s, want: %s", got, want)
+	}
+
+	// The rotation job pushes the new CRL to the proxy.
+	fakeCACli.crl.Store([]byte("crl2"))
+	select {
+	case crl := <-pushed:
+		if want := []byte("crl2"); !bytes.Equal(crl, want) {
+			t.Errorf("pushed CRL: got: %s, want: %s", crl, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("CRL update wasn't pushed to the proxy")
+	}
+}
+
 // TestGatewayAgentGenerateSecret verifies that ingress gateway agent manages secret cache correctly.
 func TestGatewayAgentGenerateSecret(t *testing.T) {
 	sc := createSecretCache()
@@ -803,6 +850,15 @@ func (c *mockCAClient) CSRSign(ctx context.Context, csrPEM []byte, subjectID str
 	return mockCertChainRemain, nil
 }
 
+type mockCRLCAClient struct {
+	*mockCAClient
+	crl atomic.Value
+}
+
+func (c *mockCRLCAClient) GetCRL(ctx context.Context, token string) ([]byte, error) {
+	return c.crl.Load().([]byte), nil
+}
+
 func convertToBytes(ss []string) []byte {
 	res := []byte{}
 	for _, s := range ss {
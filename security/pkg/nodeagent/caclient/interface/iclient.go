@@ -23,3 +23,9 @@ type Client interface {
 	CSRSign(ctx context.Context, csrPEM []byte, subjectID string,
 		certValidTTLInSec int64) ([]string /*PEM-encoded certificate chain*/, error)
 }
+
+// CRLClient is implemented by the clients of CAs distributing a certificate revocation list.
+type CRLClient interface {
+	// GetCRL returns the PEM-encoded certificate revocation list of the CA.
+	GetCRL(ctx context.Context, token string) ([]byte, error)
+}
@@ -44,8 +44,11 @@ type citadelClient struct {
 	enableTLS     bool
 	caTLSRootCert []byte
 	client        pb.IstioCertificateServiceClient
+	crlClient     pb.IstioRevocationServiceClient
 }
 
+var _ caClientInterface.CRLClient = &citadelClient{}
+
 // NewCitadelClient create a CA client for Citadel.
 func NewCitadelClient(endpoint string, tls bool, rootCert []byte) (caClientInterface.Client, error) {
 	c := &citadelClient{
@@ -74,6 +77,7 @@ func NewCitadelClient(endpoint string, tls bool, rootCert []byte) (caClientInter
 	}
 
 	c.client = pb.NewIstioCertificateServiceClient(conn)
+	c.crlClient = pb.NewIstioRevocationServiceClient(conn)
 	return c, nil
 }
 
@@ -102,6 +106,19 @@ func (c *citadelClient) CSRSign(ctx context.Context, csrPEM []byte, token string
 	return resp.CertChain, nil
 }
 
+// GetCRL calls Citadel to get its certificate revocation list.
+func (c *citadelClient) GetCRL(ctx context.Context, token string) ([]byte, error) {
+	// add Bearer prefix, which is required by Citadel.
+	token = bearerTokenPrefix + token
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("Authorization", token))
+	resp, err := c.crlClient.GetCertificateRevocationList(ctx, &pb.CertificateRevocationListRequest{})
+	if err != nil {
+		citadelClientLog.Errorf("Failed to get certificate revocation list: %v", err)
+		return nil, err
+	}
+	return []byte(resp.Crl), nil
+}
+
 func (c *citadelClient) getTLSDialOption() (grpc.DialOption, error) {
 	// Load the TLS root certificate from the specified file.
 	// Create a certificate pool
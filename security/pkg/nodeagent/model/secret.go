@@ -24,6 +24,9 @@ type SecretItem struct {
 
 	RootCert []byte
 
+	// CRL is the PEM-encoded certificate revocation list of the CA, set on root cert items.
+	CRL []byte
+
 	// ResourceName passed from envoy SDS discovery request.
 	// "ROOTCA" for root cert request, "default" for key/cert request.
 	ResourceName string
@@ -511,3 +511,41 @@ func (ms *mockSecretStore) DeleteSecret(conID, resourceName string) {
 func (ms *mockSecretStore) ShouldWaitForIngressGatewaySecret(connectionID, resourceName, token string) bool {
 	return false
 }
+
+func TestSDSDiscoveryResponseWithCRL(t *testing.T) {
+	secret := &model.SecretItem{
+		RootCert:     fakeRootCert,
+		CRL:          []byte("crl"),
+		ResourceName: cache.RootCertReqResourceName,
+		Version:      time.Now().String(),
+	}
+	resp, err := sdsDiscoveryResponse(secret, "conID", cache.RootCertReqResourceName)
+	if err != nil {
+		t.Fatalf("sdsDiscoveryResponse failed: %v", err)
+	}
+	var pb authapi.Secret
+	if err := types.UnmarshalAny(&resp.Resources[0], &pb); err != nil {
+		t.Fatalf("UnmarshalAny SDS response failed: %v", err)
+	}
+
+	expectedResponseSecret := authapi.Secret{
+		Name: "ROOTCA",
+		Type: &authapi.Secret_ValidationContext{
+			ValidationContext: &authapi.CertificateValidationContext{
+				TrustedCa: &core.DataSource{
+					Specifier: &core.DataSource_InlineBytes{
+						InlineBytes: fakeRootCert,
+					},
+				},
+				Crl: &core.DataSource{
+					Specifier: &core.DataSource_InlineBytes{
+						InlineBytes: []byte("crl"),
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(pb, expectedResponseSecret) {
+		t.Errorf("secret key: got %+v, want %+v", pb, expectedResponseSecret)
+	}
+}
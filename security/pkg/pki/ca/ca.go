@@ -67,6 +67,8 @@ const (
 	selfSignedCA caTypes = iota
 	// pluggedCertCA means the Istio CA uses a operator-specified key/cert.
 	pluggedCertCA
+	// externalCA means the Istio CA delegates the signing to an external CA.
+	externalCA
 )
 
 // CertificateAuthority contains methods to be supported by a CA.
@@ -76,6 +78,10 @@ type CertificateAuthority interface {
 	Sign(csrPEM []byte, subjectIDs []string, ttl time.Duration, forCA bool) ([]byte, error)
 	// GetCAKeyCertBundle returns the KeyCertBundle used by CA.
 	GetCAKeyCertBundle() util.KeyCertBundle
+	// Revoke adds a certificate issued by the CA to its certificate revocation list.
+	Revoke(cert RevokedCertificate) error
+	// GetCRL returns the PEM-encoded certificate revocation list signed by the CA.
+	GetCRL() ([]byte, error)
 }
 
 // IstioCAOptions holds the configurations for creating an Istio CA.
@@ -90,6 +96,14 @@ type IstioCAOptions struct {
 
 	LivenessProbeOptions *probe.Options
 	ProbeCheckInterval   time.Duration
+
+	// RevocationStore persists the revoked certificates. Revocation is disabled if nil.
+	RevocationStore RevocationStore
+	// CRLTTL is the validity of the certificate revocation lists, DefaultCRLTTL if zero.
+	CRLTTL time.Duration
+
+	// Signer issues the certificates. The signing key of KeyCertBundle is used if nil.
+	Signer Signer
 }
 
 // IstioCA generates keys and certificates for Istio identities.
@@ -98,8 +112,11 @@ type IstioCA struct {
 	maxCertTTL time.Duration
 
 	keyCertBundle util.KeyCertBundle
+	signer        Signer
 
 	livenessProbe *probe.Probe
+
+	revocationList *revocationList
 }
 
 // Append root certificates in rootCertFile to the input certificate.
@@ -189,7 +206,8 @@ func NewSelfSignedIstioCAOptions(ctx context.Context, caCertTTL, certTTL, maxCer
 		log.Infof("Using self-generated public key: %v", string(rootCerts))
 	} else {
 		log.Infof("Load signing key and cert from existing secret %s:%s", caSecret.Namespace, caSecret.Name)
-		rootCerts, err := appendRootCerts(caSecret.Data[caCertID], rootCertFile)
+		// During a root rotation, both roots are trusted.
+		rootCerts, err := appendRootCerts(trustedRootCerts(caSecret), rootCertFile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to append root certificates (%v)", err)
 		}
@@ -248,14 +266,44 @@ func NewPluggedCertIstioCAOptions(certChainFile, signingCertFile, signingKeyFile
 	return caOpts, nil
 }
 
+// NewExternalIstioCAOptions returns a new IstioCAOptions instance for a CA delegating the
+// signing to an external CA, whose root certificates are in rootCertFile.
+func NewExternalIstioCAOptions(signer Signer, rootCertFile string, certTTL, maxCertTTL time.Duration,
+	namespace string, client corev1.CoreV1Interface) (caOpts *IstioCAOptions, err error) {
+	caOpts = &IstioCAOptions{
+		CAType:     externalCA,
+		CertTTL:    certTTL,
+		MaxCertTTL: maxCertTTL,
+		Signer:     signer,
+	}
+	if caOpts.KeyCertBundle, err = util.NewKeyCertBundleWithRootCertFromFile(rootCertFile); err != nil {
+		return nil, fmt.Errorf("failed to create CA KeyCertBundle (%v)", err)
+	}
+	if err = updateCertInConfigmap(namespace, client, caOpts.KeyCertBundle.GetRootCertPem()); err != nil {
+		log.Errorf("Failed to write Citadel cert to configmap (%v). Node agents will not be able to connect.", err)
+	}
+	return caOpts, nil
+}
+
 // NewIstioCA returns a new IstioCA instance.
 func NewIstioCA(opts *IstioCAOptions) (*IstioCA, error) {
 	ca := &IstioCA{
 		certTTL:       opts.CertTTL,
 		maxCertTTL:    opts.MaxCertTTL,
 		keyCertBundle: opts.KeyCertBundle,
+		signer:        opts.Signer,
 		livenessProbe: probe.NewProbe(),
 	}
+	if ca.signer == nil {
+		ca.signer = &localSigner{keyCertBundle: opts.KeyCertBundle}
+	}
+
+	if opts.RevocationStore != nil {
+		var err error
+		if ca.revocationList, err = newRevocationList(opts.RevocationStore, opts.CRLTTL); err != nil {
+			return nil, err
+		}
+	}
 
 	return ca, nil
 }
@@ -264,11 +312,6 @@ func NewIstioCA(opts *IstioCAOptions) (*IstioCA, error) {
 // the signed certificate is a CA certificate, otherwise, it is a workload certificate.
 // TODO(myidpt): Add error code to identify the Sign error types.
 func (ca *IstioCA) Sign(csrPEM []byte, subjectIDs []string, requestedLifetime time.Duration, forCA bool) ([]byte, error) {
-	signingCert, signingKey, _, _ := ca.keyCertBundle.GetAll()
-	if signingCert == nil {
-		return nil, NewError(CANotReady, fmt.Errorf("Istio CA is not ready")) // nolint
-	}
-
 	csr, err := util.ParsePemEncodedCSR(csrPEM)
 	if err != nil {
 		return nil, NewError(CSRError, err)
@@ -285,17 +328,13 @@ func (ca *IstioCA) Sign(csrPEM []byte, subjectIDs []string, requestedLifetime ti
 			"requested TTL %s is greater than the max allowed TTL %s", requestedLifetime, ca.maxCertTTL))
 	}
 
-	certBytes, err := util.GenCertFromCSR(csr, signingCert, csr.PublicKey, *signingKey, subjectIDs, lifetime, forCA)
+	cert, err := ca.signer.Sign(csr, csrPEM, subjectIDs, lifetime, forCA)
 	if err != nil {
+		if e, ok := err.(*Error); ok {
+			return nil, e
+		}
 		return nil, NewError(CertGenError, err)
 	}
-
-	block := &pem.Block{
-		Type:  "CERTIFICATE",
-		Bytes: certBytes,
-	}
-	cert := pem.EncodeToMemory(block)
-
 	return cert, nil
 }
 
@@ -304,6 +343,36 @@ func (ca *IstioCA) GetCAKeyCertBundle() util.KeyCertBundle {
 	return ca.keyCertBundle
 }
 
+// Revoke adds a certificate to the certificate revocation list of the CA.
+func (ca *IstioCA) Revoke(cert RevokedCertificate) error {
+	if ca.revocationList == nil {
+		return NewError(RevocationNotEnabled, fmt.Errorf("certificate revocation is not enabled"))
+	}
+	if cert.SerialNumber == nil {
+		return NewError(RevocationError, fmt.Errorf("the serial number of the certificate is missing"))
+	}
+	if err := ca.revocationList.revoke(cert, time.Now()); err != nil {
+		return NewError(RevocationError, err)
+	}
+	return nil
+}
+
+// GetCRL returns the PEM-encoded certificate revocation list signed by the CA.
+func (ca *IstioCA) GetCRL() ([]byte, error) {
+	if ca.revocationList == nil {
+		return nil, NewError(RevocationNotEnabled, fmt.Errorf("certificate revocation is not enabled"))
+	}
+	signingCert, signingKey, _, _ := ca.keyCertBundle.GetAll()
+	if signingCert == nil {
+		return nil, NewError(CANotReady, fmt.Errorf("Istio CA is not ready")) // nolint
+	}
+	crl, err := ca.revocationList.getCRL(signingCert, *signingKey, time.Now())
+	if err != nil {
+		return nil, NewError(RevocationError, err)
+	}
+	return crl, nil
+}
+
 // BuildSecret returns a secret struct, contents of which are filled with parameters passed in.
 func BuildSecret(saName, scrtName, namespace string, certChain, privateKey, rootCert, caCert, caPrivateKey []byte, secretType v1.SecretType) *v1.Secret {
 	var ServiceAccountNameAnnotation map[string]string
@@ -19,6 +19,7 @@ import (
 	"context"
 	"crypto/x509"
 	"encoding/base64"
+	"fmt"
 	"io/ioutil"
 	"reflect"
 	"testing"
@@ -357,6 +358,71 @@ func TestCreatePluggedCertCA(t *testing.T) {
 	}
 }
 
+type fakeSigner struct {
+	cert       []byte
+	err        error
+	subjectIDs []string
+	lifetime   time.Duration
+}
+
+func (s *fakeSigner) Sign(_ *x509.CertificateRequest, _ []byte, subjectIDs []string, lifetime time.Duration,
+	_ bool) ([]byte, error) {
+	s.subjectIDs = subjectIDs
+	s.lifetime = lifetime
+	return s.cert, s.err
+}
+
+func TestCreateExternalCA(t *testing.T) {
+	rootCertFile := "../testdata/multilevelpki/root-cert.pem"
+	client := fake.NewSimpleClientset()
+	signer := &fakeSigner{cert: []byte("cert")}
+
+	caopts, err := NewExternalIstioCAOptions(signer, rootCertFile, 30*time.Minute, time.Hour, "default",
+		client.CoreV1())
+	if err != nil {
+		t.Fatalf("Failed to create an external CA Options: %v", err)
+	}
+	ca, err := NewIstioCA(caopts)
+	if err != nil {
+		t.Fatalf("Failed to create an external CA: %v", err)
+	}
+	if !comparePem(ca.GetCAKeyCertBundle().GetRootCertPem(), rootCertFile) {
+		t.Errorf("Failed to verify loading of root cert pem.")
+	}
+	strCertFromConfigMap, err := configmap.NewController("default", client.CoreV1()).GetCATLSRootCert()
+	if err != nil {
+		t.Errorf("Cannot get the CA cert from configmap (%v)", err)
+	}
+	if certFromConfigMap, _ := base64.StdEncoding.DecodeString(strCertFromConfigMap); !comparePem(certFromConfigMap, rootCertFile) {
+		t.Errorf("The cert in configmap is not the root cert of the external CA")
+	}
+
+	csrPEM, _, err := util.GenCSR(util.CertOptions{Host: "spiffe://example.com/ns/foo/sa/bar", RSAKeySize: 2048})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := ca.Sign(csrPEM, []string{"spiffe://example.com/ns/foo/sa/bar"}, 0, false)
+	if err != nil || string(cert) != "cert" {
+		t.Errorf("Sign() returned %q, %v, want the certificate of the signer", cert, err)
+	}
+	if !reflect.DeepEqual(signer.subjectIDs, []string{"spiffe://example.com/ns/foo/sa/bar"}) ||
+		signer.lifetime != 30*time.Minute {
+		t.Errorf("the signer got %v, %v, want the subject IDs and the default TTL", signer.subjectIDs, signer.lifetime)
+	}
+
+	signer.err = fmt.Errorf("unavailable")
+	if _, err = ca.Sign(csrPEM, nil, time.Minute, false); err == nil || err.(*Error).ErrorType() != "CERT_GEN_ERROR" {
+		t.Errorf("Sign() returned %v, want a CERT_GEN_ERROR", err)
+	}
+	signer.err = NewError(CANotReady, fmt.Errorf("unavailable"))
+	if _, err = ca.Sign(csrPEM, nil, time.Minute, false); err == nil || err.(*Error).ErrorType() != "CA_NOT_READY" {
+		t.Errorf("Sign() returned %v, want a CA_NOT_READY", err)
+	}
+	if _, err = ca.Sign([]byte("invalid"), nil, time.Minute, false); err == nil || err.(*Error).ErrorType() != "CSR_ERROR" {
+		t.Errorf("Sign() returned %v, want a CSR_ERROR", err)
+	}
+}
+
 // TODO: merge tests for SignCSR.
 func TestSignCSRForWorkload(t *testing.T) {
 	subjectID := "spiffe://example.com/ns/foo/sa/bar"
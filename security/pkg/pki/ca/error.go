@@ -28,6 +28,10 @@ const (
 	TTLError
 	// CertGenError means an error happened during the certificate generation.
 	CertGenError
+	// RevocationNotEnabled means the CA doesn't support certificate revocation.
+	RevocationNotEnabled
+	// RevocationError means an error happened while revoking a certificate or generating the CRL.
+	RevocationError
 )
 
 // Error encapsulates the short and long errors.
@@ -52,6 +56,10 @@ func (e Error) ErrorType() string {
 		return "TTL_ERROR"
 	case CertGenError:
 		return "CERT_GEN_ERROR"
+	case RevocationNotEnabled:
+		return "REVOCATION_NOT_ENABLED"
+	case RevocationError:
+		return "REVOCATION_ERROR"
 	}
 	return "UNKNOWN"
 }
@@ -67,6 +75,10 @@ func (e Error) HTTPErrorCode() codes.Code {
 		return codes.InvalidArgument
 	case TTLError:
 		return codes.InvalidArgument
+	case RevocationNotEnabled:
+		return codes.FailedPrecondition
+	case RevocationError:
+		return codes.Internal
 	}
 	return codes.Internal
 }
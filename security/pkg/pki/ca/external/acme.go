@@ -0,0 +1,485 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"istio.io/istio/security/pkg/pki/ca"
+	"istio.io/istio/security/pkg/pki/util"
+	"istio.io/pkg/log"
+)
+
+const (
+	joseContentType = "application/jose+json"
+
+	errBadNonce = "urn:ietf:params:acme:error:badNonce"
+
+	// acmePollInterval is the interval between the checks of the status of an order.
+	acmePollInterval = 500 * time.Millisecond
+)
+
+// ACMEConfig configures a signer for a CA implementing the ACME protocol (RFC 8555).
+type ACMEConfig struct {
+	// DirectoryURL is the URL of the ACME directory.
+	DirectoryURL string
+	// AccountKeyFile has the PEM-encoded ECDSA P-256 key of the ACME account, if set. An
+	// ephemeral key is generated otherwise, and a new account is registered at each start.
+	AccountKeyFile string
+	// Email is the contact of the ACME account, if set.
+	Email string
+	// RootCertFile has the root certificates used to verify the CA server, if set. The system
+	// roots are used otherwise.
+	RootCertFile string
+	// Timeout of the issuance of a certificate, DefaultTimeout if zero.
+	Timeout time.Duration
+}
+
+type acmeDirectory struct {
+	NewNonce   string `json:"newNonce"`
+	NewAccount string `json:"newAccount"`
+	NewOrder   string `json:"newOrder"`
+}
+
+type acmeIdentifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type acmeOrder struct {
+	Status         string           `json:"status"`
+	Identifiers    []acmeIdentifier `json:"identifiers"`
+	NotAfter       string           `json:"notAfter,omitempty"`
+	Authorizations []string         `json:"authorizations"`
+	Finalize       string           `json:"finalize"`
+	Certificate    string           `json:"certificate"`
+}
+
+type acmeAuthorization struct {
+	Status     string         `json:"status"`
+	Identifier acmeIdentifier `json:"identifier"`
+}
+
+type acmeProblem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+	status int
+}
+
+func (p *acmeProblem) Error() string {
+	return fmt.Sprintf("ACME error %d %s: %s", p.status, p.Type, p.Detail)
+}
+
+type acmeSigner struct {
+	config ACMEConfig
+	client *http.Client
+	key    *ecdsa.PrivateKey
+
+	// registerMutex serializes the registrations of the account.
+	registerMutex sync.Mutex
+	// mutex protects the state shared by the orders: the directory, the account and the
+	// nonces. The orders are processed concurrently.
+	mutex     sync.Mutex
+	directory *acmeDirectory
+	// accountURL is the key ID of the account, set once the account is registered.
+	accountURL string
+	nonces     []string
+}
+
+var _ ca.Signer = &acmeSigner{}
+
+// NewACMESigner returns a signer ordering the certificates from an ACME CA. The CA must be
+// configured to authorize the identities of the workloads for the account, as the signer
+// doesn't solve challenges. SPIFFE IDs are ordered as "uri" identifiers.
+func NewACMESigner(config ACMEConfig) (ca.Signer, error) {
+	if config.DirectoryURL == "" {
+		return nil, fmt.Errorf("the ACME directory URL is required")
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	tlsConfig, err := newTLSConfig(config.RootCertFile)
+	if err != nil {
+		return nil, err
+	}
+	s := &acmeSigner{
+		config: config,
+		client: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}
+	if config.AccountKeyFile != "" {
+		data, err := ioutil.ReadFile(config.AccountKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the ACME account key (%v)", err)
+		}
+		key, err := util.ParsePemEncodedKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the ACME account key (%v)", err)
+		}
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok || ecKey.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("the ACME account key must be an ECDSA P-256 key")
+		}
+		s.key = ecKey
+	} else {
+		log.Info("No ACME account key is configured, using an ephemeral account")
+		if s.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *acmeSigner) Sign(csr *x509.CertificateRequest, _ []byte, subjectIDs []string, lifetime time.Duration,
+	forCA bool) ([]byte, error) {
+	if forCA {
+		return nil, ca.NewError(ca.CSRError, fmt.Errorf("the ACME CA doesn't sign CA certificates"))
+	}
+	// The ACME CA issues certificates for the identities of the CSR, which must be the
+	// authenticated ones.
+	csrIDs, err := util.ExtractIDs(csr.Extensions)
+	if err != nil || !sameIDs(csrIDs, subjectIDs) {
+		return nil, ca.NewError(ca.CSRError, fmt.Errorf("the CSR must request the identities %v", subjectIDs))
+	}
+
+	directory, err := s.register()
+	if err != nil {
+		return nil, ca.NewError(ca.CANotReady, err)
+	}
+
+	order := &acmeOrder{NotAfter: time.Now().Add(lifetime).UTC().Format(time.RFC3339)}
+	for _, id := range subjectIDs {
+		order.Identifiers = append(order.Identifiers, identifier(id))
+	}
+	resp, err := s.post(directory.NewOrder, order, order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the ACME order (%v)", err)
+	}
+	orderURL := resp.Header.Get("Location")
+
+	deadline := time.Now().Add(s.config.Timeout)
+	for order.Status != "valid" {
+		switch order.Status {
+		case "pending":
+			if err = s.checkAuthorizations(order); err != nil {
+				return nil, err
+			}
+		case "ready":
+			finalize := struct {
+				CSR string `json:"csr"`
+			}{base64.RawURLEncoding.EncodeToString(csr.Raw)}
+			if _, err = s.post(order.Finalize, finalize, order); err != nil {
+				return nil, fmt.Errorf("failed to finalize the ACME order (%v)", err)
+			}
+			continue
+		case "processing":
+		default:
+			return nil, fmt.Errorf("the ACME order is %s", order.Status)
+		}
+		if time.Now().After(deadline) {
+			return nil, ca.NewError(ca.CANotReady, fmt.Errorf("timed out waiting for the ACME order"))
+		}
+		time.Sleep(acmePollInterval)
+		if _, err = s.post(orderURL, nil, order); err != nil {
+			return nil, fmt.Errorf("failed to get the ACME order (%v)", err)
+		}
+	}
+
+	resp, err = s.post(order.Certificate, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download the certificate (%v)", err)
+	}
+	chain, err := splitPEMChain(resp.body)
+	if err != nil {
+		return nil, err
+	}
+	return checkCertChain(csr, chain)
+}
+
+// checkAuthorizations returns an error unless the authorizations of a pending order are valid.
+func (s *acmeSigner) checkAuthorizations(order *acmeOrder) error {
+	for _, authzURL := range order.Authorizations {
+		var authz acmeAuthorization
+		if _, err := s.post(authzURL, nil, &authz); err != nil {
+			return fmt.Errorf("failed to get the ACME authorization (%v)", err)
+		}
+		if authz.Status != "valid" {
+			return fmt.Errorf("the ACME authorization of %s is %s, the CA must authorize the identities of the "+
+				"account", authz.Identifier.Value, authz.Status)
+		}
+	}
+	return nil
+}
+
+// register fetches the directory and registers the account, if not done yet, and returns
+// the directory.
+func (s *acmeSigner) register() (*acmeDirectory, error) {
+	s.registerMutex.Lock()
+	defer s.registerMutex.Unlock()
+
+	directory, accountURL := s.account()
+	if directory == nil {
+		resp, err := s.client.Get(s.config.DirectoryURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the ACME directory (%v)", err)
+		}
+		defer resp.Body.Close() // nolint: errcheck
+		directory = &acmeDirectory{}
+		if err = json.NewDecoder(resp.Body).Decode(directory); err != nil {
+			return nil, fmt.Errorf("invalid ACME directory (%v)", err)
+		}
+		s.mutex.Lock()
+		s.directory = directory
+		s.mutex.Unlock()
+	}
+	if accountURL != "" {
+		return directory, nil
+	}
+	account := struct {
+		TermsOfServiceAgreed bool     `json:"termsOfServiceAgreed"`
+		Contact              []string `json:"contact,omitempty"`
+	}{TermsOfServiceAgreed: true}
+	if s.config.Email != "" {
+		account.Contact = []string{"mailto:" + s.config.Email}
+	}
+	resp, err := s.post(directory.NewAccount, account, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register the ACME account (%v)", err)
+	}
+	if accountURL = resp.Header.Get("Location"); accountURL == "" {
+		return nil, fmt.Errorf("the ACME CA returned no account URL")
+	}
+	s.mutex.Lock()
+	s.accountURL = accountURL
+	s.mutex.Unlock()
+	log.Infof("Using ACME account %s", accountURL)
+	return directory, nil
+}
+
+// account returns the directory and the account URL, nil and empty until known.
+func (s *acmeSigner) account() (*acmeDirectory, string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.directory, s.accountURL
+}
+
+type acmeResponse struct {
+	*http.Response
+	body []byte
+}
+
+// post sends a signed request, or a POST-as-GET request if the payload is nil, and decodes the
+// JSON response in out if not nil. Requests rejected for a bad nonce are retried once.
+func (s *acmeSigner) post(url string, payload, out interface{}) (*acmeResponse, error) {
+	resp, err := s.postOnce(url, payload)
+	if p, ok := err.(*acmeProblem); ok && p.Type == errBadNonce {
+		resp, err = s.postOnce(url, payload)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if out != nil {
+		if err = json.Unmarshal(resp.body, out); err != nil {
+			return nil, fmt.Errorf("invalid ACME response (%v)", err)
+		}
+	}
+	return resp, nil
+}
+
+func (s *acmeSigner) postOnce(url string, payload interface{}) (*acmeResponse, error) {
+	nonce, err := s.nonce()
+	if err != nil {
+		return nil, err
+	}
+	body, err := s.sign(url, nonce, payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", joseContentType)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	if n := resp.Header.Get("Replay-Nonce"); n != "" {
+		s.mutex.Lock()
+		s.nonces = append(s.nonces, n)
+		s.mutex.Unlock()
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		p := &acmeProblem{status: resp.StatusCode}
+		if json.Unmarshal(data, p) != nil || p.Type == "" {
+			p.Detail = strings.TrimSpace(string(data))
+		}
+		return nil, p
+	}
+	return &acmeResponse{Response: resp, body: data}, nil
+}
+
+// nonce returns an unused nonce, fetching one if needed.
+func (s *acmeSigner) nonce() (string, error) {
+	s.mutex.Lock()
+	if len(s.nonces) > 0 {
+		n := s.nonces[len(s.nonces)-1]
+		s.nonces = s.nonces[:len(s.nonces)-1]
+		s.mutex.Unlock()
+		return n, nil
+	}
+	newNonce := s.directory.NewNonce
+	s.mutex.Unlock()
+
+	resp, err := s.client.Head(newNonce)
+	if err != nil {
+		return "", fmt.Errorf("failed to get an ACME nonce (%v)", err)
+	}
+	_ = resp.Body.Close()
+	n := resp.Header.Get("Replay-Nonce")
+	if n == "" {
+		return "", fmt.Errorf("the ACME CA returned no nonce")
+	}
+	return n, nil
+}
+
+// sign returns the JWS of the payload, in the flattened JSON serialization. The account key is
+// identified by its URL once registered, and by its JWK otherwise.
+func (s *acmeSigner) sign(url, nonce string, payload interface{}) ([]byte, error) {
+	header := map[string]interface{}{
+		"alg":   "ES256",
+		"nonce": nonce,
+		"url":   url,
+	}
+	if _, accountURL := s.account(); accountURL != "" {
+		header["kid"] = accountURL
+	} else {
+		header["jwk"] = jwk(&s.key.PublicKey)
+	}
+	protected, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	var encodedPayload string
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		encodedPayload = base64.RawURLEncoding.EncodeToString(data)
+	}
+	encodedProtected := base64.RawURLEncoding.EncodeToString(protected)
+
+	digest := sha256.Sum256([]byte(encodedProtected + "." + encodedPayload))
+	r, ss, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	signature := append(padded(r, 32), padded(ss, 32)...)
+	return json.Marshal(map[string]string{
+		"protected": encodedProtected,
+		"payload":   encodedPayload,
+		"signature": base64.RawURLEncoding.EncodeToString(signature),
+	})
+}
+
+// jwk returns the JSON web key of an ECDSA P-256 public key.
+func jwk(key *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(padded(key.X, 32)),
+		"y":   base64.RawURLEncoding.EncodeToString(padded(key.Y, 32)),
+	}
+}
+
+func padded(n *big.Int, size int) []byte {
+	b := n.Bytes()
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
+
+// identifier returns the ACME identifier of a subject ID.
+func identifier(id string) acmeIdentifier {
+	switch {
+	case strings.Contains(id, "://"):
+		return acmeIdentifier{Type: "uri", Value: id}
+	case net.ParseIP(id) != nil:
+		return acmeIdentifier{Type: "ip", Value: id}
+	default:
+		return acmeIdentifier{Type: "dns", Value: id}
+	}
+}
+
+func sameIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// splitPEMChain returns the PEM-encoded certificates of a chain.
+func splitPEMChain(data []byte) ([]string, error) {
+	var chain []string
+	for {
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		chain = append(chain, string(pem.EncodeToMemory(block)))
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("the ACME CA returned no certificate")
+	}
+	return chain, nil
+}
@@ -0,0 +1,397 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"istio.io/istio/security/pkg/pki/ca"
+	"istio.io/istio/security/pkg/pki/util"
+)
+
+type stubOrder struct {
+	acmeOrder
+	chain string
+}
+
+// acmeStub is an ACME CA, which issues the certificates of the orders without challenges.
+type acmeStub struct {
+	ca     *testCA
+	server *httptest.Server
+
+	mutex    sync.Mutex
+	nonce    int
+	nonces   map[string]bool
+	accounts map[string]*ecdsa.PublicKey
+	orders   []*stubOrder
+	// pendingAuthz makes the authorizations of the orders pending.
+	pendingAuthz bool
+	// badNonces is the number of requests to reject with a badNonce error.
+	badNonces int
+	// held keeps the first order processing until it is closed, if not nil.
+	held chan struct{}
+}
+
+func newACMEStub(t *testing.T) *acmeStub {
+	s := &acmeStub{
+		ca:       newTestCA(t),
+		nonces:   map[string]bool{},
+		accounts: map[string]*ecdsa.PublicKey{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+func (s *acmeStub) url(path string, args ...interface{}) string {
+	return s.server.URL + fmt.Sprintf(path, args...)
+}
+
+func (s *acmeStub) newNonce() string {
+	s.nonce++
+	n := fmt.Sprintf("nonce-%d", s.nonce)
+	s.nonces[n] = true
+	return n
+}
+
+func (s *acmeStub) problem(w http.ResponseWriter, status int, typ, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&acmeProblem{Type: "urn:ietf:params:acme:error:" + typ, Detail: detail})
+}
+
+func (s *acmeStub) reply(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (s *acmeStub) serve(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	w.Header().Set("Replay-Nonce", s.newNonce())
+	switch {
+	case r.URL.Path == "/directory":
+		s.reply(w, http.StatusOK, &acmeDirectory{
+			NewNonce:   s.url("/new-nonce"),
+			NewAccount: s.url("/new-account"),
+			NewOrder:   s.url("/new-order"),
+		})
+		return
+	case r.URL.Path == "/new-nonce":
+		return
+	}
+
+	key, payload, err := s.verify(r)
+	if err != nil {
+		s.problem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+	if s.badNonces > 0 {
+		s.badNonces--
+		s.problem(w, http.StatusBadRequest, "badNonce", "bad nonce")
+		return
+	}
+
+	var id int
+	switch {
+	case r.URL.Path == "/new-account":
+		kid := s.url("/account/%d", len(s.accounts))
+		s.accounts[kid] = key
+		w.Header().Set("Location", kid)
+		s.reply(w, http.StatusCreated, map[string]string{"status": "valid"})
+	case r.URL.Path == "/new-order":
+		order := &stubOrder{}
+		if err := json.Unmarshal(payload, &order.acmeOrder); err != nil {
+			s.problem(w, http.StatusBadRequest, "malformed", err.Error())
+			return
+		}
+		id = len(s.orders)
+		s.orders = append(s.orders, order)
+		order.Finalize = s.url("/finalize/%d", id)
+		order.Status = "ready"
+		if s.pendingAuthz {
+			order.Status = "pending"
+			order.Authorizations = []string{s.url("/authz/%d", id)}
+		}
+		w.Header().Set("Location", s.url("/order/%d", id))
+		s.reply(w, http.StatusCreated, &order.acmeOrder)
+	case scan(r.URL.Path, "/authz/%d", &id):
+		s.reply(w, http.StatusOK, &acmeAuthorization{Status: "pending", Identifier: s.orders[id].Identifiers[0]})
+	case scan(r.URL.Path, "/finalize/%d", &id):
+		order := s.orders[id]
+		var finalize struct {
+			CSR string `json:"csr"`
+		}
+		_ = json.Unmarshal(payload, &finalize)
+		der, _ := base64.RawURLEncoding.DecodeString(finalize.CSR)
+		csr, err := x509.ParseCertificateRequest(der)
+		if order.Status != "ready" || err != nil {
+			s.problem(w, http.StatusForbidden, "orderNotReady", "cannot finalize the order")
+			return
+		}
+		var ids []string
+		for _, identifier := range order.Identifiers {
+			ids = append(ids, identifier.Value)
+		}
+		notAfter, _ := time.Parse(time.RFC3339, order.NotAfter)
+		order.chain = s.ca.sign(csr, ids, time.Until(notAfter)) + string(s.ca.certPEM)
+		// The certificate is issued on the next poll.
+		order.Status = "processing"
+		s.reply(w, http.StatusOK, &order.acmeOrder)
+	case scan(r.URL.Path, "/order/%d", &id):
+		order := s.orders[id]
+		if id == 0 && s.held != nil {
+			select {
+			case <-s.held:
+			default:
+				s.reply(w, http.StatusOK, &order.acmeOrder)
+				return
+			}
+		}
+		if order.Status == "processing" {
+			order.Status = "valid"
+			order.Certificate = s.url("/cert/%d", id)
+		}
+		s.reply(w, http.StatusOK, &order.acmeOrder)
+	case scan(r.URL.Path, "/cert/%d", &id):
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		_, _ = w.Write([]byte(s.orders[id].chain))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func scan(path, format string, id *int) bool {
+	_, err := fmt.Sscanf(path, format, id)
+	return err == nil
+}
+
+// verify checks the JWS of a request, and returns the account key and the payload.
+func (s *acmeStub) verify(r *http.Request) (*ecdsa.PublicKey, []byte, error) {
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != joseContentType {
+		return nil, nil, fmt.Errorf("not a JWS request")
+	}
+	var jws struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+		Signature string `json:"signature"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		return nil, nil, err
+	}
+	var header struct {
+		Alg   string            `json:"alg"`
+		Nonce string            `json:"nonce"`
+		URL   string            `json:"url"`
+		Kid   string            `json:"kid"`
+		JWK   map[string]string `json:"jwk"`
+	}
+	protected, _ := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if err := json.Unmarshal(protected, &header); err != nil {
+		return nil, nil, err
+	}
+	if header.Alg != "ES256" || header.URL != s.url(r.URL.Path) || !s.nonces[header.Nonce] {
+		return nil, nil, fmt.Errorf("invalid header %s", protected)
+	}
+	delete(s.nonces, header.Nonce)
+
+	key := s.accounts[header.Kid]
+	if r.URL.Path == "/new-account" {
+		x, _ := base64.RawURLEncoding.DecodeString(header.JWK["x"])
+		y, _ := base64.RawURLEncoding.DecodeString(header.JWK["y"])
+		key = &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	}
+	if key == nil {
+		return nil, nil, fmt.Errorf("unknown account %q", header.Kid)
+	}
+	signature, _ := base64.RawURLEncoding.DecodeString(jws.Signature)
+	digest := sha256.Sum256([]byte(jws.Protected + "." + jws.Payload))
+	if len(signature) != 64 || !ecdsa.Verify(key, digest[:], new(big.Int).SetBytes(signature[:32]),
+		new(big.Int).SetBytes(signature[32:])) {
+		return nil, nil, fmt.Errorf("invalid signature")
+	}
+	payload, _ := base64.RawURLEncoding.DecodeString(jws.Payload)
+	return key, payload, nil
+}
+
+func TestACMESigner(t *testing.T) {
+	stub := newACMEStub(t)
+	defer stub.server.Close()
+	// The signer retries the requests rejected for a bad nonce.
+	stub.badNonces = 1
+
+	dir, err := ioutil.TempDir("", "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(dir, "account.pem")
+	if err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewACMESigner(ACMEConfig{DirectoryURL: stub.url("/directory"), AccountKeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("NewACMESigner() failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		csr, csrPEM := genCSR(t, testID)
+		chain, err := signer.Sign(csr, csrPEM, []string{testID}, time.Hour, false)
+		if err != nil {
+			t.Fatalf("Sign() failed: %v", err)
+		}
+		cert := stub.ca.verify(chain, csr)
+		if ids, _ := util.ExtractIDs(cert.Extensions); len(ids) != 1 || ids[0] != testID {
+			t.Errorf("got identities %v, want %s", ids, testID)
+		}
+		if ttl := time.Until(cert.NotAfter); ttl > time.Hour || ttl < 55*time.Minute {
+			t.Errorf("got TTL %v, want 1h", ttl)
+		}
+	}
+
+	if len(stub.accounts) != 1 {
+		t.Errorf("got %d accounts, want 1", len(stub.accounts))
+	}
+	for _, accountKey := range stub.accounts {
+		if accountKey.X.Cmp(key.X) != 0 || accountKey.Y.Cmp(key.Y) != 0 {
+			t.Error("the account doesn't use the configured key")
+		}
+	}
+	if len(stub.orders) != 2 || stub.orders[0].Identifiers[0] != (acmeIdentifier{Type: "uri", Value: testID}) {
+		t.Errorf("unexpected orders %+v", stub.orders)
+	}
+}
+
+func TestACMESignerConcurrentOrders(t *testing.T) {
+	stub := newACMEStub(t)
+	defer stub.server.Close()
+	stub.held = make(chan struct{})
+
+	signer, err := NewACMESigner(ACMEConfig{DirectoryURL: stub.url("/directory")})
+	if err != nil {
+		t.Fatalf("NewACMESigner() failed: %v", err)
+	}
+	sign := func(csr *x509.CertificateRequest, csrPEM []byte, errs chan<- error) {
+		_, err := signer.Sign(csr, csrPEM, []string{testID}, time.Hour, false)
+		errs <- err
+	}
+
+	heldCSR, heldCSRPEM := genCSR(t, testID)
+	heldErrs := make(chan error, 1)
+	go sign(heldCSR, heldCSRPEM, heldErrs)
+	for {
+		stub.mutex.Lock()
+		created := len(stub.orders) > 0
+		stub.mutex.Unlock()
+		if created {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The second order is issued while the first one is processing.
+	csr, csrPEM := genCSR(t, testID)
+	errs := make(chan error, 1)
+	go sign(csr, csrPEM, errs)
+	select {
+	case err = <-errs:
+		if err != nil {
+			t.Errorf("Sign() failed: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Error("Sign() is blocked by the processing order")
+	}
+
+	close(stub.held)
+	if err = <-heldErrs; err != nil {
+		t.Errorf("Sign() of the held order failed: %v", err)
+	}
+}
+
+func TestACMESignerErrors(t *testing.T) {
+	testCases := map[string]struct {
+		id           string
+		subjectIDs   []string
+		forCA        bool
+		pendingAuthz bool
+		expectErr    string
+		expectType   string
+	}{
+		"Unauthorized identity": {
+			id:           testID,
+			subjectIDs:   []string{testID},
+			pendingAuthz: true,
+			expectErr:    "the CA must authorize the identities",
+		},
+		"CSR for another identity": {
+			id:         "spiffe://cluster.local/ns/default/sa/bar",
+			subjectIDs: []string{testID},
+			expectErr:  "the CSR must request the identities",
+			expectType: "CSR_ERROR",
+		},
+		"CA certificate": {
+			id:         testID,
+			subjectIDs: []string{testID},
+			forCA:      true,
+			expectErr:  "doesn't sign CA certificates",
+			expectType: "CSR_ERROR",
+		},
+	}
+
+	for id, tc := range testCases {
+		stub := newACMEStub(t)
+		stub.pendingAuthz = tc.pendingAuthz
+		signer, err := NewACMESigner(ACMEConfig{DirectoryURL: stub.url("/directory")})
+		if err != nil {
+			t.Fatalf("%s: NewACMESigner() failed: %v", id, err)
+		}
+		csr, csrPEM := genCSR(t, tc.id)
+		_, err = signer.Sign(csr, csrPEM, tc.subjectIDs, time.Hour, tc.forCA)
+		stub.server.Close()
+
+		if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+			t.Errorf("%s: got error %v, want %q", id, err, tc.expectErr)
+		} else if caErr, ok := err.(*ca.Error); tc.expectType != "" && (!ok || caErr.ErrorType() != tc.expectType) {
+			t.Errorf("%s: got error %v, want type %s", id, err, tc.expectType)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package external provides signers delegating the issuance of the Citadel certificates to an
+// external CA.
+package external
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"istio.io/istio/security/pkg/pki/ca"
+	"istio.io/istio/security/pkg/pki/util"
+)
+
+// DefaultTimeout is the default timeout of the requests to the external CA.
+const DefaultTimeout = 30 * time.Second
+
+// HTTPConfig configures a signer for an external CA with an HTTP API.
+type HTTPConfig struct {
+	// URL is the endpoint signing the CSRs.
+	URL string
+	// TokenFile has the bearer token sent to the CA, if set. The file is read for each request,
+	// so that the token can be rotated.
+	TokenFile string
+	// RootCertFile has the root certificates used to verify the CA server, if set. The system
+	// roots are used otherwise.
+	RootCertFile string
+	// Timeout of the requests, DefaultTimeout if zero.
+	Timeout time.Duration
+}
+
+// SignRequest is the request sent to the external CA.
+type SignRequest struct {
+	// CSR is the PEM-encoded certificate signing request.
+	CSR string `json:"csr"`
+	// SubjectIDs are the identities to set in the SAN of the certificate, which may differ from
+	// the identities requested by the CSR.
+	SubjectIDs []string `json:"subjectIds"`
+	// TTLSeconds is the lifetime of the certificate.
+	TTLSeconds int64 `json:"ttlSeconds"`
+	// IsCA is true if the certificate is for a CA.
+	IsCA bool `json:"isCA"`
+}
+
+// SignResponse is the response of the external CA.
+type SignResponse struct {
+	// CertChain has the PEM-encoded certificate, followed by the intermediate certificates
+	// of its chain.
+	CertChain []string `json:"certChain"`
+}
+
+type httpSigner struct {
+	config HTTPConfig
+	client *http.Client
+}
+
+var _ ca.Signer = &httpSigner{}
+
+// NewHTTPSigner returns a signer sending the CSRs to an external CA over HTTP. The CA receives a
+// JSON SignRequest in a POST request, and replies with a JSON SignResponse.
+func NewHTTPSigner(config HTTPConfig) (ca.Signer, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("the URL of the external CA is required")
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	tlsConfig, err := newTLSConfig(config.RootCertFile)
+	if err != nil {
+		return nil, err
+	}
+	return &httpSigner{
+		config: config,
+		client: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+func (s *httpSigner) Sign(csr *x509.CertificateRequest, csrPEM []byte, subjectIDs []string, lifetime time.Duration,
+	forCA bool) ([]byte, error) {
+	body, err := json.Marshal(&SignRequest{
+		CSR:        string(csrPEM),
+		SubjectIDs: subjectIDs,
+		TTLSeconds: int64(lifetime.Seconds()),
+		IsCA:       forCA,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.config.TokenFile != "" {
+		token, err := ioutil.ReadFile(s.config.TokenFile)
+		if err != nil {
+			return nil, ca.NewError(ca.CANotReady, fmt.Errorf("failed to read the token of the external CA (%v)", err))
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, ca.NewError(ca.CANotReady, fmt.Errorf("failed to reach the external CA (%v)", err))
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, ca.NewError(ca.CANotReady, fmt.Errorf("failed to read the response of the external CA (%v)", err))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the external CA returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	var signResp SignResponse
+	if err = json.Unmarshal(data, &signResp); err != nil {
+		return nil, fmt.Errorf("invalid response of the external CA (%v)", err)
+	}
+	return checkCertChain(csr, signResp.CertChain)
+}
+
+// checkCertChain verifies that the certificate of the chain is for the CSR, and returns the
+// concatenated chain.
+func checkCertChain(csr *x509.CertificateRequest, chain []string) ([]byte, error) {
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("the external CA returned no certificate")
+	}
+	cert, err := util.ParsePemEncodedCertificate([]byte(chain[0]))
+	if err != nil {
+		return nil, fmt.Errorf("the external CA returned an invalid certificate (%v)", err)
+	}
+	if !samePublicKey(cert, csr) {
+		return nil, fmt.Errorf("the external CA returned a certificate which doesn't match the CSR")
+	}
+	var out []byte
+	for _, c := range chain {
+		if len(out) > 0 {
+			out = []byte(strings.TrimSuffix(string(out), "\n") + "\n")
+		}
+		out = append(out, c...)
+	}
+	return out, nil
+}
+
+func samePublicKey(cert *x509.Certificate, csr *x509.CertificateRequest) bool {
+	certKey, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return false
+	}
+	csrKey, err := x509.MarshalPKIXPublicKey(csr.PublicKey)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(certKey, csrKey)
+}
+
+func newTLSConfig(rootCertFile string) (*tls.Config, error) {
+	if rootCertFile == "" {
+		return nil, nil
+	}
+	rootCerts, err := ioutil.ReadFile(rootCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the root certificates of the external CA (%v)", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(rootCerts) {
+		return nil, fmt.Errorf("no valid root certificate in %s", rootCertFile)
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"istio.io/istio/security/pkg/pki/ca"
+	"istio.io/istio/security/pkg/pki/util"
+)
+
+const testID = "spiffe://cluster.local/ns/default/sa/foo"
+
+// testCA signs the certificates of the external CA servers.
+type testCA struct {
+	t       *testing.T
+	cert    *x509.Certificate
+	certPEM []byte
+	key     crypto.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	certPEM, keyPEM, err := util.GenCertKeyFromOptions(util.CertOptions{
+		TTL:          time.Hour,
+		Org:          "external.ca.org",
+		IsCA:         true,
+		IsSelfSigned: true,
+		RSAKeySize:   2048,
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate the CA certificate: %v", err)
+	}
+	cert, err := util.ParsePemEncodedCertificate(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := util.ParsePemEncodedKey(keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{t: t, cert: cert, certPEM: certPEM, key: key}
+}
+
+func (c *testCA) sign(csr *x509.CertificateRequest, subjectIDs []string, ttl time.Duration) string {
+	der, err := util.GenCertFromCSR(csr, c.cert, csr.PublicKey, c.key, subjectIDs, ttl, false)
+	if err != nil {
+		c.t.Fatalf("Failed to sign the CSR: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// verify checks that the chain has the certificate signed by the CA for the CSR, followed by the
+// CA certificate, and returns the certificate.
+func (c *testCA) verify(chain []byte, csr *x509.CertificateRequest) *x509.Certificate {
+	c.t.Helper()
+	var certs []*x509.Certificate
+	for rest := chain; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) != 2 || !certs[1].Equal(c.cert) {
+		c.t.Fatalf("got %d certificates, want the certificate and the CA certificate", len(certs))
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(c.cert)
+	if _, err := certs[0].Verify(x509.VerifyOptions{Roots: pool}); err != nil {
+		c.t.Errorf("the certificate isn't signed by the CA: %v", err)
+	}
+	if !samePublicKey(certs[0], csr) {
+		c.t.Error("the certificate doesn't match the CSR")
+	}
+	return certs[0]
+}
+
+func genCSR(t *testing.T, id string) (*x509.CertificateRequest, []byte) {
+	csrPEM, _, err := util.GenCSR(util.CertOptions{Host: id, RSAKeySize: 2048})
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := util.ParsePemEncodedCSR(csrPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return csr, csrPEM
+}
+
+func TestHTTPSigner(t *testing.T) {
+	testCA := newTestCA(t)
+	csr, csrPEM := genCSR(t, testID)
+	otherCSR, _ := genCSR(t, testID)
+
+	dir, err := ioutil.TempDir("", "external-ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+	if err = ioutil.WriteFile(tokenFile, []byte("secret-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		handler    func(w http.ResponseWriter, req *SignRequest)
+		tokenFile  string
+		expectErr  string
+		expectType string
+	}{
+		"Signed": {
+			handler: func(w http.ResponseWriter, req *SignRequest) {
+				_ = json.NewEncoder(w).Encode(&SignResponse{CertChain: []string{
+					testCA.sign(csr, req.SubjectIDs, time.Duration(req.TTLSeconds)*time.Second),
+					string(testCA.certPEM),
+				}})
+			},
+			tokenFile: tokenFile,
+		},
+		"Certificate for another CSR": {
+			handler: func(w http.ResponseWriter, req *SignRequest) {
+				_ = json.NewEncoder(w).Encode(&SignResponse{CertChain: []string{
+					testCA.sign(otherCSR, req.SubjectIDs, time.Hour),
+				}})
+			},
+			expectErr: "doesn't match the CSR",
+		},
+		"Error status": {
+			handler: func(w http.ResponseWriter, req *SignRequest) {
+				http.Error(w, "policy violation", http.StatusForbidden)
+			},
+			expectErr: "403 Forbidden: policy violation",
+		},
+		"Missing token file": {
+			tokenFile:  filepath.Join(dir, "missing"),
+			expectErr:  "failed to read the token",
+			expectType: "CA_NOT_READY",
+		},
+	}
+
+	for id, tc := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if tc.tokenFile != "" && r.Header.Get("Authorization") != "Bearer secret-token" {
+				t.Errorf("%s: got authorization %q", id, r.Header.Get("Authorization"))
+			}
+			var req SignRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("%s: invalid request: %v", id, err)
+			}
+			if req.CSR != string(csrPEM) || !reflect.DeepEqual(req.SubjectIDs, []string{testID}) ||
+				req.TTLSeconds != 3600 || req.IsCA {
+				t.Errorf("%s: unexpected request %+v", id, req)
+			}
+			tc.handler(w, &req)
+		}))
+
+		signer, err := NewHTTPSigner(HTTPConfig{URL: server.URL, TokenFile: tc.tokenFile})
+		if err != nil {
+			t.Fatalf("%s: NewHTTPSigner() failed: %v", id, err)
+		}
+		chain, err := signer.Sign(csr, csrPEM, []string{testID}, time.Hour, false)
+		server.Close()
+
+		if tc.expectErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+				t.Errorf("%s: got error %v, want %q", id, err, tc.expectErr)
+			} else if caErr, ok := err.(*ca.Error); tc.expectType != "" && (!ok || caErr.ErrorType() != tc.expectType) {
+				t.Errorf("%s: got error %v, want type %s", id, err, tc.expectType)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: Sign() failed: %v", id, err)
+			continue
+		}
+		testCA.verify(chain, csr)
+	}
+}
+
+func TestHTTPSignerUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	signer, err := NewHTTPSigner(HTTPConfig{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, csrPEM := genCSR(t, testID)
+	_, err = signer.Sign(csr, csrPEM, []string{testID}, time.Hour, false)
+	if caErr, ok := err.(*ca.Error); !ok || caErr.ErrorType() != "CA_NOT_READY" {
+		t.Errorf("got error %v, want CA_NOT_READY", err)
+	}
+}
@@ -28,6 +28,10 @@ type FakeCA struct {
 	SignErr       *ca.Error
 	KeyCertBundle util.KeyCertBundle
 	ReceivedIDs   []string
+	Revoked       []ca.RevokedCertificate
+	RevokeErr     *ca.Error
+	CRL           []byte
+	CRLErr        *ca.Error
 }
 
 // Sign returns the SignErr if SignErr is not nil, otherwise, it returns SignedCert.
@@ -47,3 +51,20 @@ func (ca *FakeCA) GetCAKeyCertBundle() util.KeyCertBundle {
 	}
 	return ca.KeyCertBundle
 }
+
+// Revoke records the certificate in Revoked, unless RevokeErr is not nil.
+func (ca *FakeCA) Revoke(cert ca.RevokedCertificate) error {
+	if ca.RevokeErr != nil {
+		return ca.RevokeErr
+	}
+	ca.Revoked = append(ca.Revoked, cert)
+	return nil
+}
+
+// GetCRL returns the CRLErr if CRLErr is not nil, otherwise, it returns CRL.
+func (ca *FakeCA) GetCRL() ([]byte, error) {
+	if ca.CRLErr != nil {
+		return nil, ca.CRLErr
+	}
+	return ca.CRL, nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ca
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"istio.io/istio/security/pkg/pki/util"
+	"istio.io/pkg/log"
+)
+
+const (
+	// RevocationListConfigMap stores the certificates revoked by the CA for persistency purpose.
+	RevocationListConfigMap = "istio-ca-revocation-list"
+	// revokedCertificatesID is the key of the revoked certificates in the ConfigMap.
+	revokedCertificatesID = "revoked-certificates.json"
+
+	// DefaultCRLTTL is the default validity of the certificate revocation lists.
+	DefaultCRLTTL = 24 * time.Hour
+)
+
+// RevokedCertificate is a certificate revoked before it expires.
+type RevokedCertificate struct {
+	SerialNumber   *big.Int  `json:"serialNumber"`
+	RevocationTime time.Time `json:"revocationTime"`
+	// NotAfter is the expiration time of the certificate, if known. Expired certificates
+	// are dropped from the revocation list.
+	NotAfter time.Time `json:"notAfter,omitempty"`
+}
+
+// RevocationStore persists the certificates revoked by the CA.
+type RevocationStore interface {
+	// Load returns the revoked certificates.
+	Load() ([]RevokedCertificate, error)
+	// Save replaces the revoked certificates.
+	Save([]RevokedCertificate) error
+}
+
+type configMapRevocationStore struct {
+	client    corev1.CoreV1Interface
+	namespace string
+}
+
+// NewConfigMapRevocationStore returns a RevocationStore persisting the revoked certificates in
+// the RevocationListConfigMap of the namespace.
+func NewConfigMapRevocationStore(client corev1.CoreV1Interface, namespace string) RevocationStore {
+	return &configMapRevocationStore{client: client, namespace: namespace}
+}
+
+func (s *configMapRevocationStore) Load() ([]RevokedCertificate, error) {
+	cm, err := s.client.ConfigMaps(s.namespace).Get(RevocationListConfigMap, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the revocation list: %v", err)
+	}
+	var revoked []RevokedCertificate
+	if data := cm.Data[revokedCertificatesID]; data != "" {
+		if err = json.Unmarshal([]byte(data), &revoked); err != nil {
+			return nil, fmt.Errorf("failed to parse the revocation list: %v", err)
+		}
+	}
+	return revoked, nil
+}
+
+func (s *configMapRevocationStore) Save(revoked []RevokedCertificate) error {
+	data, err := json.Marshal(revoked)
+	if err != nil {
+		return err
+	}
+	cm, err := s.client.ConfigMaps(s.namespace).Get(RevocationListConfigMap, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: RevocationListConfigMap, Namespace: s.namespace},
+			Data:       map[string]string{revokedCertificatesID: string(data)},
+		}
+		_, err = s.client.ConfigMaps(s.namespace).Create(cm)
+	} else if err == nil {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[revokedCertificatesID] = string(data)
+		_, err = s.client.ConfigMaps(s.namespace).Update(cm)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the revocation list: %v", err)
+	}
+	return nil
+}
+
+// revocationList is the list of certificates revoked by a CA, and the last CRL generated
+// from it.
+type revocationList struct {
+	mutex   sync.Mutex
+	store   RevocationStore
+	crlTTL  time.Duration
+	revoked map[string]RevokedCertificate
+
+	crl           []byte
+	crlNextUpdate time.Time
+	// crlSigner is the signing certificate of the CRL, which is regenerated when the CA
+	// signing certificate changes.
+	crlSigner *x509.Certificate
+}
+
+func newRevocationList(store RevocationStore, crlTTL time.Duration) (*revocationList, error) {
+	if crlTTL <= 0 {
+		crlTTL = DefaultCRLTTL
+	}
+	l := &revocationList{
+		store:   store,
+		crlTTL:  crlTTL,
+		revoked: map[string]RevokedCertificate{},
+	}
+	revoked, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range revoked {
+		if r.SerialNumber != nil {
+			l.revoked[r.SerialNumber.String()] = r
+		}
+	}
+	return l, nil
+}
+
+// revoke adds a certificate to the list, and persists the list.
+func (l *revocationList) revoke(r RevokedCertificate, now time.Time) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	key := r.SerialNumber.String()
+	if _, f := l.revoked[key]; f {
+		return nil
+	}
+	l.revoked[key] = r
+	l.prune(now)
+	if err := l.store.Save(l.list()); err != nil {
+		delete(l.revoked, key)
+		return err
+	}
+	// Regenerate the CRL on the next request.
+	l.crl = nil
+	log.Infof("Revoked certificate with serial number %x", r.SerialNumber)
+	return nil
+}
+
+// getCRL returns the PEM-encoded CRL signed by the key. The CRL is regenerated when the list
+// or the signing certificate changes, and when half of its validity has elapsed.
+func (l *revocationList) getCRL(signingCert *x509.Certificate, signingKey crypto.PrivateKey, now time.Time) ([]byte, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.crl != nil && l.crlSigner.Equal(signingCert) && now.Before(l.crlNextUpdate.Add(-l.crlTTL/2)) {
+		return l.crl, nil
+	}
+
+	l.prune(now)
+	var revoked []pkix.RevokedCertificate
+	for _, r := range l.list() {
+		revoked = append(revoked, pkix.RevokedCertificate{
+			SerialNumber:   r.SerialNumber,
+			RevocationTime: r.RevocationTime,
+		})
+	}
+	nextUpdate := now.Add(l.crlTTL)
+	der, err := signingCert.CreateCRL(rand.Reader, signingKey, revoked, now, nextUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the CRL: %v", err)
+	}
+	l.crl = pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+	l.crlNextUpdate = nextUpdate
+	l.crlSigner = signingCert
+	return l.crl, nil
+}
+
+// prune drops the expired certificates, which are rejected even if not revoked.
+func (l *revocationList) prune(now time.Time) {
+	for key, r := range l.revoked {
+		if !r.NotAfter.IsZero() && r.NotAfter.Before(now) {
+			delete(l.revoked, key)
+		}
+	}
+}
+
+// list returns the revoked certificates, sorted by serial number.
+func (l *revocationList) list() []RevokedCertificate {
+	out := make([]RevokedCertificate, 0, len(l.revoked))
+	for _, r := range l.revoked {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].SerialNumber.Cmp(out[j].SerialNumber) < 0
+	})
+	return out
+}
+
+// ParseRevocationRequest returns the certificate to revoke, from either a PEM-encoded
+// certificate or a hex-encoded serial number.
+func ParseRevocationRequest(certPEM, serialHex string, now time.Time) (RevokedCertificate, error) {
+	r := RevokedCertificate{RevocationTime: now}
+	if certPEM != "" {
+		cert, err := util.ParsePemEncodedCertificate([]byte(certPEM))
+		if err != nil {
+			return r, err
+		}
+		r.SerialNumber = cert.SerialNumber
+		r.NotAfter = cert.NotAfter
+		return r, nil
+	}
+	serial, ok := new(big.Int).SetString(serialHex, 16)
+	if !ok || serial.Sign() <= 0 {
+		return r, fmt.Errorf("invalid serial number %q", serialHex)
+	}
+	r.SerialNumber = serial
+	return r, nil
+}
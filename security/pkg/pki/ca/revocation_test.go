@@ -0,0 +1,142 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ca
+
+import (
+	"bytes"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	"istio.io/istio/security/pkg/pki/util"
+)
+
+func createRevocationCA(t *testing.T, store RevocationStore) *IstioCA {
+	t.Helper()
+	ca, err := createCA(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ca.revocationList, err = newRevocationList(store, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	return ca
+}
+
+func parseCRL(t *testing.T, ca *IstioCA, crlPEM []byte) []*big.Int {
+	t.Helper()
+	crl, err := x509.ParseCRL(crlPEM)
+	if err != nil {
+		t.Fatalf("failed to parse the CRL: %v", err)
+	}
+	signingCert, _, _, _ := ca.GetCAKeyCertBundle().GetAll()
+	if err = signingCert.CheckCRLSignature(crl); err != nil {
+		t.Errorf("invalid CRL signature: %v", err)
+	}
+	var serials []*big.Int
+	for _, r := range crl.TBSCertList.RevokedCertificates {
+		serials = append(serials, r.SerialNumber)
+	}
+	return serials
+}
+
+func TestRevoke(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	store := NewConfigMapRevocationStore(client.CoreV1(), "default")
+	ca := createRevocationCA(t, store)
+
+	csrPEM, _, err := util.GenCSR(util.CertOptions{Host: "spiffe://example.com/ns/foo/sa/bar", RSAKeySize: 2048})
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, err := ca.Sign(csrPEM, []string{"spiffe://example.com/ns/foo/sa/bar"}, time.Minute, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crl, err := ca.GetCRL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serials := parseCRL(t, ca, crl); len(serials) != 0 {
+		t.Errorf("got revoked certificates %v, want none", serials)
+	}
+
+	now := time.Now()
+	revoked, err := ParseRevocationRequest(string(certPEM), "", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired := RevokedCertificate{SerialNumber: big.NewInt(1), RevocationTime: now, NotAfter: now.Add(-time.Minute)}
+	bySerial, err := ParseRevocationRequest("", "2a", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []RevokedCertificate{revoked, expired, bySerial, revoked} {
+		if err = ca.Revoke(r); err != nil {
+			t.Fatalf("Revoke(%x) failed: %v", r.SerialNumber, err)
+		}
+	}
+
+	crl, err = ca.GetCRL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	serials := parseCRL(t, ca, crl)
+	if len(serials) != 2 || serials[0].Int64() != 42 || serials[1].Cmp(revoked.SerialNumber) != 0 {
+		t.Errorf("got revoked certificates %v, want 42 and %v", serials, revoked.SerialNumber)
+	}
+	if again, _ := ca.GetCRL(); !bytes.Equal(again, crl) {
+		t.Error("the CRL was regenerated without changes")
+	}
+
+	// The revoked certificates are persisted across restarts.
+	restarted := createRevocationCA(t, NewConfigMapRevocationStore(client.CoreV1(), "default"))
+	crl, err = restarted.GetCRL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serials := parseCRL(t, restarted, crl); len(serials) != 2 {
+		t.Errorf("got revoked certificates %v after restart, want 2", serials)
+	}
+}
+
+func TestRevokeNotEnabled(t *testing.T) {
+	ca, err := createCA(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ca.Revoke(RevokedCertificate{SerialNumber: big.NewInt(1)}); err == nil ||
+		err.(*Error).ErrorType() != "REVOCATION_NOT_ENABLED" {
+		t.Errorf("Revoke() got error %v, want REVOCATION_NOT_ENABLED", err)
+	}
+	if _, err := ca.GetCRL(); err == nil || err.(*Error).ErrorType() != "REVOCATION_NOT_ENABLED" {
+		t.Errorf("GetCRL() got error %v, want REVOCATION_NOT_ENABLED", err)
+	}
+}
+
+func TestParseRevocationRequest(t *testing.T) {
+	for _, serial := range []string{"", "xyz", "-1", "0"} {
+		if _, err := ParseRevocationRequest("", serial, time.Now()); err == nil {
+			t.Errorf("ParseRevocationRequest(%q) succeeded, want an error", serial)
+		}
+	}
+	if _, err := ParseRevocationRequest("not a certificate", "", time.Now()); err == nil {
+		t.Error("ParseRevocationRequest() succeeded for an invalid certificate, want an error")
+	}
+}
@@ -0,0 +1,389 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ca
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"istio.io/istio/security/pkg/pki/util"
+	"istio.io/pkg/log"
+)
+
+// RootRotationPhase is the phase of the rotation of the root certificate. A rotation goes through
+// the following phases, so that workloads trust both roots while their certificates are reissued:
+//
+// 1. RootRotationNewRootTrusted: a new root is trusted, the old root still signs.
+// 2. RootRotationNewRootSigning: the new root signs, the old root is still trusted.
+// 3. RootRotationNone: the old root is no longer trusted.
+//
+// A self-signed CA generates the new root, which signs the certificates itself. A CA with
+// plugged certificates is given a new signing cert and key with their chain and root: the new
+// root is trusted first, then the new signing cert signs. The root may be unchanged, to rotate
+// the intermediate signing cert only.
+type RootRotationPhase string
+
+const (
+	// RootRotationNone means no rotation is in progress.
+	RootRotationNone RootRotationPhase = ""
+	// RootRotationNewRootTrusted means both roots are trusted, and the old root signs certificates.
+	RootRotationNewRootTrusted RootRotationPhase = "NewRootTrusted"
+	// RootRotationNewRootSigning means both roots are trusted, and the new root signs certificates.
+	RootRotationNewRootSigning RootRotationPhase = "NewRootSigning"
+
+	// pendingCACertID is the signing certificate trusted before signing certificates.
+	pendingCACertID = "pending-ca-cert.pem"
+	// pendingCAPrivateKeyID is the private key of the pending signing certificate.
+	pendingCAPrivateKeyID = "pending-ca-key.pem"
+	// retiredCACertID is the self-signed root certificate still trusted after the end of its signing.
+	retiredCACertID = "retired-ca-cert.pem"
+	// pendingCertChainID is the cert chain of the pending plugged signing certificate.
+	pendingCertChainID = "pending-cert-chain.pem"
+	// pendingRootCertID is the root certificate of the pending plugged signing certificate.
+	pendingRootCertID = "pending-root-cert.pem"
+	// retiredRootCertID is the plugged root certificate still trusted after the end of its signing.
+	retiredRootCertID = "retired-root-cert.pem"
+
+	// rootRotationPhaseAnnotation is the annotation of the CA secret with the rotation phase.
+	rootRotationPhaseAnnotation = "istio.io/root-rotation-phase"
+	// rootRotationAdvancedAnnotation is the annotation of the CA secret with the time at which the new
+	// root started signing certificates.
+	rootRotationAdvancedAnnotation = "istio.io/root-rotation-advanced"
+)
+
+// RootRotationStatus describes the rotation of the root certificate.
+type RootRotationStatus struct {
+	Phase RootRotationPhase
+	// Advanced is the time at which the new root started signing certificates.
+	Advanced time.Time
+	// TrustedRoots are the trusted root certificates, the signing one first.
+	TrustedRoots []*RootCertSummary
+}
+
+// RootCertSummary identifies a root certificate.
+type RootCertSummary struct {
+	SerialNumber string
+	NotAfter     time.Time
+}
+
+func (s *RootCertSummary) String() string {
+	return fmt.Sprintf("serial number %s, expires %s", s.SerialNumber, s.NotAfter.Format(time.RFC3339))
+}
+
+// rootRotationPhase returns the rotation phase of a CA secret.
+func rootRotationPhase(secret *v1.Secret) RootRotationPhase {
+	return RootRotationPhase(secret.Annotations[rootRotationPhaseAnnotation])
+}
+
+// isPluggedCertSecret returns true if a CA secret has plugged certificates, with their root,
+// rather than a self-signed root. The root is empty in CASecret.
+func isPluggedCertSecret(secret *v1.Secret) bool {
+	return len(secret.Data[RootCertID]) > 0
+}
+
+// trustedRootCerts returns the root certificates of a CA secret, the signing one first.
+func trustedRootCerts(secret *v1.Secret) []byte {
+	if isPluggedCertSecret(secret) {
+		roots := [][]byte{secret.Data[RootCertID]}
+		switch rootRotationPhase(secret) {
+		case RootRotationNewRootTrusted:
+			roots = append(roots, secret.Data[pendingRootCertID])
+		case RootRotationNewRootSigning:
+			roots = append(roots, secret.Data[retiredRootCertID])
+		}
+		return joinPEM(roots...)
+	}
+
+	roots := [][]byte{secret.Data[caCertID]}
+	switch rootRotationPhase(secret) {
+	case RootRotationNewRootTrusted:
+		roots = append(roots, secret.Data[pendingCACertID])
+	case RootRotationNewRootSigning:
+		roots = append(roots, secret.Data[retiredCACertID])
+	}
+	return joinPEM(roots...)
+}
+
+// joinPEM concatenates PEM-encoded certificates, skipping the empty and the repeated ones.
+func joinPEM(certs ...[]byte) []byte {
+	var out []byte
+	for _, cert := range certs {
+		if len(cert) == 0 || bytes.Contains(out, bytes.TrimSpace(cert)) {
+			continue
+		}
+		if len(out) > 0 {
+			out = []byte(strings.TrimSuffix(string(out), "\n") + "\n")
+		}
+		out = append(out, cert...)
+	}
+	return out
+}
+
+// GetRootRotationStatus returns the status of the rotation of the root certificate, for the CA
+// secret of a self-signed CA (CASecret) or of plugged certificates.
+func GetRootRotationStatus(client corev1.CoreV1Interface, namespace, secretName string) (*RootRotationStatus, error) {
+	secret, err := client.Secrets(namespace).Get(secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret %s:%s (%v)", namespace, secretName, err)
+	}
+	status := &RootRotationStatus{Phase: rootRotationPhase(secret)}
+	if advanced, f := secret.Annotations[rootRotationAdvancedAnnotation]; f {
+		if status.Advanced, err = time.Parse(time.RFC3339, advanced); err != nil {
+			return nil, fmt.Errorf("invalid annotation %s (%v)", rootRotationAdvancedAnnotation, err)
+		}
+	}
+	rest := trustedRootCerts(secret)
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse root certificate (%v)", err)
+		}
+		status.TrustedRoots = append(status.TrustedRoots, &RootCertSummary{
+			SerialNumber: fmt.Sprintf("%x", cert.SerialNumber),
+			NotAfter:     cert.NotAfter,
+		})
+	}
+	return status, nil
+}
+
+// StartRootRotation generates a new self-signed root certificate, which is trusted but doesn't
+// sign certificates until AdvanceRootRotation. The new root has the same organization, TTL and
+// key size as the current one.
+func StartRootRotation(client corev1.CoreV1Interface, namespace string) error {
+	return updateCASecret(client, namespace, CASecret, func(secret *v1.Secret) error {
+		if isPluggedCertSecret(secret) {
+			return fmt.Errorf("the secret has plugged certificates, the new signing cert and root must be given")
+		}
+		if phase := rootRotationPhase(secret); phase != RootRotationNone {
+			return fmt.Errorf("a root rotation is already in progress (phase %s)", phase)
+		}
+		bundle, err := util.NewVerifiedKeyCertBundleFromPem(
+			secret.Data[caCertID], secret.Data[caPrivateKeyID], nil, secret.Data[caCertID])
+		if err != nil {
+			return fmt.Errorf("failed to load the current root (%v)", err)
+		}
+		cert, key, _, _ := bundle.GetAll()
+		keySize, err := util.GetRSAKeySize(*key)
+		if err != nil {
+			return err
+		}
+		options := util.CertOptions{
+			TTL:          cert.NotAfter.Sub(cert.NotBefore),
+			IsCA:         true,
+			IsSelfSigned: true,
+			RSAKeySize:   keySize,
+		}
+		if len(cert.Subject.Organization) > 0 {
+			options.Org = cert.Subject.Organization[0]
+		}
+		pemCert, pemKey, err := util.GenCertKeyFromOptions(options)
+		if err != nil {
+			return fmt.Errorf("unable to generate the new root cert and key (%v)", err)
+		}
+		secret.Data[pendingCACertID] = pemCert
+		secret.Data[pendingCAPrivateKeyID] = pemKey
+		secret.Annotations[rootRotationPhaseAnnotation] = string(RootRotationNewRootTrusted)
+		return nil
+	})
+}
+
+// StartPluggedCertRotation stages a new plugged signing cert and key, with their cert chain and
+// root, in the CA secret of plugged certificates. The new root is trusted, but the new signing
+// cert doesn't sign certificates until AdvanceRootRotation. The root may be the current one.
+func StartPluggedCertRotation(client corev1.CoreV1Interface, namespace, secretName string,
+	certPem, keyPem, certChainPem, rootCertPem []byte) error {
+	return updateCASecret(client, namespace, secretName, func(secret *v1.Secret) error {
+		if !isPluggedCertSecret(secret) {
+			return fmt.Errorf("the secret has no plugged certificates (no %s)", RootCertID)
+		}
+		if phase := rootRotationPhase(secret); phase != RootRotationNone {
+			return fmt.Errorf("a root rotation is already in progress (phase %s)", phase)
+		}
+		if len(rootCertPem) == 0 {
+			rootCertPem = secret.Data[RootCertID]
+		}
+		bundle, err := util.NewVerifiedKeyCertBundleFromPem(certPem, keyPem, certChainPem, rootCertPem)
+		if err != nil {
+			return fmt.Errorf("invalid new signing cert (%v)", err)
+		}
+		if cert, _, _, _ := bundle.GetAll(); !cert.IsCA {
+			return fmt.Errorf("the new signing cert is not authorized to sign other certificates")
+		}
+		secret.Data[pendingCACertID] = certPem
+		secret.Data[pendingCAPrivateKeyID] = keyPem
+		secret.Data[pendingCertChainID] = certChainPem
+		secret.Data[pendingRootCertID] = rootCertPem
+		secret.Annotations[rootRotationPhaseAnnotation] = string(RootRotationNewRootTrusted)
+		return nil
+	})
+}
+
+// AdvanceRootRotation makes the new root certificate, or the new plugged signing cert, sign
+// certificates. The old root is still trusted, until FinishRootRotation.
+func AdvanceRootRotation(client corev1.CoreV1Interface, namespace, secretName string) error {
+	return updateCASecret(client, namespace, secretName, func(secret *v1.Secret) error {
+		if phase := rootRotationPhase(secret); phase != RootRotationNewRootTrusted {
+			return fmt.Errorf("the root rotation can't be advanced in phase %q, it must be started first", phase)
+		}
+		if isPluggedCertSecret(secret) {
+			return advancePluggedCertRotation(secret)
+		}
+		if _, err := util.NewVerifiedKeyCertBundleFromPem(secret.Data[pendingCACertID],
+			secret.Data[pendingCAPrivateKeyID], nil, secret.Data[pendingCACertID]); err != nil {
+			return fmt.Errorf("invalid new root (%v)", err)
+		}
+		secret.Data[retiredCACertID] = secret.Data[caCertID]
+		secret.Data[caCertID] = secret.Data[pendingCACertID]
+		secret.Data[caPrivateKeyID] = secret.Data[pendingCAPrivateKeyID]
+		delete(secret.Data, pendingCACertID)
+		delete(secret.Data, pendingCAPrivateKeyID)
+		secret.Annotations[rootRotationPhaseAnnotation] = string(RootRotationNewRootSigning)
+		secret.Annotations[rootRotationAdvancedAnnotation] = time.Now().Format(time.RFC3339)
+		return nil
+	})
+}
+
+// advancePluggedCertRotation replaces the plugged certificates with the pending ones, and keeps
+// trusting the old root.
+func advancePluggedCertRotation(secret *v1.Secret) error {
+	if _, err := util.NewVerifiedKeyCertBundleFromPem(secret.Data[pendingCACertID], secret.Data[pendingCAPrivateKeyID],
+		secret.Data[pendingCertChainID], secret.Data[pendingRootCertID]); err != nil {
+		return fmt.Errorf("invalid new signing cert (%v)", err)
+	}
+	secret.Data[retiredRootCertID] = secret.Data[RootCertID]
+	secret.Data[caCertID] = secret.Data[pendingCACertID]
+	secret.Data[caPrivateKeyID] = secret.Data[pendingCAPrivateKeyID]
+	secret.Data[CertChainID] = secret.Data[pendingCertChainID]
+	secret.Data[RootCertID] = secret.Data[pendingRootCertID]
+	for _, id := range []string{pendingCACertID, pendingCAPrivateKeyID, pendingCertChainID, pendingRootCertID} {
+		delete(secret.Data, id)
+	}
+	secret.Annotations[rootRotationPhaseAnnotation] = string(RootRotationNewRootSigning)
+	secret.Annotations[rootRotationAdvancedAnnotation] = time.Now().Format(time.RFC3339)
+	return nil
+}
+
+// FinishRootRotation stops trusting the old root certificate. The certificates signed by the old
+// root are rejected afterwards, so the rotation can't be finished before minWait has elapsed since
+// it was advanced, which should be at least the max workload certificate TTL.
+func FinishRootRotation(client corev1.CoreV1Interface, namespace, secretName string, minWait time.Duration) error {
+	return updateCASecret(client, namespace, secretName, func(secret *v1.Secret) error {
+		if phase := rootRotationPhase(secret); phase != RootRotationNewRootSigning {
+			return fmt.Errorf("the root rotation can't be finished in phase %q, it must be advanced first", phase)
+		}
+		advanced, err := time.Parse(time.RFC3339, secret.Annotations[rootRotationAdvancedAnnotation])
+		if err != nil {
+			return fmt.Errorf("invalid annotation %s (%v)", rootRotationAdvancedAnnotation, err)
+		}
+		if wait := time.Until(advanced.Add(minWait)); wait > 0 {
+			return fmt.Errorf("certificates signed by the old root may still be in use, retry in %s", wait.Round(time.Second))
+		}
+		delete(secret.Data, retiredCACertID)
+		delete(secret.Data, retiredRootCertID)
+		delete(secret.Annotations, rootRotationPhaseAnnotation)
+		delete(secret.Annotations, rootRotationAdvancedAnnotation)
+		return nil
+	})
+}
+
+// updateCASecret applies the changes to a CA secret.
+func updateCASecret(client corev1.CoreV1Interface, namespace, secretName string, update func(*v1.Secret) error) error {
+	secret, err := client.Secrets(namespace).Get(secretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to read secret %s:%s (%v)", namespace, secretName, err)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	if err = update(secret); err != nil {
+		return err
+	}
+	if _, err = client.Secrets(namespace).Update(secret); err != nil {
+		return fmt.Errorf("failed to update secret %s:%s (%v)", namespace, secretName, err)
+	}
+	return nil
+}
+
+// WatchCASecret reloads the signing key/cert and the trusted roots of the CA when its secret
+// changes, until the stop channel is closed. The secret is CASecret for a self-signed CA, or the
+// secret of the plugged certificates. The trusted roots are published in the istio-security
+// ConfigMap, and are distributed to workloads with their certificates.
+func (ca *IstioCA) WatchCASecret(client corev1.CoreV1Interface, namespace, secretName, rootCertFile string,
+	interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// The secret is read at once, a restarted CA may be in the middle of a rotation.
+		secret, err := client.Secrets(namespace).Get(secretName, metav1.GetOptions{})
+		if err != nil {
+			log.Warnf("Failed to read secret %s:%s (%v)", namespace, secretName, err)
+		} else if err = ca.reloadCASecret(secret, rootCertFile, namespace, client); err != nil {
+			log.Errorf("Failed to reload secret %s:%s (%v)", namespace, secretName, err)
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reloadCASecret updates the key/cert bundle of the CA with the content of its secret, if changed.
+// The additional roots of rootCertFile are trusted by a self-signed CA. The cert chain of plugged
+// certificates is published with the trusted roots.
+func (ca *IstioCA) reloadCASecret(secret *v1.Secret, rootCertFile, namespace string, client corev1.CoreV1Interface) error {
+	rootCerts := trustedRootCerts(secret)
+	certChain := secret.Data[CertChainID]
+	published := joinPEM(certChain, rootCerts)
+	if !isPluggedCertSecret(secret) {
+		var err error
+		if rootCerts, err = appendRootCerts(rootCerts, rootCertFile); err != nil {
+			return fmt.Errorf("failed to append root certificates (%v)", err)
+		}
+		certChain, published = nil, rootCerts
+	}
+
+	certBytes, _, currentCertChain, currentRootCerts := ca.keyCertBundle.GetAllPem()
+	if bytes.Equal(certBytes, secret.Data[caCertID]) && bytes.Equal(currentCertChain, certChain) &&
+		bytes.Equal(currentRootCerts, rootCerts) {
+		return nil
+	}
+	if err := ca.keyCertBundle.VerifyAndSetAll(secret.Data[caCertID], secret.Data[caPrivateKeyID], certChain,
+		rootCerts); err != nil {
+		return fmt.Errorf("invalid CA key/cert (%v)", err)
+	}
+	log.Infof("Reloaded signing key and cert from secret %s:%s (root rotation phase %q)",
+		namespace, secret.Name, rootRotationPhase(secret))
+	if err := updateCertInConfigmap(namespace, client, published); err != nil {
+		log.Errorf("Failed to write Citadel cert to configmap (%v). Node agents will not be able to connect.", err)
+	}
+	return nil
+}
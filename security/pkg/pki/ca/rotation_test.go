@@ -0,0 +1,389 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ca
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"istio.io/istio/security/pkg/k8s/configmap"
+	"istio.io/istio/security/pkg/pki/util"
+)
+
+type rotationFixture struct {
+	t      *testing.T
+	client *fake.Clientset
+	ca     *IstioCA
+	// secret is the name of the CA secret.
+	secret string
+}
+
+func newRotationFixture(t *testing.T) *rotationFixture {
+	client := fake.NewSimpleClientset()
+	caopts, err := NewSelfSignedIstioCAOptions(context.Background(), time.Hour, 30*time.Minute, time.Hour,
+		"test.ca.org", false, "default", -1, client.CoreV1(), "")
+	if err != nil {
+		t.Fatalf("Failed to create a self-signed CA Options: %v", err)
+	}
+	ca, err := NewIstioCA(caopts)
+	if err != nil {
+		t.Fatalf("Failed to create a self-signed CA: %v", err)
+	}
+	return &rotationFixture{t: t, client: client, ca: ca, secret: CASecret}
+}
+
+// newCAKeyCert returns a CA key and cert, signed by the parent or self-signed if nil.
+func newCAKeyCert(t *testing.T, org string, parentCert, parentKey []byte) (certPEM, keyPEM []byte) {
+	options := util.CertOptions{
+		IsCA:         true,
+		IsSelfSigned: parentCert == nil,
+		TTL:          time.Hour,
+		Org:          org,
+		RSAKeySize:   2048,
+	}
+	if parentCert != nil {
+		bundle, err := util.NewVerifiedKeyCertBundleFromPem(parentCert, parentKey, nil, parentCert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, key, _, _ := bundle.GetAll()
+		options.SignerCert, options.SignerPriv = cert, *key
+	}
+	certPEM, keyPEM, err := util.GenCertKeyFromOptions(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM, keyPEM
+}
+
+// newPluggedRotationFixture returns a CA with plugged certificates, an intermediate of the root,
+// loaded from the cacerts secret.
+func newPluggedRotationFixture(t *testing.T, rootCert, intCert, intKey []byte) *rotationFixture {
+	client := fake.NewSimpleClientset()
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cacerts", Namespace: "default"},
+		Data: map[string][]byte{
+			caCertID:       intCert,
+			caPrivateKeyID: intKey,
+			CertChainID:    intCert,
+			RootCertID:     rootCert,
+		},
+	}
+	if _, err := client.CoreV1().Secrets("default").Create(secret); err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := util.NewVerifiedKeyCertBundleFromPem(intCert, intKey, intCert, rootCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := NewIstioCA(&IstioCAOptions{
+		CAType:        pluggedCertCA,
+		CertTTL:       30 * time.Minute,
+		MaxCertTTL:    time.Hour,
+		KeyCertBundle: bundle,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create a plugged-cert CA: %v", err)
+	}
+	return &rotationFixture{t: t, client: client, ca: ca, secret: "cacerts"}
+}
+
+// reload reloads the CA secret, as done by WatchCASecret.
+func (f *rotationFixture) reload() {
+	f.t.Helper()
+	secret, err := f.client.CoreV1().Secrets("default").Get(f.secret, metav1.GetOptions{})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	if err = f.ca.reloadCASecret(secret, "", "default", f.client.CoreV1()); err != nil {
+		f.t.Fatalf("reloadCASecret() failed: %v", err)
+	}
+}
+
+// roots returns the trusted roots of the CA, and checks that they are published in the ConfigMap
+// with the cert chain.
+func (f *rotationFixture) roots() []*x509.Certificate {
+	f.t.Helper()
+	rootPEM := f.ca.GetCAKeyCertBundle().GetRootCertPem()
+	encoded, err := configmap.NewController("default", f.client.CoreV1()).GetCATLSRootCert()
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	want := joinPEM(f.ca.GetCAKeyCertBundle().GetCertChainPem(), rootPEM)
+	if published, _ := base64.StdEncoding.DecodeString(encoded); !bytes.Equal(published, want) {
+		f.t.Errorf("the roots in the configmap don't match the cert chain and roots of the CA")
+	}
+	var roots []*x509.Certificate
+	for rest := rootPEM; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			f.t.Fatal(err)
+		}
+		roots = append(roots, cert)
+	}
+	if status := f.status(); len(status.TrustedRoots) != len(roots) {
+		f.t.Errorf("got %d roots in the status, want %d", len(status.TrustedRoots), len(roots))
+	}
+	return roots
+}
+
+func (f *rotationFixture) status() *RootRotationStatus {
+	f.t.Helper()
+	status, err := GetRootRotationStatus(f.client.CoreV1(), "default", f.secret)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return status
+}
+
+func (f *rotationFixture) signingCert() *x509.Certificate {
+	cert, _, _, _ := f.ca.GetCAKeyCertBundle().GetAll()
+	return cert
+}
+
+// sign returns a workload certificate signed by the CA, with its cert chain.
+func (f *rotationFixture) sign() []byte {
+	f.t.Helper()
+	csrPEM, _, err := util.GenCSR(util.CertOptions{Host: "spiffe://example.com/ns/foo/sa/bar", RSAKeySize: 2048})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	certPEM, err := f.ca.Sign(csrPEM, []string{"spiffe://example.com/ns/foo/sa/bar"}, time.Minute, false)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return append(certPEM, f.ca.GetCAKeyCertBundle().GetCertChainPem()...)
+}
+
+// verify returns true if the certificate, followed by its cert chain, is trusted by the roots
+// of the CA.
+func (f *rotationFixture) verify(certPEM []byte) bool {
+	cert, err := util.ParsePemEncodedCertificate(certPEM)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(f.ca.GetCAKeyCertBundle().GetRootCertPem())
+	intermediates := x509.NewCertPool()
+	intermediates.AppendCertsFromPEM(certPEM)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: pool, Intermediates: intermediates})
+	return err == nil
+}
+
+func TestRootRotation(t *testing.T) {
+	f := newRotationFixture(t)
+	oldRoot := f.signingCert()
+	oldCert := f.sign()
+	if status := f.status(); status.Phase != RootRotationNone || len(status.TrustedRoots) != 1 {
+		t.Fatalf("got status %+v, want no rotation and one root", status)
+	}
+
+	if err := AdvanceRootRotation(f.client.CoreV1(), "default", f.secret); err == nil {
+		t.Error("AdvanceRootRotation() succeeded before the rotation started")
+	}
+
+	// Both roots are trusted, the old one signs.
+	if err := StartRootRotation(f.client.CoreV1(), "default"); err != nil {
+		t.Fatalf("StartRootRotation() failed: %v", err)
+	}
+	if err := StartRootRotation(f.client.CoreV1(), "default"); err == nil {
+		t.Error("StartRootRotation() succeeded twice")
+	}
+	f.reload()
+	roots := f.roots()
+	if len(roots) != 2 || !roots[0].Equal(oldRoot) || roots[1].Equal(oldRoot) {
+		t.Fatalf("got %d roots, want the old root and a new root", len(roots))
+	}
+	newRoot := roots[1]
+	if newRoot.Subject.Organization[0] != "test.ca.org" || newRoot.NotAfter.Sub(newRoot.NotBefore) != time.Hour {
+		t.Errorf("the new root doesn't have the organization and TTL of the old root")
+	}
+	if !f.signingCert().Equal(oldRoot) {
+		t.Error("the signing cert changed when the rotation started")
+	}
+
+	// Both roots are trusted, the new one signs.
+	if err := FinishRootRotation(f.client.CoreV1(), "default", f.secret, 0); err == nil {
+		t.Error("FinishRootRotation() succeeded before the rotation advanced")
+	}
+	if err := AdvanceRootRotation(f.client.CoreV1(), "default", f.secret); err != nil {
+		t.Fatalf("AdvanceRootRotation() failed: %v", err)
+	}
+	f.reload()
+	if status := f.status(); status.Phase != RootRotationNewRootSigning || status.Advanced.IsZero() {
+		t.Errorf("got status %+v, want the new root signing", status)
+	}
+	if roots := f.roots(); len(roots) != 2 || !roots[0].Equal(newRoot) || !roots[1].Equal(oldRoot) {
+		t.Fatalf("got %d roots, want the new root and the old root", len(roots))
+	}
+	if !f.signingCert().Equal(newRoot) {
+		t.Error("the signing cert isn't the new root")
+	}
+	newCert := f.sign()
+	if !f.verify(oldCert) || !f.verify(newCert) {
+		t.Error("the certificates signed by both roots should be trusted")
+	}
+
+	// Only the new root is trusted.
+	if err := FinishRootRotation(f.client.CoreV1(), "default", f.secret, time.Hour); err == nil {
+		t.Error("FinishRootRotation() succeeded while certificates signed by the old root may be in use")
+	}
+	if err := FinishRootRotation(f.client.CoreV1(), "default", f.secret, 0); err != nil {
+		t.Fatalf("FinishRootRotation() failed: %v", err)
+	}
+	f.reload()
+	if roots := f.roots(); len(roots) != 1 || !roots[0].Equal(newRoot) {
+		t.Fatalf("got %d roots, want the new root", len(roots))
+	}
+	if f.verify(oldCert) || !f.verify(newCert) {
+		t.Error("only the certificates signed by the new root should be trusted")
+	}
+}
+
+func TestCreateSelfSignedIstioCADuringRootRotation(t *testing.T) {
+	f := newRotationFixture(t)
+	if err := StartRootRotation(f.client.CoreV1(), "default"); err != nil {
+		t.Fatalf("StartRootRotation() failed: %v", err)
+	}
+
+	// A restarted Citadel trusts both roots.
+	caopts, err := NewSelfSignedIstioCAOptions(context.Background(), time.Hour, 30*time.Minute, time.Hour,
+		"test.ca.org", false, "default", -1, f.client.CoreV1(), "")
+	if err != nil {
+		t.Fatalf("Failed to create a self-signed CA Options: %v", err)
+	}
+	if f.ca, err = NewIstioCA(caopts); err != nil {
+		t.Fatalf("Failed to create a self-signed CA: %v", err)
+	}
+	if roots := f.roots(); len(roots) != 2 {
+		t.Errorf("got %d roots, want 2", len(roots))
+	}
+}
+
+func TestPluggedCertRotation(t *testing.T) {
+	oldRoot, oldRootKey := newCAKeyCert(t, "old root", nil, nil)
+	oldInt, oldIntKey := newCAKeyCert(t, "old intermediate", oldRoot, oldRootKey)
+	newRoot, newRootKey := newCAKeyCert(t, "new root", nil, nil)
+	newInt, newIntKey := newCAKeyCert(t, "new intermediate", newRoot, newRootKey)
+	f := newPluggedRotationFixture(t, oldRoot, oldInt, oldIntKey)
+	oldCert := f.sign()
+
+	if err := StartRootRotation(f.client.CoreV1(), "default"); err == nil {
+		t.Error("StartRootRotation() generated a root for plugged certificates")
+	}
+	if err := StartPluggedCertRotation(f.client.CoreV1(), "default", f.secret, newInt, newIntKey, newInt,
+		oldRoot); err == nil {
+		t.Error("StartPluggedCertRotation() succeeded with a signing cert of another root")
+	}
+
+	// Both roots are trusted, the old intermediate signs.
+	if err := StartPluggedCertRotation(f.client.CoreV1(), "default", f.secret, newInt, newIntKey, newInt,
+		newRoot); err != nil {
+		t.Fatalf("StartPluggedCertRotation() failed: %v", err)
+	}
+	f.reload()
+	if status := f.status(); status.Phase != RootRotationNewRootTrusted {
+		t.Errorf("got status %+v, want the new root trusted", status)
+	}
+	if roots := f.roots(); len(roots) != 2 || roots[0].Subject.Organization[0] != "old root" ||
+		roots[1].Subject.Organization[0] != "new root" {
+		t.Fatalf("got %d roots, want the old root and the new root", len(roots))
+	}
+	if f.signingCert().Subject.Organization[0] != "old intermediate" {
+		t.Error("the signing cert changed when the rotation started")
+	}
+
+	// Both roots are trusted, the new intermediate signs with its chain.
+	if err := AdvanceRootRotation(f.client.CoreV1(), "default", f.secret); err != nil {
+		t.Fatalf("AdvanceRootRotation() failed: %v", err)
+	}
+	f.reload()
+	if roots := f.roots(); len(roots) != 2 || roots[0].Subject.Organization[0] != "new root" ||
+		roots[1].Subject.Organization[0] != "old root" {
+		t.Fatalf("got %d roots, want the new root and the old root", len(roots))
+	}
+	if f.signingCert().Subject.Organization[0] != "new intermediate" {
+		t.Error("the signing cert isn't the new intermediate")
+	}
+	if chain := f.ca.GetCAKeyCertBundle().GetCertChainPem(); !bytes.Equal(chain, newInt) {
+		t.Error("the cert chain isn't the one of the new intermediate")
+	}
+	newCert := f.sign()
+	if !f.verify(oldCert) || !f.verify(newCert) {
+		t.Error("the certificates signed by both intermediates should be trusted")
+	}
+
+	// Only the new root is trusted.
+	if err := FinishRootRotation(f.client.CoreV1(), "default", f.secret, 0); err != nil {
+		t.Fatalf("FinishRootRotation() failed: %v", err)
+	}
+	f.reload()
+	if roots := f.roots(); len(roots) != 1 || roots[0].Subject.Organization[0] != "new root" {
+		t.Fatalf("got %d roots, want the new root", len(roots))
+	}
+	if f.verify(oldCert) || !f.verify(newCert) {
+		t.Error("only the certificates signed by the new intermediate should be trusted")
+	}
+}
+
+func TestPluggedCertRotationSameRoot(t *testing.T) {
+	root, rootKey := newCAKeyCert(t, "root", nil, nil)
+	oldInt, oldIntKey := newCAKeyCert(t, "old intermediate", root, rootKey)
+	newInt, newIntKey := newCAKeyCert(t, "new intermediate", root, rootKey)
+	f := newPluggedRotationFixture(t, root, oldInt, oldIntKey)
+	oldCert := f.sign()
+
+	// The current root is kept, and trusted once.
+	if err := StartPluggedCertRotation(f.client.CoreV1(), "default", f.secret, newInt, newIntKey, newInt,
+		nil); err != nil {
+		t.Fatalf("StartPluggedCertRotation() failed: %v", err)
+	}
+	if err := AdvanceRootRotation(f.client.CoreV1(), "default", f.secret); err != nil {
+		t.Fatalf("AdvanceRootRotation() failed: %v", err)
+	}
+	f.reload()
+	if roots := f.roots(); len(roots) != 1 {
+		t.Fatalf("got %d roots, want the root", len(roots))
+	}
+	if f.signingCert().Subject.Organization[0] != "new intermediate" {
+		t.Error("the signing cert isn't the new intermediate")
+	}
+	if newCert := f.sign(); !f.verify(oldCert) || !f.verify(newCert) {
+		t.Error("the certificates signed by both intermediates should be trusted")
+	}
+}
+
+func TestPluggedCertRotationOfSelfSignedCA(t *testing.T) {
+	f := newRotationFixture(t)
+	root, rootKey := newCAKeyCert(t, "root", nil, nil)
+	newInt, newIntKey := newCAKeyCert(t, "new intermediate", root, rootKey)
+	if err := StartPluggedCertRotation(f.client.CoreV1(), "default", CASecret, newInt, newIntKey, newInt,
+		root); err == nil {
+		t.Error("StartPluggedCertRotation() succeeded for a self-signed CA")
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ca
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"istio.io/istio/security/pkg/pki/util"
+)
+
+// Signer issues the certificates of an IstioCA. The CA validates the CSR and the TTL before
+// calling the signer.
+type Signer interface {
+	// Sign returns the PEM-encoded certificate for the CSR, followed by the intermediate
+	// certificates of its chain which are not in the CA key/cert bundle.
+	Sign(csr *x509.CertificateRequest, csrPEM []byte, subjectIDs []string, lifetime time.Duration, forCA bool) ([]byte, error)
+}
+
+// localSigner signs certificates with the signing key of the CA key/cert bundle. It is used by
+// the self-signed and plugged-cert CAs.
+type localSigner struct {
+	keyCertBundle util.KeyCertBundle
+}
+
+func (s *localSigner) Sign(csr *x509.CertificateRequest, _ []byte, subjectIDs []string, lifetime time.Duration,
+	forCA bool) ([]byte, error) {
+	signingCert, signingKey, _, _ := s.keyCertBundle.GetAll()
+	if signingCert == nil {
+		return nil, NewError(CANotReady, fmt.Errorf("Istio CA is not ready")) // nolint
+	}
+
+	certBytes, err := util.GenCertFromCSR(csr, signingCert, csr.PublicKey, *signingKey, subjectIDs, lifetime, forCA)
+	if err != nil {
+		return nil, NewError(CertGenError, err)
+	}
+
+	block := &pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: certBytes,
+	}
+	return pem.EncodeToMemory(block), nil
+}
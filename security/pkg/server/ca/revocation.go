@@ -0,0 +1,84 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ca
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"istio.io/istio/security/pkg/pki/ca"
+	pb "istio.io/istio/security/proto"
+	"istio.io/pkg/log"
+)
+
+// RevokeCertificate adds a certificate to the certificate revocation list of the CA. Only the
+// revocation admins are allowed to revoke certificates.
+func (s *Server) RevokeCertificate(ctx context.Context, request *pb.RevokeCertificateRequest) (
+	*pb.RevokeCertificateResponse, error) {
+	caller := s.authenticate(ctx)
+	if caller == nil {
+		log.Warn("request authentication failure")
+		s.monitoring.AuthnError.Inc()
+		return nil, status.Error(codes.Unauthenticated, "request authenticate failure")
+	}
+	if !s.isRevocationAdmin(caller.Identities) {
+		log.Warnf("certificate revocation denied to %v", caller.Identities)
+		return nil, status.Errorf(codes.PermissionDenied, "%v is not allowed to revoke certificates", caller.Identities)
+	}
+
+	if request.Certificate == "" && request.SerialNumber == "" {
+		return nil, status.Error(codes.InvalidArgument, "either the certificate or its serial number is required")
+	}
+	cert, err := ca.ParseRevocationRequest(request.Certificate, request.SerialNumber, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid revocation request (%v)", err)
+	}
+	if err = s.ca.Revoke(cert); err != nil {
+		log.Errorf("certificate revocation error (%v)", err)
+		return nil, status.Errorf(err.(*ca.Error).HTTPErrorCode(), "certificate revocation error (%v)", err)
+	}
+	log.Infof("%v revoked certificate with serial number %x", caller.Identities, cert.SerialNumber)
+	return &pb.RevokeCertificateResponse{}, nil
+}
+
+// GetCertificateRevocationList returns the certificate revocation list of the CA to the
+// authenticated callers.
+func (s *Server) GetCertificateRevocationList(ctx context.Context, request *pb.CertificateRevocationListRequest) (
+	*pb.CertificateRevocationListResponse, error) {
+	if caller := s.authenticate(ctx); caller == nil {
+		log.Warn("request authentication failure")
+		s.monitoring.AuthnError.Inc()
+		return nil, status.Error(codes.Unauthenticated, "request authenticate failure")
+	}
+
+	crl, err := s.ca.GetCRL()
+	if err != nil {
+		log.Errorf("CRL generation error (%v)", err)
+		return nil, status.Errorf(err.(*ca.Error).HTTPErrorCode(), "CRL generation error (%v)", err)
+	}
+	return &pb.CertificateRevocationListResponse{Crl: string(crl)}, nil
+}
+
+func (s *Server) isRevocationAdmin(identities []string) bool {
+	for _, id := range identities {
+		if s.revocationAdmins[id] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ca
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"istio.io/istio/security/pkg/pki/ca"
+	mockca "istio.io/istio/security/pkg/pki/ca/mock"
+	pb "istio.io/istio/security/proto"
+)
+
+const revocationAdmin = "spiffe://cluster.local/ns/istio-system/sa/admin"
+
+func TestRevokeCertificate(t *testing.T) {
+	testCases := map[string]struct {
+		authenticators []authenticator
+		ca             *mockca.FakeCA
+		request        *pb.RevokeCertificateRequest
+		code           codes.Code
+	}{
+		"Unauthenticated request": {
+			authenticators: []authenticator{&mockAuthenticator{errMsg: "Not authorized"}},
+			ca:             &mockca.FakeCA{},
+			request:        &pb.RevokeCertificateRequest{SerialNumber: "2a"},
+			code:           codes.Unauthenticated,
+		},
+		"Not a revocation admin": {
+			authenticators: []authenticator{&mockAuthenticator{identities: []string{"spiffe://cluster.local/ns/foo/sa/bar"}}},
+			ca:             &mockca.FakeCA{},
+			request:        &pb.RevokeCertificateRequest{SerialNumber: "2a"},
+			code:           codes.PermissionDenied,
+		},
+		"Missing certificate": {
+			authenticators: []authenticator{&mockAuthenticator{identities: []string{revocationAdmin}}},
+			ca:             &mockca.FakeCA{},
+			request:        &pb.RevokeCertificateRequest{},
+			code:           codes.InvalidArgument,
+		},
+		"Invalid serial number": {
+			authenticators: []authenticator{&mockAuthenticator{identities: []string{revocationAdmin}}},
+			ca:             &mockca.FakeCA{},
+			request:        &pb.RevokeCertificateRequest{SerialNumber: "serial"},
+			code:           codes.InvalidArgument,
+		},
+		"Revocation not enabled": {
+			authenticators: []authenticator{&mockAuthenticator{identities: []string{revocationAdmin}}},
+			ca: &mockca.FakeCA{
+				RevokeErr: ca.NewError(ca.RevocationNotEnabled, fmt.Errorf("not enabled")),
+			},
+			request: &pb.RevokeCertificateRequest{SerialNumber: "2a"},
+			code:    codes.FailedPrecondition,
+		},
+		"Successful revocation": {
+			authenticators: []authenticator{&mockAuthenticator{identities: []string{revocationAdmin}}},
+			ca:             &mockca.FakeCA{},
+			request:        &pb.RevokeCertificateRequest{SerialNumber: "2a"},
+			code:           codes.OK,
+		},
+	}
+
+	for id, c := range testCases {
+		server := &Server{
+			ca:               c.ca,
+			authenticators:   c.authenticators,
+			monitoring:       newMonitoringMetrics(),
+			revocationAdmins: map[string]bool{revocationAdmin: true},
+		}
+		_, err := server.RevokeCertificate(context.Background(), c.request)
+		s, _ := status.FromError(err)
+		if code := s.Code(); c.code != code {
+			t.Errorf("Case %s: expecting code to be (%d) but got (%d): %s", id, c.code, code, s.Message())
+		} else if c.code == codes.OK {
+			if len(c.ca.Revoked) != 1 || c.ca.Revoked[0].SerialNumber.Int64() != 42 {
+				t.Errorf("Case %s: expecting serial number 42 to be revoked but got %v", id, c.ca.Revoked)
+			}
+		}
+	}
+}
+
+func TestGetCertificateRevocationList(t *testing.T) {
+	testCases := map[string]struct {
+		authenticators []authenticator
+		ca             *mockca.FakeCA
+		code           codes.Code
+	}{
+		"Unauthenticated request": {
+			authenticators: []authenticator{&mockAuthenticator{errMsg: "Not authorized"}},
+			ca:             &mockca.FakeCA{CRL: []byte("crl")},
+			code:           codes.Unauthenticated,
+		},
+		"CRL error": {
+			authenticators: []authenticator{&mockAuthenticator{identities: []string{"id"}}},
+			ca: &mockca.FakeCA{
+				CRLErr: ca.NewError(ca.RevocationError, fmt.Errorf("cannot sign")),
+			},
+			code: codes.Internal,
+		},
+		"Successful request": {
+			authenticators: []authenticator{&mockAuthenticator{identities: []string{"id"}}},
+			ca:             &mockca.FakeCA{CRL: []byte("crl")},
+			code:           codes.OK,
+		},
+	}
+
+	for id, c := range testCases {
+		server := &Server{
+			ca:             c.ca,
+			authenticators: c.authenticators,
+			monitoring:     newMonitoringMetrics(),
+		}
+		response, err := server.GetCertificateRevocationList(context.Background(), &pb.CertificateRevocationListRequest{})
+		s, _ := status.FromError(err)
+		if code := s.Code(); c.code != code {
+			t.Errorf("Case %s: expecting code to be (%d) but got (%d): %s", id, c.code, code, s.Message())
+		} else if c.code == codes.OK && response.Crl != "crl" {
+			t.Errorf("Case %s: expecting CRL to be (crl) but got (%s)", id, response.Crl)
+		}
+	}
+}
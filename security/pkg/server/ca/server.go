@@ -19,6 +19,7 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
@@ -49,8 +50,8 @@ type authenticator interface {
 	Authenticate(ctx context.Context) (*authenticate.Caller, error)
 }
 
-// Server implements IstioCAService and IstioCertificateService and provides the services on the
-// specified port.
+// Server implements IstioCAService, IstioCertificateService and IstioRevocationService and provides
+// the services on the specified port.
 type Server struct {
 	authenticators []authenticator
 	authorizer     authorizer
@@ -61,6 +62,8 @@ type Server struct {
 	forCA          bool
 	port           int
 	monitoring     monitoringMetrics
+	// revocationAdmins are the identities allowed to revoke certificates.
+	revocationAdmins map[string]bool
 }
 
 // CreateCertificate handles an incoming certificate signing request (CSR). It does
@@ -179,6 +182,7 @@ func (s *Server) Run() error {
 	grpcServer := grpc.NewServer(grpcOptions...)
 	pb.RegisterIstioCAServiceServer(grpcServer, s)
 	pb.RegisterIstioCertificateServiceServer(grpcServer, s)
+	pb.RegisterIstioRevocationServiceServer(grpcServer, s)
 
 	grpc_prometheus.EnableHandlingTimeHistogram()
 	grpc_prometheus.Register(grpcServer)
@@ -196,8 +200,10 @@ func (s *Server) Run() error {
 	return nil
 }
 
-// New creates a new instance of `IstioCAServiceServer`.
-func New(ca ca.CertificateAuthority, ttl time.Duration, forCA bool, hostlist []string, port int, trustDomain string) (*Server, error) {
+// New creates a new instance of `IstioCAServiceServer`. The revocationAdmins are the identities
+// allowed to revoke certificates.
+func New(ca ca.CertificateAuthority, ttl time.Duration, forCA bool, hostlist []string, port int, trustDomain string,
+	revocationAdmins []string) (*Server, error) {
 	if len(hostlist) == 0 {
 		return nil, fmt.Errorf("failed to create grpc server hostlist empty")
 	}
@@ -231,26 +237,27 @@ func New(ca ca.CertificateAuthority, ttl time.Duration, forCA bool, hostlist []s
 	version.Info.RecordComponentBuildTag("citadel")
 	rootCertExpiryTimestamp.Set(extractRootCertExpiryTimestamp(ca))
 
+	admins := make(map[string]bool, len(revocationAdmins))
+	for _, id := range revocationAdmins {
+		admins[id] = true
+	}
+
 	server := &Server{
-		authenticators: authenticators,
-		authorizer:     &registryAuthorizor{registry.GetIdentityRegistry()},
-		serverCertTTL:  ttl,
-		ca:             ca,
-		hostnames:      hostlist,
-		forCA:          forCA,
-		port:           port,
-		monitoring:     newMonitoringMetrics(),
+		authenticators:   authenticators,
+		authorizer:       &registryAuthorizor{registry.GetIdentityRegistry()},
+		serverCertTTL:    ttl,
+		ca:               ca,
+		hostnames:        hostlist,
+		forCA:            forCA,
+		port:             port,
+		monitoring:       newMonitoringMetrics(),
+		revocationAdmins: admins,
 	}
 	return server, nil
 }
 
 func (s *Server) createTLSServerOption() grpc.ServerOption {
-	cp := x509.NewCertPool()
-	rootCertBytes := s.ca.GetCAKeyCertBundle().GetRootCertPem()
-	cp.AppendCertsFromPEM(rootCertBytes)
-
 	config := &tls.Config{
-		ClientCAs:  cp,
 		ClientAuth: tls.VerifyClientCertIfGiven,
 		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
 			if s.certificate == nil || shouldRefresh(s.certificate) {
@@ -264,11 +271,22 @@ func (s *Server) createTLSServerOption() grpc.ServerOption {
 			return s.certificate, nil
 		},
 	}
+	// The trusted roots change during root rotations, so the client CAs are read for each connection.
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cp := x509.NewCertPool()
+		cp.AppendCertsFromPEM(s.ca.GetCAKeyCertBundle().GetRootCertPem())
+		c := config.Clone()
+		c.ClientCAs = cp
+		c.GetConfigForClient = nil
+		return c, nil
+	}
 	return grpc.Creds(credentials.NewTLS(config))
 }
 
 func (s *Server) applyServerCertificate() (*tls.Certificate, error) {
+	// The CSR requests the hostnames, as required by the external CAs signing the CSR as is.
 	opts := util.CertOptions{
+		Host:       strings.Join(s.hostnames, ","),
 		RSAKeySize: 2048,
 	}
 
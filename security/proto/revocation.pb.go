@@ -0,0 +1,1102 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: security/proto/revocation.proto
+
+package istio_v1_auth
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	io "io"
+	math "math"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type RevokeCertificateRequest struct {
+	// PEM-encoded certificate to revoke. Either the certificate or its serial number is required.
+	Certificate string `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// Hex-encoded serial number of the certificate to revoke.
+	SerialNumber string `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+}
+
+func (m *RevokeCertificateRequest) Reset()      { *m = RevokeCertificateRequest{} }
+func (*RevokeCertificateRequest) ProtoMessage() {}
+func (*RevokeCertificateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31c00d0a5eb0ab4a, []int{0}
+}
+func (m *RevokeCertificateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevokeCertificateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RevokeCertificateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RevokeCertificateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeCertificateRequest.Merge(m, src)
+}
+func (m *RevokeCertificateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevokeCertificateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeCertificateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeCertificateRequest proto.InternalMessageInfo
+
+func (m *RevokeCertificateRequest) GetCertificate() string {
+	if m != nil {
+		return m.Certificate
+	}
+	return ""
+}
+
+func (m *RevokeCertificateRequest) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+type RevokeCertificateResponse struct {
+}
+
+func (m *RevokeCertificateResponse) Reset()      { *m = RevokeCertificateResponse{} }
+func (*RevokeCertificateResponse) ProtoMessage() {}
+func (*RevokeCertificateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31c00d0a5eb0ab4a, []int{1}
+}
+func (m *RevokeCertificateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevokeCertificateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RevokeCertificateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RevokeCertificateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeCertificateResponse.Merge(m, src)
+}
+func (m *RevokeCertificateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevokeCertificateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeCertificateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeCertificateResponse proto.InternalMessageInfo
+
+type CertificateRevocationListRequest struct {
+}
+
+func (m *CertificateRevocationListRequest) Reset()      { *m = CertificateRevocationListRequest{} }
+func (*CertificateRevocationListRequest) ProtoMessage() {}
+func (*CertificateRevocationListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31c00d0a5eb0ab4a, []int{2}
+}
+func (m *CertificateRevocationListRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CertificateRevocationListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CertificateRevocationListRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CertificateRevocationListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateRevocationListRequest.Merge(m, src)
+}
+func (m *CertificateRevocationListRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CertificateRevocationListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateRevocationListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificateRevocationListRequest proto.InternalMessageInfo
+
+type CertificateRevocationListResponse struct {
+	// PEM-encoded certificate revocation list, signed by the CA.
+	Crl string `protobuf:"bytes,1,opt,name=crl,proto3" json:"crl,omitempty"`
+}
+
+func (m *CertificateRevocationListResponse) Reset()      { *m = CertificateRevocationListResponse{} }
+func (*CertificateRevocationListResponse) ProtoMessage() {}
+func (*CertificateRevocationListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31c00d0a5eb0ab4a, []int{3}
+}
+func (m *CertificateRevocationListResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CertificateRevocationListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CertificateRevocationListResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CertificateRevocationListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateRevocationListResponse.Merge(m, src)
+}
+func (m *CertificateRevocationListResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CertificateRevocationListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateRevocationListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificateRevocationListResponse proto.InternalMessageInfo
+
+func (m *CertificateRevocationListResponse) GetCrl() string {
+	if m != nil {
+		return m.Crl
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*RevokeCertificateRequest)(nil), "istio.v1.auth.RevokeCertificateRequest")
+	proto.RegisterType((*RevokeCertificateResponse)(nil), "istio.v1.auth.RevokeCertificateResponse")
+	proto.RegisterType((*CertificateRevocationListRequest)(nil), "istio.v1.auth.CertificateRevocationListRequest")
+	proto.RegisterType((*CertificateRevocationListResponse)(nil), "istio.v1.auth.CertificateRevocationListResponse")
+}
+
+func init() { proto.RegisterFile("security/proto/revocation.proto", fileDescriptor_31c00d0a5eb0ab4a) }
+
+var fileDescriptor_31c00d0a5eb0ab4a = []byte{
+	// 311 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xbd, 0x4e, 0xf3, 0x30,
+	0x14, 0x86, 0xed, 0x7e, 0xd2, 0x27, 0x61, 0xa8, 0x04, 0x1e, 0x50, 0x29, 0xe8, 0x50, 0xc2, 0x40,
+	0xa7, 0x94, 0xdf, 0x1b, 0x80, 0x01, 0x21, 0x21, 0x86, 0x70, 0x01, 0x28, 0xb5, 0x0e, 0xaa, 0x45,
+	0x89, 0x8b, 0xed, 0x44, 0x62, 0x43, 0x62, 0x61, 0xe4, 0x32, 0xb8, 0x14, 0xc6, 0x8c, 0x1d, 0x89,
+	0xb3, 0x30, 0xf6, 0x12, 0x50, 0xd3, 0x54, 0xa5, 0x40, 0x29, 0x5b, 0xf4, 0xe8, 0x3d, 0x27, 0xcf,
+	0x79, 0x65, 0xb6, 0x69, 0x50, 0xc4, 0x5a, 0xda, 0xfb, 0x56, 0x4f, 0x2b, 0xab, 0x5a, 0x1a, 0x13,
+	0x25, 0x42, 0x2b, 0x55, 0xe4, 0x17, 0x80, 0x57, 0xa5, 0xb1, 0x52, 0xf9, 0xc9, 0x9e, 0x1f, 0xc6,
+	0xb6, 0xe3, 0x85, 0xac, 0x16, 0x60, 0xa2, 0x6e, 0xf0, 0x04, 0xb5, 0x95, 0xd7, 0x52, 0x84, 0x16,
+	0x03, 0xbc, 0x8b, 0xd1, 0x58, 0xde, 0x60, 0x8b, 0x62, 0x42, 0x6b, 0xb4, 0x41, 0x9b, 0x0b, 0xc1,
+	0x67, 0xc4, 0xb7, 0x59, 0xd5, 0xa0, 0x96, 0x61, 0xf7, 0x2a, 0x8a, 0x6f, 0xdb, 0xa8, 0x6b, 0x95,
+	0x22, 0xb3, 0x34, 0x82, 0x17, 0x05, 0xf3, 0xd6, 0xd9, 0xda, 0x0f, 0xbf, 0x30, 0x3d, 0x15, 0x19,
+	0xf4, 0x3c, 0xd6, 0x98, 0xc2, 0x63, 0xdb, 0x73, 0x69, 0x6c, 0xe9, 0xe1, 0x1d, 0xb1, 0xad, 0x5f,
+	0x32, 0xa3, 0x45, 0x7c, 0x99, 0xfd, 0x13, 0xba, 0x5b, 0x4a, 0x0e, 0x3f, 0xf7, 0x9f, 0x2a, 0x6c,
+	0xf5, 0x6c, 0x78, 0xec, 0x64, 0xe2, 0x12, 0x75, 0x22, 0x05, 0xf2, 0x0e, 0x5b, 0xf9, 0xa6, 0xc4,
+	0x77, 0xfc, 0xa9, 0x6a, 0xfc, 0x59, 0xbd, 0xd4, 0x9b, 0xf3, 0x83, 0xe5, 0x75, 0x84, 0x3f, 0x52,
+	0xb6, 0x71, 0x8a, 0x76, 0xa6, 0x3f, 0x6f, 0x7d, 0x59, 0x36, 0xaf, 0x8d, 0xfa, 0xee, 0xdf, 0x07,
+	0xc6, 0x16, 0xc7, 0x87, 0x69, 0x06, 0xa4, 0x9f, 0x01, 0x19, 0x64, 0x40, 0x1f, 0x1c, 0xd0, 0x17,
+	0x07, 0xf4, 0xd5, 0x01, 0x4d, 0x1d, 0xd0, 0x37, 0x07, 0xf4, 0xdd, 0x01, 0x19, 0x38, 0xa0, 0xcf,
+	0x39, 0x90, 0x34, 0x07, 0xd2, 0xcf, 0x81, 0xb4, 0xff, 0x17, 0x2f, 0xe6, 0xe0, 0x63, 0x00, 0x03,
+	0x2a, 0xd3, 0xae, 0x54, 0x02, 0x00, 0x00,
+}
+
+func (this *RevokeCertificateRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RevokeCertificateRequest)
+	if !ok {
+		that2, ok := that.(RevokeCertificateRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Certificate != that1.Certificate {
+		return false
+	}
+	if this.SerialNumber != that1.SerialNumber {
+		return false
+	}
+	return true
+}
+func (this *RevokeCertificateResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RevokeCertificateResponse)
+	if !ok {
+		that2, ok := that.(RevokeCertificateResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
+}
+func (this *CertificateRevocationListRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CertificateRevocationListRequest)
+	if !ok {
+		that2, ok := that.(CertificateRevocationListRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
+}
+func (this *CertificateRevocationListResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CertificateRevocationListResponse)
+	if !ok {
+		that2, ok := that.(CertificateRevocationListResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Crl != that1.Crl {
+		return false
+	}
+	return true
+}
+func (this *RevokeCertificateRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&istio_v1_auth.RevokeCertificateRequest{")
+	s = append(s, "Certificate: "+fmt.Sprintf("%#v", this.Certificate)+",\n")
+	s = append(s, "SerialNumber: "+fmt.Sprintf("%#v", this.SerialNumber)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RevokeCertificateResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&istio_v1_auth.RevokeCertificateResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CertificateRevocationListRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&istio_v1_auth.CertificateRevocationListRequest{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CertificateRevocationListResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&istio_v1_auth.CertificateRevocationListResponse{")
+	s = append(s, "Crl: "+fmt.Sprintf("%#v", this.Crl)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringRevocation(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// IstioRevocationServiceClient is the client API for IstioRevocationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IstioRevocationServiceClient interface {
+	// Revokes a certificate issued by the CA before it expires.
+	RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*RevokeCertificateResponse, error)
+	// Returns the certificate revocation list of the CA.
+	GetCertificateRevocationList(ctx context.Context, in *CertificateRevocationListRequest, opts ...grpc.CallOption) (*CertificateRevocationListResponse, error)
+}
+
+type istioRevocationServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewIstioRevocationServiceClient(cc *grpc.ClientConn) IstioRevocationServiceClient {
+	return &istioRevocationServiceClient{cc}
+}
+
+func (c *istioRevocationServiceClient) RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*RevokeCertificateResponse, error) {
+	out := new(RevokeCertificateResponse)
+	err := c.cc.Invoke(ctx, "/istio.v1.auth.IstioRevocationService/RevokeCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *istioRevocationServiceClient) GetCertificateRevocationList(ctx context.Context, in *CertificateRevocationListRequest, opts ...grpc.CallOption) (*CertificateRevocationListResponse, error) {
+	out := new(CertificateRevocationListResponse)
+	err := c.cc.Invoke(ctx, "/istio.v1.auth.IstioRevocationService/GetCertificateRevocationList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IstioRevocationServiceServer is the server API for IstioRevocationService service.
+type IstioRevocationServiceServer interface {
+	// Revokes a certificate issued by the CA before it expires.
+	RevokeCertificate(context.Context, *RevokeCertificateRequest) (*RevokeCertificateResponse, error)
+	// Returns the certificate revocation list of the CA.
+	GetCertificateRevocationList(context.Context, *CertificateRevocationListRequest) (*CertificateRevocationListResponse, error)
+}
+
+func RegisterIstioRevocationServiceServer(s *grpc.Server, srv IstioRevocationServiceServer) {
+	s.RegisterService(&_IstioRevocationService_serviceDesc, srv)
+}
+
+func _IstioRevocationService_RevokeCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IstioRevocationServiceServer).RevokeCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/istio.v1.auth.IstioRevocationService/RevokeCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IstioRevocationServiceServer).RevokeCertificate(ctx, req.(*RevokeCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IstioRevocationService_GetCertificateRevocationList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CertificateRevocationListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IstioRevocationServiceServer).GetCertificateRevocationList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/istio.v1.auth.IstioRevocationService/GetCertificateRevocationList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IstioRevocationServiceServer).GetCertificateRevocationList(ctx, req.(*CertificateRevocationListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _IstioRevocationService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "istio.v1.auth.IstioRevocationService",
+	HandlerType: (*IstioRevocationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RevokeCertificate",
+			Handler:    _IstioRevocationService_RevokeCertificate_Handler,
+		},
+		{
+			MethodName: "GetCertificateRevocationList",
+			Handler:    _IstioRevocationService_GetCertificateRevocationList_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "security/proto/revocation.proto",
+}
+
+func (m *RevokeCertificateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeCertificateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Certificate) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRevocation(dAtA, i, uint64(len(m.Certificate)))
+		i += copy(dAtA[i:], m.Certificate)
+	}
+	if len(m.SerialNumber) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRevocation(dAtA, i, uint64(len(m.SerialNumber)))
+		i += copy(dAtA[i:], m.SerialNumber)
+	}
+	return i, nil
+}
+
+func (m *RevokeCertificateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeCertificateResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *CertificateRevocationListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertificateRevocationListRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *CertificateRevocationListResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertificateRevocationListResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Crl) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRevocation(dAtA, i, uint64(len(m.Crl)))
+		i += copy(dAtA[i:], m.Crl)
+	}
+	return i, nil
+}
+
+func encodeVarintRevocation(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *RevokeCertificateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovRevocation(uint64(l))
+	}
+	l = len(m.SerialNumber)
+	if l > 0 {
+		n += 1 + l + sovRevocation(uint64(l))
+	}
+	return n
+}
+
+func (m *RevokeCertificateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *CertificateRevocationListRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *CertificateRevocationListResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Crl)
+	if l > 0 {
+		n += 1 + l + sovRevocation(uint64(l))
+	}
+	return n
+}
+
+func sovRevocation(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozRevocation(x uint64) (n int) {
+	return sovRevocation(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *RevokeCertificateRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RevokeCertificateRequest{`,
+		`Certificate:` + fmt.Sprintf("%v", this.Certificate) + `,`,
+		`SerialNumber:` + fmt.Sprintf("%v", this.SerialNumber) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RevokeCertificateResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RevokeCertificateResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CertificateRevocationListRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CertificateRevocationListRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CertificateRevocationListResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CertificateRevocationListResponse{`,
+		`Crl:` + fmt.Sprintf("%v", this.Crl) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringRevocation(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *RevokeCertificateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRevocation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RevokeCertificateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RevokeCertificateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRevocation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SerialNumber", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRevocation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SerialNumber = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRevocation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RevokeCertificateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRevocation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RevokeCertificateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RevokeCertificateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRevocation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertificateRevocationListRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRevocation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CertificateRevocationListRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CertificateRevocationListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRevocation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertificateRevocationListResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRevocation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CertificateRevocationListResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CertificateRevocationListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Crl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRevocation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Crl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRevocation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRevocation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipRevocation(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowRevocation
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRevocation
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRevocation
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthRevocation
+			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthRevocation
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowRevocation
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipRevocation(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthRevocation
+				}
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthRevocation = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowRevocation   = fmt.Errorf("proto: integer overflow")
+)